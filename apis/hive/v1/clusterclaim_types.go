@@ -9,7 +9,15 @@ import (
 // ClusterClaimSpec defines the desired state of the ClusterClaim.
 type ClusterClaimSpec struct {
 	// ClusterPoolName is the name of the cluster pool from which to claim a cluster.
-	ClusterPoolName string `json:"clusterPoolName"`
+	// If it is not set, Hive sets it to a pool in the namespace of the claim that satisfies the Constraints of the
+	// claim. Once set, it does not change.
+	// +optional
+	ClusterPoolName string `json:"clusterPoolName,omitempty"`
+
+	// Constraints are requirements on the claimed cluster. They are used to choose a cluster pool for a claim that
+	// does not name one in ClusterPoolName.
+	// +optional
+	Constraints *ClusterClaimConstraints `json:"constraints,omitempty"`
 
 	// Subjects hold references to which to authorize access to the claimed cluster.
 	// +optional
@@ -32,6 +40,75 @@ type ClusterClaimSpec struct {
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// Priority determines the order in which pending claims are assigned clusters by the pool. Claims with a higher
+	// priority are served first. Claims of equal priority are served according to the pool's ClaimPolicy, which by
+	// default is oldest first.
+	// If the pool's ClaimPolicy allows preemption, a pending claim may cause an assigned claim of lower priority to
+	// be deleted to make room in the pool.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Extensions are requests for more time for the claim. Each extension adds its Duration to the lifetime of the
+	// claim, up to the maximum lifetime allowed by the pool. Append an extension to renew the claim again.
+	// The outcome of the latest extension is reported in the LifetimeExtended condition.
+	// +optional
+	Extensions []ClusterClaimExtension `json:"extensions,omitempty"`
+
+	// Release hands the claimed cluster back before the lifetime of the claim has elapsed. Once set, Hive deletes the
+	// claim and the cluster is deprovisioned.
+	// +optional
+	Release *ClusterClaimRelease `json:"release,omitempty"`
+}
+
+// ClusterClaimConstraints are requirements on the cluster pool from which a claim is served. A pool must satisfy all
+// of the constraints that are set.
+type ClusterClaimConstraints struct {
+	// PoolSelector selects, by their labels, the cluster pools in the namespace of the claim that may serve the claim.
+	// If not set, any pool in the namespace may serve the claim.
+	// +optional
+	PoolSelector *metav1.LabelSelector `json:"poolSelector,omitempty"`
+
+	// Version is a range of OpenShift versions, such as ">=4.14.0 <4.15.0" or "4.14.x". The version of a pool is taken
+	// from the tag of the release image of its ClusterImageSet.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Platform is the cloud platform of the cluster, such as aws, azure or gcp.
+	// +optional
+	Platform string `json:"platform,omitempty"`
+
+	// Region is the cloud region of the cluster.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// ClusterDeploymentSelector selects pools by the labels they apply to their ClusterDeployments.
+	// +optional
+	ClusterDeploymentSelector *metav1.LabelSelector `json:"clusterDeploymentSelector,omitempty"`
+
+	// ClusterImageSetSelector selects pools by the labels of their ClusterImageSet.
+	// +optional
+	ClusterImageSetSelector *metav1.LabelSelector `json:"clusterImageSetSelector,omitempty"`
+}
+
+// ClusterClaimExtension is a request to extend the lifetime of a claim.
+type ClusterClaimExtension struct {
+	// Duration is the additional time requested for the claim.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	Duration metav1.Duration `json:"duration"`
+
+	// Reason explains why more time is needed.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// ClusterClaimRelease is a request to hand the claimed cluster back early.
+type ClusterClaimRelease struct {
+	// Reason explains why the cluster is being released.
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // ClusterClaimStatus defines the observed state of ClusterClaim.
@@ -44,6 +121,20 @@ type ClusterClaimStatus struct {
 	// when the lifetime has elapsed, the claim will be deleted by Hive.
 	// +optional
 	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// GrantedExtension is the total time added to the lifetime of the claim by its extensions, after capping at the
+	// maximum lifetime allowed by the pool. It is included in Lifetime.
+	// +optional
+	GrantedExtension *metav1.Duration `json:"grantedExtension,omitempty"`
+
+	// ObservedExtensions is the number of extensions of the claim that have been processed.
+	// +optional
+	ObservedExtensions int32 `json:"observedExtensions,omitempty"`
+
+	// QueuePosition is the position of the claim, starting at 1, among the pending claims of its pool waiting for a
+	// cluster to be assigned. It is unset once the claim has been assigned a cluster.
+	// +optional
+	QueuePosition int32 `json:"queuePosition,omitempty"`
 }
 
 // ClusterClaimCondition contains details for the current condition of a cluster claim.
@@ -84,6 +175,14 @@ const (
 	ClusterClaimPendingCondition ClusterClaimConditionType = "Pending"
 	// ClusterRunningCondition is true when a claimed cluster is running and ready for use.
 	ClusterRunningCondition ClusterClaimConditionType = "ClusterRunning"
+	// ClusterClaimLifetimeExtendedCondition reports the outcome of the latest extension of the lifetime of the claim.
+	ClusterClaimLifetimeExtendedCondition ClusterClaimConditionType = "LifetimeExtended"
+	// ClusterClaimReleasedCondition is true when the claimed cluster has been released before the lifetime of the claim
+	// elapsed.
+	ClusterClaimReleasedCondition ClusterClaimConditionType = "Released"
+	// ClusterClaimPreemptedCondition is true when the claim has been preempted by a pending claim of higher priority.
+	// The claim is deleted immediately afterwards.
+	ClusterClaimPreemptedCondition ClusterClaimConditionType = "Preempted"
 )
 
 // +genclient
@@ -98,6 +197,8 @@ const (
 // +kubebuilder:printcolumn:name="ClusterNamespace",type="string",JSONPath=".spec.namespace"
 // +kubebuilder:printcolumn:name="ClusterRunning",type="string",JSONPath=".status.conditions[?(@.type=='ClusterRunning')].reason"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Priority",type="integer",JSONPath=".spec.priority",priority=1
+// +kubebuilder:printcolumn:name="Lifetime",type="string",JSONPath=".status.lifetime",priority=1
 type ClusterClaim struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
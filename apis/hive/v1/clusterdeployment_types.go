@@ -186,6 +186,13 @@ type ClusterDeploymentSpec struct {
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	HibernateAfter *metav1.Duration `json:"hibernateAfter,omitempty"`
 
+	// HibernationSchedule defines calendar windows during which the cluster should be running. At the start of
+	// each window Hive will set PowerState to Running, and at the end of each window Hive will set PowerState
+	// to Hibernating. The PowerState may still be changed manually between scheduled transitions.
+	// For ClusterDeployments belonging to a ClusterPool, the schedule only takes effect once the cluster is claimed.
+	// +optional
+	HibernationSchedule *HibernationSchedule `json:"hibernationSchedule,omitempty"`
+
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	// +optional
 	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
@@ -198,6 +205,42 @@ type ClusterDeploymentSpec struct {
 	BoundServiceAccountSigningKeySecretRef *corev1.LocalObjectReference `json:"boundServiceAccountSigningKeySecretRef,omitempty"`
 }
 
+// HibernationSchedule defines calendar windows during which a cluster should be running. Outside of all windows
+// the cluster should be hibernating.
+type HibernationSchedule struct {
+	// TimeZone is the IANA time zone name (e.g. "America/New_York") in which the windows are evaluated.
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// RunWindows is the list of windows during which the cluster should be running.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	RunWindows []HibernationRunWindow `json:"runWindows"`
+}
+
+// HibernationRunWindow is a recurring window of time during which a cluster should be running.
+type HibernationRunWindow struct {
+	// Days are the days of the week on which the window starts. When omitted, the window applies to every day.
+	// +optional
+	Days []Weekday `json:"days,omitempty"`
+
+	// Start is the time of day, in 24-hour HH:MM format, at which the window starts.
+	// +kubebuilder:validation:Pattern="^([01][0-9]|2[0-3]):[0-5][0-9]$"
+	// +required
+	Start string `json:"start"`
+
+	// End is the time of day, in 24-hour HH:MM format, at which the window ends. If End is not after Start, the
+	// window ends on the following day.
+	// +kubebuilder:validation:Pattern="^([01][0-9]|2[0-3]):[0-5][0-9]$"
+	// +required
+	End string `json:"end"`
+}
+
+// Weekday is a day of the week.
+// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+type Weekday string
+
 // ClusterInstallLocalReference provides reference to an object that implements
 // the hivecontract ClusterInstall. The namespace of the object is same as the
 // ClusterDeployment.
@@ -282,6 +325,10 @@ type ClusterPoolReference struct {
 	// The Customization exists in the ClusterPool namespace.
 	// +optional
 	CustomizationRef *corev1.LocalObjectReference `json:"clusterDeploymentCustomization,omitempty"`
+	// InventoryRefs are the ClusterPool Inventory entries, other than the ClusterDeploymentCustomization, reserved
+	// for this ClusterDeployment. The entries exist in the ClusterPool namespace.
+	// +optional
+	InventoryRefs []InventoryEntry `json:"inventoryRefs,omitempty"`
 }
 
 // ClusterMetadata contains metadata information about the installed cluster.
@@ -365,6 +412,10 @@ type ClusterDeploymentStatus struct {
 	// +optional
 	PowerState ClusterPowerState `json:"powerState,omitempty"`
 
+	// HibernationSchedule reports the state of the HibernationSchedule, if one is configured.
+	// +optional
+	HibernationSchedule *HibernationScheduleStatus `json:"hibernationSchedule,omitempty"`
+
 	// ProvisionRef is a reference to the last ClusterProvision created for the deployment
 	// +optional
 	ProvisionRef *corev1.LocalObjectReference `json:"provisionRef,omitempty"`
@@ -373,6 +424,143 @@ type ClusterDeploymentStatus struct {
 	// perform the installation.
 	// +optional
 	Platform *PlatformStatus `json:"platformStatus,omitempty"`
+
+	// ProvisionRetries records the failed install attempts of the cluster, and how the RetryPolicies of the
+	// FailedProvisionConfig of the HiveConfig were applied to them.
+	// +optional
+	ProvisionRetries *ProvisionRetriesStatus `json:"provisionRetries,omitempty"`
+
+	// Cost is the estimated cost of the cluster, calculated from the cluster-price-table ConfigMap in the Hive
+	// namespace. It is only set for installed clusters, and only when the price table exists.
+	// +optional
+	Cost *ClusterCostStatus `json:"cost,omitempty"`
+
+	// CloudResources is the inventory of the cloud resources tagged for the cluster, and of those among them that
+	// are orphaned. It is only set when the cloud resource inventory is enabled in HiveConfig.
+	// +optional
+	CloudResources *CloudResourcesStatus `json:"cloudResources,omitempty"`
+}
+
+// CloudResourcesStatus is the inventory of the cloud resources of a cluster.
+type CloudResourcesStatus struct {
+	// Resources counts the cloud resources of the cluster by kind.
+	// +optional
+	Resources []CloudResourceCount `json:"resources,omitempty"`
+
+	// OrphanCount is the number of cloud resources of the cluster that are orphaned.
+	// +optional
+	OrphanCount int32 `json:"orphanCount,omitempty"`
+
+	// Orphans lists the orphaned cloud resources of the cluster. At most 50 are listed; OrphanCount has the total.
+	// +optional
+	Orphans []OrphanedCloudResource `json:"orphans,omitempty"`
+
+	// LastScanTime is the time the cloud resources of the cluster were last listed.
+	LastScanTime metav1.Time `json:"lastScanTime"`
+}
+
+// CloudResourceCount is the number of cloud resources of a kind.
+type CloudResourceCount struct {
+	// Kind is the kind of the cloud resources, e.g. ec2:volume or Microsoft.Network/publicIPAddresses.
+	Kind string `json:"kind"`
+
+	// Count is the number of cloud resources of the kind.
+	Count int32 `json:"count"`
+}
+
+// OrphanedCloudResourceReason is the reason a cloud resource is orphaned.
+type OrphanedCloudResourceReason string
+
+const (
+	// OrphanedCloudResourceServiceDeleted is the reason for a load balancer, or a resource of one, whose Service no
+	// longer exists in the cluster or is no longer of type LoadBalancer.
+	OrphanedCloudResourceServiceDeleted OrphanedCloudResourceReason = "ServiceDeleted"
+	// OrphanedCloudResourcePersistentVolumeDeleted is the reason for a volume whose PersistentVolume no longer exists
+	// in the cluster.
+	OrphanedCloudResourcePersistentVolumeDeleted OrphanedCloudResourceReason = "PersistentVolumeDeleted"
+	// OrphanedCloudResourceClusterDeprovisioned is the reason for a resource left behind by a deprovision of the
+	// cluster that completed or failed.
+	OrphanedCloudResourceClusterDeprovisioned OrphanedCloudResourceReason = "ClusterDeprovisioned"
+)
+
+// OrphanedCloudResource is a cloud resource of a cluster that is no longer used by it.
+type OrphanedCloudResource struct {
+	// ID identifies the resource on its cloud platform, e.g. its ARN on AWS.
+	ID string `json:"id"`
+
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+
+	// Reason is why the resource is orphaned.
+	Reason OrphanedCloudResourceReason `json:"reason"`
+
+	// CreatedFor is the namespace/name of the Service, or the name of the PersistentVolume, the resource was created
+	// for.
+	// +optional
+	CreatedFor string `json:"createdFor,omitempty"`
+}
+
+// ClusterCostStatus is the estimated cost of a cluster. Costs are decimal numbers in the Currency of the price table.
+type ClusterCostStatus struct {
+	// Currency is the currency of the costs, as set in the price table.
+	// +optional
+	Currency string `json:"currency,omitempty"`
+
+	// HourlyCost is the estimated cost of running the cluster for an hour in its current power state. Only the
+	// cluster rate of the price table applies to hibernating clusters.
+	HourlyCost string `json:"hourlyCost"`
+
+	// CumulativeCost is the estimated cost of the cluster since it was installed. It is not kept anywhere else, so it
+	// is lost when the ClusterDeployment is deleted.
+	CumulativeCost string `json:"cumulativeCost"`
+
+	// UnpricedMachines is the number of machines of the cluster whose instance type has no rate in the price
+	// table. They are not included in HourlyCost.
+	// +optional
+	UnpricedMachines int32 `json:"unpricedMachines,omitempty"`
+
+	// LastUpdateTime is the time CumulativeCost was calculated at.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// ProvisionRetriesStatus records the failed install attempts of a cluster, and how retry policies were applied to them.
+type ProvisionRetriesStatus struct {
+	// Failures counts the failed install attempts by the reason they failed for.
+	// +optional
+	Failures []ProvisionFailureCount `json:"failures,omitempty"`
+
+	// Fallback is the retry policy fallback applied to the cluster. The installer applies it to the install config.
+	// +optional
+	Fallback *ProvisionRetryFallback `json:"fallback,omitempty"`
+
+	// PrevRegion is the region of the last failed install attempt, when the Fallback has since changed the region
+	// of the cluster. The resources of that attempt are cleaned up from this region.
+	// +optional
+	PrevRegion string `json:"prevRegion,omitempty"`
+}
+
+// ProvisionFailureCount is the number of failed install attempts of a cluster for a reason.
+type ProvisionFailureCount struct {
+	// Reason is the reason of the ProvisionFailed condition of the failed install attempts.
+	Reason string `json:"reason"`
+
+	// Count is the number of install attempts that failed for the Reason.
+	Count int32 `json:"count"`
+}
+
+// HibernationScheduleStatus reports the scheduled PowerState transitions of a ClusterDeployment.
+type HibernationScheduleStatus struct {
+	// LastTransitionTime is the time of the most recent scheduled PowerState transition applied by Hive.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// NextTransitionTime is the time of the next scheduled PowerState transition.
+	// +optional
+	NextTransitionTime *metav1.Time `json:"nextTransitionTime,omitempty"`
+
+	// NextPowerState is the PowerState the cluster will be set to at NextTransitionTime.
+	// +optional
+	NextPowerState ClusterPowerState `json:"nextPowerState,omitempty"`
 }
 
 // ClusterDeploymentCondition contains details for the current condition of a cluster deployment
@@ -465,6 +653,10 @@ const (
 	// retries (without InstallAttemptsLimit changes or other hive configuration stopping further retries).
 	ProvisionStoppedCondition ClusterDeploymentConditionType = "ProvisionStopped"
 
+	// ProvisionRetryPolicyAppliedCondition is True when the next install attempt is governed by one of the
+	// RetryPolicies of the FailedProvisionConfig of the HiveConfig. The Reason and Message describe how.
+	ProvisionRetryPolicyAppliedCondition ClusterDeploymentConditionType = "ProvisionRetryPolicyApplied"
+
 	// Provisioned is True when a cluster is installed; False while it is provisioning or deprovisioning.
 	// The Reason indicates where it is in that lifecycle.
 	ProvisionedCondition ClusterDeploymentConditionType = "Provisioned"
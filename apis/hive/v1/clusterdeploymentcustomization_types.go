@@ -4,6 +4,7 @@ import (
 	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
@@ -11,8 +12,8 @@ const (
 	// worked properly on the last applied cluster deployment.
 	CustomizationApplyReasonSucceeded = "Succeeded"
 	// CustomizationApplyReasonBrokenSyntax indicates that Hive failed to apply
-	// customization patches on install-config. More details would be found in
-	// ApplySucceded condition message.
+	// customization patches on install-config, ClusterDeployment, MachinePools or manifests.
+	// More details would be found in ApplySucceded condition message.
 	CustomizationApplyReasonBrokenSyntax = "BrokenBySyntax"
 	// CustomizationApplyReasonBrokenCloud indicates that cluster deployment provision has failed
 	// when using this customization. More details would be found in the ApplySucceeded condition message.
@@ -41,9 +42,27 @@ type ClusterDeploymentCustomization struct {
 type ClusterDeploymentCustomizationSpec struct {
 	// InstallConfigPatches is a list of patches to be applied to the install-config.
 	InstallConfigPatches []PatchEntity `json:"installConfigPatches,omitempty"`
+
+	// ClusterDeploymentPatches is a list of patches to be applied to the generated ClusterDeployment, such as to its
+	// labels, annotations or platform fields. The name, namespace and clusterPoolRef of the ClusterDeployment cannot be
+	// changed, and its labels and annotations can only be patched entry by entry.
+	// +optional
+	ClusterDeploymentPatches []PatchEntity `json:"clusterDeploymentPatches,omitempty"`
+
+	// MachinePoolPatches is a list of patches to be applied to each of the generated MachinePools.
+	// +optional
+	MachinePoolPatches []PatchEntity `json:"machinePoolPatches,omitempty"`
+
+	// ManifestPatches is a list of patches to be applied to the manifests of the generated ClusterDeployment. The patches
+	// are applied to an object mapping each manifest file name to its contents. For example, a patch adding the path
+	// "/99-custom.yaml" adds a manifest file named 99-custom.yaml. A manifests Secret is generated for the
+	// ClusterDeployment if there is not one already.
+	// +optional
+	ManifestPatches []PatchEntity `json:"manifestPatches,omitempty"`
 }
 
-// PatchEntity represent a json patch (RFC 6902) to be applied to the install-config
+// PatchEntity represent a json patch (RFC 6902) to be applied to the install-config, ClusterDeployment, MachinePools or
+// manifests
 type PatchEntity struct {
 	// Op is the operation to perform: add, remove, replace, move, copy, test
 	// +required
@@ -54,9 +73,15 @@ type PatchEntity struct {
 	// From is the json path to copy or move the value from
 	// +optional
 	From string `json:"from,omitempty"`
-	// Value is the value to be used in the operation
-	// +required
+	// Value is the string value to be used in the operation. It is ignored if TypedValue is set.
+	// +optional
 	Value string `json:"value"`
+	// TypedValue is the value to be used in the operation, when it is not a string. It may be any JSON value: a string,
+	// number, boolean, object, array or null. Value must be empty if TypedValue is set.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	TypedValue *runtime.RawExtension `json:"typedValue,omitempty"`
 }
 
 // ClusterDeploymentCustomizationStatus defines the observed state of ClusterDeploymentCustomization.
@@ -69,7 +94,8 @@ type ClusterDeploymentCustomizationStatus struct {
 	// +optional
 	ClusterPoolRef *corev1.LocalObjectReference `json:"clusterPoolRef,omitempty"`
 
-	// LastAppliedConfiguration contains the last applied patches to the install-config.
+	// LastAppliedConfiguration contains the last applied patches to the install-config, ClusterDeployment,
+	// MachinePools and manifests.
 	// The information will retain for reference in case the customization is updated.
 	// +optional
 	LastAppliedConfiguration string `json:"lastAppliedConfiguration,omitempty"`
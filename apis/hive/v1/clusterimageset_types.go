@@ -1,18 +1,124 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ClusterImageSetSpec defines the desired state of ClusterImageSet
 type ClusterImageSetSpec struct {
 	// ReleaseImage is the image that contains the payload to use when installing
-	// a cluster.
+	// a cluster. It is required unless Channel is set, in which case Hive sets it to the
+	// latest release of the channel.
+	// +optional
 	ReleaseImage string `json:"releaseImage"`
+
+	// Channel, if set, makes Hive follow an update channel, keeping ReleaseImage at the latest release
+	// of the channel that passes signature verification.
+	// +optional
+	Channel *ClusterImageSetChannel `json:"channel,omitempty"`
+}
+
+// ClusterImageSetChannel is an update channel followed by a ClusterImageSet. The releases of the channel are read
+// from a Cincinnati-compatible update graph, either served at GraphURL or stored in the ConfigMap referenced by
+// GraphConfigMapRef. Exactly one of GraphURL and GraphConfigMapRef must be set.
+type ClusterImageSetChannel struct {
+	// Name is the name of the channel, such as stable-4.14.
+	Name string `json:"name"`
+
+	// GraphURL is the URL of a Cincinnati-compatible update graph endpoint. It is queried with the channel and arch
+	// parameters.
+	// +optional
+	GraphURL string `json:"graphURL,omitempty"`
+
+	// GraphConfigMapRef refers to a ConfigMap in the namespace of Hive holding the update graph as JSON under the
+	// "graph.json" key.
+	// +optional
+	GraphConfigMapRef *corev1.LocalObjectReference `json:"graphConfigMapRef,omitempty"`
+
+	// Architecture is the architecture of the releases to follow. Defaults to amd64.
+	// +optional
+	Architecture string `json:"architecture,omitempty"`
+
+	// Version restricts the releases followed to a range of versions, such as "4.14.x" or ">=4.14.0 <4.15.0".
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// PollInterval is how often the update graph is read. Defaults to 1h.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
 }
 
 // ClusterImageSetStatus defines the observed state of ClusterImageSet
-type ClusterImageSetStatus struct{}
+type ClusterImageSetStatus struct {
+	// Version is the version of the ReleaseImage, when it was resolved from the channel.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// LastCheckedTime is the last time the update graph of the channel was read.
+	// +optional
+	LastCheckedTime *metav1.Time `json:"lastCheckedTime,omitempty"`
+
+	// History lists the releases resolved from the channel, most recent first. At most 10 releases are kept.
+	// +optional
+	History []ClusterImageSetRelease `json:"history,omitempty"`
+
+	// Conditions includes more detailed status for the ClusterImageSet.
+	// +optional
+	Conditions []ClusterImageSetCondition `json:"conditions,omitempty"`
+}
+
+// ClusterImageSetRelease is a release resolved from the channel of a ClusterImageSet.
+type ClusterImageSetRelease struct {
+	// Version is the version of the release.
+	Version string `json:"version"`
+	// ReleaseImage is the image of the release.
+	ReleaseImage string `json:"releaseImage"`
+	// ResolvedTime is when the release was resolved from the channel.
+	ResolvedTime metav1.Time `json:"resolvedTime"`
+}
+
+// ClusterImageSetCondition contains details for the current condition of a ClusterImageSet.
+type ClusterImageSetCondition struct {
+	// Type is the type of the condition.
+	Type ClusterImageSetConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterImageSetConditionType is a valid value for ClusterImageSetCondition.Type.
+type ClusterImageSetConditionType string
+
+// ConditionType satisfies the conditions.Condition interface
+func (c ClusterImageSetCondition) ConditionType() ConditionType {
+	return c.Type
+}
+
+// String satisfies the conditions.ConditionType interface
+func (t ClusterImageSetConditionType) String() string {
+	return string(t)
+}
+
+const (
+	// ClusterImageSetChannelResolvedCondition is true when the latest release of the channel was resolved from the
+	// update graph.
+	ClusterImageSetChannelResolvedCondition ClusterImageSetConditionType = "ChannelResolved"
+)
 
 // +genclient:nonNamespaced
 // +genclient
@@ -23,6 +129,8 @@ type ClusterImageSetStatus struct{}
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Release",type="string",JSONPath=".spec.releaseImage"
+// +kubebuilder:printcolumn:name="Channel",type="string",JSONPath=".spec.channel.name",priority=1
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",priority=1
 // +kubebuilder:resource:path=clusterimagesets,shortName=imgset,scope=Cluster
 type ClusterImageSet struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -102,6 +102,127 @@ type ClusterPoolSpec struct {
 	// additional features of the installer.
 	// +optional
 	InstallerEnv []corev1.EnvVar `json:"installerEnv,omitempty"`
+
+	// Autoscaling enables adjusting the effective Size and RunningCount of the pool based on the rate at which
+	// ClusterClaims have been observed. When set, Size and RunningCount act as lower bounds.
+	// +optional
+	Autoscaling *ClusterPoolAutoscaling `json:"autoscaling,omitempty"`
+
+	// ClaimPolicy configures the order in which pending ClusterClaims are assigned clusters, and whether assigned
+	// claims may be preempted by pending claims of higher priority.
+	// +optional
+	ClaimPolicy *ClusterPoolClaimPolicy `json:"claimPolicy,omitempty"`
+
+	// ReleaseRollover, if set, makes the pool replace its unclaimed clusters when the ReleaseImage of its
+	// ClusterImageSet changes, such as when the ClusterImageSet follows a channel. Clusters installed from an older
+	// release image are treated as stale and replaced at the rate configured here.
+	// +optional
+	ReleaseRollover *ClusterPoolReleaseRollover `json:"releaseRollover,omitempty"`
+}
+
+// ClusterPoolReleaseRollover configures how a ClusterPool replaces its unclaimed clusters with clusters of a new
+// release. Stale clusters are deleted in batches once all of the clusters of the pool are installed.
+type ClusterPoolReleaseRollover struct {
+	// MaxConcurrent is the maximum number of stale clusters deleted in one batch. It is further limited by the
+	// MaxConcurrent of the pool. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+
+	// Interval is the minimum time between batches. Defaults to no delay beyond waiting for the replacements of the
+	// previous batch to be installed.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// ClusterPoolClaimPolicy configures how a ClusterPool arbitrates between competing ClusterClaims.
+type ClusterPoolClaimPolicy struct {
+	// FairShareLabel is the key of a label on ClusterClaims identifying the consumer (e.g. a team or CI job) on
+	// whose behalf the claim was made. Among pending claims of equal priority, consumers are served in turn, oldest
+	// claim first, so that a flood of claims from one consumer does not starve the others. Claims without the label
+	// are treated as belonging to a single consumer.
+	// By default, pending claims of equal priority are served oldest first.
+	// +optional
+	FairShareLabel string `json:"fairShareLabel,omitempty"`
+
+	// Preemption, if set, allows a pending claim to displace an assigned claim of lower priority when the pool
+	// cannot create more clusters because it has reached MaxSize. The displaced claim is deleted, releasing its
+	// cluster for deprovisioning.
+	// +optional
+	Preemption *ClusterClaimPreemption `json:"preemption,omitempty"`
+}
+
+// ClusterClaimPreemption configures preemption of assigned ClusterClaims.
+type ClusterClaimPreemption struct {
+	// GracePeriod is the minimum amount of time a claim must have held its cluster before it may be preempted.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// Note: due to discrepancies in validation vs parsing, we use a Pattern instead of `Format=duration`. See
+	// https://bugzilla.redhat.com/show_bug.cgi?id=2050332
+	// https://github.com/kubernetes/apimachinery/issues/131
+	// https://github.com/kubernetes/apiextensions-apiserver/issues/56
+	// +required
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	GracePeriod metav1.Duration `json:"gracePeriod"`
+}
+
+// ClusterPoolAutoscalingMode is the algorithm used to predict ClusterClaim demand for a ClusterPool.
+// +kubebuilder:validation:Enum=MovingAverage;TimeOfDay
+type ClusterPoolAutoscalingMode string
+
+const (
+	// ClusterPoolAutoscalingMovingAverage predicts demand from the number of claims observed during the trailing
+	// Window.
+	ClusterPoolAutoscalingMovingAverage ClusterPoolAutoscalingMode = "MovingAverage"
+	// ClusterPoolAutoscalingTimeOfDay predicts demand from the average number of claims observed during the upcoming
+	// Window at the same time of day on each of the days in the History period.
+	ClusterPoolAutoscalingTimeOfDay ClusterPoolAutoscalingMode = "TimeOfDay"
+)
+
+// ClusterPoolAutoscaling configures predictive sizing of a ClusterPool.
+type ClusterPoolAutoscaling struct {
+	// Mode is the algorithm used to predict demand. The default is MovingAverage.
+	// +kubebuilder:default=MovingAverage
+	// +optional
+	Mode ClusterPoolAutoscalingMode `json:"mode,omitempty"`
+
+	// MaxSize is the upper bound for the effective Size of the pool. It must not be less than Size.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	MaxSize int32 `json:"maxSize"`
+
+	// MaxRunningCount is the upper bound for the effective RunningCount of the pool. It must not be less than
+	// RunningCount. By default the RunningCount is not adjusted.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRunningCount *int32 `json:"maxRunningCount,omitempty"`
+
+	// Window is the period over which demand is predicted. It should be roughly the time it takes to replenish the
+	// pool, i.e. to install a new cluster. The default is one hour.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// Note: due to discrepancies in validation vs parsing, we use a Pattern instead of `Format=duration`. See
+	// https://bugzilla.redhat.com/show_bug.cgi?id=2050332
+	// https://github.com/kubernetes/apimachinery/issues/131
+	// https://github.com/kubernetes/apiextensions-apiserver/issues/56
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	Window *metav1.Duration `json:"window,omitempty"`
+
+	// History is how far back claims are considered by the TimeOfDay mode. It is rounded down to whole days. The
+	// default is seven days. Ignored by the MovingAverage mode.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// Note: due to discrepancies in validation vs parsing, we use a Pattern instead of `Format=duration`. See
+	// https://bugzilla.redhat.com/show_bug.cgi?id=2050332
+	// https://github.com/kubernetes/apimachinery/issues/131
+	// https://github.com/kubernetes/apiextensions-apiserver/issues/56
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	History *metav1.Duration `json:"history,omitempty"`
 }
 
 type HibernationConfig struct {
@@ -118,17 +239,38 @@ type HibernationConfig struct {
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	ResumeTimeout metav1.Duration `json:"resumeTimeout"`
+
+	// Schedule defines calendar windows during which claimed clusters of the pool should be running; outside of those
+	// windows they will be hibernated. It is kept in sync as the HibernationSchedule of the unclaimed
+	// ClusterDeployments of the pool, and takes effect once a cluster is claimed. Until then, the power state of
+	// unclaimed clusters is governed by RunningCount. Changing the schedule does not affect clusters already claimed.
+	// +optional
+	Schedule *HibernationSchedule `json:"schedule,omitempty"`
 }
 
 // InventoryEntryKind is the Kind of the inventory entry.
-// +kubebuilder:validation:Enum="";ClusterDeploymentCustomization
+// +kubebuilder:validation:Enum="";ClusterDeploymentCustomization;Secret;ConfigMap
 type InventoryEntryKind string
 
-const ClusterDeploymentCustomizationInventoryEntry InventoryEntryKind = "ClusterDeploymentCustomization"
+const (
+	// ClusterDeploymentCustomizationInventoryEntry refers to a ClusterDeploymentCustomization whose patches are
+	// applied to the install config of the cluster.
+	ClusterDeploymentCustomizationInventoryEntry InventoryEntryKind = "ClusterDeploymentCustomization"
+	// SecretInventoryEntry refers to a Secret holding per-cluster cloud credentials. The reserved Secret is copied
+	// into the namespace of the ClusterDeployment, which uses the copy as the credentials of its cloud platform.
+	SecretInventoryEntry InventoryEntryKind = "Secret"
+	// ConfigMapInventoryEntry refers to a ConfigMap of per-cluster manifests, such as ones configuring a
+	// pre-allocated VIP or a reserved DNS name. The reserved ConfigMap is copied into the namespace of the
+	// ClusterDeployment, which adds the manifests of the copy to its install.
+	ConfigMapInventoryEntry InventoryEntryKind = "ConfigMap"
+)
 
 // InventoryEntry maintains a reference to a custom resource consumed by a clusterpool to customize the cluster deployment.
+// When the inventory contains entries of several kinds, one entry of each kind is reserved for every cluster; the
+// pool cannot grow once the entries of any kind are exhausted.
 type InventoryEntry struct {
-	// Kind denotes the kind of the referenced resource. The default is ClusterDeploymentCustomization, which is also currently the only supported value.
+	// Kind denotes the kind of the referenced resource. The default is ClusterDeploymentCustomization.
+	// The referenced resource must exist in the ClusterPool namespace.
 	// +kubebuilder:default=ClusterDeploymentCustomization
 	Kind InventoryEntryKind `json:"kind,omitempty"`
 	// Name is the name of the referenced resource.
@@ -178,6 +320,61 @@ type ClusterPoolStatus struct {
 	// Conditions includes more detailed status for the cluster pool
 	// +optional
 	Conditions []ClusterPoolCondition `json:"conditions,omitempty"`
+
+	// Autoscaling reports the targets computed for a pool with Spec.Autoscaling configured.
+	// +optional
+	Autoscaling *ClusterPoolAutoscalingStatus `json:"autoscaling,omitempty"`
+
+	// ReleaseImage is the release image of the ClusterImageSet of the pool, for a pool with Spec.ReleaseRollover
+	// configured. Unclaimed clusters installed from another release image are stale.
+	// +optional
+	ReleaseImage string `json:"releaseImage,omitempty"`
+
+	// BaseReleaseImage is the release image of the ClusterImageSet of the pool when Spec.ReleaseRollover was
+	// enabled. Unclaimed clusters keep the pool version they were created with while the pool is at this release
+	// image, so enabling ReleaseRollover does not make them stale.
+	// +optional
+	BaseReleaseImage string `json:"baseReleaseImage,omitempty"`
+
+	// LastRolloverTime is when the pool last deleted a batch of stale clusters, for a pool with
+	// Spec.ReleaseRollover configured.
+	// +optional
+	LastRolloverTime *metav1.Time `json:"lastRolloverTime,omitempty"`
+}
+
+// ClusterPoolAutoscalingStatus reports the observed claim history and computed targets of an autoscaling ClusterPool.
+type ClusterPoolAutoscalingStatus struct {
+	// TargetSize is the effective Size of the pool.
+	TargetSize int32 `json:"targetSize"`
+
+	// TargetRunningCount is the effective RunningCount of the pool.
+	TargetRunningCount int32 `json:"targetRunningCount"`
+
+	// Reason is a one-word, CamelCase reason for the current targets.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human-readable explanation of the current targets.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ClaimHistory holds the number of ClusterClaims observed for the pool in each hour, oldest first. Hours without
+	// claims are omitted, and hours are dropped once they are older than needed by the configured Mode.
+	// +optional
+	ClaimHistory []ClusterPoolClaimCount `json:"claimHistory,omitempty"`
+
+	// LastClaimTime is the creation time of the most recent ClusterClaim counted in ClaimHistory.
+	// +optional
+	LastClaimTime *metav1.Time `json:"lastClaimTime,omitempty"`
+}
+
+// ClusterPoolClaimCount is the number of ClusterClaims created for a pool in an hour.
+type ClusterPoolClaimCount struct {
+	// Hour is the start of the hour.
+	Hour metav1.Time `json:"hour"`
+
+	// Count is the number of ClusterClaims created during the hour.
+	Count int32 `json:"count"`
 }
 
 // ClusterPoolCondition contains details for the current condition of a cluster pool
@@ -225,6 +422,9 @@ const (
 	ClusterPoolAllClustersCurrentCondition ClusterPoolConditionType = "AllClustersCurrent"
 	// ClusterPoolInventoryValidCondition is set to provide information on whether the cluster pool inventory is valid.
 	ClusterPoolInventoryValidCondition ClusterPoolConditionType = "InventoryValid"
+	// ClusterPoolInventoryAvailableCondition is set to provide information on whether the cluster pool inventory has
+	// unreserved entries of every kind from which to create more clusters.
+	ClusterPoolInventoryAvailableCondition ClusterPoolConditionType = "InventoryAvailable"
 	// ClusterPoolDeletionPossibleCondition gives information about a deleted ClusterPool which is pending cleanup.
 	// Note that it is normal for this condition to remain Initialized/Unknown until the ClusterPool is deleted.
 	ClusterPoolDeletionPossibleCondition ClusterPoolConditionType = "DeletionPossible"
@@ -237,6 +437,10 @@ const (
 	// InventoryReasonInvalid is used when there is something wrong with ClusterDeploymentCustomization, for example
 	// patching issue, provisioning failure, missing, etc.
 	InventoryReasonInvalid = "Invalid"
+	// InventoryReasonAvailable is used when there are unreserved inventory entries of every kind.
+	InventoryReasonAvailable = "Available"
+	// InventoryReasonExhausted is used when all of the inventory entries of some kind are reserved.
+	InventoryReasonExhausted = "Exhausted"
 )
 
 // +genclient
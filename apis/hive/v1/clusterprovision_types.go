@@ -65,8 +65,44 @@ type ClusterProvisionStatus struct {
 	// Conditions includes more detailed status for the cluster provision
 	// +optional
 	Conditions []ClusterProvisionCondition `json:"conditions,omitempty"`
+
+	// Timeline lists the phases of the installer reached so far, in order, as parsed by the install pod from the
+	// install log.
+	// +optional
+	Timeline []ClusterProvisionPhase `json:"timeline,omitempty"`
+}
+
+// ClusterProvisionPhase is a phase of the installer along with when it started and completed.
+type ClusterProvisionPhase struct {
+	// Name is the name of the phase.
+	Name ClusterProvisionPhaseName `json:"name"`
+	// StartTime is when the installer started the phase.
+	StartTime metav1.Time `json:"startTime"`
+	// CompletionTime is when the installer completed the phase. It is unset while the phase is in progress, or if the
+	// installer failed during the phase.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
+// ClusterProvisionPhaseName is the name of a phase of the installer.
+// +kubebuilder:validation:Enum=InfrastructureCreation;APIWait;Bootstrap;ClusterInitialization
+type ClusterProvisionPhaseName string
+
+const (
+	// ClusterProvisionPhaseInfrastructureCreation is the phase where the installer creates the cloud resources of the
+	// cluster.
+	ClusterProvisionPhaseInfrastructureCreation ClusterProvisionPhaseName = "InfrastructureCreation"
+	// ClusterProvisionPhaseAPIWait is the phase where the installer waits for the Kubernetes API of the bootstrap
+	// node to come up.
+	ClusterProvisionPhaseAPIWait ClusterProvisionPhaseName = "APIWait"
+	// ClusterProvisionPhaseBootstrap is the phase where the installer waits for the bootstrap node to bring up the
+	// control plane.
+	ClusterProvisionPhaseBootstrap ClusterProvisionPhaseName = "Bootstrap"
+	// ClusterProvisionPhaseClusterInitialization is the phase where the installer destroys the bootstrap resources and
+	// waits for the cluster operators to become available.
+	ClusterProvisionPhaseClusterInitialization ClusterProvisionPhaseName = "ClusterInitialization"
+)
+
 // ClusterProvisionStage is the stage of provisioning.
 type ClusterProvisionStage string
 
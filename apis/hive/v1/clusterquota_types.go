@@ -0,0 +1,94 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterQuotaSpec defines limits on the clusters that may be consumed by a set of namespaces.
+type ClusterQuotaSpec struct {
+	// NamespaceSelector is a LabelSelector indicating the namespaces to which the quota applies. Usage is aggregated
+	// across all of the selected namespaces. To limit a single namespace, select it by its
+	// kubernetes.io/metadata.name label. An empty selector selects all namespaces.
+	// ClusterDeployments created for a ClusterPool are counted against the namespace of the ClusterPool, not their own.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// Hard is the set of limits enforced for the selected namespaces.
+	Hard ClusterQuotaLimits `json:"hard"`
+}
+
+// ClusterQuotaLimits is a set of limits on cluster consumption. An unset limit is not enforced.
+type ClusterQuotaLimits struct {
+	// ClusterDeployments is the maximum number of ClusterDeployments, including unclaimed ClusterPool clusters and
+	// clusters that are being deprovisioned.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ClusterDeployments *int32 `json:"clusterDeployments,omitempty"`
+
+	// RunningClusterDeployments is the maximum number of ClusterDeployments whose PowerState is not Hibernating.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RunningClusterDeployments *int32 `json:"runningClusterDeployments,omitempty"`
+
+	// ClusterPoolSize is the maximum total Size of ClusterPools. For ClusterPools with Autoscaling configured, the
+	// autoscaling MaxSize is counted.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ClusterPoolSize *int32 `json:"clusterPoolSize,omitempty"`
+}
+
+// ClusterQuotaUsage is the observed consumption of the resources limited by a ClusterQuota.
+type ClusterQuotaUsage struct {
+	// ClusterDeployments is the number of ClusterDeployments.
+	ClusterDeployments int32 `json:"clusterDeployments"`
+
+	// RunningClusterDeployments is the number of ClusterDeployments whose PowerState is not Hibernating.
+	RunningClusterDeployments int32 `json:"runningClusterDeployments"`
+
+	// ClusterPoolSize is the total Size of ClusterPools.
+	ClusterPoolSize int32 `json:"clusterPoolSize"`
+}
+
+// ClusterQuotaStatus defines the observed state of ClusterQuota.
+type ClusterQuotaStatus struct {
+	// Used is the current consumption in the selected namespaces.
+	// +optional
+	Used ClusterQuotaUsage `json:"used,omitempty"`
+
+	// Namespaces is the number of namespaces currently selected by the quota.
+	// +optional
+	Namespaces int32 `json:"namespaces,omitempty"`
+}
+
+// +genclient:nonNamespaced
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterQuota limits the number of clusters, running clusters and ClusterPool capacity that may be consumed by the
+// namespaces it selects. It is enforced when ClusterDeployments and ClusterPools are created or updated, and by the
+// ClusterPool controller when it adds or resumes clusters.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ClusterDeployments",type="string",JSONPath=".status.used.clusterDeployments"
+// +kubebuilder:printcolumn:name="Running",type="string",JSONPath=".status.used.runningClusterDeployments"
+// +kubebuilder:printcolumn:name="PoolSize",type="string",JSONPath=".status.used.clusterPoolSize"
+// +kubebuilder:resource:path=clusterquotas,scope=Cluster
+type ClusterQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterQuotaSpec   `json:"spec,omitempty"`
+	Status ClusterQuotaStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterQuotaList contains a list of ClusterQuota
+type ClusterQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterQuota{}, &ClusterQuotaList{})
+}
@@ -12,6 +12,18 @@ type ClusterRelocateSpec struct {
 
 	// ClusterDeploymentSelector is a LabelSelector indicating which clusters will be relocated.
 	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector"`
+
+	// DryRun, if true, causes the matching clusters to be listed in the status along with the resources that would be
+	// copied to the destination Hive instance, without relocating anything.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Cancel, if true, stops relocating the matching clusters and rolls back the relocations that have not finished.
+	// The resources copied to the destination Hive instance are removed, without deprovisioning the cluster, and the
+	// source Hive instance resumes reconciling the cluster. A cluster that has already been deleted from the source Hive
+	// instance cannot be rolled back.
+	// +optional
+	Cancel bool `json:"cancel,omitempty"`
 }
 
 // KubeconfigSecretReference is a reference to a secret containing the kubeconfig for a remote cluster.
@@ -23,7 +35,67 @@ type KubeconfigSecretReference struct {
 }
 
 // ClusterRelocateStatus defines the observed state of ClusterRelocate.
-type ClusterRelocateStatus struct{}
+type ClusterRelocateStatus struct {
+	// MatchedClusters is the number of clusters that have matched the ClusterRelocate.
+	// +optional
+	MatchedClusters int32 `json:"matchedClusters,omitempty"`
+
+	// CompletedClusters is the number of clusters that have been relocated to the destination Hive instance.
+	// +optional
+	CompletedClusters int32 `json:"completedClusters,omitempty"`
+
+	// FailedClusters is the number of clusters whose relocation has failed.
+	// +optional
+	FailedClusters int32 `json:"failedClusters,omitempty"`
+
+	// Clusters is the relocation status of each cluster that has matched the ClusterRelocate.
+	// +optional
+	Clusters []ClusterRelocateClusterStatus `json:"clusters,omitempty"`
+}
+
+// ClusterRelocatePhase is the phase of the relocation of a cluster.
+// +kubebuilder:validation:Enum=Planned;Copying;Completed;Failed;RolledBack
+type ClusterRelocatePhase string
+
+const (
+	// PlannedClusterRelocatePhase means that the cluster would be relocated if the ClusterRelocate were not in
+	// dry-run mode.
+	PlannedClusterRelocatePhase ClusterRelocatePhase = "Planned"
+	// CopyingClusterRelocatePhase means that the resources of the cluster are being copied to the destination Hive
+	// instance.
+	CopyingClusterRelocatePhase ClusterRelocatePhase = "Copying"
+	// CompletedClusterRelocatePhase means that the cluster has been relocated to the destination Hive instance.
+	CompletedClusterRelocatePhase ClusterRelocatePhase = "Completed"
+	// FailedClusterRelocatePhase means that the relocation of the cluster has failed or has been aborted.
+	FailedClusterRelocatePhase ClusterRelocatePhase = "Failed"
+	// RolledBackClusterRelocatePhase means that the relocation of the cluster was cancelled and the resources copied to
+	// the destination Hive instance have been removed.
+	RolledBackClusterRelocatePhase ClusterRelocatePhase = "RolledBack"
+)
+
+// ClusterRelocateClusterStatus is the relocation status of a cluster.
+type ClusterRelocateClusterStatus struct {
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the ClusterDeployment.
+	Name string `json:"name"`
+
+	// Phase is the phase of the relocation of the cluster.
+	Phase ClusterRelocatePhase `json:"phase"`
+
+	// Message describes why the relocation failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Resources are the resources, as "Kind/name", that have been copied to the destination Hive instance. In dry-run
+	// mode, these are the resources that would be copied. The ClusterDeployment is always copied last.
+	// +optional
+	Resources []string `json:"resources,omitempty"`
+
+	// LastTransitionTime is the time when the phase last changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
 
 // +genclient:nonNamespaced
 // +genclient
@@ -33,6 +105,9 @@ type ClusterRelocateStatus struct{}
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Selector",type="string",JSONPath=".spec.clusterDeploymentSelector"
+// +kubebuilder:printcolumn:name="Matched",type="integer",JSONPath=".status.matchedClusters"
+// +kubebuilder:printcolumn:name="Completed",type="integer",JSONPath=".status.completedClusters"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failedClusters"
 // +kubebuilder:resource:path=clusterrelocates
 type ClusterRelocate struct {
 	metav1.TypeMeta   `json:",inline"`
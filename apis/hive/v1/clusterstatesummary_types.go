@@ -0,0 +1,98 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterStateSummarySpec defines the clusters whose ClusterStates are summarized, and how they are grouped.
+type ClusterStateSummarySpec struct {
+	// ClusterDeploymentSelector selects the ClusterDeployments whose ClusterStates are summarized. An empty selector
+	// selects all ClusterDeployments.
+	// +optional
+	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector,omitempty"`
+
+	// GroupByLabels is a list of ClusterDeployment label keys. When set, the clusters are also summarized separately
+	// for each combination of values of these labels. Clusters without a label are grouped under an empty value.
+	// +optional
+	GroupByLabels []string `json:"groupByLabels,omitempty"`
+}
+
+// ClusterStateSummaryStatus is the health of the cluster operators of the summarized clusters.
+type ClusterStateSummaryStatus struct {
+	// LastUpdated is the last time the summary changed.
+	// +optional
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+
+	// Clusters is the number of selected clusters with a ClusterState.
+	Clusters int32 `json:"clusters"`
+
+	// Operators is the number of clusters on which each cluster operator is degraded, unavailable or progressing.
+	// Cluster operators that are healthy on all clusters are omitted.
+	// +optional
+	Operators []ClusterOperatorSummary `json:"operators,omitempty"`
+
+	// Groups summarizes the clusters of each combination of values of the GroupByLabels.
+	// +optional
+	Groups []ClusterStateSummaryGroup `json:"groups,omitempty"`
+}
+
+// ClusterOperatorSummary is the number of clusters on which a cluster operator is unhealthy.
+type ClusterOperatorSummary struct {
+	// Name is the name of the cluster operator.
+	Name string `json:"name"`
+
+	// Degraded is the number of clusters on which the cluster operator is Degraded.
+	Degraded int32 `json:"degraded"`
+
+	// Unavailable is the number of clusters on which the cluster operator is not Available.
+	Unavailable int32 `json:"unavailable"`
+
+	// Progressing is the number of clusters on which the cluster operator is Progressing.
+	Progressing int32 `json:"progressing"`
+}
+
+// ClusterStateSummaryGroup summarizes the clusters with the same values of the GroupByLabels.
+type ClusterStateSummaryGroup struct {
+	// Labels are the values of the GroupByLabels shared by the clusters of the group.
+	Labels map[string]string `json:"labels"`
+
+	// Clusters is the number of clusters in the group.
+	Clusters int32 `json:"clusters"`
+
+	// Operators is the number of clusters of the group on which each cluster operator is degraded, unavailable or
+	// progressing. Cluster operators that are healthy on all clusters of the group are omitted.
+	// +optional
+	Operators []ClusterOperatorSummary `json:"operators,omitempty"`
+}
+
+// +genclient:nonNamespaced
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterStateSummary aggregates the cluster operator conditions reported in the ClusterStates of a set of clusters,
+// giving a fleet-wide view of cluster operator health.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Clusters",type="integer",JSONPath=".status.clusters"
+// +kubebuilder:printcolumn:name="LastUpdated",type="date",JSONPath=".status.lastUpdated"
+// +kubebuilder:resource:path=clusterstatesummaries,scope=Cluster
+type ClusterStateSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterStateSummarySpec   `json:"spec,omitempty"`
+	Status ClusterStateSummaryStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterStateSummaryList contains a list of ClusterStateSummary
+type ClusterStateSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterStateSummary `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterStateSummary{}, &ClusterStateSummaryList{})
+}
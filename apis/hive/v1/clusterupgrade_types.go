@@ -0,0 +1,238 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ClusterUpgradeSpec defines the desired OpenShift version of a set of clusters, and how the clusters are upgraded to
+// it.
+type ClusterUpgradeSpec struct {
+	// ClusterDeploymentSelector selects the ClusterDeployments to upgrade. Only installed clusters are upgraded.
+	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector"`
+
+	// DesiredUpdate is the release to which the clusters are upgraded.
+	DesiredUpdate ClusterUpgradeRelease `json:"desiredUpdate"`
+
+	// MaxConcurrent is the number of clusters, or the percentage of the selected clusters (e.g. "10%"), that may be
+	// upgrading at the same time. Percentages are rounded up. Defaults to 1.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MaxConcurrent *intstr.IntOrString `json:"maxConcurrent,omitempty"`
+
+	// MaxFailures is the number of clusters that may fail to upgrade before no further upgrades are started. The
+	// default of 0 halts the upgrade as soon as any cluster fails.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxFailures int32 `json:"maxFailures,omitempty"`
+
+	// Timeout is how long a cluster may take to upgrade before it is considered to have failed. Defaults to 3h.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// MaintenanceWindow, if set, limits the starting of upgrades to the windows of the schedule. Upgrades that are in
+	// progress when a window ends are not interrupted.
+	// +optional
+	MaintenanceWindow *ClusterUpgradeMaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// ClusterUpgradeRelease identifies an OpenShift release by version, image or both. At least one of Version and Image
+// must be set.
+type ClusterUpgradeRelease struct {
+	// Version is the OpenShift version of the release, such as 4.14.3.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Image is the pull spec of the release image. When set, the clusters are upgraded to this image even if it is not
+	// in their update graph.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// ClusterUpgradeMaintenanceWindow is a weekly schedule of windows during which upgrades may be started.
+type ClusterUpgradeMaintenanceWindow struct {
+	// TimeZone is the IANA time zone name (e.g. "America/New_York") in which the windows are evaluated.
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Windows is the list of windows during which upgrades may be started.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Windows []HibernationRunWindow `json:"windows"`
+}
+
+// ClusterUpgradePhase is the phase of a ClusterUpgrade.
+// +kubebuilder:validation:Enum=Progressing;Waiting;Halted;Complete
+type ClusterUpgradePhase string
+
+const (
+	// ProgressingClusterUpgradePhase means that clusters are being upgraded.
+	ProgressingClusterUpgradePhase ClusterUpgradePhase = "Progressing"
+	// WaitingClusterUpgradePhase means that clusters remain to be upgraded but the maintenance window is closed.
+	WaitingClusterUpgradePhase ClusterUpgradePhase = "Waiting"
+	// HaltedClusterUpgradePhase means that more clusters failed to upgrade than allowed. No further upgrades are
+	// started until the ClusterUpgrade is changed.
+	HaltedClusterUpgradePhase ClusterUpgradePhase = "Halted"
+	// CompleteClusterUpgradePhase means that the ClusterUpgrade selects at least one cluster, and all of the selected
+	// clusters have been upgraded.
+	CompleteClusterUpgradePhase ClusterUpgradePhase = "Complete"
+)
+
+// ClusterUpgradeClusterState is the state of the upgrade of one cluster.
+// +kubebuilder:validation:Enum=Pending;Upgrading;Upgraded;Failed;Conflict
+type ClusterUpgradeClusterState string
+
+const (
+	// PendingClusterUpgradeClusterState means that the upgrade of the cluster has not been started.
+	PendingClusterUpgradeClusterState ClusterUpgradeClusterState = "Pending"
+	// UpgradingClusterUpgradeClusterState means that the cluster is upgrading.
+	UpgradingClusterUpgradeClusterState ClusterUpgradeClusterState = "Upgrading"
+	// UpgradedClusterUpgradeClusterState means that the cluster runs the desired release.
+	UpgradedClusterUpgradeClusterState ClusterUpgradeClusterState = "Upgraded"
+	// FailedClusterUpgradeClusterState means that the cluster did not finish upgrading within the timeout.
+	FailedClusterUpgradeClusterState ClusterUpgradeClusterState = "Failed"
+	// ConflictClusterUpgradeClusterState means that the cluster is also selected by an older ClusterUpgrade which is
+	// not complete. The cluster is not upgraded until that ClusterUpgrade completes or no longer selects it.
+	ConflictClusterUpgradeClusterState ClusterUpgradeClusterState = "Conflict"
+)
+
+// ClusterUpgradeStatus defines the observed state of a ClusterUpgrade.
+type ClusterUpgradeStatus struct {
+	// ObservedGeneration is the generation of the ClusterUpgrade that the status reflects.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is the phase of the upgrade.
+	// +optional
+	Phase ClusterUpgradePhase `json:"phase,omitempty"`
+
+	// TargetClusters is the number of installed clusters selected by the ClusterUpgrade, including those in
+	// conflict with another ClusterUpgrade.
+	TargetClusters int32 `json:"targetClusters"`
+
+	// UpgradingClusters is the number of target clusters that are upgrading.
+	UpgradingClusters int32 `json:"upgradingClusters"`
+
+	// UpgradedClusters is the number of target clusters that run the desired release.
+	UpgradedClusters int32 `json:"upgradedClusters"`
+
+	// FailedClusters is the number of target clusters that failed to upgrade.
+	FailedClusters int32 `json:"failedClusters"`
+
+	// Clusters is the state of the upgrade of each target cluster.
+	// +optional
+	Clusters []ClusterUpgradeClusterStatus `json:"clusters,omitempty"`
+
+	// Conditions includes more detailed status for the ClusterUpgrade.
+	// +optional
+	Conditions []ClusterUpgradeCondition `json:"conditions,omitempty"`
+}
+
+// ClusterUpgradeClusterStatus is the state of the upgrade of one cluster.
+type ClusterUpgradeClusterStatus struct {
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the ClusterDeployment.
+	Name string `json:"name"`
+
+	// State is the state of the upgrade of the cluster.
+	State ClusterUpgradeClusterState `json:"state"`
+
+	// Version is the version the cluster was last observed to be running or upgrading to.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// StartedTime is when the upgrade of the cluster was started.
+	// +optional
+	StartedTime *metav1.Time `json:"startedTime,omitempty"`
+
+	// CompletedTime is when the cluster was observed to have finished upgrading.
+	// +optional
+	CompletedTime *metav1.Time `json:"completedTime,omitempty"`
+
+	// Message is a human-readable description of the state of the upgrade of the cluster.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterUpgradeCondition contains details for the current condition of a ClusterUpgrade.
+type ClusterUpgradeCondition struct {
+	// Type is the type of the condition.
+	Type ClusterUpgradeConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterUpgradeConditionType is a valid value for ClusterUpgradeCondition.Type.
+type ClusterUpgradeConditionType string
+
+// ConditionType satisfies the conditions.Condition interface
+func (c ClusterUpgradeCondition) ConditionType() ConditionType {
+	return c.Type
+}
+
+// String satisfies the conditions.ConditionType interface
+func (t ClusterUpgradeConditionType) String() string {
+	return string(t)
+}
+
+const (
+	// ClusterUpgradeProgressingCondition is true while target clusters remain to be upgraded.
+	ClusterUpgradeProgressingCondition ClusterUpgradeConditionType = "Progressing"
+	// ClusterUpgradeFailedCondition is true when one or more target clusters failed to upgrade.
+	ClusterUpgradeFailedCondition ClusterUpgradeConditionType = "Failed"
+)
+
+// +genclient:nonNamespaced
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterUpgrade upgrades the OpenShift version of the installed clusters selected by it. Hive sets the desired update
+// of the ClusterVersion of each cluster, at most MaxConcurrent at a time and only within the maintenance window, and
+// monitors the clusters until they run the desired release.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.desiredUpdate.version"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Targets",type="string",JSONPath=".status.targetClusters"
+// +kubebuilder:printcolumn:name="Upgraded",type="string",JSONPath=".status.upgradedClusters"
+// +kubebuilder:printcolumn:name="Failed",type="string",JSONPath=".status.failedClusters"
+// +kubebuilder:resource:path=clusterupgrades,scope=Cluster
+type ClusterUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterUpgradeSpec   `json:"spec,omitempty"`
+	Status ClusterUpgradeStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterUpgradeList contains a list of ClusterUpgrade
+type ClusterUpgradeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterUpgrade `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterUpgrade{}, &ClusterUpgradeList{})
+}
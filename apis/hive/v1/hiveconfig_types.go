@@ -147,6 +147,11 @@ type HiveConfigSpec struct {
 	// clusters to ArgoCD, and remove them when they are deprovisioned.
 	ArgoCD ArgoCDConfig `json:"argoCDConfig,omitempty"`
 
+	// CloudResources contains settings for the inventory of the cloud resources of clusters, and the sweep of the
+	// resources of clusters Hive no longer knows about.
+	// +optional
+	CloudResources CloudResourcesConfig `json:"cloudResources,omitempty"`
+
 	FeatureGates *FeatureGateSelection `json:"featureGates,omitempty"`
 
 	// ExportMetrics has been disabled and has no effect. If upgrading from a version where it was
@@ -373,6 +378,87 @@ type ArgoCDConfig struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// CloudResourcesConfig contains settings for the inventory of the cloud resources of clusters, and the sweep of the
+// resources of clusters Hive no longer knows about.
+type CloudResourcesConfig struct {
+	// Inventory specifies configuration for the periodic inventory of the cloud resources of installed clusters,
+	// which is recorded in the status of their ClusterDeployments.
+	// +optional
+	Inventory CloudResourceInventoryConfig `json:"inventory,omitempty"`
+
+	// Sweeper specifies configuration for the periodic sweep of cloud accounts for resources tagged for infra IDs
+	// that no ClusterDeployment, ClusterProvision or ClusterDeprovision owns.
+	// +optional
+	Sweeper CloudResourceSweeperConfig `json:"sweeper,omitempty"`
+}
+
+// CloudResourceInventoryConfig contains settings for the periodic inventory of the cloud resources of clusters.
+type CloudResourceInventoryConfig struct {
+	// Enabled dictates if the cloud resources of clusters are inventoried periodically.
+	// If not specified, the default is disabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Interval is a string duration indicating how often the cloud resources of each cluster are listed.
+	// The default interval is six hours.
+	// +optional
+	Interval string `json:"interval,omitempty"`
+}
+
+// CloudResourceSweeperConfig contains settings for the periodic sweep of cloud accounts for the resources of clusters
+// Hive no longer knows about.
+type CloudResourceSweeperConfig struct {
+	// Enabled dictates if the configured accounts are swept periodically.
+	// If not specified, the default is disabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Interval is a string duration indicating how often the accounts are swept.
+	// The default interval is 24 hours.
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// GracePeriod is a string duration indicating how long the resources of an unowned infra ID must have existed
+	// before they are deprovisioned. The age of an infra ID is that of its oldest instance. The resources of infra IDs
+	// without instances, whose age is unknown, are never deprovisioned, only reported.
+	// The default grace period is 24 hours.
+	// +optional
+	GracePeriod string `json:"gracePeriod,omitempty"`
+
+	// Deprovision dictates if the resources of unowned infra IDs older than the grace period, and matching Include,
+	// are deprovisioned. Include is required when Deprovision is set.
+	// If not specified, the sweeper runs in dry-run mode, and unowned infra IDs are only logged and reported in
+	// metrics.
+	// +optional
+	Deprovision bool `json:"deprovision,omitempty"`
+
+	// Include is a list of infra IDs, or shell patterns matching them (e.g. "hive-*"), whose resources may be
+	// deprovisioned. The resources of unowned infra IDs matching no entry are only reported, so that the sweeper
+	// only deprovisions the resources of the clusters the accounts are known to hold.
+	// +optional
+	Include []string `json:"include,omitempty"`
+
+	// Allowlist is a list of infra IDs, or shell patterns matching them (e.g. "ci-*"), whose resources are never
+	// deprovisioned, such as those of clusters sharing the accounts that are not managed by Hive.
+	// +optional
+	Allowlist []string `json:"allowlist,omitempty"`
+
+	// AWS is the list of AWS accounts and regions to sweep.
+	// +optional
+	AWS []CloudResourceSweeperAWSAccount `json:"aws,omitempty"`
+}
+
+// CloudResourceSweeperAWSAccount is an AWS account swept for the resources of clusters Hive no longer knows about.
+type CloudResourceSweeperAWSAccount struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// AWS to list, and deprovision, the resources of the account.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Regions are the regions of the account to sweep.
+	// +kubebuilder:validation:MinItems=1
+	Regions []string `json:"regions"`
+}
+
 // BackupConfig contains settings for the Velero backup integration.
 type BackupConfig struct {
 	// Velero specifies configuration for the Velero backup integration.
@@ -406,13 +492,77 @@ type FailedProvisionConfig struct {
 	// TODO: Figure out how to mark SkipGatherLogs as deprecated (more than just a comment)
 
 	// DEPRECATED: This flag is no longer respected and will be removed in the future.
-	SkipGatherLogs bool                      `json:"skipGatherLogs,omitempty"`
-	AWS            *FailedProvisionAWSConfig `json:"aws,omitempty"`
+	SkipGatherLogs bool `json:"skipGatherLogs,omitempty"`
+
+	// The following configure where the logs of failed installations are uploaded. Only one of them may be set.
+
+	// AWS configures uploading logs to AWS S3, or to an S3 compatible provider such as MinIO.
+	AWS *FailedProvisionAWSConfig `json:"aws,omitempty"`
+	// Azure configures uploading logs to Azure Blob Storage.
+	// +optional
+	Azure *FailedProvisionAzureConfig `json:"azure,omitempty"`
+	// GCP configures uploading logs to Google Cloud Storage.
+	// +optional
+	GCP *FailedProvisionGCPConfig `json:"gcp,omitempty"`
+	// HTTP configures uploading logs with HTTP PUT requests.
+	// +optional
+	HTTP *FailedProvisionHTTPConfig `json:"http,omitempty"`
+	// PersistentVolumeClaim configures saving logs to a PersistentVolumeClaim.
+	// +optional
+	PersistentVolumeClaim *FailedProvisionPVCConfig `json:"persistentVolumeClaim,omitempty"`
+
 	// RetryReasons is a list of installFailingReason strings from the [additional-]install-log-regexes ConfigMaps.
 	// If specified, Hive will only retry a failed installation if it results in one of the listed reasons. If
 	// omitted (not the same thing as empty!), Hive will retry regardless of the failure reason. (The total number
 	// of install attempts is still constrained by ClusterDeployment.Spec.InstallAttemptsLimit.)
 	RetryReasons *[]string `json:"retryReasons,omitempty"`
+	// RetryPolicies configure how Hive retries installations that failed for particular reasons. The first policy
+	// listing the installFailingReason of a failed installation applies to it, and the installation is retried even
+	// if RetryReasons does not list that reason. Installations that failed for other reasons are retried as before.
+	// +optional
+	RetryPolicies []ProvisionRetryPolicy `json:"retryPolicies,omitempty"`
+}
+
+// ProvisionRetryPolicy configures how Hive retries installations that failed for particular reasons.
+type ProvisionRetryPolicy struct {
+	// Reasons is a list of installFailingReason strings from the [additional-]install-log-regexes ConfigMaps.
+	Reasons []string `json:"reasons"`
+
+	// AttemptsLimit is the maximum number of install attempts of a cluster that may fail for one of the Reasons.
+	// Once reached, Hive stops retrying. The total number of install attempts is still constrained by
+	// ClusterDeployment.Spec.InstallAttemptsLimit.
+	// +optional
+	AttemptsLimit *int32 `json:"attemptsLimit,omitempty"`
+
+	// Backoff is how long Hive waits before retrying after the first install attempt that failed for one of the
+	// Reasons. The wait doubles for each subsequent such failure, up to MaxBackoff. If unset, Hive waits as it does
+	// for other failures: one minute, doubling for each install attempt, up to 24 hours.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+
+	// MaxBackoff is the longest Hive waits before retrying. Defaults to 24 hours.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// Fallbacks are changes Hive makes to the cluster before retrying, typically to work around quota or capacity
+	// failures. After the Nth install attempt that failed for one of the Reasons, the Nth fallback is applied, or the
+	// last one once they are exhausted. Fallbacks are only supported on AWS, Azure and GCP.
+	// +optional
+	Fallbacks []ProvisionRetryFallback `json:"fallbacks,omitempty"`
+}
+
+// ProvisionRetryFallback is a change made to a cluster before retrying its installation.
+type ProvisionRetryFallback struct {
+	// Region replaces the region of the cluster. The fields of the install config and of the MachinePools of the
+	// cluster tied to the previous region, such as availability zones, subnets and AMI IDs, are cleared, so that the
+	// installer picks those of the new region.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// InstanceType replaces the instance type of the control plane and compute machine pools of the install config,
+	// and of the MachinePools of the cluster.
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
 }
 
 // ManageDNSConfig contains the domain being managed, and the cloud-specific
@@ -459,10 +609,74 @@ type FailedProvisionAWSConfig struct {
 	// ServiceEndpoint is the url to connect to an S3 compatible provider.
 	ServiceEndpoint string `json:"serviceEndpoint,omitempty"`
 
+	// ForcePathStyle addresses the Bucket in the path of request URLs rather than in their host name, as S3
+	// compatible providers such as MinIO usually require.
+	// +optional
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+
 	// Bucket is the S3 bucket to store the logs in.
 	Bucket string `json:"bucket,omitempty"`
 }
 
+// FailedProvisionAzureConfig contains Azure-specific info to upload log files.
+type FailedProvisionAzureConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// Azure Storage. The service principal will need permission to write blobs to the Container, for example
+	// through the Storage Blob Data Contributor role.
+	// Secret should have a key named osServicePrincipal.json containing the clientId, clientSecret and tenantId
+	// of the service principal.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// StorageAccount is the name of the storage account to store the logs in.
+	StorageAccount string `json:"storageAccount"`
+
+	// Container is the blob container to store the logs in.
+	Container string `json:"container"`
+
+	// CloudName is the name of the Azure cloud environment of the StorageAccount.
+	// +kubebuilder:default=AzurePublicCloud
+	// +optional
+	CloudName azure.CloudEnvironment `json:"cloudName,omitempty"`
+
+	// ServiceEndpoint is the url of the blob service of the StorageAccount. It defaults to the endpoint of the
+	// StorageAccount in the cloud environment.
+	// +optional
+	ServiceEndpoint string `json:"serviceEndpoint,omitempty"`
+}
+
+// FailedProvisionGCPConfig contains GCP-specific info to upload log files.
+type FailedProvisionGCPConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// Google Cloud Storage. The service account will need permission to create objects in the Bucket.
+	// Secret should have a key named osServiceAccount.json containing the key of the service account.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Bucket is the Cloud Storage bucket to store the logs in.
+	Bucket string `json:"bucket"`
+}
+
+// FailedProvisionHTTPConfig contains info to upload log files with HTTP PUT requests.
+type FailedProvisionHTTPConfig struct {
+	// URL is the base url to upload the logs to. Each log file is uploaded with a PUT request to the URL followed by
+	// the path the log would have in a bucket.
+	URL string `json:"url"`
+
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate the
+	// requests. Secret should have either a key named token, sent as a bearer token, or keys named username and
+	// password, sent with basic authentication. It may also have a key named ca.crt with the PEM encoded
+	// certificates of the certificate authorities to trust.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// FailedProvisionPVCConfig contains info to save log files to a PersistentVolumeClaim.
+type FailedProvisionPVCConfig struct {
+	// ClaimName is the name of the PersistentVolumeClaim to save the logs to. Hive does not create the claim, and
+	// does not save the logs of ClusterDeployments in namespaces where it does not exist. It may be bound to a
+	// volume shared by all of them.
+	ClaimName string `json:"claimName"`
+}
+
 // ManageDNSAWSConfig contains AWS-specific info to manage a given domain.
 type ManageDNSAWSConfig struct {
 	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
@@ -543,7 +757,7 @@ type ControllerConfig struct {
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;machinepool;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync
+// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;machinepool;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;clusterquota;hibernation;clusterclaim;clusterimageset;clusterstatesummary;clusterupgrade;clustercost;cloudinventory;cloudsweeper;metrics;clustersync;selectorsyncsetrollout
 type ControllerName string
 
 func (controllerName ControllerName) String() string {
@@ -566,28 +780,36 @@ func (c ControllerNames) Contains(controllerName ControllerName) bool {
 
 // WARNING: All the controller names below should also be added to the kubebuilder validation of the type ControllerName
 const (
-	ClusterClaimControllerName         ControllerName = "clusterclaim"
-	ClusterDeploymentControllerName    ControllerName = "clusterDeployment"
-	ClusterDeprovisionControllerName   ControllerName = "clusterDeprovision"
-	ClusterpoolControllerName          ControllerName = "clusterpool"
-	ClusterpoolNamespaceControllerName ControllerName = "clusterpoolnamespace"
-	ClusterProvisionControllerName     ControllerName = "clusterProvision"
-	ClusterRelocateControllerName      ControllerName = "clusterRelocate"
-	ClusterStateControllerName         ControllerName = "clusterState"
-	ClusterVersionControllerName       ControllerName = "clusterversion"
-	ControlPlaneCertsControllerName    ControllerName = "controlPlaneCerts"
-	DNSEndpointControllerName          ControllerName = "dnsendpoint"
-	DNSZoneControllerName              ControllerName = "dnszone"
-	FakeClusterInstallControllerName   ControllerName = "fakeclusterinstall"
-	HibernationControllerName          ControllerName = "hibernation"
-	RemoteIngressControllerName        ControllerName = "remoteingress"
-	SyncIdentityProviderControllerName ControllerName = "syncidentityprovider"
-	UnreachableControllerName          ControllerName = "unreachable"
-	VeleroBackupControllerName         ControllerName = "velerobackup"
-	MetricsControllerName              ControllerName = "metrics"
-	ClustersyncControllerName          ControllerName = "clustersync"
-	AWSPrivateLinkControllerName       ControllerName = "awsprivatelink"
-	HiveControllerName                 ControllerName = "hive"
+	ClusterClaimControllerName           ControllerName = "clusterclaim"
+	CloudInventoryControllerName         ControllerName = "cloudinventory"
+	CloudSweeperControllerName           ControllerName = "cloudsweeper"
+	ClusterCostControllerName            ControllerName = "clustercost"
+	ClusterDeploymentControllerName      ControllerName = "clusterDeployment"
+	ClusterImageSetControllerName        ControllerName = "clusterimageset"
+	ClusterDeprovisionControllerName     ControllerName = "clusterDeprovision"
+	ClusterpoolControllerName            ControllerName = "clusterpool"
+	ClusterpoolNamespaceControllerName   ControllerName = "clusterpoolnamespace"
+	ClusterQuotaControllerName           ControllerName = "clusterquota"
+	ClusterProvisionControllerName       ControllerName = "clusterProvision"
+	ClusterRelocateControllerName        ControllerName = "clusterRelocate"
+	ClusterStateControllerName           ControllerName = "clusterState"
+	ClusterStateSummaryControllerName    ControllerName = "clusterstatesummary"
+	ClusterUpgradeControllerName         ControllerName = "clusterupgrade"
+	ClusterVersionControllerName         ControllerName = "clusterversion"
+	ControlPlaneCertsControllerName      ControllerName = "controlPlaneCerts"
+	DNSEndpointControllerName            ControllerName = "dnsendpoint"
+	DNSZoneControllerName                ControllerName = "dnszone"
+	FakeClusterInstallControllerName     ControllerName = "fakeclusterinstall"
+	HibernationControllerName            ControllerName = "hibernation"
+	RemoteIngressControllerName          ControllerName = "remoteingress"
+	SyncIdentityProviderControllerName   ControllerName = "syncidentityprovider"
+	UnreachableControllerName            ControllerName = "unreachable"
+	VeleroBackupControllerName           ControllerName = "velerobackup"
+	MetricsControllerName                ControllerName = "metrics"
+	ClustersyncControllerName            ControllerName = "clustersync"
+	SelectorSyncSetRolloutControllerName ControllerName = "selectorsyncsetrollout"
+	AWSPrivateLinkControllerName         ControllerName = "awsprivatelink"
+	HiveControllerName                   ControllerName = "hive"
 
 	// DeprecatedRemoteMachinesetControllerName was deprecated but can be used to disable the
 	// MachinePool controller which supercedes it for compatability.
@@ -4,6 +4,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // SyncSetResourceApplyMode is a string representing the mode with which to
@@ -44,6 +45,27 @@ const (
 	CreateOrUpdateSyncSetApplyBehavior SyncSetApplyBehavior = "CreateOrUpdate"
 )
 
+// SyncSetDriftDetection is a string representing how to handle changes made
+// directly in the target cluster to resources that are managed by a syncset.
+// +kubebuilder:validation:Enum="";None;Correct;Audit
+type SyncSetDriftDetection string
+
+const (
+	// NoneSyncSetDriftDetection is the default. Resources are reapplied
+	// periodically without checking whether they have drifted.
+	NoneSyncSetDriftDetection SyncSetDriftDetection = "None"
+
+	// CorrectSyncSetDriftDetection results in resources getting compared with
+	// their live state in the target cluster before being reapplied. Any drift
+	// found is reported and then corrected by the reapply.
+	CorrectSyncSetDriftDetection SyncSetDriftDetection = "Correct"
+
+	// AuditSyncSetDriftDetection results in resources getting compared with
+	// their live state in the target cluster instead of being reapplied. Any
+	// drift found is reported but left in place.
+	AuditSyncSetDriftDetection SyncSetDriftDetection = "Audit"
+)
+
 // SyncSetPatchApplyMode is a string representing the mode with which to apply
 // SyncSet Patches.
 type SyncSetPatchApplyMode string
@@ -245,13 +267,76 @@ type SyncSetCommonSpec struct {
 	ApplyBehavior SyncSetApplyBehavior `json:"applyBehavior,omitempty"`
 
 	// EnableResourceTemplates, if True, causes hive to honor golang text/templates in Resources.
-	// While the standard syntax is supported, it won't do you a whole lot of good as the parser
-	// does not pass a data object (i.e. there is no "dot" for you to use). This currently exists
-	// to expose a single function: {{ fromCDLabel "some.label/key" }} will
-	// be substituted with the string value of ClusterDeployment.Labels["some.label/key"]. The
-	// empty string is interpolated if there are no labels, or if the indicated key does not exist.
+	// The data object ("dot") is a read-only view of the ClusterDeployment with the fields Name,
+	// Namespace, Labels, Annotations, BaseDomain, Platform, Region, InfraID, ClusterID and APIURL,
+	// e.g. {{ .Name }}. The following functions are also available:
+	// {{ fromCDLabel "some.label/key" }} and {{ fromCDAnnotation "some.annotation/key" }} will be
+	// substituted with the string value of the label/annotation on the ClusterDeployment, or the
+	// empty string if it does not exist.
+	// {{ fromSecret "name" "key" }} and {{ fromConfigMap "name" "key" }} will be substituted with the
+	// value of the key in the Secret/ConfigMap of that name in the ClusterDeployment's namespace.
+	// It is an error if the Secret/ConfigMap or the key does not exist.
+	// The string functions lower, upper, trim, trimPrefix, trimSuffix, replace, contains,
+	// hasPrefix, hasSuffix, split, splitList, join, quote, b64enc, b64dec, trunc and default behave
+	// like their sprig equivalents, except that b64dec fails on invalid input rather than returning
+	// the error message.
 	// Note that this only works in values (not e.g. map keys) that are of type string.
 	EnableResourceTemplates bool `json:"enableResourceTemplates,omitempty"`
+
+	// DryRun, if True, causes hive to perform a dry run of the apply of the Resources against
+	// each target cluster instead of applying them. Nothing is created, modified or deleted in the
+	// target clusters; a summary of the changes that would be made is recorded in the status of the
+	// ClusterSync for each cluster. Patches and SecretMappings are not applied in dry-run mode.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DriftDetection indicates whether the Resources are compared with their live state in the target cluster when
+	// it is time to reapply them. The default value of "None" disables drift detection.
+	// A value of "Correct" indicates that drifted resources are reported and then reapplied.
+	// A value of "Audit" indicates that drifted resources are reported but not reapplied. Resources are still applied
+	// when the syncset changes.
+	// Drifted resources are recorded in the status of the ClusterSync for each cluster. Patches and SecretMappings
+	// are not checked for drift.
+	// +optional
+	DriftDetection SyncSetDriftDetection `json:"driftDetection,omitempty"`
+
+	// HealthChecks are assertions about the state of the target cluster that must hold once the Resources have been
+	// applied. When a new generation of the syncset has been applied, hive evaluates the health checks until they
+	// all pass or the HealthCheckTimeout expires. If they do not pass in time, the Resources of the last generation
+	// of the syncset that passed its health checks are reapplied to the cluster, and the current generation is not
+	// applied again until the syncset is changed.
+	// +optional
+	HealthChecks []SyncSetHealthCheck `json:"healthChecks,omitempty"`
+
+	// HealthCheckTimeout is how long the HealthChecks may take to pass after a new generation of the syncset has been
+	// applied. Defaults to 10 minutes.
+	// +optional
+	HealthCheckTimeout *metav1.Duration `json:"healthCheckTimeout,omitempty"`
+}
+
+// SyncSetHealthCheck asserts that an object in the target cluster has a status condition with the expected status,
+// e.g. that a Deployment is Available or that a ClusterOperator is not Degraded.
+type SyncSetHealthCheck struct {
+	// APIVersion is the Group and Version of the object to check.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the Kind of the object to check.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the object to check. Leave empty for cluster-scoped objects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the object to check.
+	Name string `json:"name"`
+
+	// ConditionType is the type of the condition in status.conditions of the object, e.g. Available or Degraded.
+	ConditionType string `json:"conditionType"`
+
+	// ConditionStatus is the status that the condition is expected to have. Defaults to True.
+	// +kubebuilder:validation:Enum="True";"False";"Unknown"
+	// +optional
+	ConditionStatus corev1.ConditionStatus `json:"conditionStatus,omitempty"`
 }
 
 // SelectorSyncSetSpec defines the SyncSetCommonSpec resources and patches to sync along
@@ -264,6 +349,32 @@ type SelectorSyncSetSpec struct {
 	// applies to in any namespace.
 	// +optional
 	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector,omitempty"`
+
+	// RolloutStrategy, if set, causes changes to the SelectorSyncSet to be applied to the matching clusters in
+	// waves rather than to all of them at once. Clusters that have not yet been reached by the rollout keep the
+	// resources of the previous generation of the SelectorSyncSet.
+	// +optional
+	RolloutStrategy *SelectorSyncSetRolloutStrategy `json:"rolloutStrategy,omitempty"`
+}
+
+// SelectorSyncSetRolloutStrategy describes how changes to a SelectorSyncSet are rolled out to the matching clusters.
+type SelectorSyncSetRolloutStrategy struct {
+	// WaveSize is the number of clusters, or the percentage of the matching clusters (e.g. "10%"), to which a change
+	// is rolled out in each wave. Percentages are rounded up. Each wave includes at least one cluster.
+	// +kubebuilder:validation:XIntOrString
+	WaveSize intstr.IntOrString `json:"waveSize"`
+
+	// PauseBetweenWaves is how long to wait after every cluster in a wave has applied the change before starting the
+	// next wave.
+	// +optional
+	PauseBetweenWaves *metav1.Duration `json:"pauseBetweenWaves,omitempty"`
+
+	// MaxFailuresPerWave is the number of clusters in a wave that may fail to apply the change before the rollout is
+	// halted. The default of 0 halts the rollout as soon as any cluster fails. A halted rollout is restarted by
+	// changing the SelectorSyncSet.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxFailuresPerWave int32 `json:"maxFailuresPerWave,omitempty"`
 }
 
 // SyncSetSpec defines the SyncSetCommonSpec resources and patches to sync along with
@@ -284,6 +395,70 @@ type SyncSetStatus struct {
 
 // SelectorSyncSetStatus defines the observed state of a SelectorSyncSet
 type SelectorSyncSetStatus struct {
+	// Rollout is the progress of the rollout of the current generation of the SelectorSyncSet. This is only set when
+	// the SelectorSyncSet has a RolloutStrategy.
+	// +optional
+	Rollout *SelectorSyncSetRolloutStatus `json:"rollout,omitempty"`
+}
+
+// SelectorSyncSetRolloutPhase is the phase of the rollout of a SelectorSyncSet.
+// +kubebuilder:validation:Enum=Progressing;Waiting;Halted;Complete
+type SelectorSyncSetRolloutPhase string
+
+const (
+	// ProgressingSelectorSyncSetRolloutPhase means that the clusters in the current wave are applying the change.
+	ProgressingSelectorSyncSetRolloutPhase SelectorSyncSetRolloutPhase = "Progressing"
+	// WaitingSelectorSyncSetRolloutPhase means that the current wave has completed and the rollout is pausing before
+	// starting the next wave.
+	WaitingSelectorSyncSetRolloutPhase SelectorSyncSetRolloutPhase = "Waiting"
+	// HaltedSelectorSyncSetRolloutPhase means that too many clusters in the current wave failed to apply the change,
+	// or that the ClusterDeploymentSelector is invalid. No further waves are started until the SelectorSyncSet is
+	// changed.
+	HaltedSelectorSyncSetRolloutPhase SelectorSyncSetRolloutPhase = "Halted"
+	// CompleteSelectorSyncSetRolloutPhase means that the change has been released to all matching clusters.
+	CompleteSelectorSyncSetRolloutPhase SelectorSyncSetRolloutPhase = "Complete"
+)
+
+// SelectorSyncSetRolloutStatus is the progress of the rollout of a SelectorSyncSet.
+type SelectorSyncSetRolloutStatus struct {
+	// ObservedGeneration is the generation of the SelectorSyncSet that is being rolled out.
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// Phase is the phase of the rollout.
+	Phase SelectorSyncSetRolloutPhase `json:"phase"`
+
+	// Wave is the current wave of the rollout, starting at 1.
+	Wave int32 `json:"wave"`
+
+	// TotalWaves is the number of waves needed to reach all of the target clusters.
+	TotalWaves int32 `json:"totalWaves"`
+
+	// TargetClusters is the number of installed and reachable clusters matching the SelectorSyncSet.
+	TargetClusters int32 `json:"targetClusters"`
+
+	// ReleasedClusters is the number of target clusters in the current and previous waves. These clusters are
+	// permitted to apply the current generation of the SelectorSyncSet.
+	ReleasedClusters int32 `json:"releasedClusters"`
+
+	// UpdatedClusters is the number of target clusters that have successfully applied the current generation of the
+	// SelectorSyncSet, and passed its health checks if it has any.
+	UpdatedClusters int32 `json:"updatedClusters"`
+
+	// FailedClusters is the number of target clusters in the current wave that have failed to apply the current
+	// generation of the SelectorSyncSet, or whose health checks did not pass in time.
+	FailedClusters int32 `json:"failedClusters"`
+
+	// ReleasedKeyLimit is the rollout key of the last cluster in the current wave. Clusters whose rollout key is less
+	// than or equal to this value are permitted to apply the current generation of the SelectorSyncSet.
+	ReleasedKeyLimit int64 `json:"releasedKeyLimit"`
+
+	// LastTransitionTime is the time when the rollout last changed phase or wave.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Message is a human-readable description of the state of the rollout.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient
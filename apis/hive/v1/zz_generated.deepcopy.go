@@ -23,6 +23,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -490,6 +491,138 @@ func (in *CheckpointStatus) DeepCopy() *CheckpointStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourceCount) DeepCopyInto(out *CloudResourceCount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourceCount.
+func (in *CloudResourceCount) DeepCopy() *CloudResourceCount {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourceCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourceInventoryConfig) DeepCopyInto(out *CloudResourceInventoryConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourceInventoryConfig.
+func (in *CloudResourceInventoryConfig) DeepCopy() *CloudResourceInventoryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourceInventoryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourceSweeperAWSAccount) DeepCopyInto(out *CloudResourceSweeperAWSAccount) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourceSweeperAWSAccount.
+func (in *CloudResourceSweeperAWSAccount) DeepCopy() *CloudResourceSweeperAWSAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourceSweeperAWSAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourceSweeperConfig) DeepCopyInto(out *CloudResourceSweeperConfig) {
+	*out = *in
+	if in.Allowlist != nil {
+		in, out := &in.Allowlist, &out.Allowlist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = make([]CloudResourceSweeperAWSAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourceSweeperConfig.
+func (in *CloudResourceSweeperConfig) DeepCopy() *CloudResourceSweeperConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourceSweeperConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourcesConfig) DeepCopyInto(out *CloudResourcesConfig) {
+	*out = *in
+	out.Inventory = in.Inventory
+	in.Sweeper.DeepCopyInto(&out.Sweeper)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourcesConfig.
+func (in *CloudResourcesConfig) DeepCopy() *CloudResourcesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourcesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourcesStatus) DeepCopyInto(out *CloudResourcesStatus) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]CloudResourceCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Orphans != nil {
+		in, out := &in.Orphans, &out.Orphans
+		*out = make([]OrphanedCloudResource, len(*in))
+		copy(*out, *in)
+	}
+	in.LastScanTime.DeepCopyInto(&out.LastScanTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourcesStatus.
+func (in *CloudResourcesStatus) DeepCopy() *CloudResourcesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourcesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClaim) DeepCopyInto(out *ClusterClaim) {
 	*out = *in
@@ -536,6 +669,54 @@ func (in *ClusterClaimCondition) DeepCopy() *ClusterClaimCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimConstraints) DeepCopyInto(out *ClusterClaimConstraints) {
+	*out = *in
+	if in.PoolSelector != nil {
+		in, out := &in.PoolSelector, &out.PoolSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterDeploymentSelector != nil {
+		in, out := &in.ClusterDeploymentSelector, &out.ClusterDeploymentSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterImageSetSelector != nil {
+		in, out := &in.ClusterImageSetSelector, &out.ClusterImageSetSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimConstraints.
+func (in *ClusterClaimConstraints) DeepCopy() *ClusterClaimConstraints {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimConstraints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimExtension) DeepCopyInto(out *ClusterClaimExtension) {
+	*out = *in
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimExtension.
+func (in *ClusterClaimExtension) DeepCopy() *ClusterClaimExtension {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimExtension)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClaimList) DeepCopyInto(out *ClusterClaimList) {
 	*out = *in
@@ -569,9 +750,47 @@ func (in *ClusterClaimList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimPreemption) DeepCopyInto(out *ClusterClaimPreemption) {
+	*out = *in
+	out.GracePeriod = in.GracePeriod
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimPreemption.
+func (in *ClusterClaimPreemption) DeepCopy() *ClusterClaimPreemption {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimPreemption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimRelease) DeepCopyInto(out *ClusterClaimRelease) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimRelease.
+func (in *ClusterClaimRelease) DeepCopy() *ClusterClaimRelease {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimRelease)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClaimSpec) DeepCopyInto(out *ClusterClaimSpec) {
 	*out = *in
+	if in.Constraints != nil {
+		in, out := &in.Constraints, &out.Constraints
+		*out = new(ClusterClaimConstraints)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Subjects != nil {
 		in, out := &in.Subjects, &out.Subjects
 		*out = make([]rbacv1.Subject, len(*in))
@@ -582,6 +801,16 @@ func (in *ClusterClaimSpec) DeepCopyInto(out *ClusterClaimSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]ClusterClaimExtension, len(*in))
+		copy(*out, *in)
+	}
+	if in.Release != nil {
+		in, out := &in.Release, &out.Release
+		*out = new(ClusterClaimRelease)
+		**out = **in
+	}
 	return
 }
 
@@ -610,6 +839,11 @@ func (in *ClusterClaimStatus) DeepCopyInto(out *ClusterClaimStatus) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.GrantedExtension != nil {
+		in, out := &in.GrantedExtension, &out.GrantedExtension
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -623,6 +857,23 @@ func (in *ClusterClaimStatus) DeepCopy() *ClusterClaimStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCostStatus) DeepCopyInto(out *ClusterCostStatus) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCostStatus.
+func (in *ClusterCostStatus) DeepCopy() *ClusterCostStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCostStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterDeployment) DeepCopyInto(out *ClusterDeployment) {
 	*out = *in
@@ -736,7 +987,30 @@ func (in *ClusterDeploymentCustomizationSpec) DeepCopyInto(out *ClusterDeploymen
 	if in.InstallConfigPatches != nil {
 		in, out := &in.InstallConfigPatches, &out.InstallConfigPatches
 		*out = make([]PatchEntity, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterDeploymentPatches != nil {
+		in, out := &in.ClusterDeploymentPatches, &out.ClusterDeploymentPatches
+		*out = make([]PatchEntity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MachinePoolPatches != nil {
+		in, out := &in.MachinePoolPatches, &out.MachinePoolPatches
+		*out = make([]PatchEntity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManifestPatches != nil {
+		in, out := &in.ManifestPatches, &out.ManifestPatches
+		*out = make([]PatchEntity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -864,6 +1138,11 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.HibernationSchedule != nil {
+		in, out := &in.HibernationSchedule, &out.HibernationSchedule
+		*out = new(HibernationSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InstallAttemptsLimit != nil {
 		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
 		*out = new(int32)
@@ -925,6 +1204,11 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 		in, out := &in.InstalledTimestamp, &out.InstalledTimestamp
 		*out = (*in).DeepCopy()
 	}
+	if in.HibernationSchedule != nil {
+		in, out := &in.HibernationSchedule, &out.HibernationSchedule
+		*out = new(HibernationScheduleStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ProvisionRef != nil {
 		in, out := &in.ProvisionRef, &out.ProvisionRef
 		*out = new(corev1.LocalObjectReference)
@@ -935,6 +1219,21 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 		*out = new(PlatformStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ProvisionRetries != nil {
+		in, out := &in.ProvisionRetries, &out.ProvisionRetries
+		*out = new(ProvisionRetriesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cost != nil {
+		in, out := &in.Cost, &out.Cost
+		*out = new(ClusterCostStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudResources != nil {
+		in, out := &in.CloudResources, &out.CloudResources
+		*out = new(CloudResourcesStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1123,8 +1422,8 @@ func (in *ClusterImageSet) DeepCopyInto(out *ClusterImageSet) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -1146,6 +1445,50 @@ func (in *ClusterImageSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetChannel) DeepCopyInto(out *ClusterImageSetChannel) {
+	*out = *in
+	if in.GraphConfigMapRef != nil {
+		in, out := &in.GraphConfigMapRef, &out.GraphConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetChannel.
+func (in *ClusterImageSetChannel) DeepCopy() *ClusterImageSetChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetCondition) DeepCopyInto(out *ClusterImageSetCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetCondition.
+func (in *ClusterImageSetCondition) DeepCopy() *ClusterImageSetCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterImageSetList) DeepCopyInto(out *ClusterImageSetList) {
 	*out = *in
@@ -1196,43 +1539,83 @@ func (in *ClusterImageSetReference) DeepCopy() *ClusterImageSetReference {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetSpec) DeepCopyInto(out *ClusterImageSetSpec) {
+func (in *ClusterImageSetRelease) DeepCopyInto(out *ClusterImageSetRelease) {
 	*out = *in
+	in.ResolvedTime.DeepCopyInto(&out.ResolvedTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetSpec.
-func (in *ClusterImageSetSpec) DeepCopy() *ClusterImageSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetRelease.
+func (in *ClusterImageSetRelease) DeepCopy() *ClusterImageSetRelease {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSetSpec)
+	out := new(ClusterImageSetRelease)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetStatus) DeepCopyInto(out *ClusterImageSetStatus) {
+func (in *ClusterImageSetSpec) DeepCopyInto(out *ClusterImageSetSpec) {
 	*out = *in
+	if in.Channel != nil {
+		in, out := &in.Channel, &out.Channel
+		*out = new(ClusterImageSetChannel)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetStatus.
-func (in *ClusterImageSetStatus) DeepCopy() *ClusterImageSetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetSpec.
+func (in *ClusterImageSetSpec) DeepCopy() *ClusterImageSetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSetStatus)
+	out := new(ClusterImageSetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterIngress) DeepCopyInto(out *ClusterIngress) {
+func (in *ClusterImageSetStatus) DeepCopyInto(out *ClusterImageSetStatus) {
 	*out = *in
-	if in.NamespaceSelector != nil {
-		in, out := &in.NamespaceSelector, &out.NamespaceSelector
-		*out = new(metav1.LabelSelector)
+	if in.LastCheckedTime != nil {
+		in, out := &in.LastCheckedTime, &out.LastCheckedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ClusterImageSetRelease, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterImageSetCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetStatus.
+func (in *ClusterImageSetStatus) DeepCopy() *ClusterImageSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterIngress) DeepCopyInto(out *ClusterIngress) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.RouteSelector != nil {
@@ -1342,6 +1725,22 @@ func (in *ClusterOperatorState) DeepCopy() *ClusterOperatorState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperatorSummary) DeepCopyInto(out *ClusterOperatorSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperatorSummary.
+func (in *ClusterOperatorSummary) DeepCopy() *ClusterOperatorSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperatorSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPlatformMetadata) DeepCopyInto(out *ClusterPlatformMetadata) {
 	*out = *in
@@ -1401,6 +1800,81 @@ func (in *ClusterPool) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolAutoscaling) DeepCopyInto(out *ClusterPoolAutoscaling) {
+	*out = *in
+	if in.MaxRunningCount != nil {
+		in, out := &in.MaxRunningCount, &out.MaxRunningCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolAutoscaling.
+func (in *ClusterPoolAutoscaling) DeepCopy() *ClusterPoolAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolAutoscalingStatus) DeepCopyInto(out *ClusterPoolAutoscalingStatus) {
+	*out = *in
+	if in.ClaimHistory != nil {
+		in, out := &in.ClaimHistory, &out.ClaimHistory
+		*out = make([]ClusterPoolClaimCount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastClaimTime != nil {
+		in, out := &in.LastClaimTime, &out.LastClaimTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolAutoscalingStatus.
+func (in *ClusterPoolAutoscalingStatus) DeepCopy() *ClusterPoolAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolClaimCount) DeepCopyInto(out *ClusterPoolClaimCount) {
+	*out = *in
+	in.Hour.DeepCopyInto(&out.Hour)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolClaimCount.
+func (in *ClusterPoolClaimCount) DeepCopy() *ClusterPoolClaimCount {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolClaimCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolClaimLifetime) DeepCopyInto(out *ClusterPoolClaimLifetime) {
 	*out = *in
@@ -1427,6 +1901,27 @@ func (in *ClusterPoolClaimLifetime) DeepCopy() *ClusterPoolClaimLifetime {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolClaimPolicy) DeepCopyInto(out *ClusterPoolClaimPolicy) {
+	*out = *in
+	if in.Preemption != nil {
+		in, out := &in.Preemption, &out.Preemption
+		*out = new(ClusterClaimPreemption)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolClaimPolicy.
+func (in *ClusterPoolClaimPolicy) DeepCopy() *ClusterPoolClaimPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolClaimPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolCondition) DeepCopyInto(out *ClusterPoolCondition) {
 	*out = *in
@@ -1490,6 +1985,11 @@ func (in *ClusterPoolReference) DeepCopyInto(out *ClusterPoolReference) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.InventoryRefs != nil {
+		in, out := &in.InventoryRefs, &out.InventoryRefs
+		*out = make([]InventoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1503,6 +2003,32 @@ func (in *ClusterPoolReference) DeepCopy() *ClusterPoolReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolReleaseRollover) DeepCopyInto(out *ClusterPoolReleaseRollover) {
+	*out = *in
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolReleaseRollover.
+func (in *ClusterPoolReleaseRollover) DeepCopy() *ClusterPoolReleaseRollover {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolReleaseRollover)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 	*out = *in
@@ -1560,7 +2086,7 @@ func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 	if in.HibernationConfig != nil {
 		in, out := &in.HibernationConfig, &out.HibernationConfig
 		*out = new(HibernationConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Inventory != nil {
 		in, out := &in.Inventory, &out.Inventory
@@ -1574,6 +2100,21 @@ func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ClusterPoolAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClaimPolicy != nil {
+		in, out := &in.ClaimPolicy, &out.ClaimPolicy
+		*out = new(ClusterPoolClaimPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReleaseRollover != nil {
+		in, out := &in.ReleaseRollover, &out.ReleaseRollover
+		*out = new(ClusterPoolReleaseRollover)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1597,6 +2138,15 @@ func (in *ClusterPoolStatus) DeepCopyInto(out *ClusterPoolStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ClusterPoolAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastRolloverTime != nil {
+		in, out := &in.LastRolloverTime, &out.LastRolloverTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -1689,6 +2239,27 @@ func (in *ClusterProvisionList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionPhase) DeepCopyInto(out *ClusterProvisionPhase) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionPhase.
+func (in *ClusterProvisionPhase) DeepCopy() *ClusterProvisionPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterProvisionSpec) DeepCopyInto(out *ClusterProvisionSpec) {
 	*out = *in
@@ -1772,6 +2343,13 @@ func (in *ClusterProvisionStatus) DeepCopyInto(out *ClusterProvisionStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Timeline != nil {
+		in, out := &in.Timeline, &out.Timeline
+		*out = make([]ClusterProvisionPhase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1786,7 +2364,7 @@ func (in *ClusterProvisionStatus) DeepCopy() *ClusterProvisionStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocate) DeepCopyInto(out *ClusterRelocate) {
+func (in *ClusterQuota) DeepCopyInto(out *ClusterQuota) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1795,18 +2373,18 @@ func (in *ClusterRelocate) DeepCopyInto(out *ClusterRelocate) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocate.
-func (in *ClusterRelocate) DeepCopy() *ClusterRelocate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuota.
+func (in *ClusterQuota) DeepCopy() *ClusterQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocate)
+	out := new(ClusterQuota)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
+func (in *ClusterQuota) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1814,13 +2392,44 @@ func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
+func (in *ClusterQuotaLimits) DeepCopyInto(out *ClusterQuotaLimits) {
+	*out = *in
+	if in.ClusterDeployments != nil {
+		in, out := &in.ClusterDeployments, &out.ClusterDeployments
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RunningClusterDeployments != nil {
+		in, out := &in.RunningClusterDeployments, &out.RunningClusterDeployments
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClusterPoolSize != nil {
+		in, out := &in.ClusterPoolSize, &out.ClusterPoolSize
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaLimits.
+func (in *ClusterQuotaLimits) DeepCopy() *ClusterQuotaLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQuotaLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQuotaList) DeepCopyInto(out *ClusterQuotaList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterRelocate, len(*in))
+		*out = make([]ClusterQuota, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1828,18 +2437,18 @@ func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateList.
-func (in *ClusterRelocateList) DeepCopy() *ClusterRelocateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaList.
+func (in *ClusterQuotaList) DeepCopy() *ClusterQuotaList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateList)
+	out := new(ClusterQuotaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRelocateList) DeepCopyObject() runtime.Object {
+func (in *ClusterQuotaList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1847,94 +2456,78 @@ func (in *ClusterRelocateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateSpec) DeepCopyInto(out *ClusterRelocateSpec) {
+func (in *ClusterQuotaSpec) DeepCopyInto(out *ClusterQuotaSpec) {
 	*out = *in
-	out.KubeconfigSecretRef = in.KubeconfigSecretRef
-	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.Hard.DeepCopyInto(&out.Hard)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateSpec.
-func (in *ClusterRelocateSpec) DeepCopy() *ClusterRelocateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaSpec.
+func (in *ClusterQuotaSpec) DeepCopy() *ClusterQuotaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateSpec)
+	out := new(ClusterQuotaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateStatus) DeepCopyInto(out *ClusterRelocateStatus) {
+func (in *ClusterQuotaStatus) DeepCopyInto(out *ClusterQuotaStatus) {
 	*out = *in
+	out.Used = in.Used
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateStatus.
-func (in *ClusterRelocateStatus) DeepCopy() *ClusterRelocateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaStatus.
+func (in *ClusterQuotaStatus) DeepCopy() *ClusterQuotaStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateStatus)
+	out := new(ClusterQuotaStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterState) DeepCopyInto(out *ClusterState) {
+func (in *ClusterQuotaUsage) DeepCopyInto(out *ClusterQuotaUsage) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterState.
-func (in *ClusterState) DeepCopy() *ClusterState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaUsage.
+func (in *ClusterQuotaUsage) DeepCopy() *ClusterQuotaUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterState)
+	out := new(ClusterQuotaUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterState) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateList) DeepCopyInto(out *ClusterStateList) {
+func (in *ClusterRelocate) DeepCopyInto(out *ClusterRelocate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterState, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateList.
-func (in *ClusterStateList) DeepCopy() *ClusterStateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocate.
+func (in *ClusterRelocate) DeepCopy() *ClusterRelocate {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateList)
+	out := new(ClusterRelocate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterStateList) DeepCopyObject() runtime.Object {
+func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1942,23 +2535,180 @@ func (in *ClusterStateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateSpec) DeepCopyInto(out *ClusterStateSpec) {
+func (in *ClusterRelocateClusterStatus) DeepCopyInto(out *ClusterRelocateClusterStatus) {
 	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSpec.
-func (in *ClusterStateSpec) DeepCopy() *ClusterStateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateClusterStatus.
+func (in *ClusterRelocateClusterStatus) DeepCopy() *ClusterRelocateClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateSpec)
+	out := new(ClusterRelocateClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
+func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRelocate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateList.
+func (in *ClusterRelocateList) DeepCopy() *ClusterRelocateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRelocateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocateSpec) DeepCopyInto(out *ClusterRelocateSpec) {
+	*out = *in
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateSpec.
+func (in *ClusterRelocateSpec) DeepCopy() *ClusterRelocateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocateStatus) DeepCopyInto(out *ClusterRelocateStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterRelocateClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateStatus.
+func (in *ClusterRelocateStatus) DeepCopy() *ClusterRelocateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterState) DeepCopyInto(out *ClusterState) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterState.
+func (in *ClusterState) DeepCopy() *ClusterState {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterState) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateList) DeepCopyInto(out *ClusterStateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateList.
+func (in *ClusterStateList) DeepCopy() *ClusterStateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterStateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateSpec) DeepCopyInto(out *ClusterStateSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSpec.
+func (in *ClusterStateSpec) DeepCopy() *ClusterStateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
 	*out = *in
 	if in.LastUpdated != nil {
 		in, out := &in.LastUpdated, &out.LastUpdated
@@ -1974,12 +2724,360 @@ func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateStatus.
-func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateStatus.
+func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateSummary) DeepCopyInto(out *ClusterStateSummary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSummary.
+func (in *ClusterStateSummary) DeepCopy() *ClusterStateSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterStateSummary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateSummaryGroup) DeepCopyInto(out *ClusterStateSummaryGroup) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Operators != nil {
+		in, out := &in.Operators, &out.Operators
+		*out = make([]ClusterOperatorSummary, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSummaryGroup.
+func (in *ClusterStateSummaryGroup) DeepCopy() *ClusterStateSummaryGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateSummaryGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateSummaryList) DeepCopyInto(out *ClusterStateSummaryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterStateSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSummaryList.
+func (in *ClusterStateSummaryList) DeepCopy() *ClusterStateSummaryList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateSummaryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterStateSummaryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateSummarySpec) DeepCopyInto(out *ClusterStateSummarySpec) {
+	*out = *in
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.GroupByLabels != nil {
+		in, out := &in.GroupByLabels, &out.GroupByLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSummarySpec.
+func (in *ClusterStateSummarySpec) DeepCopy() *ClusterStateSummarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateSummarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateSummaryStatus) DeepCopyInto(out *ClusterStateSummaryStatus) {
+	*out = *in
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+	if in.Operators != nil {
+		in, out := &in.Operators, &out.Operators
+		*out = make([]ClusterOperatorSummary, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]ClusterStateSummaryGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSummaryStatus.
+func (in *ClusterStateSummaryStatus) DeepCopy() *ClusterStateSummaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateSummaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgrade) DeepCopyInto(out *ClusterUpgrade) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgrade.
+func (in *ClusterUpgrade) DeepCopy() *ClusterUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterUpgrade) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeClusterStatus) DeepCopyInto(out *ClusterUpgradeClusterStatus) {
+	*out = *in
+	if in.StartedTime != nil {
+		in, out := &in.StartedTime, &out.StartedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedTime != nil {
+		in, out := &in.CompletedTime, &out.CompletedTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeClusterStatus.
+func (in *ClusterUpgradeClusterStatus) DeepCopy() *ClusterUpgradeClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeCondition) DeepCopyInto(out *ClusterUpgradeCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeCondition.
+func (in *ClusterUpgradeCondition) DeepCopy() *ClusterUpgradeCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeList) DeepCopyInto(out *ClusterUpgradeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterUpgrade, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeList.
+func (in *ClusterUpgradeList) DeepCopy() *ClusterUpgradeList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterUpgradeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeMaintenanceWindow) DeepCopyInto(out *ClusterUpgradeMaintenanceWindow) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]HibernationRunWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeMaintenanceWindow.
+func (in *ClusterUpgradeMaintenanceWindow) DeepCopy() *ClusterUpgradeMaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeMaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeRelease) DeepCopyInto(out *ClusterUpgradeRelease) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeRelease.
+func (in *ClusterUpgradeRelease) DeepCopy() *ClusterUpgradeRelease {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeRelease)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeSpec) DeepCopyInto(out *ClusterUpgradeSpec) {
+	*out = *in
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	out.DesiredUpdate = in.DesiredUpdate
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(ClusterUpgradeMaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeSpec.
+func (in *ClusterUpgradeSpec) DeepCopy() *ClusterUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeStatus) DeepCopyInto(out *ClusterUpgradeStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterUpgradeClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterUpgradeCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeStatus.
+func (in *ClusterUpgradeStatus) DeepCopy() *ClusterUpgradeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateStatus)
+	out := new(ClusterUpgradeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2332,6 +3430,23 @@ func (in *FailedProvisionAWSConfig) DeepCopy() *FailedProvisionAWSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionAzureConfig) DeepCopyInto(out *FailedProvisionAzureConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAzureConfig.
+func (in *FailedProvisionAzureConfig) DeepCopy() *FailedProvisionAzureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionAzureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 	*out = *in
@@ -2340,6 +3455,26 @@ func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 		*out = new(FailedProvisionAWSConfig)
 		**out = **in
 	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(FailedProvisionAzureConfig)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(FailedProvisionGCPConfig)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(FailedProvisionHTTPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(FailedProvisionPVCConfig)
+		**out = **in
+	}
 	if in.RetryReasons != nil {
 		in, out := &in.RetryReasons, &out.RetryReasons
 		*out = new([]string)
@@ -2349,6 +3484,13 @@ func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 			copy(*out, *in)
 		}
 	}
+	if in.RetryPolicies != nil {
+		in, out := &in.RetryPolicies, &out.RetryPolicies
+		*out = make([]ProvisionRetryPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2362,6 +3504,60 @@ func (in *FailedProvisionConfig) DeepCopy() *FailedProvisionConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionGCPConfig) DeepCopyInto(out *FailedProvisionGCPConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionGCPConfig.
+func (in *FailedProvisionGCPConfig) DeepCopy() *FailedProvisionGCPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionGCPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionHTTPConfig) DeepCopyInto(out *FailedProvisionHTTPConfig) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionHTTPConfig.
+func (in *FailedProvisionHTTPConfig) DeepCopy() *FailedProvisionHTTPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionHTTPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionPVCConfig) DeepCopyInto(out *FailedProvisionPVCConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionPVCConfig.
+func (in *FailedProvisionPVCConfig) DeepCopy() *FailedProvisionPVCConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionPVCConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureGateSelection) DeepCopyInto(out *FeatureGateSelection) {
 	*out = *in
@@ -2472,6 +3668,11 @@ func (in *GCPDNSZoneStatus) DeepCopy() *GCPDNSZoneStatus {
 func (in *HibernationConfig) DeepCopyInto(out *HibernationConfig) {
 	*out = *in
 	out.ResumeTimeout = in.ResumeTimeout
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(HibernationSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2485,6 +3686,74 @@ func (in *HibernationConfig) DeepCopy() *HibernationConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationRunWindow) DeepCopyInto(out *HibernationRunWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationRunWindow.
+func (in *HibernationRunWindow) DeepCopy() *HibernationRunWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationRunWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationSchedule) DeepCopyInto(out *HibernationSchedule) {
+	*out = *in
+	if in.RunWindows != nil {
+		in, out := &in.RunWindows, &out.RunWindows
+		*out = make([]HibernationRunWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationSchedule.
+func (in *HibernationSchedule) DeepCopy() *HibernationSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationScheduleStatus) DeepCopyInto(out *HibernationScheduleStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextTransitionTime != nil {
+		in, out := &in.NextTransitionTime, &out.NextTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationScheduleStatus.
+func (in *HibernationScheduleStatus) DeepCopy() *HibernationScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
 	*out = *in
@@ -2629,6 +3898,7 @@ func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 		**out = **in
 	}
 	out.ArgoCD = in.ArgoCD
+	in.CloudResources.DeepCopyInto(&out.CloudResources)
 	if in.FeatureGates != nil {
 		in, out := &in.FeatureGates, &out.FeatureGates
 		*out = new(FeatureGateSelection)
@@ -3205,6 +4475,22 @@ func (in *OpenStackClusterDeprovision) DeepCopy() *OpenStackClusterDeprovision {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedCloudResource) DeepCopyInto(out *OrphanedCloudResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedCloudResource.
+func (in *OrphanedCloudResource) DeepCopy() *OrphanedCloudResource {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedCloudResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OvirtClusterDeprovision) DeepCopyInto(out *OvirtClusterDeprovision) {
 	*out = *in
@@ -3226,6 +4512,11 @@ func (in *OvirtClusterDeprovision) DeepCopy() *OvirtClusterDeprovision {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PatchEntity) DeepCopyInto(out *PatchEntity) {
 	*out = *in
+	if in.TypedValue != nil {
+		in, out := &in.TypedValue, &out.TypedValue
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3326,6 +4617,105 @@ func (in *PlatformStatus) DeepCopy() *PlatformStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionFailureCount) DeepCopyInto(out *ProvisionFailureCount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionFailureCount.
+func (in *ProvisionFailureCount) DeepCopy() *ProvisionFailureCount {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionFailureCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionRetriesStatus) DeepCopyInto(out *ProvisionRetriesStatus) {
+	*out = *in
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]ProvisionFailureCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(ProvisionRetryFallback)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionRetriesStatus.
+func (in *ProvisionRetriesStatus) DeepCopy() *ProvisionRetriesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionRetriesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionRetryFallback) DeepCopyInto(out *ProvisionRetryFallback) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionRetryFallback.
+func (in *ProvisionRetryFallback) DeepCopy() *ProvisionRetryFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionRetryFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionRetryPolicy) DeepCopyInto(out *ProvisionRetryPolicy) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AttemptsLimit != nil {
+		in, out := &in.AttemptsLimit, &out.AttemptsLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make([]ProvisionRetryFallback, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionRetryPolicy.
+func (in *ProvisionRetryPolicy) DeepCopy() *ProvisionRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Provisioning) DeepCopyInto(out *Provisioning) {
 	*out = *in
@@ -3514,7 +4904,7 @@ func (in *SelectorSyncSet) DeepCopyInto(out *SelectorSyncSet) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -3569,11 +4959,58 @@ func (in *SelectorSyncSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorSyncSetRolloutStatus) DeepCopyInto(out *SelectorSyncSetRolloutStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorSyncSetRolloutStatus.
+func (in *SelectorSyncSetRolloutStatus) DeepCopy() *SelectorSyncSetRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorSyncSetRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorSyncSetRolloutStrategy) DeepCopyInto(out *SelectorSyncSetRolloutStrategy) {
+	*out = *in
+	out.WaveSize = in.WaveSize
+	if in.PauseBetweenWaves != nil {
+		in, out := &in.PauseBetweenWaves, &out.PauseBetweenWaves
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorSyncSetRolloutStrategy.
+func (in *SelectorSyncSetRolloutStrategy) DeepCopy() *SelectorSyncSetRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorSyncSetRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorSyncSetSpec) DeepCopyInto(out *SelectorSyncSetSpec) {
 	*out = *in
 	in.SyncSetCommonSpec.DeepCopyInto(&out.SyncSetCommonSpec)
 	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(SelectorSyncSetRolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3590,6 +5027,11 @@ func (in *SelectorSyncSetSpec) DeepCopy() *SelectorSyncSetSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorSyncSetStatus) DeepCopyInto(out *SelectorSyncSetStatus) {
 	*out = *in
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(SelectorSyncSetRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3829,6 +5271,16 @@ func (in *SyncSetCommonSpec) DeepCopyInto(out *SyncSetCommonSpec) {
 		*out = make([]SecretMapping, len(*in))
 		copy(*out, *in)
 	}
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make([]SyncSetHealthCheck, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthCheckTimeout != nil {
+		in, out := &in.HealthCheckTimeout, &out.HealthCheckTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -3842,6 +5294,22 @@ func (in *SyncSetCommonSpec) DeepCopy() *SyncSetCommonSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSetHealthCheck) DeepCopyInto(out *SyncSetHealthCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSetHealthCheck.
+func (in *SyncSetHealthCheck) DeepCopy() *SyncSetHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSetHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSetList) DeepCopyInto(out *SyncSetList) {
 	*out = *in
@@ -78,6 +78,105 @@ type SyncStatus struct {
 	// FirstSuccessTime is the time when the SyncSet or SelectorSyncSet was first successfully applied to the cluster.
 	// +optional
 	FirstSuccessTime *metav1.Time `json:"firstSuccessTime,omitempty"`
+
+	// DryRunResults describe the changes that applying the resources of the SyncSet or SelectorSyncSet would make to
+	// the cluster. This is only set when the SyncSet or SelectorSyncSet is in dry-run mode.
+	// +optional
+	DryRunResults []DryRunResult `json:"dryRunResults,omitempty"`
+
+	// DriftedResources are the resources that were found to differ from the SyncSet or SelectorSyncSet in the cluster
+	// during the last drift check. This is only set when drift detection is enabled for the SyncSet or SelectorSyncSet.
+	// +optional
+	DriftedResources []DriftedResource `json:"driftedResources,omitempty"`
+
+	// LastDriftCheckTime is the time when the resources of the SyncSet or SelectorSyncSet were last checked for drift.
+	// +optional
+	LastDriftCheckTime *metav1.Time `json:"lastDriftCheckTime,omitempty"`
+
+	// HealthCheck is the state of the health checks of the current generation of the SyncSet or SelectorSyncSet.
+	// This is only set when the SyncSet or SelectorSyncSet has health checks.
+	// +optional
+	HealthCheck *SyncHealthCheckStatus `json:"healthCheck,omitempty"`
+}
+
+// DriftedResource is a resource whose live state in the cluster differs from the SyncSet or SelectorSyncSet.
+type DriftedResource struct {
+	SyncResourceReference `json:",inline"`
+
+	// Missing is true if the resource has been deleted from the cluster.
+	// +optional
+	Missing bool `json:"missing,omitempty"`
+
+	// ChangedFields are the paths of the fields whose live values differ from the SyncSet or SelectorSyncSet.
+	// +optional
+	ChangedFields []string `json:"changedFields,omitempty"`
+}
+
+// SyncHealthCheckPhase is the phase of the health checks of a SyncSet or SelectorSyncSet.
+// +kubebuilder:validation:Enum=Pending;Healthy;RolledBack;Unhealthy
+type SyncHealthCheckPhase string
+
+const (
+	// PendingSyncHealthCheckPhase means that the health checks have not all passed yet.
+	PendingSyncHealthCheckPhase SyncHealthCheckPhase = "Pending"
+	// HealthySyncHealthCheckPhase means that the health checks have all passed.
+	HealthySyncHealthCheckPhase SyncHealthCheckPhase = "Healthy"
+	// RolledBackSyncHealthCheckPhase means that the health checks did not pass in time and that the resources of the
+	// last healthy generation have been reapplied.
+	RolledBackSyncHealthCheckPhase SyncHealthCheckPhase = "RolledBack"
+	// UnhealthySyncHealthCheckPhase means that the health checks did not pass in time and that there was no
+	// healthy generation to roll back to.
+	UnhealthySyncHealthCheckPhase SyncHealthCheckPhase = "Unhealthy"
+)
+
+// SyncHealthCheckStatus is the state of the health checks of a SyncSet or SelectorSyncSet.
+type SyncHealthCheckStatus struct {
+	// Phase is the phase of the health checks.
+	Phase SyncHealthCheckPhase `json:"phase"`
+
+	// StartTime is the time when the current generation was applied and the health checks started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// Message describes the health checks that have not passed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// RolledBackToGeneration is the generation of the SyncSet or SelectorSyncSet whose resources were reapplied.
+	// This is only set when Phase is RolledBack.
+	// +optional
+	RolledBackToGeneration int64 `json:"rolledBackToGeneration,omitempty"`
+}
+
+// DryRunAction is the change that applying a resource would make to a cluster.
+// +kubebuilder:validation:Enum=Create;Update;None
+type DryRunAction string
+
+const (
+	// CreateDryRunAction means that the resource does not exist in the cluster and would be created.
+	CreateDryRunAction DryRunAction = "Create"
+	// UpdateDryRunAction means that the resource exists in the cluster and would be modified.
+	UpdateDryRunAction DryRunAction = "Update"
+	// NoneDryRunAction means that the resource exists in the cluster and would not be modified.
+	NoneDryRunAction DryRunAction = "None"
+)
+
+// DryRunResult is the result of a dry-run apply of a single resource of a SyncSet or SelectorSyncSet.
+type DryRunResult struct {
+	SyncResourceReference `json:",inline"`
+
+	// Action is the change that applying the resource would make to the cluster. This is not set when the dry-run
+	// apply failed.
+	// +optional
+	Action DryRunAction `json:"action,omitempty"`
+
+	// ChangedFields are the paths of the fields of the resource that would be modified, added or removed. This is
+	// only set when Action is Update.
+	// +optional
+	ChangedFields []string `json:"changedFields,omitempty"`
+
+	// FailureMessage is a message describing why the dry-run apply of the resource failed.
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
 }
 
 // SyncResourceReference is a reference to a resource that is synced to a cluster via a SyncSet or SelectorSyncSet.
@@ -98,7 +197,7 @@ type SyncResourceReference struct {
 }
 
 // SyncSetResult is the result of a sync attempt.
-// +kubebuilder:validation:Enum=Success;Failure
+// +kubebuilder:validation:Enum=Success;Failure;DryRun
 type SyncSetResult string
 
 const (
@@ -108,6 +207,10 @@ const (
 	// FailureSyncSetResult is the result when there was an error when attempting to apply the SyncSet or SelectorSyncSet
 	// to the cluster
 	FailureSyncSetResult SyncSetResult = "Failure"
+
+	// DryRunSyncSetResult is the result when the SyncSet or SelectorSyncSet is in dry-run mode and its resources were
+	// dry-run against the cluster without error. Nothing was applied.
+	DryRunSyncSetResult SyncSetResult = "DryRun"
 )
 
 // ClusterSyncCondition contains details for the current condition of a ClusterSync
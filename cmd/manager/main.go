@@ -28,15 +28,22 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/argocdregister"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	"github.com/openshift/hive/pkg/controller/cloudinventory"
+	"github.com/openshift/hive/pkg/controller/cloudsweeper"
 	"github.com/openshift/hive/pkg/controller/clusterclaim"
+	"github.com/openshift/hive/pkg/controller/clustercost"
 	"github.com/openshift/hive/pkg/controller/clusterdeployment"
 	"github.com/openshift/hive/pkg/controller/clusterdeprovision"
+	"github.com/openshift/hive/pkg/controller/clusterimageset"
 	"github.com/openshift/hive/pkg/controller/clusterpool"
 	"github.com/openshift/hive/pkg/controller/clusterpoolnamespace"
 	"github.com/openshift/hive/pkg/controller/clusterprovision"
+	"github.com/openshift/hive/pkg/controller/clusterquota"
 	"github.com/openshift/hive/pkg/controller/clusterrelocate"
 	"github.com/openshift/hive/pkg/controller/clusterstate"
+	"github.com/openshift/hive/pkg/controller/clusterstatesummary"
 	"github.com/openshift/hive/pkg/controller/clustersync"
+	"github.com/openshift/hive/pkg/controller/clusterupgrade"
 	"github.com/openshift/hive/pkg/controller/clusterversion"
 	"github.com/openshift/hive/pkg/controller/controlplanecerts"
 	"github.com/openshift/hive/pkg/controller/dnsendpoint"
@@ -46,6 +53,7 @@ import (
 	"github.com/openshift/hive/pkg/controller/machinepool"
 	"github.com/openshift/hive/pkg/controller/metrics"
 	"github.com/openshift/hive/pkg/controller/remoteingress"
+	"github.com/openshift/hive/pkg/controller/selectorsyncsetrollout"
 	"github.com/openshift/hive/pkg/controller/syncidentityprovider"
 	"github.com/openshift/hive/pkg/controller/unreachable"
 	"github.com/openshift/hive/pkg/controller/utils"
@@ -65,29 +73,37 @@ const (
 type controllerSetupFunc func(manager.Manager) error
 
 var controllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
-	clusterclaim.ControllerName:         clusterclaim.Add,
-	clusterdeployment.ControllerName:    clusterdeployment.Add,
-	clusterdeprovision.ControllerName:   clusterdeprovision.Add,
-	clusterpoolnamespace.ControllerName: clusterpoolnamespace.Add,
-	clusterprovision.ControllerName:     clusterprovision.Add,
-	clusterrelocate.ControllerName:      clusterrelocate.Add,
-	clusterstate.ControllerName:         clusterstate.Add,
-	clustersync.ControllerName:          clustersync.Add,
-	clusterversion.ControllerName:       clusterversion.Add,
-	controlplanecerts.ControllerName:    controlplanecerts.Add,
-	dnsendpoint.ControllerName:          dnsendpoint.Add,
-	dnszone.ControllerName:              dnszone.Add,
-	fakeclusterinstall.ControllerName:   fakeclusterinstall.Add,
-	metrics.ControllerName:              metrics.Add,
-	remoteingress.ControllerName:        remoteingress.Add,
-	machinepool.ControllerName:          machinepool.Add,
-	syncidentityprovider.ControllerName: syncidentityprovider.Add,
-	unreachable.ControllerName:          unreachable.Add,
-	velerobackup.ControllerName:         velerobackup.Add,
-	clusterpool.ControllerName:          clusterpool.Add,
-	hibernation.ControllerName:          hibernation.Add,
-	awsprivatelink.ControllerName:       awsprivatelink.Add,
-	argocdregister.ControllerName:       argocdregister.Add,
+	cloudinventory.ControllerName:         cloudinventory.Add,
+	cloudsweeper.ControllerName:           cloudsweeper.Add,
+	clusterclaim.ControllerName:           clusterclaim.Add,
+	clustercost.ControllerName:            clustercost.Add,
+	clusterdeployment.ControllerName:      clusterdeployment.Add,
+	clusterdeprovision.ControllerName:     clusterdeprovision.Add,
+	clusterimageset.ControllerName:        clusterimageset.Add,
+	clusterpoolnamespace.ControllerName:   clusterpoolnamespace.Add,
+	clusterprovision.ControllerName:       clusterprovision.Add,
+	clusterquota.ControllerName:           clusterquota.Add,
+	clusterrelocate.ControllerName:        clusterrelocate.Add,
+	clusterstate.ControllerName:           clusterstate.Add,
+	clusterstatesummary.ControllerName:    clusterstatesummary.Add,
+	clustersync.ControllerName:            clustersync.Add,
+	clusterupgrade.ControllerName:         clusterupgrade.Add,
+	clusterversion.ControllerName:         clusterversion.Add,
+	controlplanecerts.ControllerName:      controlplanecerts.Add,
+	dnsendpoint.ControllerName:            dnsendpoint.Add,
+	dnszone.ControllerName:                dnszone.Add,
+	fakeclusterinstall.ControllerName:     fakeclusterinstall.Add,
+	metrics.ControllerName:                metrics.Add,
+	remoteingress.ControllerName:          remoteingress.Add,
+	selectorsyncsetrollout.ControllerName: selectorsyncsetrollout.Add,
+	machinepool.ControllerName:            machinepool.Add,
+	syncidentityprovider.ControllerName:   syncidentityprovider.Add,
+	unreachable.ControllerName:            unreachable.Add,
+	velerobackup.ControllerName:           velerobackup.Add,
+	clusterpool.ControllerName:            clusterpool.Add,
+	hibernation.ControllerName:            hibernation.Add,
+	awsprivatelink.ControllerName:         awsprivatelink.Add,
+	argocdregister.ControllerName:         argocdregister.Add,
 }
 
 // disabledControllerEquivalents contains a mapping of old controller names to their new equivalent so that CLI parameters like --controllers and --disabled-controllers continue to work
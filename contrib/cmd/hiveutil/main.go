@@ -14,6 +14,7 @@ import (
 	"github.com/openshift/hive/contrib/pkg/createcluster"
 	"github.com/openshift/hive/contrib/pkg/deprovision"
 	"github.com/openshift/hive/contrib/pkg/report"
+	"github.com/openshift/hive/contrib/pkg/syncset"
 	"github.com/openshift/hive/contrib/pkg/testresource"
 	"github.com/openshift/hive/contrib/pkg/verification"
 	"github.com/openshift/hive/contrib/pkg/version"
@@ -44,6 +45,7 @@ func newHiveutilCommand() *cobra.Command {
 		},
 	}
 	cmd.AddCommand(deprovision.NewDeprovisionAWSWithTagsCommand())
+	cmd.AddCommand(deprovision.NewDeprovisionAWSSweepCommand())
 	cmd.AddCommand(deprovision.NewDeprovisionCommand())
 	cmd.AddCommand(verification.NewVerifyImportsCommand())
 	cmd.AddCommand(installmanager.NewInstallManagerCommand())
@@ -56,6 +58,7 @@ func newHiveutilCommand() *cobra.Command {
 	cmd.AddCommand(version.NewVersionCommand())
 	cmd.AddCommand(clusterpool.NewClusterPoolCommand())
 	cmd.AddCommand(awsprivatelink.NewAWSPrivateLinkCommand())
+	cmd.AddCommand(syncset.NewSyncSetCommand())
 
 	return cmd
 }
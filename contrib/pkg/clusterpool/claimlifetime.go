@@ -0,0 +1,118 @@
+package clusterpool
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/contrib/pkg/utils"
+)
+
+type ClaimLifetimeOptions struct {
+	Name      string
+	Namespace string
+	Duration  time.Duration
+	Reason    string
+
+	log log.FieldLogger
+}
+
+func NewExtendClaimCommand() *cobra.Command {
+	opt := &ClaimLifetimeOptions{log: log.WithField("command", "clusterpool claim extend")}
+
+	cmd := &cobra.Command{
+		Use:   "extend CLAIM_NAME",
+		Short: "extends the lifetime of a ClusterClaim",
+		Long:  "requests more time for the ClusterClaim, up to the maximum lifetime allowed by its ClusterPool",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			opt.Name = args[0]
+			if opt.Duration <= 0 {
+				opt.log.Fatal("--by must be a positive duration")
+			}
+			if err := opt.run(opt.extend); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+			fmt.Printf("Requested extension of %s for ClusterClaim %s; see its LifetimeExtended condition for the outcome\n", opt.Duration, opt.Name)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the cluster claim")
+	flags.DurationVar(&opt.Duration, "by", 0, "Additional time requested for the cluster claim")
+	flags.StringVar(&opt.Reason, "reason", "", "Why more time is needed")
+
+	return cmd
+}
+
+func NewReleaseClaimCommand() *cobra.Command {
+	opt := &ClaimLifetimeOptions{log: log.WithField("command", "clusterpool claim release")}
+
+	cmd := &cobra.Command{
+		Use:   "release CLAIM_NAME",
+		Short: "releases the cluster of a ClusterClaim",
+		Long:  "hands the claimed cluster back before the lifetime of the ClusterClaim has elapsed. The claim is deleted and the cluster deprovisioned.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			opt.Name = args[0]
+			if err := opt.run(opt.release); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+			fmt.Printf("Released cluster of ClusterClaim %s\n", opt.Name)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the cluster claim")
+	flags.StringVar(&opt.Reason, "reason", "", "Why the cluster is being released")
+
+	return cmd
+}
+
+// run applies the change to the claim, retrying on conflicts.
+func (o *ClaimLifetimeOptions) run(change func(*hivev1.ClusterClaim)) error {
+	c, err := utils.GetClient()
+	if err != nil {
+		return err
+	}
+	if len(o.Namespace) == 0 {
+		o.Namespace, err = utils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+	key := client.ObjectKey{Namespace: o.Namespace, Name: o.Name}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		claim := &hivev1.ClusterClaim{}
+		if err := c.Get(context.Background(), key, claim); err != nil {
+			return err
+		}
+		change(claim)
+		return c.Update(context.Background(), claim)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "could not update ClusterClaim %s", key)
+	}
+	return nil
+}
+
+func (o *ClaimLifetimeOptions) extend(claim *hivev1.ClusterClaim) {
+	claim.Spec.Extensions = append(claim.Spec.Extensions, hivev1.ClusterClaimExtension{
+		Duration: metav1.Duration{Duration: o.Duration},
+		Reason:   o.Reason,
+	})
+}
+
+func (o *ClaimLifetimeOptions) release(claim *hivev1.ClusterClaim) {
+	claim.Spec.Release = &hivev1.ClusterClaimRelease{Reason: o.Reason}
+}
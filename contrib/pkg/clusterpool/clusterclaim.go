@@ -47,6 +47,9 @@ func NewClaimClusterPoolCommand() *cobra.Command {
 		"Namespace to create cluster claim in. Has to be the namespace in which the cluster pool is deployed")
 	flags.DurationVar(&opt.Lifetime, "lifetime", 0, "Lifetime of the cluster claim")
 
+	cmd.AddCommand(NewExtendClaimCommand())
+	cmd.AddCommand(NewReleaseClaimCommand())
+
 	return cmd
 }
 
@@ -0,0 +1,150 @@
+package deprovision
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/cloudinventory"
+	"github.com/openshift/installer/pkg/destroy/aws"
+)
+
+// AWSSweepOptions is the set of options for the sweep of AWS regions for the resources of unowned infra IDs.
+type AWSSweepOptions struct {
+	// Regions are the regions to sweep.
+	Regions []string
+	// GracePeriod is how long the resources of an infra ID must have existed before they are deprovisioned.
+	GracePeriod time.Duration
+	// Allowlist are the infra IDs, or shell patterns matching them, whose resources are never deprovisioned.
+	Allowlist []string
+	// Include are the infra IDs, or shell patterns matching them, whose resources may be deprovisioned.
+	Include []string
+	// Deprovision deprovisions the resources of the unowned infra IDs older than the grace period and matching the
+	// include list. The sweep is a dry run otherwise.
+	Deprovision bool
+	// LogLevel is the log level of the uninstaller.
+	LogLevel string
+}
+
+// NewDeprovisionAWSSweepCommand is the entrypoint to create the 'aws-sweep' subcommand
+func NewDeprovisionAWSSweepCommand() *cobra.Command {
+	opt := &AWSSweepOptions{}
+	cmd := &cobra.Command{
+		Use:   "aws-sweep",
+		Short: "Find, and optionally deprovision, AWS resources tagged for infra IDs no cluster owns",
+		Long: `Lists the infra IDs of the kubernetes.io/cluster/<infraID> tags of the resources in the given regions of the
+AWS account of the current credentials, and prints those that no ClusterDeployment, ClusterProvision or
+ClusterDeprovision of the Hive cluster owns. With --deprovision, the resources of the unowned infra IDs older than the
+grace period, matching --include and not in the allowlist are deprovisioned; the sweep is a dry run otherwise. The age of an infra ID is
+that of its oldest instance: infra IDs without instances are never deprovisioned by this command.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Error("Invalid options")
+				return
+			}
+
+			dynClient, err := utils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+
+			if err := opt.Run(dynClient); err != nil {
+				log.WithError(err).Fatal("Runtime error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringSliceVar(&opt.Regions, "regions", []string{"us-east-1"}, "AWS regions to sweep")
+	flags.DurationVar(&opt.GracePeriod, "grace-period", 24*time.Hour, "how old the resources of an unowned infra ID must be to be deprovisioned")
+	flags.StringSliceVar(&opt.Allowlist, "allowlist", nil, "infra IDs, or shell patterns matching them, whose resources are never deprovisioned")
+	flags.StringSliceVar(&opt.Include, "include", nil, "infra IDs, or shell patterns matching them, whose resources may be deprovisioned (required with --deprovision)")
+	flags.BoolVar(&opt.Deprovision, "deprovision", false, "deprovision the resources of unowned infra IDs older than the grace period and matching --include, instead of a dry run")
+	flags.StringVar(&opt.LogLevel, "loglevel", "info", "log level of the uninstaller, one of: debug, info, warn, error, fatal, panic")
+	return cmd
+}
+
+// Validate ensures that option values make sense
+func (o *AWSSweepOptions) Validate(cmd *cobra.Command) error {
+	if len(o.Regions) == 0 {
+		cmd.Usage()
+		return fmt.Errorf("at least one region must be specified")
+	}
+	if o.Deprovision && len(o.Include) == 0 {
+		cmd.Usage()
+		return fmt.Errorf("--include is required with --deprovision")
+	}
+	return o.sweepOptions().Validate()
+}
+
+func (o *AWSSweepOptions) sweepOptions() cloudinventory.SweepOptions {
+	return cloudinventory.SweepOptions{GracePeriod: o.GracePeriod, Allowlist: o.Allowlist, Include: o.Include}
+}
+
+// Run executes the command
+func (o *AWSSweepOptions) Run(dynClient client.Client) error {
+	owned, err := cloudinventory.OwnedInfraIDs(context.Background(), dynClient)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var unowned []cloudinventory.UnownedInfraID
+	for _, region := range o.Regions {
+		awsClient, err := awsclient.NewClient(nil, "", "", region)
+		if err != nil {
+			return err
+		}
+		infraIDs, err := cloudinventory.FindAWSInfraIDs(awsClient, region)
+		if err != nil {
+			return err
+		}
+		unowned = append(unowned, cloudinventory.FindUnowned(infraIDs, owned, o.sweepOptions(), now)...)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "REGION\tINFRA ID\tRESOURCES\tCREATED\tACTION")
+	for _, u := range unowned {
+		created := "unknown"
+		if !u.Created.IsZero() {
+			created = u.Created.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", u.Region, u.ID, u.ResourceCount, created, u.Action)
+	}
+	w.Flush()
+
+	if !o.Deprovision {
+		fmt.Println("\nDry run, no resources were deprovisioned. Use --deprovision to deprovision them.")
+		return nil
+	}
+	logger, err := utils.NewLogger(o.LogLevel)
+	if err != nil {
+		return err
+	}
+	for _, u := range unowned {
+		if u.Action != cloudinventory.SweepActionDeprovision {
+			continue
+		}
+		uninstaller := &aws.ClusterUninstaller{
+			Filters: []aws.Filter{
+				{fmt.Sprintf("kubernetes.io/cluster/%s", u.ID): "owned"},
+				{fmt.Sprintf("sigs.k8s.io/cluster-api-provider-aws/cluster/%s", u.ID): "owned"},
+			},
+			Region: u.Region,
+			Logger: logger.WithField("infraID", u.ID),
+		}
+		logger.WithField("infraID", u.ID).WithField("region", u.Region).Info("deprovisioning cloud resources of unowned infra ID")
+		if _, err := uninstaller.Run(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
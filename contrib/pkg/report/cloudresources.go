@@ -0,0 +1,184 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/cloudinventory"
+	"github.com/openshift/hive/pkg/remoteclient"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CloudResourcesReportOptions is the set of options for the desired report.
+type CloudResourcesReportOptions struct {
+	// Namespace filters the report to only clusters in the given namespace.
+	Namespace string
+	// ClusterDeployments are the names of the clusters to report on. All installed clusters are reported on when empty.
+	ClusterDeployments []string
+	// OrphansOnly only prints the orphaned resources.
+	OrphansOnly bool
+	// SkipRemote skips connecting to the clusters, so that only the resources left behind by deprovisions are found
+	// to be orphans.
+	SkipRemote bool
+}
+
+// NewCloudResourcesReportCommand creates a command that lists the cloud resources of clusters and outputs them.
+func NewCloudResourcesReportCommand() *cobra.Command {
+
+	opt := &CloudResourcesReportOptions{}
+	cmd := &cobra.Command{
+		Use:   "cloud-resources [CLUSTER_DEPLOYMENT]...",
+		Short: "Prints a report on the cloud resources of clusters, and those that are orphaned",
+		Long: `Lists the cloud resources tagged for each cluster with the credentials of its ClusterDeployment, and prints
+them along with the Service or PersistentVolume they were created for. Load balancers and volumes whose Service or
+PersistentVolume no longer exists in the cluster are orphans, as are all the resources of a cluster whose
+deprovision completed or failed.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				return
+			}
+
+			if err := opt.Validate(cmd); err != nil {
+				return
+			}
+
+			dynClient, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+
+			err = opt.Run(dynClient)
+			if err != nil {
+				log.WithError(err).Error("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Only include clusters in the given namespace.")
+	flags.BoolVar(&opt.OrphansOnly, "orphans-only", false, "Only print the orphaned resources.")
+	flags.BoolVar(&opt.SkipRemote, "skip-remote", false, "Do not connect to the clusters to find resources of deleted Services and PersistentVolumes.")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *CloudResourcesReportOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.ClusterDeployments = args
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *CloudResourcesReportOptions) Validate(cmd *cobra.Command) error {
+	if len(o.ClusterDeployments) > 0 && o.Namespace == "" {
+		cmd.Usage()
+		log.Info("The namespace of the ClusterDeployments must be specified")
+		return fmt.Errorf("the namespace of the ClusterDeployments must be specified")
+	}
+	return nil
+}
+
+// Run executes the command
+func (o *CloudResourcesReportOptions) Run(dynClient client.Client) error {
+	ctx := context.Background()
+	var cds []hivev1.ClusterDeployment
+	if len(o.ClusterDeployments) > 0 {
+		for _, name := range o.ClusterDeployments {
+			cd := &hivev1.ClusterDeployment{}
+			if err := dynClient.Get(ctx, client.ObjectKey{Namespace: o.Namespace, Name: name}, cd); err != nil {
+				return err
+			}
+			cds = append(cds, *cd)
+		}
+	} else {
+		cdList := &hivev1.ClusterDeploymentList{}
+		if err := dynClient.List(ctx, cdList, client.InNamespace(o.Namespace)); err != nil {
+			return err
+		}
+		cds = cdList.Items
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tKIND\tID\tREGION\tCREATED FOR\tORPHAN")
+	var total, orphaned int
+	for i := range cds {
+		cd := &cds[i]
+		logger := log.WithField("cluster", cd.Namespace+"/"+cd.Name)
+		if !cd.Spec.Installed || cd.Spec.ClusterMetadata == nil {
+			logger.Debug("cluster is not installed, skipping")
+			continue
+		}
+		if cloudinventory.ListerFor(cd) == nil {
+			logger.Info("listing cloud resources is not supported for the platform of the cluster, skipping")
+			continue
+		}
+		resources, err := cloudinventory.ListResources(cd, dynClient, logger)
+		if err != nil {
+			logger.WithError(err).Error("could not list cloud resources")
+			continue
+		}
+		reasons := map[string]hivev1.OrphanedCloudResourceReason{}
+		for _, orphan := range o.findOrphans(ctx, dynClient, cd, resources, logger) {
+			reasons[orphan.ID] = orphan.Reason
+		}
+
+		for _, r := range resources {
+			reason, orphan := reasons[r.ID]
+			if o.OrphansOnly && !orphan {
+				continue
+			}
+			createdFor := r.PersistentVolume
+			if len(r.Services) > 0 {
+				createdFor = strings.Join(r.Services, ",")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", cd.Namespace, cd.Name, r.Kind, r.ID, r.Region, createdFor, reason)
+		}
+		total += len(resources)
+		orphaned += len(reasons)
+	}
+	w.Flush()
+	fmt.Printf("\n%d cloud resources, %d orphaned\n", total, orphaned)
+	return nil
+}
+
+// findOrphans returns the orphaned resources of a cluster. All the resources are orphans when the cluster is being
+// deleted and its deprovision has completed or failed.
+func (o *CloudResourcesReportOptions) findOrphans(ctx context.Context, dynClient client.Client, cd *hivev1.ClusterDeployment, resources []cloudinventory.Resource, logger log.FieldLogger) []hivev1.OrphanedCloudResource {
+	if cd.DeletionTimestamp != nil {
+		deprovision := &hivev1.ClusterDeprovision{}
+		switch err := dynClient.Get(ctx, client.ObjectKeyFromObject(cd), deprovision); {
+		case apierrors.IsNotFound(err):
+			return nil
+		case err != nil:
+			logger.WithError(err).Warn("could not get cluster deprovision")
+			return nil
+		}
+		if cloudinventory.DeprovisionEnded(deprovision) {
+			return cloudinventory.DeprovisionOrphans(resources)
+		}
+		return nil
+	}
+	if o.SkipRemote || cd.Spec.PowerState == hivev1.ClusterPowerStateHibernating {
+		return nil
+	}
+	remoteClient, err := remoteclient.NewBuilder(dynClient, cd, "hiveutil").Build()
+	if err != nil {
+		logger.WithError(err).Warn("could not connect to cluster, orphans of deleted Services and PersistentVolumes are not reported")
+		return nil
+	}
+	expected, err := cloudinventory.LoadExpectations(ctx, remoteClient)
+	if err != nil {
+		logger.WithError(err).Warn("could not list the objects of the cluster, orphans of deleted Services and PersistentVolumes are not reported")
+		return nil
+	}
+	return cloudinventory.FindOrphans(resources, expected)
+}
@@ -0,0 +1,174 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CostReportOptions is the set of options for the desired report.
+type CostReportOptions struct {
+	// Namespace filters the report to only clusters in the given namespace.
+	Namespace string
+	// ClusterType filters the report to only clusters of the given type.
+	ClusterType string
+	// GroupBy is the ClusterDeployment label the costs are totalled by. The costs are totalled by ClusterPool when
+	// it is empty.
+	GroupBy string
+}
+
+// NewCostReportCommand creates a command that generates and outputs the cluster cost report.
+func NewCostReportCommand() *cobra.Command {
+
+	opt := &CostReportOptions{}
+	cmd := &cobra.Command{
+		Use:   "cost",
+		Short: "Prints a report on the estimated cost of all installed clusters",
+		Long: `Prints the estimated hourly and cumulative cost of the installed clusters, and their totals by ClusterPool or
+by the value of a ClusterDeployment label. Costs are estimated by the clustercost controller from the
+cluster-price-table ConfigMap in the Hive namespace; clusters it has not estimated yet are not included.
+The costs of deleted clusters are not kept, so the totals only include the clusters that currently exist.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				return
+			}
+
+			if err := opt.Validate(cmd); err != nil {
+				return
+			}
+
+			dynClient, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+
+			err = opt.Run(dynClient)
+			if err != nil {
+				log.WithError(err).Error("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Only include clusters in the given namespace.")
+	flags.StringVarP(&opt.ClusterType, "cluster-type", "", "", "Only include clusters with the given hive.openshift.io/cluster-type label.")
+	flags.StringVarP(&opt.GroupBy, "group-by", "", "", "Total the costs by the value of the given ClusterDeployment label instead of by ClusterPool. (i.e. team)")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *CostReportOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *CostReportOptions) Validate(cmd *cobra.Command) error {
+	return nil
+}
+
+// costTotal is the total cost of a group of clusters.
+type costTotal struct {
+	group      string
+	currency   string
+	clusters   int
+	hourly     float64
+	cumulative float64
+}
+
+// Run executes the command
+func (o *CostReportOptions) Run(dynClient client.Client) error {
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := dynClient.List(context.Background(), cdList, client.InNamespace(o.Namespace)); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tGROUP\tPOWER STATE\tHOURLY\tCUMULATIVE\tUNPRICED MACHINES")
+	totals := map[string]*costTotal{}
+	var unestimated int
+	for _, cd := range cdList.Items {
+		if !cd.Spec.Installed || cd.DeletionTimestamp != nil {
+			continue
+		}
+		if o.ClusterType != "" {
+			ct, ok := cd.Labels[hivev1.HiveClusterTypeLabel]
+			if !ok || ct != o.ClusterType {
+				continue
+			}
+		}
+		cost := cd.Status.Cost
+		if cost == nil {
+			unestimated++
+			continue
+		}
+		hourly, err := strconv.ParseFloat(cost.HourlyCost, 64)
+		if err != nil {
+			log.WithError(err).WithField("cluster", cd.Namespace+"/"+cd.Name).Warn("invalid hourly cost")
+			continue
+		}
+		cumulative, err := strconv.ParseFloat(cost.CumulativeCost, 64)
+		if err != nil {
+			log.WithError(err).WithField("cluster", cd.Namespace+"/"+cd.Name).Warn("invalid cumulative cost")
+			continue
+		}
+
+		group := o.group(&cd)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%.2f %s\t%.2f %s\t%d\n", cd.Namespace, cd.Name, group, cd.Status.PowerState,
+			hourly, cost.Currency, cumulative, cost.Currency, cost.UnpricedMachines)
+
+		key := group + "\x00" + cost.Currency
+		total, ok := totals[key]
+		if !ok {
+			total = &costTotal{group: group, currency: cost.Currency}
+			totals[key] = total
+		}
+		total.clusters++
+		total.hourly += hourly
+		total.cumulative += cumulative
+	}
+	w.Flush()
+
+	keys := make([]string, 0, len(totals))
+	for key := range totals {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Println()
+	fmt.Fprintln(w, "GROUP\tCLUSTERS\tHOURLY\tCUMULATIVE")
+	for _, key := range keys {
+		total := totals[key]
+		fmt.Fprintf(w, "%s\t%d\t%.2f %s\t%.2f %s\n", total.group, total.clusters, total.hourly, total.currency,
+			total.cumulative, total.currency)
+	}
+	w.Flush()
+
+	if unestimated > 0 {
+		fmt.Printf("\n%d installed clusters have no estimated cost\n", unestimated)
+	}
+	return nil
+}
+
+// group returns the group the cost of a cluster is totalled in.
+func (o *CostReportOptions) group(cd *hivev1.ClusterDeployment) string {
+	if o.GroupBy != "" {
+		if value, ok := cd.Labels[o.GroupBy]; ok {
+			return value
+		}
+		return "<none>"
+	}
+	if poolRef := cd.Spec.ClusterPoolRef; poolRef != nil {
+		return poolRef.Namespace + "/" + poolRef.PoolName
+	}
+	return "<none>"
+}
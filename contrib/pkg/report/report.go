@@ -16,5 +16,7 @@ func NewClusterReportCommand() *cobra.Command {
 	}
 	cmd.AddCommand(NewProvisioningReportCommand())
 	cmd.AddCommand(NewDeprovisioningReportCommand())
+	cmd.AddCommand(NewCostReportCommand())
+	cmd.AddCommand(NewCloudResourcesReportCommand())
 	return cmd
 }
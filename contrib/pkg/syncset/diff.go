@@ -0,0 +1,197 @@
+package syncset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/contrib/pkg/utils"
+)
+
+// DiffOptions is the set of options for rendering SyncSet dry-run diffs.
+type DiffOptions struct {
+	// ClusterName is the name of the ClusterDeployment to render diffs for.
+	ClusterName string
+	// Namespace is the namespace of the ClusterDeployment(s) to render diffs for.
+	Namespace string
+	// Selector is a label selector of the ClusterDeployments to render diffs for.
+	Selector string
+	// SyncSetName limits the diffs to those of the SyncSet or SelectorSyncSet with the given name.
+	SyncSetName string
+
+	log log.FieldLogger
+	out io.Writer
+}
+
+// NewDiffCommand creates a command that renders the dry-run results of SyncSets and SelectorSyncSets.
+func NewDiffCommand() *cobra.Command {
+	opt := &DiffOptions{
+		log: log.WithField("command", "syncset diff"),
+		out: os.Stdout,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff [CLUSTER_DEPLOYMENT_NAME]",
+		Short: "Prints the changes that SyncSets in dry-run mode would make to clusters",
+		Long: "Prints the changes recorded in ClusterSync status by SyncSets and SelectorSyncSets in dry-run mode, " +
+			"either for the named ClusterDeployment or for all ClusterDeployments matching a label selector",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if len(args) > 0 {
+				opt.ClusterName = args[0]
+			}
+			if err := opt.Validate(); err != nil {
+				opt.log.WithError(err).Fatal("invalid arguments")
+			}
+			c, err := utils.GetClient()
+			if err != nil {
+				opt.log.WithError(err).Fatal("error creating kube clients")
+			}
+			if err := opt.Run(c); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "",
+		"Namespace of the ClusterDeployment(s). Defaults to the current namespace when a ClusterDeployment name is given, otherwise all namespaces.")
+	flags.StringVarP(&opt.Selector, "selector", "l", "", "Label selector of the ClusterDeployments to print diffs for.")
+	flags.StringVar(&opt.SyncSetName, "syncset", "", "Only print diffs for the SyncSet or SelectorSyncSet with this name.")
+	return cmd
+}
+
+// Validate ensures that option values make sense
+func (o *DiffOptions) Validate() error {
+	if o.ClusterName == "" && o.Selector == "" {
+		return errors.New("either a ClusterDeployment name or a label selector is required")
+	}
+	if o.ClusterName != "" && o.Selector != "" {
+		return errors.New("a ClusterDeployment name and a label selector cannot both be specified")
+	}
+	if o.Selector != "" {
+		if _, err := labels.Parse(o.Selector); err != nil {
+			return errors.Wrap(err, "invalid label selector")
+		}
+	}
+	return nil
+}
+
+// Run executes the command
+func (o *DiffOptions) Run(c client.Client) error {
+	clusters, err := o.targetClusters(c)
+	if err != nil {
+		return err
+	}
+	for _, cluster := range clusters {
+		clusterSync := &hiveintv1alpha1.ClusterSync{}
+		switch err := c.Get(context.Background(), cluster, clusterSync); {
+		case apierrors.IsNotFound(err):
+			fmt.Fprintf(o.out, "Cluster: %s\n  no ClusterSync found\n\n", cluster)
+			continue
+		case err != nil:
+			return errors.Wrapf(err, "could not get ClusterSync for %s", cluster)
+		}
+		o.printClusterSync(clusterSync)
+	}
+	return nil
+}
+
+func (o *DiffOptions) targetClusters(c client.Client) ([]types.NamespacedName, error) {
+	if o.ClusterName != "" {
+		namespace := o.Namespace
+		if namespace == "" {
+			var err error
+			if namespace, err = utils.DefaultNamespace(); err != nil {
+				return nil, errors.Wrap(err, "cannot determine default namespace")
+			}
+		}
+		return []types.NamespacedName{{Namespace: namespace, Name: o.ClusterName}}, nil
+	}
+	selector, err := labels.Parse(o.Selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid label selector")
+	}
+	cdList := &hivev1.ClusterDeploymentList{}
+	listOpts := []client.ListOption{client.MatchingLabelsSelector{Selector: selector}}
+	if o.Namespace != "" {
+		listOpts = append(listOpts, client.InNamespace(o.Namespace))
+	}
+	if err := c.List(context.Background(), cdList, listOpts...); err != nil {
+		return nil, errors.Wrap(err, "could not list ClusterDeployments")
+	}
+	clusters := make([]types.NamespacedName, len(cdList.Items))
+	for i, cd := range cdList.Items {
+		clusters[i] = types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}
+	}
+	return clusters, nil
+}
+
+func (o *DiffOptions) printClusterSync(clusterSync *hiveintv1alpha1.ClusterSync) {
+	fmt.Fprintf(o.out, "Cluster: %s/%s\n", clusterSync.Namespace, clusterSync.Name)
+	printed := o.printSyncStatuses("SyncSet", clusterSync.Status.SyncSets)
+	printed = o.printSyncStatuses("SelectorSyncSet", clusterSync.Status.SelectorSyncSets) || printed
+	if !printed {
+		fmt.Fprintln(o.out, "  no dry-run results")
+	}
+	fmt.Fprintln(o.out)
+}
+
+func (o *DiffOptions) printSyncStatuses(kind string, statuses []hiveintv1alpha1.SyncStatus) (printed bool) {
+	for _, status := range statuses {
+		if o.SyncSetName != "" && status.Name != o.SyncSetName {
+			continue
+		}
+		if len(status.DryRunResults) == 0 {
+			continue
+		}
+		printed = true
+		fmt.Fprintf(o.out, "  %s %s (generation %d, %s):\n", kind, status.Name, status.ObservedGeneration, status.LastTransitionTime.Format("2006-01-02 15:04:05"))
+		for _, result := range status.DryRunResults {
+			fmt.Fprintf(o.out, "    %s %s\n", dryRunSymbol(result), formatResource(result.SyncResourceReference))
+			switch {
+			case result.FailureMessage != "":
+				fmt.Fprintf(o.out, "        error: %s\n", result.FailureMessage)
+			case len(result.ChangedFields) > 0:
+				fmt.Fprintf(o.out, "        changed: %s\n", strings.Join(result.ChangedFields, ", "))
+			}
+		}
+	}
+	return
+}
+
+func dryRunSymbol(result hiveintv1alpha1.DryRunResult) string {
+	if result.FailureMessage != "" {
+		return "!"
+	}
+	switch result.Action {
+	case hiveintv1alpha1.CreateDryRunAction:
+		return "+"
+	case hiveintv1alpha1.UpdateDryRunAction:
+		return "~"
+	default:
+		return "="
+	}
+}
+
+func formatResource(ref hiveintv1alpha1.SyncResourceReference) string {
+	name := ref.Name
+	if ref.Namespace != "" {
+		name = ref.Namespace + "/" + ref.Name
+	}
+	return fmt.Sprintf("%s %s %s", ref.APIVersion, ref.Kind, name)
+}
@@ -573,6 +573,11 @@ func NewClientFromSecret(secret *corev1.Secret, region string) (Client, error) {
 	return newClientFromSession(s)
 }
 
+// NewClientFromSession creates our client wrapper object for the actual AWS clients we use from an AWS session.
+func NewClientFromSession(s *session.Session) (Client, error) {
+	return newClientFromSession(s)
+}
+
 func newClientFromSession(s *session.Session, cfgs ...*aws.Config) (Client, error) {
 	return &awsClient{
 		ec2Client:     ec2.New(s, cfgs...),
@@ -10,6 +10,7 @@ import (
 
 	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	dns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	resources "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
 	gomock "github.com/golang/mock/gomock"
 	azureclient "github.com/openshift/hive/pkg/azureclient"
 )
@@ -215,6 +216,21 @@ func (mr *MockClientMockRecorder) ListResourceSKUs(ctx, filter interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceSKUs", reflect.TypeOf((*MockClient)(nil).ListResourceSKUs), ctx, filter)
 }
 
+// ListResourcesByResourceGroup mocks base method.
+func (m *MockClient) ListResourcesByResourceGroup(ctx context.Context, resourceGroupName string) (azureclient.ResourceListResultPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourcesByResourceGroup", ctx, resourceGroupName)
+	ret0, _ := ret[0].(azureclient.ResourceListResultPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourcesByResourceGroup indicates an expected call of ListResourcesByResourceGroup.
+func (mr *MockClientMockRecorder) ListResourcesByResourceGroup(ctx, resourceGroupName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourcesByResourceGroup", reflect.TypeOf((*MockClient)(nil).ListResourcesByResourceGroup), ctx, resourceGroupName)
+}
+
 // StartVirtualMachine mocks base method.
 func (m *MockClient) StartVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesStartFuture, error) {
 	m.ctrl.T.Helper()
@@ -424,3 +440,68 @@ func (mr *MockImageListResultPageMockRecorder) Values() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Values", reflect.TypeOf((*MockImageListResultPage)(nil).Values))
 }
+
+// MockResourceListResultPage is a mock of ResourceListResultPage interface.
+type MockResourceListResultPage struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceListResultPageMockRecorder
+}
+
+// MockResourceListResultPageMockRecorder is the mock recorder for MockResourceListResultPage.
+type MockResourceListResultPageMockRecorder struct {
+	mock *MockResourceListResultPage
+}
+
+// NewMockResourceListResultPage creates a new mock instance.
+func NewMockResourceListResultPage(ctrl *gomock.Controller) *MockResourceListResultPage {
+	mock := &MockResourceListResultPage{ctrl: ctrl}
+	mock.recorder = &MockResourceListResultPageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourceListResultPage) EXPECT() *MockResourceListResultPageMockRecorder {
+	return m.recorder
+}
+
+// NextWithContext mocks base method.
+func (m *MockResourceListResultPage) NextWithContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextWithContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NextWithContext indicates an expected call of NextWithContext.
+func (mr *MockResourceListResultPageMockRecorder) NextWithContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextWithContext", reflect.TypeOf((*MockResourceListResultPage)(nil).NextWithContext), ctx)
+}
+
+// NotDone mocks base method.
+func (m *MockResourceListResultPage) NotDone() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotDone")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NotDone indicates an expected call of NotDone.
+func (mr *MockResourceListResultPageMockRecorder) NotDone() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotDone", reflect.TypeOf((*MockResourceListResultPage)(nil).NotDone))
+}
+
+// Values mocks base method.
+func (m *MockResourceListResultPage) Values() []resources.GenericResourceExpanded {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Values")
+	ret0, _ := ret[0].([]resources.GenericResourceExpanded)
+	return ret0
+}
+
+// Values indicates an expected call of Values.
+func (mr *MockResourceListResultPageMockRecorder) Values() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Values", reflect.TypeOf((*MockResourceListResultPage)(nil).Values))
+}
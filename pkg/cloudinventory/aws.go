@@ -0,0 +1,108 @@
+package cloudinventory
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	awsclient "github.com/openshift/hive/pkg/awsclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// awsServiceNameTag is set by the cloud provider on the load balancers, and their security groups, it creates
+	// for Services.
+	awsServiceNameTag = "kubernetes.io/service-name"
+	// awsPersistentVolumeNameTag is set by the storage drivers on the volumes they create for PersistentVolumes.
+	awsPersistentVolumeNameTag = "kubernetes.io/created-for/pv/name"
+)
+
+func init() {
+	registerLister(&awsLister{awsClientFn: getAWSClient})
+}
+
+// Ensure awsLister implements the Lister interface. This will fail at compile time when false.
+var _ Lister = &awsLister{}
+
+// awsLister lists the resources tagged with kubernetes.io/cluster/<infraID> in the region of the cluster.
+type awsLister struct {
+	// awsClientFn is the function to build an AWS client, here for testing
+	awsClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (awsclient.Client, error)
+}
+
+// CanHandle returns true if the ClusterDeployment is on AWS.
+func (a *awsLister) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.AWS != nil
+}
+
+// ListResources lists the resources tagged for the cluster with the resource groups tagging API.
+func (a *awsLister) ListResources(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) ([]Resource, error) {
+	awsClient, err := a.awsClientFn(cd, c, logger)
+	if err != nil {
+		return nil, err
+	}
+	var resources []Resource
+	err = awsClient.GetResourcesPages(&resourcegroupstaggingapi.GetResourcesInput{
+		TagFilters: []*resourcegroupstaggingapi.TagFilter{{
+			Key: aws.String("kubernetes.io/cluster/" + cd.Spec.ClusterMetadata.InfraID),
+		}},
+	}, func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+		for _, mapping := range page.ResourceTagMappingList {
+			resources = append(resources, awsResource(mapping))
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.WithField("count", len(resources)).Debug("listed tagged AWS resources")
+	return resources, nil
+}
+
+func awsResource(mapping *resourcegroupstaggingapi.ResourceTagMapping) Resource {
+	r := Resource{ID: aws.StringValue(mapping.ResourceARN)}
+	if parsed, err := arn.Parse(r.ID); err == nil {
+		r.Region = parsed.Region
+		r.Kind = parsed.Service
+		// The resource type precedes the resource ID, separated by / or :, when the service has several types.
+		if i := strings.IndexAny(parsed.Resource, "/:"); i > 0 {
+			r.Kind += ":" + parsed.Resource[:i]
+		}
+	}
+	for _, tag := range mapping.Tags {
+		switch aws.StringValue(tag.Key) {
+		case awsServiceNameTag:
+			r.Services = []string{aws.StringValue(tag.Value)}
+		case awsPersistentVolumeNameTag:
+			r.PersistentVolume = aws.StringValue(tag.Value)
+		}
+	}
+	return r
+}
+
+func getAWSClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
+	options := awsclient.Options{
+		Region: cd.Spec.Platform.AWS.Region,
+		CredentialsSource: awsclient.CredentialsSource{
+			Secret: &awsclient.SecretCredentialsSource{
+				Namespace: cd.Namespace,
+				Ref:       &cd.Spec.Platform.AWS.CredentialsSecretRef,
+			},
+			AssumeRole: &awsclient.AssumeRoleCredentialsSource{
+				SecretRef: corev1.SecretReference{
+					Name:      controllerutils.AWSServiceProviderSecretName(""),
+					Namespace: controllerutils.GetHiveNamespace(),
+				},
+				Role: cd.Spec.Platform.AWS.CredentialsAssumeRole,
+			},
+		},
+	}
+
+	return awsclient.New(c, options)
+}
@@ -0,0 +1,94 @@
+package cloudinventory
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/awsclient"
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+)
+
+func TestAWSListResources(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	awsClient := mockaws.NewMockClient(mockCtrl)
+	awsClient.EXPECT().GetResourcesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+			if assert.Len(t, input.TagFilters, 1, "unexpected tag filters") {
+				assert.Equal(t, "kubernetes.io/cluster/test-infra", aws.StringValue(input.TagFilters[0].Key), "unexpected tag filter")
+			}
+			fn(&resourcegroupstaggingapi.GetResourcesOutput{
+				ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					awsMapping("arn:aws:ec2:us-east-1:123456789012:instance/i-0123"),
+					awsMapping("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/a0123",
+						awsServiceNameTag, "openshift-ingress/router-default"),
+				},
+			}, false)
+			fn(&resourcegroupstaggingapi.GetResourcesOutput{
+				ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					awsMapping("arn:aws:ec2:us-east-1:123456789012:volume/vol-0123",
+						awsPersistentVolumeNameTag, "pvc-0123", "kubernetes.io/created-for/pvc/name", "data"),
+					awsMapping("arn:aws:s3:::test-infra-image-registry"),
+				},
+			}, true)
+			return nil
+		})
+	lister := &awsLister{awsClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (awsclient.Client, error) {
+		return awsClient, nil
+	}}
+	cd := &hivev1.ClusterDeployment{
+		Spec: hivev1.ClusterDeploymentSpec{
+			Platform:        hivev1.Platform{AWS: &hivev1aws.Platform{Region: "us-east-1"}},
+			ClusterMetadata: &hivev1.ClusterMetadata{InfraID: "test-infra"},
+		},
+	}
+	require.True(t, lister.CanHandle(cd), "expected lister to handle AWS cluster")
+
+	resources, err := lister.ListResources(cd, nil, log.New())
+	require.NoError(t, err, "unexpected error listing resources")
+	assert.Equal(t, []Resource{
+		{
+			ID:     "arn:aws:ec2:us-east-1:123456789012:instance/i-0123",
+			Kind:   "ec2:instance",
+			Region: "us-east-1",
+		},
+		{
+			ID:       "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/a0123",
+			Kind:     "elasticloadbalancing:loadbalancer",
+			Region:   "us-east-1",
+			Services: []string{"openshift-ingress/router-default"},
+		},
+		{
+			ID:               "arn:aws:ec2:us-east-1:123456789012:volume/vol-0123",
+			Kind:             "ec2:volume",
+			Region:           "us-east-1",
+			PersistentVolume: "pvc-0123",
+		},
+		{
+			ID:   "arn:aws:s3:::test-infra-image-registry",
+			Kind: "s3",
+		},
+	}, resources, "unexpected resources")
+}
+
+func awsMapping(arn string, tags ...string) *resourcegroupstaggingapi.ResourceTagMapping {
+	mapping := &resourcegroupstaggingapi.ResourceTagMapping{
+		ResourceARN: aws.String(arn),
+		Tags: []*resourcegroupstaggingapi.Tag{{
+			Key:   aws.String("kubernetes.io/cluster/test-infra"),
+			Value: aws.String("owned"),
+		}},
+	}
+	for i := 0; i+1 < len(tags); i += 2 {
+		mapping.Tags = append(mapping.Tags, &resourcegroupstaggingapi.Tag{Key: aws.String(tags[i]), Value: aws.String(tags[i+1])})
+	}
+	return mapping
+}
@@ -0,0 +1,92 @@
+package cloudinventory
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// azureServiceTag is set by the cloud provider on the public IPs it creates for Services. Its value is a comma
+	// separated list of the Services sharing the IP.
+	azureServiceTag = "k8s-azure-service"
+	// azurePersistentVolumeNameTag is set by the storage drivers on the disks they create for PersistentVolumes.
+	azurePersistentVolumeNameTag = "kubernetes.io-created-for-pv-name"
+)
+
+func init() {
+	registerLister(&azureLister{azureClientFn: getAzureClient})
+}
+
+// Ensure azureLister implements the Lister interface. This will fail at compile time when false.
+var _ Lister = &azureLister{}
+
+// azureLister lists the resources in the resource group of the cluster, which is deleted along with its resources
+// when the cluster is deprovisioned.
+type azureLister struct {
+	// azureClientFn is the function to build an Azure client, here for testing
+	azureClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (azureclient.Client, error)
+}
+
+// CanHandle returns true if the ClusterDeployment is on Azure.
+func (a *azureLister) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.Azure != nil
+}
+
+// ListResources lists the resources in the resource group of the cluster.
+func (a *azureLister) ListResources(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) ([]Resource, error) {
+	azureClient, err := a.azureClientFn(cd, c, logger)
+	if err != nil {
+		return nil, err
+	}
+	resourceGroup := cd.Spec.ClusterMetadata.InfraID + "-rg"
+	if md := cd.Spec.ClusterMetadata.Platform; md != nil && md.Azure != nil && md.Azure.ResourceGroupName != nil {
+		resourceGroup = *md.Azure.ResourceGroupName
+	}
+	logger = logger.WithField("resourceGroup", resourceGroup)
+
+	ctx := context.TODO()
+	page, err := azureClient.ListResourcesByResourceGroup(ctx, resourceGroup)
+	if err != nil {
+		return nil, err
+	}
+	var resources []Resource
+	for ; page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range page.Values() {
+			r := Resource{
+				ID:     to.String(res.ID),
+				Kind:   to.String(res.Type),
+				Region: to.String(res.Location),
+			}
+			if services := to.String(res.Tags[azureServiceTag]); services != "" {
+				r.Services = strings.Split(services, ",")
+			}
+			r.PersistentVolume = to.String(res.Tags[azurePersistentVolumeNameTag])
+			resources = append(resources, r)
+		}
+	}
+	logger.WithField("count", len(resources)).Debug("listed Azure resources")
+	return resources, nil
+}
+
+func getAzureClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (azureclient.Client, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.Azure.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch Azure credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch Azure credentials secret")
+	}
+	return azureclient.NewClientFromSecret(secret, cd.Spec.Platform.Azure.CloudName.Name())
+}
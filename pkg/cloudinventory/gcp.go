@@ -0,0 +1,127 @@
+package cloudinventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+// gcpPersistentVolumeNameKey is set by the storage drivers in the JSON description of the disks they create for
+// PersistentVolumes.
+const gcpPersistentVolumeNameKey = "kubernetes.io/created-for/pv/name"
+
+func init() {
+	registerLister(&gcpLister{gcpClientFn: getGCPClient})
+}
+
+// Ensure gcpLister implements the Lister interface. This will fail at compile time when false.
+var _ Lister = &gcpLister{}
+
+// gcpLister lists the compute instances and forwarding rules named for the cluster, and the disks labelled for it.
+// The forwarding rules the cloud provider creates for Services are not named for the cluster, so are not listed.
+type gcpLister struct {
+	// gcpClientFn is the function to build a GCP client, here for testing
+	gcpClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (gcpclient.Client, error)
+}
+
+// CanHandle returns true if the ClusterDeployment is on GCP.
+func (a *gcpLister) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.GCP != nil
+}
+
+// ListResources lists the compute resources of the cluster.
+func (a *gcpLister) ListResources(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) ([]Resource, error) {
+	gcpClient, err := a.gcpClientFn(cd, c, logger)
+	if err != nil {
+		return nil, err
+	}
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	nameFilter := fmt.Sprintf("name eq \"%s-.*\"", infraID)
+	var resources []Resource
+
+	err = gcpClient.ListComputeInstances(gcpclient.ListComputeInstancesOptions{Filter: nameFilter},
+		func(list *compute.InstanceAggregatedList) error {
+			for _, scopedList := range list.Items {
+				for _, instance := range scopedList.Instances {
+					resources = append(resources, Resource{
+						ID:     instance.SelfLink,
+						Kind:   "compute.instances",
+						Region: path.Base(instance.Zone),
+					})
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = gcpClient.ListComputeDisks(gcpclient.ListComputeDisksOptions{
+		Filter: fmt.Sprintf("labels.kubernetes-io-cluster-%s eq \"owned\"", infraID),
+	}, func(list *compute.DiskAggregatedList) error {
+		for _, scopedList := range list.Items {
+			for _, disk := range scopedList.Disks {
+				resources = append(resources, Resource{
+					ID:               disk.SelfLink,
+					Kind:             "compute.disks",
+					Region:           path.Base(disk.Zone),
+					PersistentVolume: gcpDescription(disk.Description)[gcpPersistentVolumeNameKey],
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = gcpClient.ListComputeForwardingRules(gcpclient.ListComputeForwardingRulesOptions{Filter: nameFilter},
+		func(list *compute.ForwardingRuleAggregatedList) error {
+			for _, scopedList := range list.Items {
+				for _, rule := range scopedList.ForwardingRules {
+					resources = append(resources, Resource{
+						ID:     rule.SelfLink,
+						Kind:   "compute.forwardingRules",
+						Region: path.Base(rule.Region),
+					})
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	logger.WithField("count", len(resources)).Debug("listed GCP resources")
+	return resources, nil
+}
+
+// gcpDescription returns the keys of a description set by Kubernetes, which is a JSON object. Other descriptions
+// have no keys.
+func gcpDescription(description string) map[string]string {
+	keys := map[string]string{}
+	if err := json.Unmarshal([]byte(description), &keys); err != nil {
+		return nil
+	}
+	return keys
+}
+
+func getGCPClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (gcpclient.Client, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.GCP.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch GCP credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch GCP credentials secret")
+	}
+	return gcpclient.NewClientFromSecret(secret)
+}
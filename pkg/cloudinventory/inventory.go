@@ -0,0 +1,254 @@
+package cloudinventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// maxOrphansListed is the maximum number of orphaned resources listed in the status of a ClusterDeployment.
+const maxOrphansListed = 50
+
+// Resource is a cloud resource of a cluster.
+type Resource struct {
+	// ID identifies the resource on its cloud platform, e.g. its ARN on AWS.
+	ID string
+	// Kind is the kind of the resource, e.g. ec2:volume on AWS.
+	Kind string
+	// Region is the region or zone of the resource, when it has one.
+	Region string
+	// Services are the namespace/names of the Services the resource was created for, when it is a load balancer or
+	// a resource of one.
+	Services []string
+	// PersistentVolume is the name of the PersistentVolume the resource was created for, when it is a volume.
+	PersistentVolume string
+}
+
+// Lister lists the cloud resources of clusters on a platform.
+type Lister interface {
+	// CanHandle returns true if the lister can list the resources of the ClusterDeployment.
+	CanHandle(cd *hivev1.ClusterDeployment) bool
+
+	// ListResources lists the cloud resources of the ClusterDeployment.
+	ListResources(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) ([]Resource, error)
+}
+
+var listers []Lister
+
+func registerLister(lister Lister) {
+	listers = append(listers, lister)
+}
+
+// ListerFor returns the lister for the platform of the ClusterDeployment, or nil if its platform is not supported.
+func ListerFor(cd *hivev1.ClusterDeployment) Lister {
+	for _, lister := range listers {
+		if lister.CanHandle(cd) {
+			return lister
+		}
+	}
+	return nil
+}
+
+// ListResources lists the cloud resources of an installed ClusterDeployment, using the credentials of the
+// ClusterDeployment.
+func ListResources(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) ([]Resource, error) {
+	if cd.Spec.ClusterMetadata == nil || cd.Spec.ClusterMetadata.InfraID == "" {
+		return nil, errors.New("cluster has no infra ID")
+	}
+	lister := ListerFor(cd)
+	if lister == nil {
+		return nil, fmt.Errorf("listing cloud resources is not supported for the platform of the cluster")
+	}
+	resources, err := lister.ListResources(cd, c, logger)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list cloud resources")
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].ID < resources[j].ID })
+	return resources, nil
+}
+
+// Expectations are the objects of a cluster its cloud resources are expected to have been created for.
+type Expectations struct {
+	// LoadBalancerServices are the namespace/names of the Services of type LoadBalancer.
+	LoadBalancerServices sets.String
+	// PersistentVolumes are the names of the PersistentVolumes.
+	PersistentVolumes sets.String
+}
+
+// LoadExpectations lists the Services and PersistentVolumes of a cluster.
+func LoadExpectations(ctx context.Context, remoteClient client.Client) (*Expectations, error) {
+	expected := &Expectations{
+		LoadBalancerServices: sets.NewString(),
+		PersistentVolumes:    sets.NewString(),
+	}
+	services := &corev1.ServiceList{}
+	if err := remoteClient.List(ctx, services); err != nil {
+		return nil, errors.Wrap(err, "could not list Services")
+	}
+	for _, svc := range services.Items {
+		if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			expected.LoadBalancerServices.Insert(svc.Namespace + "/" + svc.Name)
+		}
+	}
+	pvs := &corev1.PersistentVolumeList{}
+	if err := remoteClient.List(ctx, pvs); err != nil {
+		return nil, errors.Wrap(err, "could not list PersistentVolumes")
+	}
+	for _, pv := range pvs.Items {
+		expected.PersistentVolumes.Insert(pv.Name)
+	}
+	return expected, nil
+}
+
+// FindOrphans returns the resources that were created for Services or PersistentVolumes the cluster no longer has.
+// Resources created by the installer are not orphans while the cluster exists.
+func FindOrphans(resources []Resource, expected *Expectations) []hivev1.OrphanedCloudResource {
+	var orphans []hivev1.OrphanedCloudResource
+	for _, r := range resources {
+		switch {
+		case len(r.Services) > 0:
+			if expected.LoadBalancerServices.HasAny(r.Services...) {
+				continue
+			}
+			orphans = append(orphans, hivev1.OrphanedCloudResource{
+				ID:         r.ID,
+				Kind:       r.Kind,
+				Reason:     hivev1.OrphanedCloudResourceServiceDeleted,
+				CreatedFor: strings.Join(r.Services, ","),
+			})
+		case r.PersistentVolume != "":
+			if expected.PersistentVolumes.Has(r.PersistentVolume) {
+				continue
+			}
+			orphans = append(orphans, hivev1.OrphanedCloudResource{
+				ID:         r.ID,
+				Kind:       r.Kind,
+				Reason:     hivev1.OrphanedCloudResourcePersistentVolumeDeleted,
+				CreatedFor: r.PersistentVolume,
+			})
+		}
+	}
+	return orphans
+}
+
+// DeprovisionEnded returns true when the ClusterDeprovision of a ClusterDeployment has completed or failed. The
+// resources the cluster still has then are orphans.
+func DeprovisionEnded(deprovision *hivev1.ClusterDeprovision) bool {
+	if deprovision.Status.Completed {
+		return true
+	}
+	cond := controllerutils.FindCondition(deprovision.Status.Conditions, hivev1.DeprovisionFailedClusterDeprovisionCondition)
+	return cond != nil && cond.Status == corev1.ConditionTrue
+}
+
+// ClusterDeploymentForDeprovision returns a ClusterDeployment with what the listers need to list the resources of
+// the cluster of a ClusterDeprovision, for when the ClusterDeployment itself is already gone. It returns nil if the
+// platform of the deprovision is not supported.
+func ClusterDeploymentForDeprovision(deprovision *hivev1.ClusterDeprovision) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: deprovision.Namespace,
+			Name:      deprovision.Name,
+			Labels:    map[string]string{},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: deprovision.Spec.ClusterName,
+			BaseDomain:  deprovision.Spec.BaseDomain,
+			Installed:   true,
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				InfraID:   deprovision.Spec.InfraID,
+				ClusterID: deprovision.Spec.ClusterID,
+			},
+		},
+	}
+	platform := deprovision.Spec.Platform
+	switch {
+	case platform.AWS != nil:
+		cd.Labels[hivev1.HiveClusterPlatformLabel] = constants.PlatformAWS
+		cd.Spec.Platform.AWS = &hivev1aws.Platform{
+			Region:                platform.AWS.Region,
+			CredentialsAssumeRole: platform.AWS.CredentialsAssumeRole,
+		}
+		if platform.AWS.CredentialsSecretRef != nil {
+			cd.Spec.Platform.AWS.CredentialsSecretRef = *platform.AWS.CredentialsSecretRef
+		}
+	case platform.Azure != nil:
+		cd.Labels[hivev1.HiveClusterPlatformLabel] = constants.PlatformAzure
+		cd.Spec.Platform.Azure = &hivev1azure.Platform{}
+		if platform.Azure.CredentialsSecretRef != nil {
+			cd.Spec.Platform.Azure.CredentialsSecretRef = *platform.Azure.CredentialsSecretRef
+		}
+		if platform.Azure.CloudName != nil {
+			cd.Spec.Platform.Azure.CloudName = *platform.Azure.CloudName
+		}
+		cd.Spec.ClusterMetadata.Platform = &hivev1.ClusterPlatformMetadata{
+			Azure: &hivev1azure.Metadata{ResourceGroupName: platform.Azure.ResourceGroupName},
+		}
+	case platform.GCP != nil:
+		cd.Labels[hivev1.HiveClusterPlatformLabel] = constants.PlatformGCP
+		cd.Spec.Platform.GCP = &hivev1gcp.Platform{Region: platform.GCP.Region}
+		if platform.GCP.CredentialsSecretRef != nil {
+			cd.Spec.Platform.GCP.CredentialsSecretRef = *platform.GCP.CredentialsSecretRef
+		}
+	default:
+		return nil
+	}
+	return cd
+}
+
+// DeprovisionOrphans returns all the resources as orphans of a deprovision of the cluster.
+func DeprovisionOrphans(resources []Resource) []hivev1.OrphanedCloudResource {
+	orphans := make([]hivev1.OrphanedCloudResource, len(resources))
+	for i, r := range resources {
+		orphans[i] = hivev1.OrphanedCloudResource{
+			ID:         r.ID,
+			Kind:       r.Kind,
+			Reason:     hivev1.OrphanedCloudResourceClusterDeprovisioned,
+			CreatedFor: createdFor(r),
+		}
+	}
+	return orphans
+}
+
+func createdFor(r Resource) string {
+	if len(r.Services) > 0 {
+		return strings.Join(r.Services, ",")
+	}
+	return r.PersistentVolume
+}
+
+// NewStatus returns the status of a ClusterDeployment with the given resources and orphans, scanned at now.
+func NewStatus(resources []Resource, orphans []hivev1.OrphanedCloudResource, now metav1.Time) *hivev1.CloudResourcesStatus {
+	counts := map[string]int32{}
+	for _, r := range resources {
+		counts[r.Kind]++
+	}
+	status := &hivev1.CloudResourcesStatus{
+		OrphanCount:  int32(len(orphans)),
+		LastScanTime: now,
+	}
+	for kind, count := range counts {
+		status.Resources = append(status.Resources, hivev1.CloudResourceCount{Kind: kind, Count: count})
+	}
+	sort.Slice(status.Resources, func(i, j int) bool { return status.Resources[i].Kind < status.Resources[j].Kind })
+	if len(orphans) > maxOrphansListed {
+		orphans = orphans[:maxOrphansListed]
+	}
+	status.Orphans = orphans
+	return status
+}
@@ -0,0 +1,97 @@
+package cloudinventory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+)
+
+var testResources = []Resource{
+	{ID: "i-1", Kind: "ec2:instance"},
+	{ID: "lb-1", Kind: "elasticloadbalancing:loadbalancer", Services: []string{"ns/kept"}},
+	{ID: "lb-2", Kind: "elasticloadbalancing:loadbalancer", Services: []string{"ns/deleted"}},
+	{ID: "ip-1", Kind: "Microsoft.Network/publicIPAddresses", Services: []string{"ns/deleted", "ns/kept"}},
+	{ID: "vol-1", Kind: "ec2:volume", PersistentVolume: "pvc-kept"},
+	{ID: "vol-2", Kind: "ec2:volume", PersistentVolume: "pvc-deleted"},
+}
+
+func TestLoadExpectations(t *testing.T) {
+	remoteClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kept"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cluster-ip"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+		},
+		&corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pvc-kept"}},
+	).Build()
+
+	expected, err := LoadExpectations(context.TODO(), remoteClient)
+	require.NoError(t, err, "unexpected error loading expectations")
+	assert.Equal(t, []string{"ns/kept"}, expected.LoadBalancerServices.List(), "unexpected services")
+	assert.Equal(t, []string{"pvc-kept"}, expected.PersistentVolumes.List(), "unexpected persistent volumes")
+}
+
+func TestFindOrphans(t *testing.T) {
+	expected := &Expectations{
+		LoadBalancerServices: sets.NewString("ns/kept"),
+		PersistentVolumes:    sets.NewString("pvc-kept"),
+	}
+	assert.Equal(t, []hivev1.OrphanedCloudResource{
+		{
+			ID:         "lb-2",
+			Kind:       "elasticloadbalancing:loadbalancer",
+			Reason:     hivev1.OrphanedCloudResourceServiceDeleted,
+			CreatedFor: "ns/deleted",
+		},
+		{
+			ID:         "vol-2",
+			Kind:       "ec2:volume",
+			Reason:     hivev1.OrphanedCloudResourcePersistentVolumeDeleted,
+			CreatedFor: "pvc-deleted",
+		},
+	}, FindOrphans(testResources, expected), "unexpected orphans")
+}
+
+func TestDeprovisionOrphans(t *testing.T) {
+	orphans := DeprovisionOrphans(testResources)
+	if assert.Len(t, orphans, len(testResources), "expected all resources to be orphans") {
+		assert.Equal(t, hivev1.OrphanedCloudResource{
+			ID:         "ip-1",
+			Kind:       "Microsoft.Network/publicIPAddresses",
+			Reason:     hivev1.OrphanedCloudResourceClusterDeprovisioned,
+			CreatedFor: "ns/deleted,ns/kept",
+		}, orphans[3], "unexpected orphan")
+	}
+}
+
+func TestNewStatus(t *testing.T) {
+	now := metav1.NewTime(time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC))
+	var orphans []hivev1.OrphanedCloudResource
+	for i := 0; i < maxOrphansListed+10; i++ {
+		orphans = append(orphans, hivev1.OrphanedCloudResource{ID: fmt.Sprintf("vol-%d", i), Kind: "ec2:volume"})
+	}
+
+	status := NewStatus(testResources, orphans, now)
+	assert.Equal(t, []hivev1.CloudResourceCount{
+		{Kind: "Microsoft.Network/publicIPAddresses", Count: 1},
+		{Kind: "ec2:instance", Count: 1},
+		{Kind: "ec2:volume", Count: 2},
+		{Kind: "elasticloadbalancing:loadbalancer", Count: 2},
+	}, status.Resources, "unexpected resource counts")
+	assert.Equal(t, int32(maxOrphansListed+10), status.OrphanCount, "unexpected orphan count")
+	assert.Len(t, status.Orphans, maxOrphansListed, "unexpected orphans listed")
+	assert.Equal(t, now, status.LastScanTime, "unexpected scan time")
+}
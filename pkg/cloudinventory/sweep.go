@@ -0,0 +1,285 @@
+package cloudinventory
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	awsclient "github.com/openshift/hive/pkg/awsclient"
+)
+
+const (
+	// awsClusterTagPrefix is followed by the infra ID of a cluster in the key of the tag set by the installer and
+	// the cloud provider on the resources of the cluster.
+	awsClusterTagPrefix = "kubernetes.io/cluster/"
+
+	// describeInstancesBatchSize is the number of tag keys filtered on by each DescribeInstances call.
+	describeInstancesBatchSize = 100
+)
+
+// InfraID is an infra ID the resources of a cloud account are tagged for.
+type InfraID struct {
+	// ID is the infra ID.
+	ID string
+	// Region is the region of the resources.
+	Region string
+	// ResourceCount is the number of resources tagged for the infra ID.
+	ResourceCount int
+	// Created is when the oldest instance tagged for the infra ID was launched, or zero when it has no instances.
+	Created time.Time
+}
+
+// SweepAction is what the sweeper does with the resources of an unowned infra ID.
+type SweepAction string
+
+const (
+	// SweepActionDeprovision is for the resources of infra IDs older than the grace period.
+	SweepActionDeprovision SweepAction = "Deprovision"
+	// SweepActionGracePeriod is for the resources of infra IDs within the grace period, or of unknown age.
+	SweepActionGracePeriod SweepAction = "GracePeriod"
+	// SweepActionAllowlisted is for the resources of infra IDs matching the allowlist, which are never deprovisioned.
+	SweepActionAllowlisted SweepAction = "Allowlisted"
+	// SweepActionNotIncluded is for the resources of infra IDs matching no include pattern, which are only reported.
+	SweepActionNotIncluded SweepAction = "NotIncluded"
+)
+
+// UnownedInfraID is an infra ID no cluster owns, and what the sweeper does with its resources.
+type UnownedInfraID struct {
+	InfraID
+	Action SweepAction
+}
+
+// SweepOptions are the options of a sweep for the resources of unowned infra IDs.
+type SweepOptions struct {
+	// GracePeriod is how long the resources of an infra ID must have existed before they are deprovisioned.
+	GracePeriod time.Duration
+	// Allowlist are the infra IDs, or shell patterns matching them, whose resources are never deprovisioned.
+	Allowlist []string
+	// Include are the infra IDs, or shell patterns matching them, whose resources may be deprovisioned. The resources
+	// of other infra IDs are only reported.
+	Include []string
+}
+
+// Validate returns an error if the allowlist or the include list has malformed patterns.
+func (o SweepOptions) Validate() error {
+	for _, pattern := range o.Allowlist {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(err, "invalid allowlist pattern %q", pattern)
+		}
+	}
+	for _, pattern := range o.Include {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(err, "invalid include pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// matchesAny returns true if the infra ID matches one of the patterns.
+func matchesAny(patterns []string, infraID string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, infraID); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// FindAWSInfraIDs returns the infra IDs the resources in the region of the client are tagged for, sorted by ID. The
+// tagging API keeps returning instances for a while after they are terminated, so terminated instances are neither
+// counted nor used for the age of their infra ID, and infra IDs left with no resources are dropped.
+func FindAWSInfraIDs(awsClient awsclient.Client, region string) ([]InfraID, error) {
+	counts := map[string]int{}
+	taggedInstances := sets.NewString()
+	err := awsClient.GetResourcesPages(&resourcegroupstaggingapi.GetResourcesInput{},
+		func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+			for _, mapping := range page.ResourceTagMappingList {
+				tagged := false
+				for _, tag := range mapping.Tags {
+					if infraID := awsInfraID(aws.StringValue(tag.Key)); infraID != "" {
+						counts[infraID]++
+						tagged = true
+					}
+				}
+				if id := awsInstanceID(aws.StringValue(mapping.ResourceARN)); tagged && id != "" {
+					taggedInstances.Insert(id)
+				}
+			}
+			return !lastPage
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list tagged resources")
+	}
+
+	keys := make([]*string, 0, len(counts))
+	for infraID := range counts {
+		keys = append(keys, aws.String(awsClusterTagPrefix+infraID))
+	}
+	created := map[string]time.Time{}
+	// An instance tagged for the infra IDs of separate batches is returned for each batch, and only counted once.
+	described := sets.NewString()
+	for start := 0; start < len(keys); start += describeInstancesBatchSize {
+		end := start + describeInstancesBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		input := &ec2.DescribeInstancesInput{
+			Filters: []*ec2.Filter{{Name: aws.String("tag-key"), Values: keys[start:end]}},
+		}
+		for {
+			output, err := awsClient.DescribeInstances(input)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not describe instances")
+			}
+			for _, reservation := range output.Reservations {
+				for _, instance := range reservation.Instances {
+					if id := aws.StringValue(instance.InstanceId); id != "" {
+						if described.Has(id) {
+							continue
+						}
+						described.Insert(id)
+					}
+					launched := aws.TimeValue(instance.LaunchTime)
+					terminated := awsInstanceTerminated(instance)
+					for _, tag := range instance.Tags {
+						infraID := awsInfraID(aws.StringValue(tag.Key))
+						if _, ok := counts[infraID]; !ok {
+							continue
+						}
+						if terminated {
+							if taggedInstances.Has(aws.StringValue(instance.InstanceId)) {
+								counts[infraID]--
+							}
+							continue
+						}
+						if launched.IsZero() {
+							continue
+						}
+						if prev, ok := created[infraID]; !ok || launched.Before(prev) {
+							created[infraID] = launched
+						}
+					}
+				}
+			}
+			if aws.StringValue(output.NextToken) == "" {
+				break
+			}
+			input.NextToken = output.NextToken
+		}
+	}
+
+	infraIDs := make([]InfraID, 0, len(counts))
+	for infraID, count := range counts {
+		if count <= 0 {
+			continue
+		}
+		infraIDs = append(infraIDs, InfraID{
+			ID:            infraID,
+			Region:        region,
+			ResourceCount: count,
+			Created:       created[infraID],
+		})
+	}
+	sort.Slice(infraIDs, func(i, j int) bool { return infraIDs[i].ID < infraIDs[j].ID })
+	return infraIDs, nil
+}
+
+// awsInfraID returns the infra ID in the key of a kubernetes.io/cluster/<infraID> tag, or "" for other tags.
+func awsInfraID(key string) string {
+	if !strings.HasPrefix(key, awsClusterTagPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(key, awsClusterTagPrefix)
+}
+
+// awsInstanceID returns the ID of the instance of an ARN, or "" for the ARNs of other resources.
+func awsInstanceID(resourceARN string) string {
+	parsed, err := arn.Parse(resourceARN)
+	if err != nil || parsed.Service != "ec2" || !strings.HasPrefix(parsed.Resource, "instance/") {
+		return ""
+	}
+	return strings.TrimPrefix(parsed.Resource, "instance/")
+}
+
+// awsInstanceTerminated returns true if the instance is terminated or being terminated.
+func awsInstanceTerminated(instance *ec2.Instance) bool {
+	if instance.State == nil {
+		return false
+	}
+	switch aws.StringValue(instance.State.Name) {
+	case ec2.InstanceStateNameTerminated, ec2.InstanceStateNameShuttingDown:
+		return true
+	}
+	return false
+}
+
+// OwnedInfraIDs returns the infra IDs of the ClusterDeployments, ClusterProvisions and ClusterDeprovisions in all
+// namespaces. The resources of these infra IDs are managed by Hive, and are never swept.
+func OwnedInfraIDs(ctx context.Context, c client.Client) (sets.String, error) {
+	owned := sets.NewString()
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := c.List(ctx, cds); err != nil {
+		return nil, errors.Wrap(err, "could not list ClusterDeployments")
+	}
+	for _, cd := range cds.Items {
+		if cd.Spec.ClusterMetadata != nil && cd.Spec.ClusterMetadata.InfraID != "" {
+			owned.Insert(cd.Spec.ClusterMetadata.InfraID)
+		}
+	}
+	// The infra ID of a cluster is only set on its ClusterDeployment once installed.
+	provisions := &hivev1.ClusterProvisionList{}
+	if err := c.List(ctx, provisions); err != nil {
+		return nil, errors.Wrap(err, "could not list ClusterProvisions")
+	}
+	for _, provision := range provisions.Items {
+		for _, infraID := range []*string{provision.Spec.InfraID, provision.Spec.PrevInfraID} {
+			if infraID != nil && *infraID != "" {
+				owned.Insert(*infraID)
+			}
+		}
+	}
+	deprovisions := &hivev1.ClusterDeprovisionList{}
+	if err := c.List(ctx, deprovisions); err != nil {
+		return nil, errors.Wrap(err, "could not list ClusterDeprovisions")
+	}
+	for _, deprovision := range deprovisions.Items {
+		if deprovision.Spec.InfraID != "" {
+			owned.Insert(deprovision.Spec.InfraID)
+		}
+	}
+	return owned, nil
+}
+
+// FindUnowned returns the infra IDs no cluster owns, and what the sweeper does with their resources at now. Only the
+// resources of infra IDs matching the include list are deprovisioned. The resources of an infra ID of unknown age are
+// kept, as if within the grace period.
+func FindUnowned(infraIDs []InfraID, owned sets.String, opts SweepOptions, now time.Time) []UnownedInfraID {
+	var unowned []UnownedInfraID
+	for _, infraID := range infraIDs {
+		if owned.Has(infraID.ID) {
+			continue
+		}
+		u := UnownedInfraID{InfraID: infraID, Action: SweepActionDeprovision}
+		switch {
+		case matchesAny(opts.Allowlist, infraID.ID):
+			u.Action = SweepActionAllowlisted
+		case !matchesAny(opts.Include, infraID.ID):
+			u.Action = SweepActionNotIncluded
+		case infraID.Created.IsZero() || now.Sub(infraID.Created) < opts.GracePeriod:
+			u.Action = SweepActionGracePeriod
+		}
+		unowned = append(unowned, u)
+	}
+	return unowned
+}
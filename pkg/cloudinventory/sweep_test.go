@@ -0,0 +1,143 @@
+package cloudinventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+)
+
+func TestFindAWSInfraIDs(t *testing.T) {
+	launched := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockCtrl := gomock.NewController(t)
+	awsClient := mockaws.NewMockClient(mockCtrl)
+	awsClient.EXPECT().GetResourcesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+			assert.Empty(t, input.TagFilters, "unexpected tag filters")
+			fn(&resourcegroupstaggingapi.GetResourcesOutput{
+				ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					awsMapping("arn:aws:ec2:us-east-1:123456789012:instance/i-0123"),
+					awsMapping("arn:aws:ec2:us-east-1:123456789012:volume/vol-0123"),
+					awsMapping("arn:aws:ec2:us-east-1:123456789012:instance/i-0789"),
+					{
+						ResourceARN: aws.String("arn:aws:ec2:us-east-1:123456789012:instance/i-0abc"),
+						Tags: []*resourcegroupstaggingapi.Tag{
+							{Key: aws.String("kubernetes.io/cluster/deleted-infra"), Value: aws.String("owned")},
+						},
+					},
+					{
+						ResourceARN: aws.String("arn:aws:ec2:us-east-1:123456789012:volume/vol-0456"),
+						Tags: []*resourcegroupstaggingapi.Tag{
+							{Key: aws.String("kubernetes.io/cluster/other-infra"), Value: aws.String("owned")},
+							{Key: aws.String("Name"), Value: aws.String("other")},
+						},
+					},
+				},
+			}, true)
+			return nil
+		})
+	awsClient.EXPECT().DescribeInstances(gomock.Any()).DoAndReturn(
+		func(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+			if assert.Len(t, input.Filters, 1, "unexpected filters") {
+				assert.ElementsMatch(t,
+					[]string{"kubernetes.io/cluster/test-infra", "kubernetes.io/cluster/other-infra", "kubernetes.io/cluster/deleted-infra"},
+					aws.StringValueSlice(input.Filters[0].Values),
+					"unexpected tag keys")
+			}
+			return &ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{{
+					Instances: []*ec2.Instance{
+						awsInstance("i-0123", "test-infra", launched.Add(time.Hour), ec2.InstanceStateNameRunning),
+						awsInstance("i-0456", "test-infra", launched, ec2.InstanceStateNameRunning),
+						// The tagging API keeps returning terminated instances for a while.
+						awsInstance("i-0789", "test-infra", launched.Add(-time.Hour), ec2.InstanceStateNameTerminated),
+						awsInstance("i-0abc", "deleted-infra", launched, ec2.InstanceStateNameShuttingDown),
+					},
+				}},
+			}, nil
+		})
+
+	infraIDs, err := FindAWSInfraIDs(awsClient, "us-east-1")
+	require.NoError(t, err, "unexpected error finding infra IDs")
+	assert.Equal(t, []InfraID{
+		{ID: "other-infra", Region: "us-east-1", ResourceCount: 1},
+		{ID: "test-infra", Region: "us-east-1", ResourceCount: 2, Created: launched},
+	}, infraIDs, "unexpected infra IDs")
+}
+
+func TestOwnedInfraIDs(t *testing.T) {
+	c := testfake.NewFakeClientBuilder().WithRuntimeObjects(
+		&hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "installed"},
+			Spec:       hivev1.ClusterDeploymentSpec{ClusterMetadata: &hivev1.ClusterMetadata{InfraID: "installed-infra"}},
+		},
+		&hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "installing"},
+		},
+		&hivev1.ClusterProvision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "installing-0"},
+			Spec: hivev1.ClusterProvisionSpec{
+				InfraID:     aws.String("installing-infra"),
+				PrevInfraID: aws.String("failed-infra"),
+			},
+		},
+		&hivev1.ClusterDeprovision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "deleting"},
+			Spec:       hivev1.ClusterDeprovisionSpec{InfraID: "deleting-infra"},
+		},
+	).Build()
+
+	owned, err := OwnedInfraIDs(context.TODO(), c)
+	require.NoError(t, err, "unexpected error listing owned infra IDs")
+	assert.Equal(t, []string{"deleting-infra", "failed-infra", "installed-infra", "installing-infra"}, owned.List(),
+		"unexpected owned infra IDs")
+}
+
+func TestFindUnowned(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	infraIDs := []InfraID{
+		{ID: "ci-old", Created: now.Add(-48 * time.Hour)},
+		{ID: "new", Created: now.Add(-time.Hour)},
+		{ID: "old", Created: now.Add(-48 * time.Hour)},
+		{ID: "other-old", Created: now.Add(-48 * time.Hour)},
+		{ID: "owned", Created: now.Add(-48 * time.Hour)},
+		{ID: "unknown-age"},
+	}
+	opts := SweepOptions{GracePeriod: 24 * time.Hour, Allowlist: []string{"ci-*"}, Include: []string{"ci-*", "new", "old*", "owned", "unknown-*"}}
+	require.NoError(t, opts.Validate(), "unexpected invalid options")
+
+	assert.Equal(t, []UnownedInfraID{
+		{InfraID: infraIDs[0], Action: SweepActionAllowlisted},
+		{InfraID: infraIDs[1], Action: SweepActionGracePeriod},
+		{InfraID: infraIDs[2], Action: SweepActionDeprovision},
+		{InfraID: infraIDs[3], Action: SweepActionNotIncluded},
+		{InfraID: infraIDs[5], Action: SweepActionGracePeriod},
+	}, FindUnowned(infraIDs, sets.NewString("owned"), opts, now), "unexpected unowned infra IDs")
+
+	assert.Error(t, SweepOptions{Allowlist: []string{"ci-["}}.Validate(), "expected error for malformed pattern")
+	assert.Error(t, SweepOptions{Include: []string{"ci-["}}.Validate(), "expected error for malformed include pattern")
+}
+
+func awsInstance(id, infraID string, launched time.Time, state string) *ec2.Instance {
+	return &ec2.Instance{
+		InstanceId: aws.String(id),
+		LaunchTime: aws.Time(launched),
+		State:      &ec2.InstanceState{Name: aws.String(state)},
+		Tags: []*ec2.Tag{
+			{Key: aws.String("kubernetes.io/cluster/" + infraID), Value: aws.String("owned")},
+			{Key: aws.String("Name"), Value: aws.String(infraID + "-master-0")},
+		},
+	}
+}
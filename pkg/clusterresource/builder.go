@@ -75,6 +75,9 @@ type Builder struct {
 	// HibernateAfter is the duration after which a running cluster should be automatically hibernated.
 	HibernateAfter *time.Duration
 
+	// HibernationSchedule defines calendar windows during which the cluster should be running.
+	HibernationSchedule *hivev1.HibernationSchedule
+
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	InstallAttemptsLimit *int32
 
@@ -330,6 +333,8 @@ func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 		cd.Spec.HibernateAfter = &metav1.Duration{Duration: *o.HibernateAfter}
 	}
 
+	cd.Spec.HibernationSchedule = o.HibernationSchedule
+
 	cd.Spec.InstallAttemptsLimit = o.InstallAttemptsLimit
 
 	cd.Spec.Provisioning.InstallerEnv = o.InstallerEnv
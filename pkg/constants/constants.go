@@ -28,6 +28,15 @@ const (
 	// VeleroNamespaceEnvVar is the name of the environment variable used to tell the controller manager which namespace velero backup objects should be created in.
 	VeleroNamespaceEnvVar = "HIVE_VELERO_NAMESPACE"
 
+	// CloudResourceInventoryEnvVar is the name of the environment variable used to tell the controller manager to
+	// enable the inventory of the cloud resources of clusters.
+	CloudResourceInventoryEnvVar = "HIVE_CLOUD_RESOURCE_INVENTORY"
+
+	// CloudResourceInventoryIntervalEnvVar is a Duration string indicating how often the cloud resources of each
+	// cluster are listed. It is how we plumb HiveConfig.Spec.CloudResources.Inventory.Interval from hive-operator
+	// through to the cloudinventory controller.
+	CloudResourceInventoryIntervalEnvVar = "HIVE_CLOUD_RESOURCE_INVENTORY_INTERVAL"
+
 	// DeprovisionsDisabledEnvVar is the name of the environment variable used to tell the controller manager to skip
 	// processing of any ClusterDeprovisions.
 	DeprovisionsDisabledEnvVar = "DEPROVISIONS_DISABLED"
@@ -82,6 +91,10 @@ const (
 	// JobTypeProvision is used as a value of JobTypeLabel that says the Job is specifically running the provisioner.
 	JobTypeProvision = "provision"
 
+	// SweptInfraIDLabel is the label used on the deprovision Jobs the cloud resource sweeper creates for the infra
+	// IDs no cluster owns. Its value is the infra ID.
+	SweptInfraIDLabel = "hive.openshift.io/swept-infra-id"
+
 	// DNSZoneTypeLabel is the label that is used to identify what a DNSZone is being used for.
 	DNSZoneTypeLabel = "hive.openshift.io/dnszone-type"
 
@@ -187,6 +200,11 @@ const (
 	// An incoming status indicates that the resource is on the destination side of an in-progress relocate.
 	RelocateAnnotation = "hive.openshift.io/relocate"
 
+	// RelocateCreatedNamespaceAnnotation is an annotation used on namespaces in the destination cluster of a relocation
+	// to indicate that the namespace was created by the relocation. The value of the annotation is the name of the
+	// ClusterRelocate. Rolling back the relocation deletes the namespace only when it was created by the relocation.
+	RelocateCreatedNamespaceAnnotation = "hive.openshift.io/created-by-relocate"
+
 	// ManagedDomainsFileEnvVar if present, points to a simple text
 	// file that includes a valid managed domain per line. Cluster deployments
 	// requesting that their domains be managed must have a base domain
@@ -369,17 +387,27 @@ const (
 	// InstallLogsUploadProviderAWS is used to specify that AWS is the cloud provider to upload logs to.
 	InstallLogsUploadProviderAWS = "aws"
 
-	// InstallLogsCredentialsSecretRefEnvVar is the environment variable specifying what secret to use for storing logs.
-	InstallLogsCredentialsSecretRefEnvVar = "HIVE_INSTALL_LOGS_CREDENTIALS_SECRET"
+	// InstallLogsUploadProviderAzure is used to specify that logs are uploaded to Azure Blob Storage.
+	InstallLogsUploadProviderAzure = "azure"
+
+	// InstallLogsUploadProviderGCP is used to specify that logs are uploaded to Google Cloud Storage.
+	InstallLogsUploadProviderGCP = "gcp"
+
+	// InstallLogsUploadProviderHTTP is used to specify that logs are uploaded with HTTP PUT requests.
+	InstallLogsUploadProviderHTTP = "http"
 
-	// InstallLogsAWSRegionEnvVar is the environment variable specifying the region to use with S3
-	InstallLogsAWSRegionEnvVar = "HIVE_INSTALL_LOGS_AWS_REGION"
+	// InstallLogsUploadProviderPVC is used to specify that logs are saved to a PersistentVolumeClaim.
+	InstallLogsUploadProviderPVC = "pvc"
 
-	// InstallLogsAWSServiceEndpointEnvVar is the environment variable specifying the S3 endpoint to use.
-	InstallLogsAWSServiceEndpointEnvVar = "HIVE_INSTALL_LOGS_AWS_S3_URL"
+	// InstallLogsUploadConfigEnvVar is the environment variable containing the JSON FailedProvisionConfig
+	// specifying where to upload logs to.
+	InstallLogsUploadConfigEnvVar = "HIVE_INSTALL_LOGS_UPLOAD_CONFIG"
 
-	// InstallLogsAWSS3BucketEnvVar is the environment variable specifying the S3 bucket to use.
-	InstallLogsAWSS3BucketEnvVar = "HIVE_INSTALL_LOGS_AWS_S3_BUCKET"
+	// InstallLogsDir is the directory where the PersistentVolumeClaim to save logs to is mounted.
+	InstallLogsDir = "/install-logs"
+
+	// InstallLogsCredentialsSecretRefEnvVar is the environment variable specifying what secret to use for storing logs.
+	InstallLogsCredentialsSecretRefEnvVar = "HIVE_INSTALL_LOGS_CREDENTIALS_SECRET"
 
 	// HiveFakeClusterAnnotation can be set to true on a cluster deployment to create a fake cluster that never
 	// provisions resources, and all communication with the cluster will be faked.
@@ -407,6 +435,18 @@ const (
 	// stale, allowing it to set the ClusterPool's "ClusterDeploymentsCurrent" status condition.
 	ClusterDeploymentPoolSpecHashAnnotation = "hive.openshift.io/cluster-pool-spec-hash"
 
+	// DeprovisionOrphansReportedAnnotation annotates a ClusterDeprovision once the cloud resources its deprovision
+	// left behind have been reported by the cloudinventory controller, so that they are only reported once.
+	DeprovisionOrphansReportedAnnotation = "hive.openshift.io/deprovision-orphans-reported"
+
+	// InventoryReservedByClusterDeploymentAnnotation annotates a Secret or ConfigMap listed in a ClusterPool's
+	// Inventory. It is the name of the ClusterDeployment for which the entry is reserved.
+	InventoryReservedByClusterDeploymentAnnotation = "hive.openshift.io/inventory-reserved-by-cluster-deployment"
+
+	// InventoryReservedByClusterPoolAnnotation annotates a Secret or ConfigMap listed in a ClusterPool's Inventory.
+	// It is the name of the ClusterPool which reserved the entry.
+	InventoryReservedByClusterPoolAnnotation = "hive.openshift.io/inventory-reserved-by-cluster-pool"
+
 	// HiveAWSServiceProviderCredentialsSecretRefEnvVar is the environment variable specifying what secret to use for
 	// assuming the service provider credentials for AWS clusters.
 	HiveAWSServiceProviderCredentialsSecretRefEnvVar = "HIVE_AWS_SERVICE_PROVIDER_CREDENTIALS_SECRET"
@@ -427,6 +467,10 @@ const (
 	// configurations. See HiveConfig.Spec.MetricsConfig.
 	MetricsConfigFileEnvVar = "METRICS_CONFIG_FILE"
 
+	// CloudResourceSweeperConfigFileEnvVar points to a text file containing configuration for the sweep of cloud
+	// accounts for the resources of clusters Hive no longer knows about. See HiveConfig.Spec.CloudResources.Sweeper.
+	CloudResourceSweeperConfigFileEnvVar = "CLOUD_RESOURCE_SWEEPER_CONFIG_FILE"
+
 	// HiveReleaseImageVerificationConfigMapNamespaceEnvVar is used to configure the config map that will be used
 	// to verify the release images being used for cluster deployments.
 	HiveReleaseImageVerificationConfigMapNamespaceEnvVar = "HIVE_RELEASE_IMAGE_VERIFICATION_CONFIGMAP_NS"
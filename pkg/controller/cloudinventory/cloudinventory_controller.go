@@ -0,0 +1,325 @@
+package cloudinventory
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/cloudinventory"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.CloudInventoryControllerName
+
+	// defaultInterval is how often the cloud resources of each cluster are listed, unless configured in HiveConfig.
+	defaultInterval = 6 * time.Hour
+
+	// maxOrphansInEvent limits the number of orphans named in the event for a deprovision that left resources behind.
+	maxOrphansInEvent = 5
+)
+
+// Add creates a new CloudInventory controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+
+	// Don't run the cloud inventory controller unless explicitly enabled.
+	if !strings.EqualFold(os.Getenv(constants.CloudResourceInventoryEnvVar), "true") {
+		return nil
+	}
+
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileCloudInventory
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileCloudInventory {
+	logger := log.WithField("controller", ControllerName)
+	interval := defaultInterval
+	if envInterval := os.Getenv(constants.CloudResourceInventoryIntervalEnvVar); len(envInterval) > 0 {
+		if d, err := time.ParseDuration(envInterval); err != nil || d <= 0 {
+			logger.WithField("interval", envInterval).Warn("invalid cloud resource inventory interval, using the default")
+		} else {
+			interval = d
+		}
+	}
+	r := &ReconcileCloudInventory{
+		Client:        controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:        logger,
+		interval:      interval,
+		listResources: cloudinventory.ListResources,
+		eventRecorder: mgr.GetEventRecorderFor(ControllerName.String()),
+		now:           time.Now,
+	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileCloudInventory, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("cloudinventory-controller", mgr, controller.Options{
+		Reconciler:              controllerutils.NewDelayingReconciler(r, r.logger),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments. Clusters are only scanned once per interval however they change.
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &hivev1.ClusterDeployment{}),
+		controllerutils.NewRateLimitedUpdateEventHandler(&handler.EnqueueRequestForObject{}, controllerutils.IsClusterDeploymentErrorUpdateEvent),
+	); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeprovisions, which are named after their ClusterDeployment, to scan clusters as
+	// soon as their deprovision ends. The ClusterDeployment is deleted right after that, but its ClusterDeprovision
+	// is only garbage collected once it is gone.
+	return c.Watch(source.Kind(mgr.GetCache(), &hivev1.ClusterDeprovision{}), &handler.EnqueueRequestForObject{})
+}
+
+var _ reconcile.Reconciler = &ReconcileCloudInventory{}
+
+// ReconcileCloudInventory lists the cloud resources of ClusterDeployments and finds those that are orphaned.
+type ReconcileCloudInventory struct {
+	client.Client
+	logger log.FieldLogger
+
+	// interval is how often the cloud resources of each cluster are listed.
+	interval time.Duration
+
+	// listResources lists the cloud resources of a cluster, here for testing.
+	listResources func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) ([]cloudinventory.Resource, error)
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// eventRecorder records events for the resources left behind by deprovisions, which outlive the
+	// ClusterDeployment for a while.
+	eventRecorder record.EventRecorder
+
+	// now returns the current time, here for testing.
+	now func() time.Time
+}
+
+// Reconcile lists the cloud resources of a ClusterDeployment once per interval. The resources created for Services
+// and PersistentVolumes the cluster no longer has are orphans, as are all the resources left behind by a failed or
+// completed deprovision.
+func (r *ReconcileCloudInventory) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	logger.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(ctx, request.NamespacedName, cd); {
+	case apierrors.IsNotFound(err):
+		// The resources left behind by its deprovision may still need to be reported.
+		logger.Debug("cluster deployment not found")
+		return r.reconcileDeprovision(ctx, request, nil, logger)
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+	logger = controllerutils.AddLogFields(controllerutils.MetaObjectLogTagger{Object: cd}, logger)
+
+	if !cd.Spec.Installed || cd.Spec.ClusterMetadata == nil {
+		logger.Debug("cluster is not installed, skipping")
+		return reconcile.Result{}, nil
+	}
+	if cloudinventory.ListerFor(cd) == nil {
+		logger.Debug("listing cloud resources is not supported for the platform of the cluster, skipping")
+		return reconcile.Result{}, nil
+	}
+
+	if cd.DeletionTimestamp != nil {
+		if cd.Spec.PreserveOnDelete {
+			logger.Debug("cluster is being deleted without deprovisioning, skipping")
+			return reconcile.Result{}, nil
+		}
+		return r.reconcileDeprovision(ctx, request, cd, logger)
+	}
+
+	now := metav1.NewTime(r.now().Truncate(time.Second))
+	if requeueAfter := r.untilNextScan(cd, now); requeueAfter > 0 {
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	resources, err := r.listResources(cd, r.Client, logger)
+	if err != nil {
+		logger.WithError(err).Error("could not list cloud resources")
+		return reconcile.Result{}, err
+	}
+
+	var status *hivev1.CloudResourcesStatus
+	if expected := r.loadExpectations(ctx, cd, logger); expected != nil {
+		status = cloudinventory.NewStatus(resources, cloudinventory.FindOrphans(resources, expected), now)
+	} else {
+		// The orphans can't be checked while the cluster is unavailable, so the last ones found are kept.
+		status = cloudinventory.NewStatus(resources, nil, now)
+		if prev := cd.Status.CloudResources; prev != nil {
+			status.OrphanCount = prev.OrphanCount
+			status.Orphans = prev.Orphans
+		}
+	}
+	return r.updateStatus(ctx, cd, status, len(resources), logger)
+}
+
+// reconcileDeprovision lists the resources left behind by the deprovision of a cluster once it has ended, and
+// reports them once. The ClusterDeployment is deleted as soon as its deprovision ends, so the cluster is listed from
+// its ClusterDeprovision if it is already gone. If it is not, which is the case while a failed deprovision is
+// retried, its status is also updated once per interval.
+func (r *ReconcileCloudInventory) reconcileDeprovision(ctx context.Context, request reconcile.Request, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+	deprovision := &hivev1.ClusterDeprovision{}
+	switch err := r.Get(ctx, request.NamespacedName, deprovision); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("cluster deprovision not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get cluster deprovision")
+		return reconcile.Result{}, err
+	}
+	if !cloudinventory.DeprovisionEnded(deprovision) {
+		logger.Debug("cluster is being deprovisioned, skipping")
+		return reconcile.Result{}, nil
+	}
+
+	reported := deprovision.Annotations[constants.DeprovisionOrphansReportedAnnotation] == "true"
+	target := cd
+	if target == nil {
+		if reported {
+			logger.Debug("orphans of the deprovision were already reported")
+			return reconcile.Result{}, nil
+		}
+		if target = cloudinventory.ClusterDeploymentForDeprovision(deprovision); target == nil || cloudinventory.ListerFor(target) == nil {
+			logger.Debug("listing cloud resources is not supported for the platform of the cluster, skipping")
+			return reconcile.Result{}, nil
+		}
+	}
+
+	now := metav1.NewTime(r.now().Truncate(time.Second))
+	if reported {
+		if requeueAfter := r.untilNextScan(cd, now); requeueAfter > 0 {
+			return reconcile.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
+
+	resources, err := r.listResources(target, r.Client, logger)
+	if err != nil {
+		logger.WithError(err).Error("could not list cloud resources")
+		return reconcile.Result{}, err
+	}
+	orphans := cloudinventory.DeprovisionOrphans(resources)
+
+	if !reported {
+		r.recordDeprovisionOrphans(target, deprovision, orphans, logger)
+		if deprovision.Annotations == nil {
+			deprovision.Annotations = map[string]string{}
+		}
+		deprovision.Annotations[constants.DeprovisionOrphansReportedAnnotation] = "true"
+		if err := r.Update(ctx, deprovision); err != nil && !apierrors.IsNotFound(err) {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not mark the orphans of the deprovision as reported")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if cd == nil {
+		return reconcile.Result{}, nil
+	}
+	result, err := r.updateStatus(ctx, cd, cloudinventory.NewStatus(resources, orphans, now), len(resources), logger)
+	if apierrors.IsNotFound(err) {
+		// The finalizer was removed once the deprovision ended; the orphans were already reported.
+		logger.Debug("cluster deployment was deleted before its cloud resources could be recorded")
+		return reconcile.Result{}, nil
+	}
+	return result, err
+}
+
+// untilNextScan returns how long to wait before the cloud resources of the cluster are listed again, or 0 if they
+// are to be listed now.
+func (r *ReconcileCloudInventory) untilNextScan(cd *hivev1.ClusterDeployment, now metav1.Time) time.Duration {
+	if prev := cd.Status.CloudResources; prev != nil {
+		if elapsed := now.Sub(prev.LastScanTime.Time); elapsed < r.interval {
+			return r.interval - elapsed
+		}
+	}
+	return 0
+}
+
+func (r *ReconcileCloudInventory) updateStatus(ctx context.Context, cd *hivev1.ClusterDeployment, status *hivev1.CloudResourcesStatus, resources int, logger log.FieldLogger) (reconcile.Result, error) {
+	cd.Status.CloudResources = status
+	if err := r.Status().Update(ctx, cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update cloud resources")
+		return reconcile.Result{}, err
+	}
+	logger.WithField("resources", resources).WithField("orphans", status.OrphanCount).Info("updated cloud resources")
+	return reconcile.Result{RequeueAfter: r.interval}, nil
+}
+
+// recordDeprovisionOrphans reports the resources left behind by the deprovision of the cluster in the log, an event
+// on the ClusterDeprovision and a metric, as the ClusterDeployment, and with it its status, is deleted as soon as the
+// deprovision ends.
+func (r *ReconcileCloudInventory) recordDeprovisionOrphans(cd *hivev1.ClusterDeployment, deprovision *hivev1.ClusterDeprovision, orphans []hivev1.OrphanedCloudResource, logger log.FieldLogger) {
+	if len(orphans) == 0 {
+		return
+	}
+	ids := make([]string, len(orphans))
+	for i, orphan := range orphans {
+		ids[i] = orphan.ID
+	}
+	logger.WithField("orphans", ids).Warn("deprovision left cloud resources behind")
+	metricDeprovisionOrphans.WithLabelValues(cd.Labels[hivev1.HiveClusterPlatformLabel]).Add(float64(len(orphans)))
+	listed := ids
+	if len(listed) > maxOrphansInEvent {
+		listed = append(listed[:maxOrphansInEvent:maxOrphansInEvent], "...")
+	}
+	r.eventRecorder.Eventf(deprovision, corev1.EventTypeWarning, "DeprovisionOrphans",
+		"Deprovision left %d cloud resources behind: %s", len(orphans), strings.Join(listed, ", "))
+}
+
+// loadExpectations lists the Services and PersistentVolumes of the cluster, or returns nil if the cluster is
+// unavailable.
+func (r *ReconcileCloudInventory) loadExpectations(ctx context.Context, cd *hivev1.ClusterDeployment, logger log.FieldLogger) *cloudinventory.Expectations {
+	if controllerutils.IsClusterPausedOrRelocating(cd, logger) || cd.Spec.PowerState == hivev1.ClusterPowerStateHibernating {
+		return nil
+	}
+	remoteClient, unreachable, _ := remoteclient.ConnectToRemoteCluster(cd, r.remoteClusterAPIClientBuilder(cd), r.Client, logger)
+	if unreachable {
+		return nil
+	}
+	expected, err := cloudinventory.LoadExpectations(ctx, remoteClient)
+	if err != nil {
+		logger.WithError(err).Warn("could not list the objects of the remote cluster")
+		return nil
+	}
+	return expected
+}
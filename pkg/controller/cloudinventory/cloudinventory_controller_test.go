@@ -0,0 +1,340 @@
+package cloudinventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/cloudinventory"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testName      = "test-cluster"
+	testInterval  = 6 * time.Hour
+)
+
+const deprovisionOrphansEvent = "Warning DeprovisionOrphans Deprovision left 4 cloud resources behind: " +
+	"arn:aws:ec2:us-east-1:123456789012:instance/i-0123, " +
+	"arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/a0123, " +
+	"arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/a4567, " +
+	"arn:aws:ec2:us-east-1:123456789012:volume/vol-0123"
+
+var testResources = []cloudinventory.Resource{
+	{ID: "arn:aws:ec2:us-east-1:123456789012:instance/i-0123", Kind: "ec2:instance"},
+	{ID: "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/a0123", Kind: "elasticloadbalancing:loadbalancer",
+		Services: []string{"openshift-ingress/router-default"}},
+	{ID: "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/a4567", Kind: "elasticloadbalancing:loadbalancer",
+		Services: []string{"test/deleted"}},
+	{ID: "arn:aws:ec2:us-east-1:123456789012:volume/vol-0123", Kind: "ec2:volume", PersistentVolume: "pvc-0123"},
+}
+
+func TestReconcileCloudInventory(t *testing.T) {
+	now := time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC)
+	prevOrphans := []hivev1.OrphanedCloudResource{{
+		ID:         "arn:aws:ec2:us-east-1:123456789012:volume/vol-0123",
+		Kind:       "ec2:volume",
+		Reason:     hivev1.OrphanedCloudResourcePersistentVolumeDeleted,
+		CreatedFor: "pvc-0123",
+	}}
+	resourceCounts := []hivev1.CloudResourceCount{
+		{Kind: "ec2:instance", Count: 1},
+		{Kind: "ec2:volume", Count: 1},
+		{Kind: "elasticloadbalancing:loadbalancer", Count: 2},
+	}
+
+	tests := []struct {
+		name            string
+		cdOptions       []testcd.Option
+		noCD            bool
+		deprovision     *hivev1.ClusterDeprovision
+		expectList      bool
+		expectReported  bool
+		expectRemote    bool
+		expectedStatus  *hivev1.CloudResourcesStatus
+		expectedRequeue time.Duration
+		expectedEvents  []string
+	}{
+		{
+			name:         "running cluster",
+			expectList:   true,
+			expectRemote: true,
+			expectedStatus: &hivev1.CloudResourcesStatus{
+				Resources:   resourceCounts,
+				OrphanCount: 1,
+				Orphans: []hivev1.OrphanedCloudResource{{
+					ID:         "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/a4567",
+					Kind:       "elasticloadbalancing:loadbalancer",
+					Reason:     hivev1.OrphanedCloudResourceServiceDeleted,
+					CreatedFor: "test/deleted",
+				}},
+				LastScanTime: metav1.NewTime(now),
+			},
+			expectedRequeue: testInterval,
+		},
+		{
+			name:      "scanned within interval",
+			cdOptions: []testcd.Option{withCloudResources(now.Add(-time.Hour), prevOrphans)},
+			expectedStatus: &hivev1.CloudResourcesStatus{
+				OrphanCount:  1,
+				Orphans:      prevOrphans,
+				LastScanTime: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			expectedRequeue: 5 * time.Hour,
+		},
+		{
+			name: "hibernating cluster keeps orphans",
+			cdOptions: []testcd.Option{
+				withCloudResources(now.Add(-7*time.Hour), prevOrphans),
+				testcd.WithPowerState(hivev1.ClusterPowerStateHibernating),
+			},
+			expectList: true,
+			expectedStatus: &hivev1.CloudResourcesStatus{
+				Resources:    resourceCounts,
+				OrphanCount:  1,
+				Orphans:      prevOrphans,
+				LastScanTime: metav1.NewTime(now),
+			},
+			expectedRequeue: testInterval,
+		},
+		{
+			name: "failed deprovision",
+			cdOptions: []testcd.Option{
+				withDeletion(),
+			},
+			deprovision: testDeprovision(false, corev1.ConditionTrue),
+			expectList:  true,
+			expectedStatus: &hivev1.CloudResourcesStatus{
+				Resources:    resourceCounts,
+				OrphanCount:  4,
+				Orphans:      cloudinventory.DeprovisionOrphans(testResources),
+				LastScanTime: metav1.NewTime(now),
+			},
+			expectedRequeue: testInterval,
+			expectedEvents:  []string{deprovisionOrphansEvent},
+			expectReported:  true,
+		},
+		{
+			name: "failed deprovision already reported",
+			cdOptions: []testcd.Option{
+				withDeletion(),
+				withCloudResources(now.Add(-7*time.Hour), cloudinventory.DeprovisionOrphans(testResources)),
+			},
+			deprovision: withReported(testDeprovision(false, corev1.ConditionTrue)),
+			expectList:  true,
+			expectedStatus: &hivev1.CloudResourcesStatus{
+				Resources:    resourceCounts,
+				OrphanCount:  4,
+				Orphans:      cloudinventory.DeprovisionOrphans(testResources),
+				LastScanTime: metav1.NewTime(now),
+			},
+			expectedRequeue: testInterval,
+			expectReported:  true,
+		},
+		{
+			name: "failed deprovision already reported and scanned within interval",
+			cdOptions: []testcd.Option{
+				withDeletion(),
+				withCloudResources(now.Add(-time.Hour), cloudinventory.DeprovisionOrphans(testResources)),
+			},
+			deprovision: withReported(testDeprovision(false, corev1.ConditionTrue)),
+			expectedStatus: &hivev1.CloudResourcesStatus{
+				OrphanCount:  4,
+				Orphans:      cloudinventory.DeprovisionOrphans(testResources),
+				LastScanTime: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			expectedRequeue: 5 * time.Hour,
+			expectReported:  true,
+		},
+		{
+			name: "completed deprovision of cluster scanned within interval",
+			cdOptions: []testcd.Option{
+				withDeletion(),
+				withCloudResources(now.Add(-time.Hour), prevOrphans),
+			},
+			deprovision: testDeprovision(true, corev1.ConditionFalse),
+			expectList:  true,
+			expectedStatus: &hivev1.CloudResourcesStatus{
+				Resources:    resourceCounts,
+				OrphanCount:  4,
+				Orphans:      cloudinventory.DeprovisionOrphans(testResources),
+				LastScanTime: metav1.NewTime(now),
+			},
+			expectedRequeue: testInterval,
+			expectedEvents:  []string{deprovisionOrphansEvent},
+			expectReported:  true,
+		},
+		{
+			name:           "completed deprovision of deleted cluster",
+			noCD:           true,
+			deprovision:    testDeprovision(true, corev1.ConditionFalse),
+			expectList:     true,
+			expectedEvents: []string{deprovisionOrphansEvent},
+			expectReported: true,
+		},
+		{
+			name:           "completed deprovision of deleted cluster already reported",
+			noCD:           true,
+			deprovision:    withReported(testDeprovision(true, corev1.ConditionFalse)),
+			expectReported: true,
+		},
+		{
+			name: "deprovision in progress",
+			cdOptions: []testcd.Option{
+				withDeletion(),
+			},
+			deprovision: testDeprovision(false, corev1.ConditionFalse),
+		},
+		{
+			name: "not installed",
+			cdOptions: []testcd.Option{
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.Installed = false
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			cdOptions := append([]testcd.Option{
+				testcd.Installed(),
+				testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1"}),
+				testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: "test-infra"}),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.UnreachableCondition,
+					Status: corev1.ConditionFalse,
+				}),
+			}, test.cdOptions...)
+			var existing []runtime.Object
+			if !test.noCD {
+				existing = append(existing, testcd.FullBuilder(testNamespace, testName, scheme.GetScheme()).Build(cdOptions...))
+			}
+			if test.deprovision != nil {
+				existing = append(existing, test.deprovision)
+			}
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build()
+			remoteClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-ingress", Name: "router-default"},
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+				},
+				&corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pvc-0123"}},
+			).Build()
+			builder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if test.expectRemote {
+				builder.EXPECT().Build().Return(remoteClient, nil)
+			}
+			listed := false
+			recorder := record.NewFakeRecorder(10)
+			r := &ReconcileCloudInventory{
+				Client:   c,
+				logger:   log.New(),
+				interval: testInterval,
+				listResources: func(cd *hivev1.ClusterDeployment, _ client.Client, _ log.FieldLogger) ([]cloudinventory.Resource, error) {
+					assert.Equal(t, "test-infra", cd.Spec.ClusterMetadata.InfraID, "unexpected infra ID listed")
+					listed = true
+					return testResources, nil
+				},
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return builder },
+				eventRecorder:                 recorder,
+				now:                           func() time.Time { return now },
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			assert.Equal(t, test.expectedRequeue, result.RequeueAfter, "unexpected requeue")
+			assert.Equal(t, test.expectList, listed, "unexpected listing of resources")
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			assert.Equal(t, test.expectedEvents, events, "unexpected events")
+
+			if test.deprovision != nil {
+				deprovision := &hivev1.ClusterDeprovision{}
+				require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, deprovision))
+				assert.Equal(t, test.expectReported, deprovision.Annotations[constants.DeprovisionOrphansReportedAnnotation] == "true",
+					"unexpected reported annotation")
+			}
+			if test.noCD {
+				return
+			}
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd))
+			if test.expectedStatus == nil {
+				assert.Nil(t, cd.Status.CloudResources, "expected no cloud resources")
+				return
+			}
+			if assert.NotNil(t, cd.Status.CloudResources, "expected cloud resources") {
+				assert.True(t, test.expectedStatus.LastScanTime.Equal(&cd.Status.CloudResources.LastScanTime),
+					"unexpected last scan time: %v", cd.Status.CloudResources.LastScanTime)
+				cd.Status.CloudResources.LastScanTime = test.expectedStatus.LastScanTime
+				assert.Equal(t, test.expectedStatus, cd.Status.CloudResources, "unexpected cloud resources")
+			}
+		})
+	}
+}
+
+func withCloudResources(lastScan time.Time, orphans []hivev1.OrphanedCloudResource) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.CloudResources = &hivev1.CloudResourcesStatus{
+			OrphanCount:  int32(len(orphans)),
+			Orphans:      orphans,
+			LastScanTime: metav1.NewTime(lastScan),
+		}
+	}
+}
+
+func withDeletion() testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		now := metav1.Now()
+		cd.DeletionTimestamp = &now
+		cd.Finalizers = []string{hivev1.FinalizerDeprovision}
+	}
+}
+
+func testDeprovision(completed bool, failed corev1.ConditionStatus) *hivev1.ClusterDeprovision {
+	return &hivev1.ClusterDeprovision{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testName},
+		Spec: hivev1.ClusterDeprovisionSpec{
+			InfraID: "test-infra",
+			Platform: hivev1.ClusterDeprovisionPlatform{
+				AWS: &hivev1.AWSClusterDeprovision{Region: "us-east-1"},
+			},
+		},
+		Status: hivev1.ClusterDeprovisionStatus{
+			Completed: completed,
+			Conditions: []hivev1.ClusterDeprovisionCondition{{
+				Type:   hivev1.DeprovisionFailedClusterDeprovisionCondition,
+				Status: failed,
+			}},
+		},
+	}
+}
+
+func withReported(deprovision *hivev1.ClusterDeprovision) *hivev1.ClusterDeprovision {
+	deprovision.Annotations = map[string]string{constants.DeprovisionOrphansReportedAnnotation: "true"}
+	return deprovision
+}
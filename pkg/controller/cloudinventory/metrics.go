@@ -0,0 +1,20 @@
+package cloudinventory
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// metricDeprovisionOrphans tracks the cloud resources left behind by deprovisions. Unlike the status of the
+	// ClusterDeployment, it outlives the cluster.
+	metricDeprovisionOrphans = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_cloud_inventory_deprovision_orphans_total",
+		Help: "The number of cloud resources found left behind by the deprovision of a cluster, by platform.",
+	}, []string{"platform"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricDeprovisionOrphans)
+}
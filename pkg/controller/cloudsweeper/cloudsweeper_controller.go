@@ -0,0 +1,272 @@
+package cloudsweeper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/cloudinventory"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/install"
+)
+
+const (
+	ControllerName = hivev1.CloudSweeperControllerName
+
+	// defaultInterval is how often the accounts are swept, unless configured in HiveConfig.
+	defaultInterval = 24 * time.Hour
+
+	// defaultGracePeriod is how old the resources of an unowned infra ID must be to be deprovisioned, unless
+	// configured in HiveConfig.
+	defaultGracePeriod = 24 * time.Hour
+
+	// deprovisionJobTTL is how long the finished deprovision jobs of the sweeper are kept. The resources of an infra
+	// ID whose deprovision failed are deprovisioned again by the first sweep after its job is deleted.
+	deprovisionJobTTL = 24 * time.Hour
+)
+
+// Add creates a new cloud resource Sweeper and adds it to the manager.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	config, err := ReadSweeperConfig()
+	if err != nil {
+		logger.WithError(err).Error("could not read cloud resource sweeper config")
+		return err
+	}
+	// Don't run the sweeper unless explicitly enabled.
+	if !config.Enabled {
+		return nil
+	}
+	s, err := NewSweeper(controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, nil), config, logger)
+	if err != nil {
+		logger.WithError(err).Error("invalid cloud resource sweeper config")
+		return err
+	}
+	return mgr.Add(s)
+}
+
+// ReadSweeperConfig reads the sweeper configuration from the file pointed to by the
+// CLOUD_RESOURCE_SWEEPER_CONFIG_FILE environment variable. The sweeper is disabled when the variable is not set or
+// the file is empty.
+func ReadSweeperConfig() (*hivev1.CloudResourceSweeperConfig, error) {
+	config := &hivev1.CloudResourceSweeperConfig{}
+	path := os.Getenv(constants.CloudResourceSweeperConfigFileEnvVar)
+	if len(path) == 0 {
+		return config, nil
+	}
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return config, err
+	}
+	if len(fileBytes) == 0 {
+		return config, nil
+	}
+	if err := json.Unmarshal(fileBytes, config); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// Sweeper runs in a goroutine and periodically sweeps the configured cloud accounts for resources tagged for infra
+// IDs that no ClusterDeployment, ClusterProvision or ClusterDeprovision owns. Like the metrics Calculator, it is not
+// a standard controller watching Kube resources: it sweeps once per interval and then goes to sleep.
+type Sweeper struct {
+	client.Client
+	logger log.FieldLogger
+
+	config      *hivev1.CloudResourceSweeperConfig
+	interval    time.Duration
+	options     cloudinventory.SweepOptions
+	hiveNSName  string
+	awsClientFn func(account hivev1.CloudResourceSweeperAWSAccount, region string) (awsclient.Client, error)
+
+	// now returns the current time, here for testing.
+	now func() time.Time
+}
+
+// NewSweeper returns a new Sweeper for the configuration.
+func NewSweeper(c client.Client, config *hivev1.CloudResourceSweeperConfig, logger log.FieldLogger) (*Sweeper, error) {
+	interval, err := parseDuration(config.Interval, defaultInterval)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid interval")
+	}
+	gracePeriod, err := parseDuration(config.GracePeriod, defaultGracePeriod)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid grace period")
+	}
+	options := cloudinventory.SweepOptions{GracePeriod: gracePeriod, Allowlist: config.Allowlist, Include: config.Include}
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	// Sweeping is opt-in per infra ID: without an include list, the sweeper could deprovision the resources of any
+	// cluster sharing the accounts.
+	if config.Deprovision && len(config.Include) == 0 {
+		return nil, errors.New("an include list is required to deprovision")
+	}
+	s := &Sweeper{
+		Client:     c,
+		logger:     logger,
+		config:     config,
+		interval:   interval,
+		options:    options,
+		hiveNSName: controllerutils.GetHiveNamespace(),
+		now:        time.Now,
+	}
+	s.awsClientFn = func(account hivev1.CloudResourceSweeperAWSAccount, region string) (awsclient.Client, error) {
+		return awsclient.NewClient(s.Client, account.CredentialsSecretRef.Name, s.hiveNSName, region)
+	}
+	return s, nil
+}
+
+func parseDuration(s string, defaultDuration time.Duration) (time.Duration, error) {
+	if s == "" {
+		return defaultDuration, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive: %s", s)
+	}
+	return d, nil
+}
+
+// Start sweeps the accounts once per interval until the context is done.
+func (s *Sweeper) Start(ctx context.Context) error {
+	s.logger.WithField("interval", s.interval).WithField("deprovision", s.config.Deprovision).Info("started cloud resource sweeper")
+	wait.UntilWithContext(ctx, s.sweep, s.interval)
+	return nil
+}
+
+// sweep finds the unowned infra IDs of each account and region, and deprovisions the resources of those matching the
+// include list and older than the grace period unless running in dry-run mode. Like hiveutil aws-sweep, it never deprovisions the resources of
+// infra IDs without instances, whose age is unknown, and only reports them.
+func (s *Sweeper) sweep(ctx context.Context) {
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, s.logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	owned, err := cloudinventory.OwnedInfraIDs(ctx, s)
+	if err != nil {
+		s.logger.WithError(err).Error("could not list the infra IDs of clusters")
+		return
+	}
+
+	now := s.now()
+	metricUnownedInfraIDs.Reset()
+	for _, account := range s.config.AWS {
+		for _, region := range account.Regions {
+			logger := s.logger.WithField("account", account.CredentialsSecretRef.Name).WithField("region", region)
+			awsClient, err := s.awsClientFn(account, region)
+			if err != nil {
+				logger.WithError(err).Error("could not create AWS client")
+				continue
+			}
+			infraIDs, err := cloudinventory.FindAWSInfraIDs(awsClient, region)
+			if err != nil {
+				logger.WithError(err).Error("could not find the infra IDs of tagged resources")
+				continue
+			}
+			for _, unowned := range cloudinventory.FindUnowned(infraIDs, owned, s.options, now) {
+				metricUnownedInfraIDs.WithLabelValues(region, string(unowned.Action)).Inc()
+				created := "unknown"
+				if !unowned.Created.IsZero() {
+					created = unowned.Created.UTC().Format(time.RFC3339)
+				}
+				iLog := logger.WithField("infraID", unowned.ID).
+					WithField("resources", unowned.ResourceCount).
+					WithField("created", created).
+					WithField("action", unowned.Action)
+				if unowned.Action != cloudinventory.SweepActionDeprovision {
+					iLog.Info("found cloud resources of unowned infra ID")
+					continue
+				}
+				if !s.config.Deprovision {
+					iLog.Info("dry run, not deprovisioning cloud resources of unowned infra ID")
+					continue
+				}
+				if err := s.deprovision(ctx, account, region, unowned.ID, iLog); err != nil {
+					iLog.WithError(err).Log(controllerutils.LogLevel(err), "could not deprovision cloud resources of unowned infra ID")
+				}
+			}
+		}
+	}
+}
+
+// deprovision creates a job in the hive namespace running the uninstaller for the resources tagged for the infra ID,
+// unless it exists already. The job is generated for a ClusterDeprovision that is never created, as the
+// clusterdeprovision controller only deprovisions the clusters of ClusterDeployments.
+func (s *Sweeper) deprovision(ctx context.Context, account hivev1.CloudResourceSweeperAWSAccount, region, infraID string, logger log.FieldLogger) error {
+	req := &hivev1.ClusterDeprovision{
+		ObjectMeta: metav1.ObjectMeta{
+			// The name is truncated, with a hash appended, to fit in the labels of the job.
+			Name:      apihelpers.GetResourceName("sweep-"+infraID, region),
+			Namespace: s.hiveNSName,
+		},
+		Spec: hivev1.ClusterDeprovisionSpec{
+			InfraID: infraID,
+			Platform: hivev1.ClusterDeprovisionPlatform{
+				AWS: &hivev1.AWSClusterDeprovision{
+					Region:               region,
+					CredentialsSecretRef: &corev1.LocalObjectReference{Name: account.CredentialsSecretRef.Name},
+				},
+			},
+		},
+	}
+	job, err := install.GenerateUninstallerJobForDeprovision(req,
+		controllerutils.UninstallServiceAccountName,
+		os.Getenv("HTTP_PROXY"),
+		os.Getenv("HTTPS_PROXY"),
+		os.Getenv("NO_PROXY"),
+		nil, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not generate uninstaller job")
+	}
+	logger = logger.WithField("job", job.Name)
+
+	switch err := s.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{}); {
+	case err == nil:
+		logger.Debug("deprovision job already exists")
+		return nil
+	case !apierrors.IsNotFound(err):
+		return errors.Wrap(err, "could not get deprovision job")
+	}
+
+	if err := controllerutils.SetupClusterUninstallServiceAccount(s, s.hiveNSName, logger); err != nil {
+		return errors.Wrap(err, "could not set up service account")
+	}
+	job.Labels[constants.JobTypeLabel] = constants.JobTypeDeprovision
+	if len(validation.IsValidLabelValue(infraID)) == 0 {
+		job.Labels[constants.SweptInfraIDLabel] = infraID
+	}
+	job.Spec.TTLSecondsAfterFinished = pointer.Int32(int32(deprovisionJobTTL.Seconds()))
+	if err := s.Create(ctx, job); err != nil {
+		return errors.Wrap(err, "could not create deprovision job")
+	}
+	metricDeprovisions.WithLabelValues(region).Inc()
+	logger.Info("created job to deprovision cloud resources of unowned infra ID")
+	return nil
+}
@@ -0,0 +1,255 @@
+package cloudsweeper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+	"github.com/openshift/hive/pkg/cloudinventory"
+	"github.com/openshift/hive/pkg/constants"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+)
+
+const (
+	testHiveNamespace = "hive"
+	testRegion        = "us-east-1"
+)
+
+var testAccount = hivev1.CloudResourceSweeperAWSAccount{
+	CredentialsSecretRef: corev1.LocalObjectReference{Name: "sweeper-creds"},
+	Regions:              []string{testRegion},
+}
+
+func TestSweep(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	old := now.Add(-48 * time.Hour)
+	longInfraID := "old-" + strings.Repeat("x", 55)
+
+	tests := []struct {
+		name         string
+		infraID      string
+		deprovision  bool
+		include      []string
+		allowlist    []string
+		existing     []runtime.Object
+		launched     map[string]time.Time
+		terminated   bool
+		expectedJobs []string
+	}{
+		{
+			name:     "dry run",
+			launched: map[string]time.Time{"old-infra": old},
+		},
+		{
+			name:         "deprovision",
+			deprovision:  true,
+			launched:     map[string]time.Time{"old-infra": old},
+			expectedJobs: []string{"old-infra"},
+		},
+		{
+			name:        "owned by cluster deployment",
+			deprovision: true,
+			existing: []runtime.Object{&hivev1.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cd"},
+				Spec:       hivev1.ClusterDeploymentSpec{ClusterMetadata: &hivev1.ClusterMetadata{InfraID: "old-infra"}},
+			}},
+			launched: map[string]time.Time{"old-infra": old},
+		},
+		{
+			name:        "owned by cluster provision",
+			deprovision: true,
+			existing: []runtime.Object{&hivev1.ClusterProvision{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cd-0"},
+				Spec:       hivev1.ClusterProvisionSpec{InfraID: aws.String("old-infra")},
+			}},
+			launched: map[string]time.Time{"old-infra": old},
+		},
+		{
+			name:        "allowlisted",
+			deprovision: true,
+			allowlist:   []string{"old-*"},
+			launched:    map[string]time.Time{"old-infra": old},
+		},
+		{
+			name:        "not included",
+			deprovision: true,
+			include:     []string{"new-*"},
+			launched:    map[string]time.Time{"old-infra": old},
+		},
+		{
+			// The tagging API still returns the terminated instance, whose age is not that of the infra ID.
+			name:        "terminated instances",
+			deprovision: true,
+			launched:    map[string]time.Time{"old-infra": old},
+			terminated:  true,
+		},
+		{
+			name:         "long infra ID",
+			infraID:      longInfraID,
+			deprovision:  true,
+			launched:     map[string]time.Time{longInfraID: old},
+			expectedJobs: []string{longInfraID},
+		},
+		{
+			name:        "within grace period",
+			deprovision: true,
+			launched:    map[string]time.Time{"old-infra": now.Add(-time.Hour)},
+		},
+		{
+			// The age of the infra ID is unknown, so its resources are only reported.
+			name:        "no instances",
+			deprovision: true,
+		},
+		{
+			name:        "job exists",
+			deprovision: true,
+			existing: []runtime.Object{&batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testHiveNamespace,
+					Name:      "sweep-old-infra-us-east-1-uninstall",
+					Labels:    map[string]string{constants.SweptInfraIDLabel: "old-infra"},
+				},
+			}},
+			launched:     map[string]time.Time{"old-infra": old},
+			expectedJobs: []string{"old-infra"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			infraID := test.infraID
+			if infraID == "" {
+				infraID = "old-infra"
+			}
+			include := test.include
+			if include == nil {
+				include = []string{"old-*"}
+			}
+			mockCtrl := gomock.NewController(t)
+			awsClient := mockaws.NewMockClient(mockCtrl)
+			awsClient.EXPECT().GetResourcesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+					fn(&resourcegroupstaggingapi.GetResourcesOutput{
+						ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{{
+							ResourceARN: aws.String("arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0123"),
+							Tags: []*resourcegroupstaggingapi.Tag{{
+								Key:   aws.String("kubernetes.io/cluster/" + infraID),
+								Value: aws.String("owned"),
+							}},
+						}},
+					}, true)
+					return nil
+				})
+			awsClient.EXPECT().DescribeInstances(gomock.Any()).DoAndReturn(
+				func(*ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+					output := &ec2.DescribeInstancesOutput{}
+					for infraID, launched := range test.launched {
+						state := ec2.InstanceStateNameRunning
+						if test.terminated {
+							state = ec2.InstanceStateNameTerminated
+						}
+						output.Reservations = append(output.Reservations, &ec2.Reservation{
+							Instances: []*ec2.Instance{{
+								LaunchTime: aws.Time(launched),
+								State:      &ec2.InstanceState{Name: aws.String(state)},
+								Tags: []*ec2.Tag{{
+									Key:   aws.String("kubernetes.io/cluster/" + infraID),
+									Value: aws.String("owned"),
+								}},
+							}},
+						})
+					}
+					return output, nil
+				})
+
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(test.existing...).Build()
+			s := &Sweeper{
+				Client: c,
+				logger: log.WithField("controller", ControllerName),
+				config: &hivev1.CloudResourceSweeperConfig{
+					Deprovision: test.deprovision,
+					AWS:         []hivev1.CloudResourceSweeperAWSAccount{testAccount},
+				},
+				options:    cloudinventory.SweepOptions{GracePeriod: 24 * time.Hour, Allowlist: test.allowlist, Include: include},
+				hiveNSName: testHiveNamespace,
+				awsClientFn: func(account hivev1.CloudResourceSweeperAWSAccount, region string) (awsclient.Client, error) {
+					assert.Equal(t, testAccount.CredentialsSecretRef, account.CredentialsSecretRef, "unexpected account")
+					assert.Equal(t, testRegion, region, "unexpected region")
+					return awsClient, nil
+				},
+				now: func() time.Time { return now },
+			}
+
+			s.sweep(context.TODO())
+
+			jobs := &batchv1.JobList{}
+			require.NoError(t, c.List(context.TODO(), jobs, client.InNamespace(testHiveNamespace)), "unexpected error listing jobs")
+			var sweptInfraIDs []string
+			for _, job := range jobs.Items {
+				sweptInfraIDs = append(sweptInfraIDs, job.Labels[constants.SweptInfraIDLabel])
+				assert.LessOrEqual(t, len(job.Name), validation.DNS1123LabelMaxLength, "job name too long")
+				assert.LessOrEqual(t, len(job.Labels[constants.ClusterDeploymentNameLabel]), validation.LabelValueMaxLength, "job label too long")
+				if job.Labels[constants.JobTypeLabel] == "" {
+					// The job existed before the sweep.
+					continue
+				}
+				assert.Equal(t, constants.JobTypeDeprovision, job.Labels[constants.JobTypeLabel], "unexpected job type")
+				if assert.NotNil(t, job.Spec.TTLSecondsAfterFinished, "expected job TTL") {
+					assert.Equal(t, int32(deprovisionJobTTL.Seconds()), *job.Spec.TTLSecondsAfterFinished, "unexpected job TTL")
+				}
+				if assert.Len(t, job.Spec.Template.Spec.Containers, 1, "unexpected containers") {
+					assert.Contains(t, job.Spec.Template.Spec.Containers[0].Args, "kubernetes.io/cluster/"+infraID+"=owned", "expected infra ID tag filter")
+				}
+			}
+			assert.Equal(t, test.expectedJobs, sweptInfraIDs, "unexpected deprovision jobs")
+		})
+	}
+}
+
+func TestReadSweeperConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config")
+	require.NoError(t, os.WriteFile(path, []byte(`{"enabled":true,"gracePeriod":"12h","aws":[{"credentialsSecretRef":{"name":"sweeper-creds"},"regions":["us-east-1"]}]}`), 0600))
+	t.Setenv(constants.CloudResourceSweeperConfigFileEnvVar, path)
+
+	config, err := ReadSweeperConfig()
+	require.NoError(t, err, "unexpected error reading config")
+	assert.True(t, config.Enabled, "expected sweeper to be enabled")
+	assert.Equal(t, []hivev1.CloudResourceSweeperAWSAccount{testAccount}, config.AWS, "unexpected accounts")
+
+	s, err := NewSweeper(nil, config, log.New())
+	require.NoError(t, err, "unexpected error creating sweeper")
+	assert.Equal(t, defaultInterval, s.interval, "unexpected interval")
+	assert.Equal(t, 12*time.Hour, s.options.GracePeriod, "unexpected grace period")
+
+	config.Deprovision = true
+	_, err = NewSweeper(nil, config, log.New())
+	assert.Error(t, err, "expected error deprovisioning without an include list")
+	config.Include = []string{"hive-*"}
+	_, err = NewSweeper(nil, config, log.New())
+	assert.NoError(t, err, "unexpected error deprovisioning with an include list")
+
+	t.Setenv(constants.CloudResourceSweeperConfigFileEnvVar, filepath.Join(dir, "missing"))
+	config, err = ReadSweeperConfig()
+	require.NoError(t, err, "unexpected error reading missing config")
+	assert.False(t, config.Enabled, "expected sweeper to be disabled")
+}
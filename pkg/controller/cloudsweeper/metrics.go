@@ -0,0 +1,25 @@
+package cloudsweeper
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// metricUnownedInfraIDs tracks the infra IDs found in the swept accounts that no cluster owns.
+	metricUnownedInfraIDs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cloud_resource_sweeper_unowned_infra_ids",
+		Help: "The number of infra IDs tagged on cloud resources that no ClusterDeployment, ClusterProvision or ClusterDeprovision owns, by region and what the sweeper does with their resources.",
+	}, []string{"region", "action"})
+	// metricDeprovisions tracks the deprovision jobs created by the sweeper.
+	metricDeprovisions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_cloud_resource_sweeper_deprovisions_total",
+		Help: "The number of jobs created by the cloud resource sweeper to deprovision the resources of unowned infra IDs.",
+	}, []string{"region"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricUnownedInfraIDs)
+	metrics.Registry.MustRegister(metricDeprovisions)
+}
@@ -87,6 +87,13 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterClaim, concurrentRecon
 		return err
 	}
 
+	// Watch for changes to ClusterPools, which may satisfy the constraints of claims that have no pool
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &hivev1.ClusterPool{}),
+		handler.EnqueueRequestsFromMapFunc(requestsForClusterPool(r.Client, r.logger))); err != nil {
+		return err
+	}
+
 	// Watch for changes to the hive-claim-owner Role
 	if err := c.Watch(
 		source.Kind(mgr.GetCache(), &rbacv1.Role{}),
@@ -129,6 +136,26 @@ func requestsForClusterDeployment(ctx context.Context, o client.Object) []reconc
 	return []reconcile.Request{{NamespacedName: *claim}}
 }
 
+// requestsForClusterPool returns the claims in the namespace of the pool that have not been assigned a pool.
+func requestsForClusterPool(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		claims := &hivev1.ClusterClaimList{}
+		if err := c.List(ctx, claims, client.InNamespace(o.GetNamespace())); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list ClusterClaims for ClusterPool")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, claim := range claims.Items {
+			if claim.Spec.ClusterPoolName == "" {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: claim.Namespace, Name: claim.Name},
+				})
+			}
+		}
+		return requests
+	}
+}
+
 func requestsForRBACResources(c client.Client, resourceName string, logger log.FieldLogger) handler.MapFunc {
 	return func(ctx context.Context, o client.Object) []reconcile.Request {
 		if o.GetName() != resourceName {
@@ -203,6 +230,14 @@ func (r *ReconcileClusterClaim) Reconcile(ctx context.Context, request reconcile
 		}
 	}
 
+	if claim.Spec.Release != nil {
+		return r.releaseClaim(claim, logger)
+	}
+
+	if claim.Spec.ClusterPoolName == "" && claim.Spec.Namespace == "" {
+		return r.reconcileClusterPoolSelection(claim, logger)
+	}
+
 	clusterName := claim.Spec.Namespace
 	if clusterName == "" {
 		logger.Debug("claim has not yet been assigned a cluster")
@@ -216,15 +251,9 @@ func (r *ReconcileClusterClaim) Reconcile(ctx context.Context, request reconcile
 		logger.Log(controllerutils.LogLevel(err), "error getting cluster pool lifetime")
 		return reconcile.Result{}, err
 	}
-	lifetime := getClaimLifetime(poolLifetime, claim.Spec.Lifetime)
-
-	if (lifetime != nil) != (claim.Status.Lifetime != nil) ||
-		lifetime != nil && claim.Status.Lifetime != nil && lifetime.Duration != claim.Status.Lifetime.Duration {
-		claim.Status.Lifetime = lifetime
-		if err := r.Status().Update(context.Background(), claim); err != nil {
-			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterClaim lifetime")
-			return reconcile.Result{}, errors.Wrap(err, "could not update ClusterClaim lifetime")
-		}
+	lifetime, err := r.reconcileLifetime(claim, poolLifetime, logger)
+	if err != nil {
+		return reconcile.Result{}, err
 	}
 
 	// Delete ClusterClaim after its lifetime elapses
@@ -18,6 +18,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
@@ -104,6 +106,7 @@ func TestReconcileClusterClaim(t *testing.T) {
 		expectHibernating                      bool
 		expectDeleted                          bool
 		expectedRequeueAfter                   *time.Duration
+		expectedLifetime                       *time.Duration
 	}{
 		{
 			name:  "initialize conditions",
@@ -599,6 +602,166 @@ func TestReconcileClusterClaim(t *testing.T) {
 			expectRBAC:           true,
 			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(2 * time.Hour),
 		},
+		{
+			name: "claim with elapsed lifetime is not deleted when extended",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(1*time.Hour),
+				testclaim.WithExtension(2*time.Hour, "still debugging"),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			existing: []runtime.Object{
+				testRole(),
+				testRoleBinding(),
+			},
+			expectCompletedClaim: true,
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:    hivev1.ClusterClaimLifetimeExtendedCondition,
+					Status:  corev1.ConditionTrue,
+					Reason:  "Extended",
+					Message: "Lifetime extended by 2h0m0s to 3h0m0s: still debugging",
+				},
+			},
+			expectRBAC:           true,
+			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(2 * time.Hour),
+			expectedLifetime:     func(d time.Duration) *time.Duration { return &d }(3 * time.Hour),
+		},
+		{
+			name: "claim extension is capped at pool maximum",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithPool(testLeasePoolName),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(1*time.Hour),
+				testclaim.WithExtension(1*time.Hour, "first"),
+				testclaim.WithExtension(3*time.Hour, "second"),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithMaximumClaimLifetime(4 * time.Hour)),
+				testRole(),
+				testRoleBinding(),
+			},
+			expectCompletedClaim: true,
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:    hivev1.ClusterClaimLifetimeExtendedCondition,
+					Status:  corev1.ConditionTrue,
+					Reason:  "CappedAtMaximum",
+					Message: "Lifetime extended by 3h0m0s to the maximum of 4h0m0s allowed by the pool: second",
+				},
+			},
+			expectRBAC:           true,
+			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(3 * time.Hour),
+			expectedLifetime:     func(d time.Duration) *time.Duration { return &d }(4 * time.Hour),
+		},
+		{
+			name: "claim at pool maximum is not extended",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithPool(testLeasePoolName),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(1*time.Hour),
+				testclaim.WithExtension(1*time.Hour, ""),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithMaximumClaimLifetime(1 * time.Hour)),
+			},
+			expectCompletedClaim: true,
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:    hivev1.ClusterClaimLifetimeExtendedCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "MaximumReached",
+					Message: "Lifetime is already the maximum of 1h0m0s allowed by the pool",
+				},
+			},
+			expectedLifetime: func(d time.Duration) *time.Duration { return &d }(1 * time.Hour),
+		},
+		{
+			name: "claim without lifetime is not extended",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithCluster(clusterName),
+				testclaim.WithExtension(1*time.Hour, ""),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:    hivev1.ClusterClaimPendingCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "ClusterClaimed",
+					Message: "Cluster claimed",
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			existing: []runtime.Object{
+				testRole(),
+				testRoleBinding(),
+			},
+			expectCompletedClaim: true,
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:   hivev1.ClusterClaimLifetimeExtendedCondition,
+					Status: corev1.ConditionFalse,
+					Reason: "NoLifetime",
+				},
+			},
+			expectRBAC: true,
+		},
+		{
+			name: "released claim is deleted",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(3*time.Hour),
+				testclaim.WithRelease("investigation finished"),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			expectCompletedClaim: true,
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:    hivev1.ClusterClaimReleasedCondition,
+					Status:  corev1.ConditionTrue,
+					Reason:  "ReleasedByClaimant",
+					Message: "Cluster released by claimant: investigation finished",
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -679,6 +842,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 				assert.NotEqual(t, test.expectAssignedClusterDeploymentDeleted, assignedClusterDeploymentExists, "unexpected assigned ClusterDeployment")
 			}
 
+			if test.expectedLifetime != nil {
+				if assert.NotNil(t, claim.Status.Lifetime, "expected lifetime in claim status") {
+					assert.Equal(t, *test.expectedLifetime, claim.Status.Lifetime.Duration, "unexpected lifetime in claim status")
+				}
+			}
+
 			if test.expectNoAssignment {
 				assert.Empty(t, claim.Spec.Namespace, "expected no assignment set on claim")
 			} else {
@@ -820,6 +989,194 @@ func Test_getClaimLifetime(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterClaim_PoolSelection(t *testing.T) {
+	scheme := scheme.GetScheme()
+	imageSet := func(name, releaseImage string, labels map[string]string) *hivev1.ClusterImageSet {
+		return &hivev1.ClusterImageSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: releaseImage},
+		}
+	}
+	withReady := func(ready int32) testcp.Option {
+		return func(pool *hivev1.ClusterPool) {
+			pool.Status.Ready = ready
+		}
+	}
+	pool := func(name string, opts ...testcp.Option) *hivev1.ClusterPool {
+		return testcp.FullBuilder(claimNamespace, name, scheme).Options(
+			testcp.WithBaseDomain("test-domain"),
+		).Build(opts...)
+	}
+	aws := func(region string) testcp.Option {
+		return testcp.WithPlatform(hivev1.Platform{AWS: &hivev1aws.Platform{Region: region}})
+	}
+	gcp := testcp.WithPlatform(hivev1.Platform{GCP: &hivev1gcp.Platform{Region: "us-east1"}})
+	imageSets := []runtime.Object{
+		imageSet("openshift-4.13", "quay.io/openshift-release-dev/ocp-release:4.13.10-x86_64", nil),
+		imageSet("openshift-4.14", "quay.io/openshift-release-dev/ocp-release:4.14.3-x86_64", map[string]string{"channel": "stable"}),
+		imageSet("openshift-digest", "quay.io/openshift-release-dev/ocp-release@sha256:abcd", nil),
+	}
+	claimBuilder := testclaim.FullBuilder(claimNamespace, claimName, scheme).Options(
+		testclaim.WithCondition(hivev1.ClusterClaimCondition{
+			Status: corev1.ConditionUnknown,
+			Type:   hivev1.ClusterClaimPendingCondition,
+		}),
+		testclaim.WithCondition(hivev1.ClusterClaimCondition{
+			Status: corev1.ConditionUnknown,
+			Type:   hivev1.ClusterRunningCondition,
+		}),
+	)
+
+	tests := []struct {
+		name            string
+		constraints     *hivev1.ClusterClaimConstraints
+		pools           []runtime.Object
+		expectedPool    string
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:        "platform and version",
+			constraints: &hivev1.ClusterClaimConstraints{Platform: "aws", Version: "4.14.x"},
+			pools: []runtime.Object{
+				pool("aws-413", aws("us-east-1"), testcp.WithImageSet("openshift-4.13")),
+				pool("aws-414", aws("us-east-1"), testcp.WithImageSet("openshift-4.14")),
+				pool("gcp-414", gcp, testcp.WithImageSet("openshift-4.14")),
+			},
+			expectedPool: "aws-414",
+		},
+		{
+			name:        "most ready pool",
+			constraints: &hivev1.ClusterClaimConstraints{Region: "us-east-1"},
+			pools: []runtime.Object{
+				pool("a", aws("us-east-1"), testcp.WithImageSet("openshift-4.14"), withReady(1)),
+				pool("b", aws("us-east-1"), testcp.WithImageSet("openshift-4.14"), withReady(3)),
+				pool("c", aws("us-west-2"), testcp.WithImageSet("openshift-4.14"), withReady(5)),
+			},
+			expectedPool: "b",
+		},
+		{
+			name: "selectors",
+			constraints: &hivev1.ClusterClaimConstraints{
+				PoolSelector:              &metav1.LabelSelector{MatchLabels: map[string]string{"team": "qa"}},
+				ClusterDeploymentSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"size": "large"}},
+				ClusterImageSetSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"channel": "stable"}},
+			},
+			pools: []runtime.Object{
+				pool("other-team", aws("us-east-1"), testcp.WithImageSet("openshift-4.14"),
+					testcp.WithClusterDeploymentLabels(map[string]string{"size": "large"})),
+				pool("small", aws("us-east-1"), testcp.WithImageSet("openshift-4.14"),
+					testcp.Generic(testgeneric.WithLabel("team", "qa"))),
+				pool("unstable", aws("us-east-1"), testcp.WithImageSet("openshift-4.13"),
+					testcp.Generic(testgeneric.WithLabel("team", "qa")),
+					testcp.WithClusterDeploymentLabels(map[string]string{"size": "large"})),
+				pool("match", aws("us-east-1"), testcp.WithImageSet("openshift-4.14"),
+					testcp.Generic(testgeneric.WithLabel("team", "qa")),
+					testcp.WithClusterDeploymentLabels(map[string]string{"size": "large"})),
+			},
+			expectedPool: "match",
+		},
+		{
+			name:        "no matching pool",
+			constraints: &hivev1.ClusterClaimConstraints{Platform: "aws", Version: ">=4.14.0 <4.15.0"},
+			pools: []runtime.Object{
+				pool("aws-413", aws("us-east-1"), testcp.WithImageSet("openshift-4.13")),
+				pool("aws-digest", aws("us-east-1"), testcp.WithImageSet("openshift-digest")),
+				pool("aws-missing", aws("us-east-1"), testcp.WithImageSet("missing")),
+				pool("gcp-414", gcp, testcp.WithImageSet("openshift-4.14")),
+			},
+			expectedReason: "NoMatchingClusterPool",
+			expectedMessage: "No ClusterPool satisfies the constraints of the claim: " +
+				"aws-413: version 4.13.10 is not in range \">=4.14.0 <4.15.0\"; " +
+				"aws-digest: version of ClusterImageSet openshift-digest is unknown: release image is referenced by digest; " +
+				"aws-missing: ClusterImageSet missing not found; " +
+				"gcp-414: platform is gcp, not aws",
+		},
+		{
+			name:            "no pools",
+			constraints:     &hivev1.ClusterClaimConstraints{Platform: "aws"},
+			expectedReason:  "NoMatchingClusterPool",
+			expectedMessage: "No ClusterPool satisfies the constraints of the claim: there are no ClusterPools in the namespace",
+		},
+		{
+			name: "no constraints",
+			pools: []runtime.Object{
+				pool("aws-414", aws("us-east-1"), testcp.WithImageSet("openshift-4.14")),
+			},
+			expectedReason: "NoClusterPool",
+		},
+		{
+			name:        "invalid version range",
+			constraints: &hivev1.ClusterClaimConstraints{Version: "four"},
+			pools: []runtime.Object{
+				pool("aws-414", aws("us-east-1"), testcp.WithImageSet("openshift-4.14")),
+			},
+			expectedReason: "InvalidConstraints",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claim := claimBuilder.Build(
+				testclaim.WithConstraints(test.constraints),
+				testclaim.Generic(testgeneric.WithFinalizer(finalizer)),
+			)
+			existing := append([]runtime.Object{claim}, imageSets...)
+			existing = append(existing, test.pools...)
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build()
+			rcp := &ReconcileClusterClaim{
+				Client: c,
+				logger: log.New(),
+			}
+
+			_, err := rcp.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: claimNamespace, Name: claimName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			claim = &hivev1.ClusterClaim{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: claimNamespace, Name: claimName}, claim))
+			assert.Equal(t, test.expectedPool, claim.Spec.ClusterPoolName, "unexpected pool for claim")
+			cond := controllerutils.FindCondition(claim.Status.Conditions, hivev1.ClusterClaimPendingCondition)
+			require.NotNil(t, cond, "expected Pending condition")
+			if test.expectedReason == "" {
+				assert.Equal(t, corev1.ConditionUnknown, cond.Status, "unexpected status of Pending condition")
+				return
+			}
+			assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected status of Pending condition")
+			assert.Equal(t, test.expectedReason, cond.Reason, "unexpected reason of Pending condition")
+			if test.expectedMessage != "" {
+				assert.Equal(t, test.expectedMessage, cond.Message, "unexpected message of Pending condition")
+			}
+		})
+	}
+}
+
+func Test_releaseImageVersion(t *testing.T) {
+	cases := []struct {
+		image       string
+		expected    string
+		expectError bool
+	}{
+		{image: "quay.io/openshift-release-dev/ocp-release:4.14.3-x86_64", expected: "4.14.3"},
+		{image: "quay.io/openshift-release-dev/ocp-release:4.15.0-rc.1-multi", expected: "4.15.0-rc.1"},
+		{image: "registry.example.com:5000/ocp/release:4.13.10", expected: "4.13.10"},
+		{image: "registry.example.com:5000/ocp/release", expectError: true},
+		{image: "quay.io/openshift-release-dev/ocp-release@sha256:abcd", expectError: true},
+		{image: "quay.io/openshift-release-dev/ocp-release:latest", expectError: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.image, func(t *testing.T) {
+			version, err := releaseImageVersion(tc.image)
+			if tc.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expected, version.String(), "unexpected version")
+		})
+	}
+}
+
 func testRole() *rbacv1.Role {
 	return &rbacv1.Role{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,167 @@
+package clusterclaim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	extendedReason        = "Extended"
+	cappedAtMaximumReason = "CappedAtMaximum"
+	maximumReachedReason  = "MaximumReached"
+	noLifetimeReason      = "NoLifetime"
+	releasedReason        = "ReleasedByClaimant"
+)
+
+// extendClaimLifetime returns the lifetime of a claim after adding the extensions requested for the claim, and the
+// portion of the extensions that was granted. The extended lifetime does not exceed the maximum lifetime for the pool.
+// A claim without a lifetime cannot be extended.
+func extendClaimLifetime(poolLifetime *hivev1.ClusterPoolClaimLifetime, lifetime *metav1.Duration, extensions []hivev1.ClusterClaimExtension) (*metav1.Duration, *metav1.Duration) {
+	if lifetime == nil || len(extensions) == 0 {
+		return lifetime, nil
+	}
+	extended := lifetime.Duration
+	for _, e := range extensions {
+		extended += e.Duration.Duration
+	}
+	if poolLifetime != nil && poolLifetime.Maximum != nil && poolLifetime.Maximum.Duration < extended {
+		extended = poolLifetime.Maximum.Duration
+	}
+	granted := extended - lifetime.Duration
+	if granted < 0 {
+		// The lifetime was already above the maximum, which only happens when there is no maximum.
+		granted = 0
+	}
+	return &metav1.Duration{Duration: extended}, &metav1.Duration{Duration: granted}
+}
+
+// lifetimeExtendedCondition returns the status, reason and message of the LifetimeExtended condition for a claim whose
+// lifetime of baseLifetime was extended to lifetime.
+func lifetimeExtendedCondition(claim *hivev1.ClusterClaim, baseLifetime, lifetime, granted *metav1.Duration) (corev1.ConditionStatus, string, string) {
+	latest := claim.Spec.Extensions[len(claim.Spec.Extensions)-1]
+	withReason := func(msg string) string {
+		if latest.Reason == "" {
+			return msg
+		}
+		return fmt.Sprintf("%s: %s", msg, latest.Reason)
+	}
+	if baseLifetime == nil {
+		return corev1.ConditionFalse, noLifetimeReason, "Claim has no lifetime to extend"
+	}
+	var requested time.Duration
+	for _, e := range claim.Spec.Extensions {
+		requested += e.Duration.Duration
+	}
+	switch {
+	case granted.Duration == requested:
+		return corev1.ConditionTrue, extendedReason,
+			withReason(fmt.Sprintf("Lifetime extended by %s to %s", granted.Duration, lifetime.Duration))
+	case granted.Duration > 0:
+		return corev1.ConditionTrue, cappedAtMaximumReason,
+			withReason(fmt.Sprintf("Lifetime extended by %s to the maximum of %s allowed by the pool", granted.Duration, lifetime.Duration))
+	default:
+		return corev1.ConditionFalse, maximumReachedReason,
+			withReason(fmt.Sprintf("Lifetime is already the maximum of %s allowed by the pool", lifetime.Duration))
+	}
+}
+
+// reconcileLifetime computes the lifetime of the claim, including any extensions, and records it in the status of the
+// claim. The extended lifetime is returned.
+func (r *ReconcileClusterClaim) reconcileLifetime(claim *hivev1.ClusterClaim, poolLifetime *hivev1.ClusterPoolClaimLifetime, logger log.FieldLogger) (*metav1.Duration, error) {
+	baseLifetime := getClaimLifetime(poolLifetime, claim.Spec.Lifetime)
+	lifetime, granted := extendClaimLifetime(poolLifetime, baseLifetime, claim.Spec.Extensions)
+
+	changed := !durationsEqual(lifetime, claim.Status.Lifetime) || !durationsEqual(granted, claim.Status.GrantedExtension)
+	claim.Status.Lifetime = lifetime
+	claim.Status.GrantedExtension = granted
+
+	var result string
+	if len(claim.Spec.Extensions) > 0 {
+		status, reason, message := lifetimeExtendedCondition(claim, baseLifetime, lifetime, granted)
+		result = reason
+		if conds, condChanged := controllerutils.SetClusterClaimConditionWithChangeCheck(
+			claim.Status.Conditions,
+			hivev1.ClusterClaimLifetimeExtendedCondition,
+			status,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		); condChanged {
+			claim.Status.Conditions = conds
+			changed = true
+		}
+	}
+	if observed := int32(len(claim.Spec.Extensions)); observed != claim.Status.ObservedExtensions {
+		for _, e := range claim.Spec.Extensions[minInt32(observed, claim.Status.ObservedExtensions):] {
+			logger.WithField("duration", e.Duration.Duration).
+				WithField("reason", e.Reason).
+				WithField("result", result).
+				Info("processed extension of ClusterClaim lifetime")
+			metricLifetimeExtensions.WithLabelValues(claim.Namespace, claim.Spec.ClusterPoolName, result).Inc()
+		}
+		claim.Status.ObservedExtensions = observed
+		changed = true
+	}
+
+	if changed {
+		if err := r.Status().Update(context.Background(), claim); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterClaim lifetime")
+			return nil, errors.Wrap(err, "could not update ClusterClaim lifetime")
+		}
+	}
+	return lifetime, nil
+}
+
+// releaseClaim deletes a claim whose cluster has been released by the claimant. The Released condition is set first so
+// that the release is visible while the claim is being deleted.
+func (r *ReconcileClusterClaim) releaseClaim(claim *hivev1.ClusterClaim, logger log.FieldLogger) (reconcile.Result, error) {
+	message := "Cluster released by claimant"
+	if claim.Spec.Release.Reason != "" {
+		message = fmt.Sprintf("%s: %s", message, claim.Spec.Release.Reason)
+	}
+	if conds, changed := controllerutils.SetClusterClaimConditionWithChangeCheck(
+		claim.Status.Conditions,
+		hivev1.ClusterClaimReleasedCondition,
+		corev1.ConditionTrue,
+		releasedReason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	); changed {
+		claim.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), claim); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
+			return reconcile.Result{}, err
+		}
+	}
+	logger.WithField("reason", claim.Spec.Release.Reason).Info("deleting ClusterClaim because its cluster was released")
+	if err := r.Delete(context.Background(), claim); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete ClusterClaim")
+		return reconcile.Result{}, errors.Wrap(err, "could not delete ClusterClaim")
+	}
+	metricClaimsReleased.WithLabelValues(claim.Namespace, claim.Spec.ClusterPoolName).Inc()
+	return reconcile.Result{}, nil
+}
+
+func durationsEqual(a, b *metav1.Duration) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Duration == b.Duration
+}
+
+func minInt32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,27 @@
+package clusterclaim
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// metricLifetimeExtensions tracks the extensions requested for ClusterClaims, labeled by the
+	// outcome, which is the reason of the LifetimeExtended condition.
+	metricLifetimeExtensions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_clusterclaim_lifetime_extensions",
+		Help: "The number of extensions of the lifetime of ClusterClaims processed, by outcome.",
+	}, []string{"clusterpool_namespace", "clusterpool_name", "result"})
+	// metricClaimsReleased tracks the ClusterClaims deleted because the claimant released the cluster
+	// before the lifetime of the claim elapsed.
+	metricClaimsReleased = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_clusterclaim_released",
+		Help: "The number of ClusterClaims deleted because the claimant released the cluster early.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricLifetimeExtensions)
+	metrics.Registry.MustRegister(metricClaimsReleased)
+}
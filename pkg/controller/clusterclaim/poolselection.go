@@ -0,0 +1,261 @@
+package clusterclaim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	noClusterPoolReason         = "NoClusterPool"
+	invalidConstraintsReason    = "InvalidConstraints"
+	noMatchingClusterPoolReason = "NoMatchingClusterPool"
+)
+
+// releaseImageArchSuffixes are the architecture suffixes of the tags of OpenShift release images.
+var releaseImageArchSuffixes = []string{"-x86_64", "-aarch64", "-arm64", "-ppc64le", "-s390x", "-multi"}
+
+// claimConstraints are the parsed constraints of a claim.
+type claimConstraints struct {
+	poolSelector         labels.Selector
+	versionRange         semver.Range
+	version              string
+	platform             string
+	region               string
+	cdSelector           labels.Selector
+	clusterImageSelector labels.Selector
+}
+
+func parseConstraints(constraints *hivev1.ClusterClaimConstraints) (*claimConstraints, error) {
+	parsed := &claimConstraints{
+		version:  constraints.Version,
+		platform: constraints.Platform,
+		region:   constraints.Region,
+	}
+	var err error
+	if parsed.poolSelector, err = parseSelector(constraints.PoolSelector); err != nil {
+		return nil, errors.Wrap(err, "invalid pool selector")
+	}
+	if parsed.cdSelector, err = parseSelector(constraints.ClusterDeploymentSelector); err != nil {
+		return nil, errors.Wrap(err, "invalid ClusterDeployment selector")
+	}
+	if parsed.clusterImageSelector, err = parseSelector(constraints.ClusterImageSetSelector); err != nil {
+		return nil, errors.Wrap(err, "invalid ClusterImageSet selector")
+	}
+	if constraints.Version != "" {
+		if parsed.versionRange, err = semver.ParseRange(constraints.Version); err != nil {
+			return nil, errors.Wrap(err, "invalid version range")
+		}
+	}
+	return parsed, nil
+}
+
+// parseSelector converts the label selector to a selector. A nil label selector selects everything.
+func parseSelector(selector *metav1.LabelSelector) (labels.Selector, error) {
+	if selector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
+
+// reconcileClusterPoolSelection chooses a cluster pool for a claim that does not name one. Of the pools in the
+// namespace of the claim that satisfy the constraints of the claim, the one with the most clusters ready to be claimed
+// is chosen. If no pool satisfies the constraints, the Pending condition of the claim explains why each pool was
+// rejected.
+func (r *ReconcileClusterClaim) reconcileClusterPoolSelection(claim *hivev1.ClusterClaim, logger log.FieldLogger) (reconcile.Result, error) {
+	if claim.Spec.Constraints == nil {
+		return reconcile.Result{}, r.setPoolSelectionPending(claim, noClusterPoolReason,
+			"Claim names no ClusterPool and has no constraints with which to choose one", logger)
+	}
+	constraints, err := parseConstraints(claim.Spec.Constraints)
+	if err != nil {
+		return reconcile.Result{}, r.setPoolSelectionPending(claim, invalidConstraintsReason, err.Error(), logger)
+	}
+
+	pools := &hivev1.ClusterPoolList{}
+	if err := r.List(context.Background(), pools, client.InNamespace(claim.Namespace)); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterPools")
+		return reconcile.Result{}, errors.Wrap(err, "could not list ClusterPools")
+	}
+	var candidates []*hivev1.ClusterPool
+	var rejections []string
+	for i := range pools.Items {
+		pool := &pools.Items[i]
+		rejection, err := r.unsatisfiedConstraint(pool, constraints, logger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if rejection != "" {
+			rejections = append(rejections, fmt.Sprintf("%s: %s", pool.Name, rejection))
+			continue
+		}
+		candidates = append(candidates, pool)
+	}
+
+	if len(candidates) == 0 {
+		message := "No ClusterPool satisfies the constraints of the claim"
+		if len(rejections) == 0 {
+			message += ": there are no ClusterPools in the namespace"
+		} else {
+			sort.Strings(rejections)
+			message += ": " + strings.Join(rejections, "; ")
+		}
+		return reconcile.Result{}, r.setPoolSelectionPending(claim, noMatchingClusterPoolReason, message, logger)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Status.Ready != candidates[j].Status.Ready {
+			return candidates[i].Status.Ready > candidates[j].Status.Ready
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	pool := candidates[0]
+	logger.WithField("pool", pool.Name).WithField("candidates", len(candidates)).
+		Info("selected ClusterPool satisfying the constraints of the claim")
+	claim.Spec.ClusterPoolName = pool.Name
+	if err := r.Update(context.Background(), claim); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not set ClusterPool of ClusterClaim")
+		return reconcile.Result{}, errors.Wrap(err, "could not set ClusterPool of ClusterClaim")
+	}
+	return reconcile.Result{}, nil
+}
+
+// unsatisfiedConstraint returns a description of the first constraint that the pool does not satisfy, or an empty
+// string if the pool satisfies all of the constraints.
+func (r *ReconcileClusterClaim) unsatisfiedConstraint(pool *hivev1.ClusterPool, constraints *claimConstraints, logger log.FieldLogger) (string, error) {
+	if pool.DeletionTimestamp != nil {
+		return "pool is being deleted", nil
+	}
+	if !constraints.poolSelector.Matches(labels.Set(pool.Labels)) {
+		return fmt.Sprintf("pool labels do not match selector %q", constraints.poolSelector), nil
+	}
+	if constraints.platform != "" {
+		if platform := poolPlatform(pool); platform != constraints.platform {
+			return fmt.Sprintf("platform is %s, not %s", platform, constraints.platform), nil
+		}
+	}
+	if constraints.region != "" {
+		if region := poolRegion(pool); region != constraints.region {
+			return fmt.Sprintf("region is %q, not %q", region, constraints.region), nil
+		}
+	}
+	if !constraints.cdSelector.Matches(labels.Set(pool.Spec.Labels)) {
+		return fmt.Sprintf("ClusterDeployment labels do not match selector %q", constraints.cdSelector), nil
+	}
+	if constraints.versionRange == nil && constraints.clusterImageSelector.Empty() {
+		return "", nil
+	}
+
+	imageSetName := pool.Spec.ImageSetRef.Name
+	imageSet := &hivev1.ClusterImageSet{}
+	switch err := r.Get(context.Background(), client.ObjectKey{Name: imageSetName}, imageSet); {
+	case apierrors.IsNotFound(err):
+		return fmt.Sprintf("ClusterImageSet %s not found", imageSetName), nil
+	case err != nil:
+		logger.WithError(err).WithField("clusterImageSet", imageSetName).
+			Log(controllerutils.LogLevel(err), "could not get ClusterImageSet")
+		return "", errors.Wrapf(err, "could not get ClusterImageSet %s", imageSetName)
+	}
+	if !constraints.clusterImageSelector.Matches(labels.Set(imageSet.Labels)) {
+		return fmt.Sprintf("ClusterImageSet %s labels do not match selector %q", imageSetName, constraints.clusterImageSelector), nil
+	}
+	if constraints.versionRange != nil {
+		version, err := releaseImageVersion(imageSet.Spec.ReleaseImage)
+		if err != nil {
+			return fmt.Sprintf("version of ClusterImageSet %s is unknown: %v", imageSetName, err), nil
+		}
+		if !constraints.versionRange(version) {
+			return fmt.Sprintf("version %s is not in range %q", version, constraints.version), nil
+		}
+	}
+	return "", nil
+}
+
+// setPoolSelectionPending records in the Pending condition of the claim why no cluster pool could be chosen for it.
+func (r *ReconcileClusterClaim) setPoolSelectionPending(claim *hivev1.ClusterClaim, reason, message string, logger log.FieldLogger) error {
+	conds, changed := controllerutils.SetClusterClaimConditionWithChangeCheck(
+		claim.Status.Conditions,
+		hivev1.ClusterClaimPendingCondition,
+		corev1.ConditionTrue,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	logger.WithField("reason", reason).Info(message)
+	claim.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), claim); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
+		return err
+	}
+	return nil
+}
+
+// releaseImageVersion returns the OpenShift version in the tag of the release image.
+func releaseImageVersion(releaseImage string) (semver.Version, error) {
+	if strings.Contains(releaseImage, "@") {
+		return semver.Version{}, errors.New("release image is referenced by digest")
+	}
+	i := strings.LastIndex(releaseImage, ":")
+	if i < 0 || strings.Contains(releaseImage[i:], "/") {
+		return semver.Version{}, errors.New("release image has no tag")
+	}
+	tag := releaseImage[i+1:]
+	for _, suffix := range releaseImageArchSuffixes {
+		tag = strings.TrimSuffix(tag, suffix)
+	}
+	return semver.ParseTolerant(tag)
+}
+
+// poolPlatform returns the platform of the clusters of the pool.
+func poolPlatform(pool *hivev1.ClusterPool) string {
+	switch p := pool.Spec.Platform; {
+	case p.AWS != nil:
+		return constants.PlatformAWS
+	case p.Azure != nil:
+		return constants.PlatformAzure
+	case p.GCP != nil:
+		return constants.PlatformGCP
+	case p.OpenStack != nil:
+		return constants.PlatformOpenStack
+	case p.VSphere != nil:
+		return constants.PlatformVSphere
+	case p.IBMCloud != nil:
+		return constants.PlatformIBMCloud
+	case p.Ovirt != nil:
+		return constants.PlatformOvirt
+	}
+	return constants.PlatformUnknown
+}
+
+// poolRegion returns the region of the clusters of the pool, or an empty string for platforms without regions.
+func poolRegion(pool *hivev1.ClusterPool) string {
+	switch p := pool.Spec.Platform; {
+	case p.AWS != nil:
+		return p.AWS.Region
+	case p.Azure != nil:
+		return p.Azure.Region
+	case p.GCP != nil:
+		return p.GCP.Region
+	case p.IBMCloud != nil:
+		return p.IBMCloud.Region
+	}
+	return ""
+}
@@ -0,0 +1,190 @@
+package clustercost
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ClusterCostControllerName
+
+	// costInterval is how often the cumulative cost of a cluster is saved. The cost is saved sooner when the hourly
+	// cost of the cluster changes.
+	costInterval = 30 * time.Minute
+)
+
+// Add creates a new ClusterCost controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileClusterCost
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterCost {
+	return &ReconcileClusterCost{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+		now:    time.Now,
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileClusterCost, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("clustercost-controller", mgr, controller.Options{
+		Reconciler:              controllerutils.NewDelayingReconciler(r, r.logger),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments, e.g. to their power state.
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &hivev1.ClusterDeployment{}),
+		controllerutils.NewRateLimitedUpdateEventHandler(&handler.EnqueueRequestForObject{}, controllerutils.IsClusterDeploymentErrorUpdateEvent),
+	); err != nil {
+		return err
+	}
+
+	// Watch for changes to MachinePools, which change the machines of their ClusterDeployment.
+	return c.Watch(
+		source.Kind(mgr.GetCache(), &hivev1.MachinePool{}),
+		handler.EnqueueRequestsFromMapFunc(requestsForMachinePool),
+	)
+}
+
+func requestsForMachinePool(ctx context.Context, o client.Object) []reconcile.Request {
+	pool, ok := o.(*hivev1.MachinePool)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: pool.Namespace, Name: pool.Spec.ClusterDeploymentRef.Name}}}
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterCost{}
+
+// ReconcileClusterCost estimates the cost of ClusterDeployments.
+type ReconcileClusterCost struct {
+	client.Client
+	logger log.FieldLogger
+
+	// now returns the current time, here for testing.
+	now func() time.Time
+}
+
+// Reconcile estimates the hourly cost of a ClusterDeployment from the price table, and adds the cost accrued since
+// it was last reconciled to its cumulative cost.
+func (r *ReconcileClusterCost) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	logger.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(ctx, request.NamespacedName, cd); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+	logger = controllerutils.AddLogFields(controllerutils.MetaObjectLogTagger{Object: cd}, logger)
+
+	if cd.DeletionTimestamp != nil || !cd.Spec.Installed || cd.Status.InstalledTimestamp == nil {
+		logger.Debug("cluster is not installed or is being deleted, skipping")
+		return reconcile.Result{}, nil
+	}
+
+	table, err := loadPriceTable(ctx, r)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not load price table")
+		return reconcile.Result{}, err
+	}
+	if table == nil {
+		logger.Debugf("no %s configmap, skipping", priceTableConfigMapName)
+		return reconcile.Result{}, nil
+	}
+
+	machines, err := clusterMachines(ctx, r, cd)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not determine the machines of the cluster")
+		return reconcile.Result{}, err
+	}
+
+	cost := nextCostStatus(cd, table, machines, metav1.NewTime(r.now().Truncate(time.Second)))
+	if prev := cd.Status.Cost; prev != nil && prev.Currency == cost.Currency && prev.HourlyCost == cost.HourlyCost &&
+		prev.UnpricedMachines == cost.UnpricedMachines && cost.LastUpdateTime.Sub(prev.LastUpdateTime.Time) < costInterval {
+		return reconcile.Result{RequeueAfter: costInterval - cost.LastUpdateTime.Sub(prev.LastUpdateTime.Time)}, nil
+	}
+	// Patch rather than update the status, which many other controllers write, so as not to conflict with them.
+	patchBase := client.MergeFrom(cd.DeepCopy())
+	cd.Status.Cost = cost
+	if err := r.Status().Patch(ctx, cd, patchBase); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update cluster cost")
+		return reconcile.Result{}, err
+	}
+	logger.WithField("hourlyCost", cost.HourlyCost).WithField("cumulativeCost", cost.CumulativeCost).Debug("updated cluster cost")
+	return reconcile.Result{RequeueAfter: costInterval}, nil
+}
+
+// nextCostStatus calculates the cost of a cluster at now. The cost accrued since the cost was last calculated is
+// added to the cumulative cost. The first time, the cost accrued since the cluster was installed is estimated from its
+// current machines.
+func nextCostStatus(cd *hivev1.ClusterDeployment, table *priceTable, machines []machineGroup, now metav1.Time) *hivev1.ClusterCostStatus {
+	hibernating := cd.Status.PowerState == hivev1.ClusterPowerStateHibernating
+	estimate := table.estimateHourlyCost(cd, machines, hibernating)
+
+	since := cd.Status.InstalledTimestamp.Time
+	prevHourly, cumulative := estimate.hourly, 0.0
+	if prev := cd.Status.Cost; prev != nil {
+		since = prev.LastUpdateTime.Time
+		if hourly, err := strconv.ParseFloat(prev.HourlyCost, 64); err == nil {
+			prevHourly = hourly
+		}
+		if total, err := strconv.ParseFloat(prev.CumulativeCost, 64); err == nil {
+			cumulative = total
+		}
+	} else if _, ok := hibernationTransition(cd, since, now.Time); ok {
+		// The cluster was in the other power state until it last started or stopped hibernating.
+		prevHourly = table.estimateHourlyCost(cd, machines, !hibernating).hourly
+	}
+	cumulative += accruedCost(cd, since, now.Time, prevHourly, estimate.hourly)
+
+	return &hivev1.ClusterCostStatus{
+		Currency:         table.Currency,
+		HourlyCost:       formatCost(estimate.hourly),
+		CumulativeCost:   formatCost(cumulative),
+		UnpricedMachines: int32(estimate.unpricedMachines),
+		LastUpdateTime:   now,
+	}
+}
+
+func formatCost(cost float64) string {
+	return strconv.FormatFloat(cost, 'f', 4, 64)
+}
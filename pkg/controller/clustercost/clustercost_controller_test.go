@@ -0,0 +1,257 @@
+package clustercost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	testmp "github.com/openshift/hive/pkg/test/machinepool"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	testNamespace  = "test-namespace"
+	testName       = "test-cluster"
+	testICSecret   = "test-install-config"
+	testPriceTable = `currency: USD
+clusterHourly: 0.05
+rates:
+- platform: aws
+  instanceType: m6i.xlarge
+  hourly: 0.20
+- platform: aws
+  region: us-east-1
+  instanceType: m6i.xlarge
+  hourly: 0.19
+- platform: aws
+  instanceType: m6i.2xlarge
+  hourly: 0.40
+`
+	testInstallConfig = `apiVersion: v1
+metadata:
+  name: test-cluster
+baseDomain: example.com
+controlPlane:
+  name: master
+  replicas: 3
+  platform:
+    aws:
+      type: m6i.2xlarge
+compute:
+- name: worker
+  replicas: 2
+  platform:
+    aws:
+      type: m6i.xlarge
+platform:
+  aws:
+    region: us-east-1
+`
+)
+
+func TestReconcileClusterCost(t *testing.T) {
+	now := time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC)
+	hoursAgo := func(hours float64) time.Time {
+		return now.Add(-time.Duration(hours * float64(time.Hour)))
+	}
+
+	tests := []struct {
+		name            string
+		cdOptions       []testcd.Option
+		machinePools    []runtime.Object
+		noPriceTable    bool
+		expectedCost    *hivev1.ClusterCostStatus
+		expectedRequeue time.Duration
+	}{
+		{
+			name: "first estimate from install config",
+			expectedCost: &hivev1.ClusterCostStatus{
+				Currency:       "USD",
+				HourlyCost:     "1.6300",
+				CumulativeCost: "16.3000",
+				LastUpdateTime: metav1.NewTime(now),
+			},
+			expectedRequeue: costInterval,
+		},
+		{
+			name: "machine pools replace install config workers",
+			machinePools: []runtime.Object{
+				testmp.FullBuilder(testNamespace, "worker", testName, scheme.GetScheme()).Build(
+					testmp.WithReplicas(4), testmp.WithAWSInstanceType("m6i.xlarge")),
+				testmp.FullBuilder(testNamespace, "infra", testName, scheme.GetScheme()).Build(
+					testmp.WithReplicas(2), testmp.WithAWSInstanceType("r5.large")),
+				testmp.FullBuilder(testNamespace, "worker", "other-cluster", scheme.GetScheme()).Build(
+					testmp.WithReplicas(10), testmp.WithAWSInstanceType("m6i.xlarge")),
+			},
+			expectedCost: &hivev1.ClusterCostStatus{
+				Currency:         "USD",
+				HourlyCost:       "2.0100",
+				CumulativeCost:   "20.1000",
+				UnpricedMachines: 2,
+				LastUpdateTime:   metav1.NewTime(now),
+			},
+			expectedRequeue: costInterval,
+		},
+		{
+			name: "first estimate of hibernating cluster",
+			cdOptions: []testcd.Option{
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateHibernating),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:               hivev1.ClusterHibernatingCondition,
+					Status:             corev1.ConditionTrue,
+					Reason:             hivev1.HibernatingReasonHibernating,
+					LastTransitionTime: metav1.NewTime(hoursAgo(4)),
+				}),
+			},
+			expectedCost: &hivev1.ClusterCostStatus{
+				Currency:       "USD",
+				HourlyCost:     "0.0500",
+				CumulativeCost: "9.9800",
+				LastUpdateTime: metav1.NewTime(now),
+			},
+			expectedRequeue: costInterval,
+		},
+		{
+			name: "cost accrued since last update",
+			cdOptions: []testcd.Option{
+				withCost("1.6300", "10.0000", hoursAgo(1)),
+			},
+			expectedCost: &hivev1.ClusterCostStatus{
+				Currency:       "USD",
+				HourlyCost:     "1.6300",
+				CumulativeCost: "11.6300",
+				LastUpdateTime: metav1.NewTime(now),
+			},
+			expectedRequeue: costInterval,
+		},
+		{
+			name: "cluster resumed since last update",
+			cdOptions: []testcd.Option{
+				withCost("0.0500", "10.0000", hoursAgo(1)),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:               hivev1.ClusterHibernatingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             hivev1.HibernatingReasonResumingOrRunning,
+					LastTransitionTime: metav1.NewTime(hoursAgo(0.5)),
+				}),
+			},
+			expectedCost: &hivev1.ClusterCostStatus{
+				Currency:       "USD",
+				HourlyCost:     "1.6300",
+				CumulativeCost: "10.8400",
+				LastUpdateTime: metav1.NewTime(now),
+			},
+			expectedRequeue: costInterval,
+		},
+		{
+			name: "unchanged cost not saved within interval",
+			cdOptions: []testcd.Option{
+				withCost("1.6300", "10.0000", hoursAgo(1.0/6)),
+			},
+			expectedCost: &hivev1.ClusterCostStatus{
+				Currency:       "USD",
+				HourlyCost:     "1.6300",
+				CumulativeCost: "10.0000",
+				LastUpdateTime: metav1.NewTime(hoursAgo(1.0 / 6)),
+			},
+			expectedRequeue: 20 * time.Minute,
+		},
+		{
+			name: "changed cost saved within interval",
+			cdOptions: []testcd.Option{
+				withCost("1.0000", "5.0000", hoursAgo(1.0/6)),
+			},
+			expectedCost: &hivev1.ClusterCostStatus{
+				Currency:       "USD",
+				HourlyCost:     "1.6300",
+				CumulativeCost: "5.1667",
+				LastUpdateTime: metav1.NewTime(now),
+			},
+			expectedRequeue: costInterval,
+		},
+		{
+			name:         "no price table",
+			noPriceTable: true,
+		},
+		{
+			name: "not installed",
+			cdOptions: []testcd.Option{
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.Installed = false
+					cd.Status.InstalledTimestamp = nil
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cdOptions := append([]testcd.Option{
+				testcd.InstalledTimestamp(hoursAgo(10)),
+				testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "aws"),
+				testcd.WithLabel(hivev1.HiveClusterRegionLabel, "us-east-1"),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateRunning),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.Provisioning = &hivev1.Provisioning{
+						InstallConfigSecretRef: &corev1.LocalObjectReference{Name: testICSecret},
+					}
+				},
+			}, test.cdOptions...)
+			existing := append([]runtime.Object{
+				testcd.FullBuilder(testNamespace, testName, scheme.GetScheme()).Build(cdOptions...),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testICSecret},
+					Data:       map[string][]byte{"install-config.yaml": []byte(testInstallConfig)},
+				},
+			}, test.machinePools...)
+			if !test.noPriceTable {
+				existing = append(existing, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: priceTableConfigMapName},
+					Data:       map[string]string{priceTableDataKey: testPriceTable},
+				})
+			}
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build()
+			r := &ReconcileClusterCost{Client: c, logger: log.New(), now: func() time.Time { return now }}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			assert.Equal(t, test.expectedRequeue, result.RequeueAfter, "unexpected requeue")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd))
+			if test.expectedCost == nil {
+				assert.Nil(t, cd.Status.Cost, "expected no cost")
+				return
+			}
+			if assert.NotNil(t, cd.Status.Cost, "expected cost") {
+				assert.True(t, test.expectedCost.LastUpdateTime.Equal(&cd.Status.Cost.LastUpdateTime),
+					"unexpected last update time: %v", cd.Status.Cost.LastUpdateTime)
+				cd.Status.Cost.LastUpdateTime = test.expectedCost.LastUpdateTime
+				assert.Equal(t, test.expectedCost, cd.Status.Cost, "unexpected cost")
+			}
+		})
+	}
+}
+
+func withCost(hourly, cumulative string, lastUpdate time.Time) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.Cost = &hivev1.ClusterCostStatus{
+			Currency:       "USD",
+			HourlyCost:     hourly,
+			CumulativeCost: cumulative,
+			LastUpdateTime: metav1.NewTime(lastUpdate),
+		}
+	}
+}
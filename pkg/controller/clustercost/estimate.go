@@ -0,0 +1,182 @@
+package clustercost
+
+import (
+	"context"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// defaultControlPlaneReplicas is the number of control plane machines the installer creates when the install config
+// does not set it.
+const defaultControlPlaneReplicas = 3
+
+// machineGroup is a number of machines of the same instance type. The instance type is empty when it is unknown.
+type machineGroup struct {
+	instanceType string
+	count        int64
+}
+
+// costEstimate is the estimated hourly cost of a cluster.
+type costEstimate struct {
+	hourly           float64
+	unpricedMachines int64
+}
+
+// clusterMachines returns the machines of a cluster. The control plane machines are read from the install config.
+// The worker machines are read from the MachinePools of the cluster, or from the install config when Hive does not
+// manage the machine pools of the cluster.
+func clusterMachines(ctx context.Context, c client.Client, cd *hivev1.ClusterDeployment) ([]machineGroup, error) {
+	var machines []machineGroup
+
+	var ic *installertypes.InstallConfig
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.InstallConfigSecretRef != nil {
+		icSecret := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.Provisioning.InstallConfigSecretRef.Name}, icSecret); err != nil {
+			return nil, errors.Wrap(err, "could not get install config secret")
+		}
+		ic = &installertypes.InstallConfig{}
+		if err := yaml.Unmarshal(icSecret.Data["install-config.yaml"], ic); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal install config")
+		}
+		replicas := int64(defaultControlPlaneReplicas)
+		if ic.ControlPlane != nil && ic.ControlPlane.Replicas != nil {
+			replicas = *ic.ControlPlane.Replicas
+		}
+		machines = append(machines, machineGroup{instanceType: installConfigInstanceType(ic, ic.ControlPlane), count: replicas})
+	}
+
+	pools := &hivev1.MachinePoolList{}
+	if err := c.List(ctx, pools, client.InNamespace(cd.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "could not list machine pools")
+	}
+	hasPools := false
+	for i := range pools.Items {
+		pool := &pools.Items[i]
+		if pool.Spec.ClusterDeploymentRef.Name != cd.Name || pool.DeletionTimestamp != nil {
+			continue
+		}
+		hasPools = true
+		machines = append(machines, machineGroup{instanceType: machinePoolInstanceType(pool), count: machinePoolReplicas(pool)})
+	}
+	if !hasPools && ic != nil {
+		for i := range ic.Compute {
+			if ic.Compute[i].Replicas == nil {
+				continue
+			}
+			machines = append(machines, machineGroup{instanceType: installConfigInstanceType(ic, &ic.Compute[i]), count: *ic.Compute[i].Replicas})
+		}
+	}
+	return machines, nil
+}
+
+// installConfigInstanceType returns the instance type of a machine pool of the install config, falling back to the
+// default machine platform of the install config.
+func installConfigInstanceType(ic *installertypes.InstallConfig, pool *installertypes.MachinePool) string {
+	if pool != nil {
+		switch p := pool.Platform; {
+		case p.AWS != nil && p.AWS.InstanceType != "":
+			return p.AWS.InstanceType
+		case p.Azure != nil && p.Azure.InstanceType != "":
+			return p.Azure.InstanceType
+		case p.GCP != nil && p.GCP.InstanceType != "":
+			return p.GCP.InstanceType
+		case p.IBMCloud != nil && p.IBMCloud.InstanceType != "":
+			return p.IBMCloud.InstanceType
+		}
+	}
+	switch p := ic.Platform; {
+	case p.AWS != nil && p.AWS.DefaultMachinePlatform != nil:
+		return p.AWS.DefaultMachinePlatform.InstanceType
+	case p.Azure != nil && p.Azure.DefaultMachinePlatform != nil:
+		return p.Azure.DefaultMachinePlatform.InstanceType
+	case p.GCP != nil && p.GCP.DefaultMachinePlatform != nil:
+		return p.GCP.DefaultMachinePlatform.InstanceType
+	case p.IBMCloud != nil && p.IBMCloud.DefaultMachinePlatform != nil:
+		return p.IBMCloud.DefaultMachinePlatform.InstanceType
+	}
+	return ""
+}
+
+func machinePoolInstanceType(pool *hivev1.MachinePool) string {
+	switch p := pool.Spec.Platform; {
+	case p.AWS != nil:
+		return p.AWS.InstanceType
+	case p.Azure != nil:
+		return p.Azure.InstanceType
+	case p.GCP != nil:
+		return p.GCP.InstanceType
+	case p.IBMCloud != nil:
+		return p.IBMCloud.InstanceType
+	}
+	return ""
+}
+
+// machinePoolReplicas returns the number of machines of a MachinePool. Autoscaled pools are counted at their current
+// size, or their minimum size until it is known.
+func machinePoolReplicas(pool *hivev1.MachinePool) int64 {
+	switch {
+	case pool.Spec.Replicas != nil:
+		return *pool.Spec.Replicas
+	case pool.Spec.Autoscaling != nil && pool.Status.Replicas > 0:
+		return int64(pool.Status.Replicas)
+	case pool.Spec.Autoscaling != nil:
+		return int64(pool.Spec.Autoscaling.MinReplicas)
+	}
+	return 0
+}
+
+// estimateHourlyCost estimates the hourly cost of a cluster when it is running or hibernating. The machines of
+// hibernating clusters are stopped, so only the cluster rate applies to them.
+func (t *priceTable) estimateHourlyCost(cd *hivev1.ClusterDeployment, machines []machineGroup, hibernating bool) costEstimate {
+	estimate := costEstimate{hourly: t.ClusterHourly}
+	if hibernating {
+		return estimate
+	}
+	platform := cd.Labels[hivev1.HiveClusterPlatformLabel]
+	region := cd.Labels[hivev1.HiveClusterRegionLabel]
+	for _, group := range machines {
+		rate, ok := t.hourlyRate(platform, region, group.instanceType)
+		if !ok {
+			estimate.unpricedMachines += group.count
+			continue
+		}
+		estimate.hourly += rate * float64(group.count)
+	}
+	return estimate
+}
+
+// hibernationTransition returns the time the cluster last started or stopped hibernating, if it is between since and
+// now.
+func hibernationTransition(cd *hivev1.ClusterDeployment, since, now time.Time) (time.Time, bool) {
+	cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	if cond == nil {
+		return time.Time{}, false
+	}
+	transition := cond.LastTransitionTime.Time
+	return transition, transition.After(since) && transition.Before(now)
+}
+
+// accruedCost returns the cost a cluster accrued between since and now at its previous hourly cost. If the cluster
+// started or stopped hibernating in between, the time after the transition is accrued at the current hourly cost.
+// Only the latest transition of the Hibernating condition is known, so if the cluster changed power state several
+// times in between, the earlier transitions are not accounted for. This is rare since the cost is recalculated as
+// soon as the power state changes.
+func accruedCost(cd *hivev1.ClusterDeployment, since, now time.Time, prevHourly, hourly float64) float64 {
+	if !now.After(since) {
+		return 0
+	}
+	if transition, ok := hibernationTransition(cd, since, now); ok {
+		return prevHourly*transition.Sub(since).Hours() + hourly*now.Sub(transition).Hours()
+	}
+	return prevHourly * now.Sub(since).Hours()
+}
@@ -0,0 +1,103 @@
+package clustercost
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// priceTableConfigMapName is the name of the ConfigMap in the Hive namespace holding the price table. Costs are
+	// not estimated while it does not exist.
+	priceTableConfigMapName = "cluster-price-table"
+
+	// priceTableDataKey is the key of the price table in the data of the ConfigMap.
+	priceTableDataKey = "prices.yaml"
+)
+
+// priceTable is the price table cluster costs are estimated from.
+type priceTable struct {
+	// Currency is the currency of the rates. It is reported along with the costs.
+	Currency string `json:"currency,omitempty"`
+
+	// ClusterHourly is the hourly cost of a cluster regardless of its machines, e.g. for its load balancers and
+	// storage. It is the only cost of hibernating clusters.
+	ClusterHourly float64 `json:"clusterHourly,omitempty"`
+
+	// Rates are the hourly costs of instance types.
+	Rates []instanceRate `json:"rates,omitempty"`
+}
+
+// instanceRate is the hourly cost of an instance type on a platform. Rates without a region apply to the regions
+// that have no rate of their own.
+type instanceRate struct {
+	Platform     string  `json:"platform"`
+	Region       string  `json:"region,omitempty"`
+	InstanceType string  `json:"instanceType"`
+	Hourly       float64 `json:"hourly"`
+}
+
+// loadPriceTable reads the price table from its ConfigMap in the Hive namespace. It returns nil if the ConfigMap does
+// not exist.
+func loadPriceTable(ctx context.Context, c client.Client) (*priceTable, error) {
+	cm := &corev1.ConfigMap{}
+	switch err := c.Get(ctx, types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: priceTableConfigMapName}, cm); {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "could not get %s configmap", priceTableConfigMapName)
+	}
+	data, ok := cm.Data[priceTableDataKey]
+	if !ok {
+		return nil, fmt.Errorf("%s configmap does not have a %q data entry", priceTableConfigMapName, priceTableDataKey)
+	}
+	return parsePriceTable([]byte(data))
+}
+
+// parsePriceTable parses and validates a price table.
+func parsePriceTable(data []byte) (*priceTable, error) {
+	table := &priceTable{}
+	if err := yaml.Unmarshal(data, table); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal price table")
+	}
+	if table.ClusterHourly < 0 {
+		return nil, errors.New("clusterHourly of the price table must not be negative")
+	}
+	for i, rate := range table.Rates {
+		switch {
+		case rate.Platform == "":
+			return nil, fmt.Errorf("rate %d of the price table has no platform", i)
+		case rate.InstanceType == "":
+			return nil, fmt.Errorf("rate %d of the price table has no instanceType", i)
+		case rate.Hourly < 0:
+			return nil, fmt.Errorf("rate %d of the price table has a negative hourly cost", i)
+		}
+	}
+	return table, nil
+}
+
+// hourlyRate returns the hourly cost of an instance type in a region, preferring a rate for the region over a rate
+// for the whole platform. It returns false if the price table has no rate for the instance type.
+func (t *priceTable) hourlyRate(platform, region, instanceType string) (float64, bool) {
+	rate, found := 0.0, false
+	for _, r := range t.Rates {
+		if r.Platform != platform || r.InstanceType != instanceType {
+			continue
+		}
+		switch r.Region {
+		case region:
+			return r.Hourly, true
+		case "":
+			rate, found = r.Hourly, true
+		}
+	}
+	return rate, found
+}
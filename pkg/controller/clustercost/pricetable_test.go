@@ -0,0 +1,110 @@
+package clustercost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriceTable(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		expectedError bool
+	}{
+		{
+			name: "valid",
+			data: testPriceTable,
+		},
+		{
+			name: "empty",
+			data: "",
+		},
+		{
+			name:          "not yaml",
+			data:          "rates: [",
+			expectedError: true,
+		},
+		{
+			name:          "missing platform",
+			data:          "rates:\n- instanceType: m6i.xlarge\n  hourly: 0.2\n",
+			expectedError: true,
+		},
+		{
+			name:          "missing instance type",
+			data:          "rates:\n- platform: aws\n  hourly: 0.2\n",
+			expectedError: true,
+		},
+		{
+			name:          "negative rate",
+			data:          "rates:\n- platform: aws\n  instanceType: m6i.xlarge\n  hourly: -0.2\n",
+			expectedError: true,
+		},
+		{
+			name:          "negative cluster rate",
+			data:          "clusterHourly: -1\n",
+			expectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parsePriceTable([]byte(test.data))
+			if test.expectedError {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+		})
+	}
+}
+
+func TestHourlyRate(t *testing.T) {
+	table, err := parsePriceTable([]byte(testPriceTable))
+	require.NoError(t, err, "unexpected error parsing price table")
+
+	tests := []struct {
+		name         string
+		platform     string
+		region       string
+		instanceType string
+		expectedRate float64
+		expectedOK   bool
+	}{
+		{
+			name:         "regional rate",
+			platform:     "aws",
+			region:       "us-east-1",
+			instanceType: "m6i.xlarge",
+			expectedRate: 0.19,
+			expectedOK:   true,
+		},
+		{
+			name:         "platform rate",
+			platform:     "aws",
+			region:       "eu-west-1",
+			instanceType: "m6i.xlarge",
+			expectedRate: 0.20,
+			expectedOK:   true,
+		},
+		{
+			name:         "unknown instance type",
+			platform:     "aws",
+			region:       "us-east-1",
+			instanceType: "r5.large",
+		},
+		{
+			name:         "other platform",
+			platform:     "gcp",
+			region:       "us-east1",
+			instanceType: "m6i.xlarge",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rate, ok := table.hourlyRate(test.platform, test.region, test.instanceType)
+			assert.Equal(t, test.expectedOK, ok, "unexpected found")
+			assert.Equal(t, test.expectedRate, rate, "unexpected rate")
+		})
+	}
+}
@@ -4466,6 +4466,104 @@ func TestCopyInstallLogSecret(t *testing.T) {
 	}
 }
 
+func TestGetInstallLogEnvVars(t *testing.T) {
+	os.Setenv(constants.FailedProvisionConfigFileEnvVar, "fake")
+	defer os.Unsetenv(constants.FailedProvisionConfigFileEnvVar)
+
+	tests := []struct {
+		name               string
+		config             string
+		expectedProvider   string
+		expectedSecretName string
+		expectedConfig     string
+		noClaim            bool
+		expectedVolume     bool
+	}{
+		{
+			name:   "no upload configured",
+			config: `{"skipGatherLogs":true}`,
+		},
+		{
+			name:               "aws",
+			config:             `{"aws":{"credentialsSecretRef":{"name":"creds"},"region":"us-east-1","bucket":"logs","serviceEndpoint":"https://minio.example.com","forcePathStyle":true}}`,
+			expectedProvider:   constants.InstallLogsUploadProviderAWS,
+			expectedSecretName: "prefix-creds",
+			expectedConfig:     `{"aws":{"credentialsSecretRef":{"name":"prefix-creds"},"region":"us-east-1","serviceEndpoint":"https://minio.example.com","bucket":"logs","forcePathStyle":true}}`,
+		},
+		{
+			name:               "azure",
+			config:             `{"azure":{"credentialsSecretRef":{"name":"creds"},"storageAccount":"account","container":"logs"}}`,
+			expectedProvider:   constants.InstallLogsUploadProviderAzure,
+			expectedSecretName: "prefix-creds",
+			expectedConfig:     `{"azure":{"credentialsSecretRef":{"name":"prefix-creds"},"storageAccount":"account","container":"logs"}}`,
+		},
+		{
+			name:             "http without credentials",
+			config:           `{"http":{"url":"https://logs.example.com"}}`,
+			expectedProvider: constants.InstallLogsUploadProviderHTTP,
+			expectedConfig:   `{"http":{"url":"https://logs.example.com"}}`,
+		},
+		{
+			name:             "pvc",
+			config:           `{"skipGatherLogs":true,"persistentVolumeClaim":{"claimName":"install-logs"}}`,
+			expectedProvider: constants.InstallLogsUploadProviderPVC,
+			expectedConfig:   `{"persistentVolumeClaim":{"claimName":"install-logs"}}`,
+			expectedVolume:   true,
+		},
+		{
+			name:             "pvc missing from namespace",
+			config:           `{"persistentVolumeClaim":{"claimName":"install-logs"}}`,
+			expectedProvider: constants.InstallLogsUploadProviderPVC,
+			expectedConfig:   `{"persistentVolumeClaim":{"claimName":"install-logs"}}`,
+			noClaim:          true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			readFile = fakeReadFile(test.config)
+			defer func() { readFile = os.ReadFile }()
+
+			envVars, err := getInstallLogEnvVars("prefix")
+			require.NoError(t, err, "unexpected error getting env vars")
+			values := map[string]string{}
+			for _, envVar := range envVars {
+				values[envVar.Name] = envVar.Value
+			}
+			assert.Equal(t, test.expectedProvider, values[constants.InstallLogsUploadProviderEnvVar], "unexpected provider")
+			assert.Equal(t, test.expectedSecretName, values[constants.InstallLogsCredentialsSecretRefEnvVar], "unexpected credentials secret")
+			if test.expectedConfig == "" {
+				assert.Empty(t, values[constants.InstallLogsUploadConfigEnvVar], "unexpected upload config")
+			} else {
+				assert.JSONEq(t, test.expectedConfig, values[constants.InstallLogsUploadConfigEnvVar], "unexpected upload config")
+			}
+
+			var existing []runtime.Object
+			if !test.noClaim {
+				existing = append(existing, &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "install-logs"},
+				})
+			}
+			rcd := &ReconcileClusterDeployment{
+				Client: testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build(),
+				scheme: scheme.GetScheme(),
+			}
+			podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "installer"}}}
+			require.NoError(t, rcd.addInstallLogsVolume(podSpec, testNamespace, log.WithField("test", test.name)), "unexpected error adding volume")
+			if test.expectedVolume {
+				if assert.Len(t, podSpec.Volumes, 1, "expected install logs volume") {
+					assert.Equal(t, "install-logs", podSpec.Volumes[0].PersistentVolumeClaim.ClaimName, "unexpected claim")
+				}
+				if assert.Len(t, podSpec.Containers[0].VolumeMounts, 1, "expected install logs volume mount") {
+					assert.Equal(t, constants.InstallLogsDir, podSpec.Containers[0].VolumeMounts[0].MountPath, "unexpected mount path")
+				}
+			} else {
+				assert.Empty(t, podSpec.Volumes, "unexpected volumes")
+				assert.Empty(t, podSpec.Containers[0].VolumeMounts, "unexpected volume mounts")
+			}
+		})
+	}
+}
+
 func TestEnsureManagedDNSZone(t *testing.T) {
 
 	goodDNSZone := func() *hivev1.DNSZone {
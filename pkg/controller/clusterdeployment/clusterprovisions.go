@@ -108,13 +108,25 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 	if cd.Spec.InstallAttemptsLimit != nil && cd.Status.InstallRestarts >= int(*cd.Spec.InstallAttemptsLimit) {
 		return setProvisionStoppedTrue(installAttemptsLimitReachedReason, "Install attempts limit reached")
 	}
-	shouldRetry, err := r.shouldRetryBasedOnFailureReason(lastFailedProvision, logger)
+	retryPolicy, retryReason, err := retryPolicyForProvision(lastFailedProvision)
 	if err != nil {
-		logger.WithError(err).Error("failed to determine whether to retry based on provision failure reason")
+		logger.WithError(err).Error("failed to find the retry policy for the provision failure reason")
 		return reconcile.Result{}, err
 	}
-	if !shouldRetry {
-		return setProvisionStoppedTrue(failureReasonNotListed, "Provision failure reason not retryable")
+	if retryPolicy != nil {
+		if retryPolicy.AttemptsLimit != nil && retryPolicyFailures(cd, retryPolicy) >= *retryPolicy.AttemptsLimit {
+			return setProvisionStoppedTrue(retryPolicyAttemptsLimitReachedReason,
+				fmt.Sprintf("Install attempts limit reached for %s failures", retryReason))
+		}
+	} else {
+		shouldRetry, err := r.shouldRetryBasedOnFailureReason(lastFailedProvision, logger)
+		if err != nil {
+			logger.WithError(err).Error("failed to determine whether to retry based on provision failure reason")
+			return reconcile.Result{}, err
+		}
+		if !shouldRetry {
+			return setProvisionStoppedTrue(failureReasonNotListed, "Provision failure reason not retryable")
+		}
 	}
 
 	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
@@ -134,6 +146,12 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		return reconcile.Result{}, nil
 	}
 
+	if retryPolicy != nil {
+		if applied, err := r.applyRetryFallback(cd, retryPolicy, retryReason, logger); err != nil || applied {
+			return reconcile.Result{}, err
+		}
+	}
+
 	if err := controllerutils.SetupClusterInstallServiceAccount(r, cd.Namespace, logger); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "error setting up service account and role")
 		return reconcile.Result{}, err
@@ -168,6 +186,10 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		logger.WithError(err).Error("could not generate installer pod spec")
 		return reconcile.Result{}, err
 	}
+	if err := r.addInstallLogsVolume(podSpec, cd.Namespace, logger); err != nil {
+		logger.WithError(err).Error("failed to add install logs volume")
+		return reconcile.Result{}, err
+	}
 
 	provision := &hivev1.ClusterProvision{
 		ObjectMeta: metav1.ObjectMeta{
@@ -526,6 +548,12 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 	reason := "MissingCondition"
 	message := fmt.Sprintf("Provision %s failed. Next provision will begin soon.", provision.Name)
 
+	retryPolicy, _, err := retryPolicyForProvision(provision)
+	if err != nil {
+		cdLog.WithError(err).Error("failed to find the retry policy for the provision failure reason")
+		return reconcile.Result{}, err
+	}
+
 	failedCond := controllerutils.FindCondition(provision.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
 	if failedCond != nil && failedCond.Status == corev1.ConditionTrue {
 		nextProvisionTime = calculateNextProvisionTime(failedCond.LastTransitionTime.Time, cd.Status.InstallRestarts, cdLog)
@@ -543,6 +571,38 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 		message,
 		controllerutils.UpdateConditionIfReasonOrMessageChange,
 	)
+
+	var retryCondChange bool
+	if retryPolicy != nil {
+		// This failure has not been recorded yet.
+		failures := retryPolicyFailures(cd, retryPolicy) + 1
+		if retryPolicy.Backoff != nil {
+			nextProvisionTime = failedCond.LastTransitionTime.Add(retryBackoff(retryPolicy, failures))
+		}
+		retryMessage := fmt.Sprintf("Install attempt %d failed for %s; next attempt at %s",
+			failures, reason, nextProvisionTime.UTC().Format(time.RFC3339))
+		if retryPolicy.AttemptsLimit != nil && failures >= *retryPolicy.AttemptsLimit {
+			retryMessage = fmt.Sprintf("Install attempt %d failed for %s; attempts limit reached", failures, reason)
+		}
+		newConditions, retryCondChange = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			newConditions,
+			hivev1.ProvisionRetryPolicyAppliedCondition,
+			corev1.ConditionTrue,
+			retryScheduledReason,
+			retryMessage,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	} else if controllerutils.FindCondition(newConditions, hivev1.ProvisionRetryPolicyAppliedCondition) != nil {
+		newConditions, retryCondChange = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			newConditions,
+			hivev1.ProvisionRetryPolicyAppliedCondition,
+			corev1.ConditionFalse,
+			noRetryPolicyReason,
+			fmt.Sprintf("No retry policy applies to %s failures", reason),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	}
+	condChange = condChange || retryCondChange
 	cd.Status.Conditions = newConditions
 
 	timeUntilNextProvision := time.Until(nextProvisionTime)
@@ -557,6 +617,13 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 	}
 
 	cdLog.Info("clearing current failed provision to make way for a new provision")
+	if retryPolicy != nil {
+		recordProvisionFailure(cd, reason)
+	}
+	// The next attempt starts in the current region of the cluster, unless a retry policy fallback moves it again.
+	if cd.Status.ProvisionRetries != nil {
+		cd.Status.ProvisionRetries.PrevRegion = ""
+	}
 	return r.clearOutCurrentProvision(cd, cdLog)
 }
 
@@ -709,39 +776,93 @@ func readProvisionFailedConfig() (*hivev1.FailedProvisionConfig, error) {
 	return config, nil
 }
 
+// getInstallLogEnvVars returns the environment variables telling the install pod where to upload the logs of a
+// failed installation. The credentials secret, if any, is copied to the namespace of the ClusterDeployment with the
+// given prefix, and the upload config refers to that copy.
 func getInstallLogEnvVars(secretPrefix string) ([]corev1.EnvVar, error) {
 	var extraEnvVars = []corev1.EnvVar{}
 	fpConfig, err := readProvisionFailedConfig()
 	if err != nil || fpConfig == nil {
 		return extraEnvVars, err
 	}
-	if awsSpec := fpConfig.AWS; awsSpec != nil {
-		// By default we will try to gather logs on failed installs:
-		extraEnvVars = []corev1.EnvVar{
-			{
-				Name:  constants.InstallLogsUploadProviderEnvVar,
-				Value: constants.InstallLogsUploadProviderAWS,
-			},
-			{
-				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
-				Value: secretPrefix + "-" + awsSpec.CredentialsSecretRef.Name,
-			},
-			{
-				Name:  constants.InstallLogsAWSRegionEnvVar,
-				Value: awsSpec.Region,
-			},
-			{
-				Name:  constants.InstallLogsAWSServiceEndpointEnvVar,
-				Value: awsSpec.ServiceEndpoint,
-			},
-			{
-				Name:  constants.InstallLogsAWSS3BucketEnvVar,
-				Value: awsSpec.Bucket,
+	provider, credsRef := controllerutils.InstallLogsUploadProvider(fpConfig)
+	if provider == "" {
+		return extraEnvVars, nil
+	}
+	// Only pass along where to upload the logs to.
+	uploadConfig := &hivev1.FailedProvisionConfig{
+		AWS:                   fpConfig.AWS,
+		Azure:                 fpConfig.Azure,
+		GCP:                   fpConfig.GCP,
+		HTTP:                  fpConfig.HTTP,
+		PersistentVolumeClaim: fpConfig.PersistentVolumeClaim,
+	}
+	if credsRef != nil && credsRef.Name != "" {
+		// credsRef points into the upload config, which thus refers to the copy.
+		credsRef.Name = secretPrefix + "-" + credsRef.Name
+		extraEnvVars = append(extraEnvVars, corev1.EnvVar{
+			Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+			Value: credsRef.Name,
+		})
+	}
+	uploadConfigJSON, err := json.Marshal(uploadConfig)
+	if err != nil {
+		return extraEnvVars, err
+	}
+	// By default we will try to gather logs on failed installs:
+	extraEnvVars = append(extraEnvVars,
+		corev1.EnvVar{
+			Name:  constants.InstallLogsUploadProviderEnvVar,
+			Value: provider,
+		},
+		corev1.EnvVar{
+			Name:  constants.InstallLogsUploadConfigEnvVar,
+			Value: string(uploadConfigJSON),
+		},
+	)
+	return extraEnvVars, nil
+}
+
+// addInstallLogsVolume mounts the PersistentVolumeClaim to save the logs of a failed installation to, if one is
+// configured, in the installer container of the install pod. Hive does not create the claim, so the volume is only
+// added if the claim exists in the namespace of the install pod; a pod mounting a missing claim would never start.
+func (r *ReconcileClusterDeployment) addInstallLogsVolume(podSpec *corev1.PodSpec, namespace string, logger log.FieldLogger) error {
+	fpConfig, err := readProvisionFailedConfig()
+	if err != nil {
+		return err
+	}
+	if provider, _ := controllerutils.InstallLogsUploadProvider(fpConfig); provider != constants.InstallLogsUploadProviderPVC {
+		return nil
+	}
+	// Only the metadata of claims is needed, so avoid caching whole claims.
+	pvc := &metav1.PartialObjectMetadata{}
+	pvc.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"))
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: fpConfig.PersistentVolumeClaim.ClaimName}, pvc); {
+	case apierrors.IsNotFound(err):
+		logger.WithField("claim", fpConfig.PersistentVolumeClaim.ClaimName).
+			Warn("install logs PersistentVolumeClaim not found in the namespace of the cluster, logs will not be saved")
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "could not get install logs PersistentVolumeClaim")
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "install-logs",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: fpConfig.PersistentVolumeClaim.ClaimName,
 			},
+		},
+	})
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name != "installer" {
+			continue
 		}
+		podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      "install-logs",
+			MountPath: constants.InstallLogsDir,
+		})
 	}
-
-	return extraEnvVars, nil
+	return nil
 }
 
 func getAWSServiceProviderEnvVars(cd *hivev1.ClusterDeployment, secretPrefix string) []corev1.EnvVar {
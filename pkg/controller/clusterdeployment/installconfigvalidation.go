@@ -40,21 +40,21 @@ func ValidateInstallConfig(cd *hivev1.ClusterDeployment, installConfigSecret *co
 		if ic.Platform.AWS == nil {
 			return ic, errors.New(noAWSPlatformErr)
 		}
-		if ic.Platform.AWS.Region != cd.Spec.Platform.AWS.Region {
+		if ic.Platform.AWS.Region != cd.Spec.Platform.AWS.Region && !regionSetByRetryFallback(cd, cd.Spec.Platform.AWS.Region) {
 			return ic, errors.New(regionMismatchErr)
 		}
 	case platform.GCP != nil:
 		if ic.Platform.GCP == nil {
 			return ic, errors.New(noGCPPlatformErr)
 		}
-		if ic.Platform.GCP.Region != cd.Spec.Platform.GCP.Region {
+		if ic.Platform.GCP.Region != cd.Spec.Platform.GCP.Region && !regionSetByRetryFallback(cd, cd.Spec.Platform.GCP.Region) {
 			return ic, errors.New(regionMismatchErr)
 		}
 	case platform.Azure != nil:
 		if ic.Platform.Azure == nil {
 			return ic, errors.New(noAzurePlatformErr)
 		}
-		if ic.Platform.Azure.Region != cd.Spec.Platform.Azure.Region {
+		if ic.Platform.Azure.Region != cd.Spec.Platform.Azure.Region && !regionSetByRetryFallback(cd, cd.Spec.Platform.Azure.Region) {
 			return ic, errors.New(regionMismatchErr)
 		}
 	case platform.VSphere != nil:
@@ -0,0 +1,266 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	retryPolicyAttemptsLimitReachedReason = "RetryPolicyAttemptsLimitReached"
+	retryScheduledReason                  = "RetryScheduled"
+	retryFallbackAppliedReason            = "RetryFallbackApplied"
+	noRetryPolicyReason                   = "NoRetryPolicy"
+
+	// defaultRetryMaxBackoff is the longest wait between install attempts, matching calculateNextProvisionTime.
+	defaultRetryMaxBackoff = 24 * time.Hour
+)
+
+// findRetryPolicy returns the first retry policy listing the failure reason, or nil if there is none.
+func findRetryPolicy(policies []hivev1.ProvisionRetryPolicy, reason string) *hivev1.ProvisionRetryPolicy {
+	for i := range policies {
+		for _, r := range policies[i].Reasons {
+			if r == reason {
+				return &policies[i]
+			}
+		}
+	}
+	return nil
+}
+
+// retryPolicyForProvision returns the retry policy for the failure reason of a failed provision, along with that
+// reason. The policy is nil if the provision is nil, or if no policy lists its failure reason.
+func retryPolicyForProvision(prov *hivev1.ClusterProvision) (*hivev1.ProvisionRetryPolicy, string, error) {
+	if prov == nil {
+		return nil, "", nil
+	}
+	cond := controllerutils.FindCondition(prov.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
+	if cond == nil {
+		return nil, "", nil
+	}
+	fpConfig, err := readProvisionFailedConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	return findRetryPolicy(fpConfig.RetryPolicies, cond.Reason), cond.Reason, nil
+}
+
+// retryPolicyFailures returns the number of failed install attempts of the cluster for any of the reasons of the policy.
+func retryPolicyFailures(cd *hivev1.ClusterDeployment, policy *hivev1.ProvisionRetryPolicy) int32 {
+	if cd.Status.ProvisionRetries == nil {
+		return 0
+	}
+	var failures int32
+	for _, f := range cd.Status.ProvisionRetries.Failures {
+		for _, reason := range policy.Reasons {
+			if f.Reason == reason {
+				failures += f.Count
+			}
+		}
+	}
+	return failures
+}
+
+// recordProvisionFailure counts a failed install attempt of the cluster. The caller is responsible for updating the
+// status of the ClusterDeployment.
+func recordProvisionFailure(cd *hivev1.ClusterDeployment, reason string) {
+	if cd.Status.ProvisionRetries == nil {
+		cd.Status.ProvisionRetries = &hivev1.ProvisionRetriesStatus{}
+	}
+	retries := cd.Status.ProvisionRetries
+	for i := range retries.Failures {
+		if retries.Failures[i].Reason == reason {
+			retries.Failures[i].Count++
+			return
+		}
+	}
+	retries.Failures = append(retries.Failures, hivev1.ProvisionFailureCount{Reason: reason, Count: 1})
+}
+
+// retryBackoff returns how long to wait before retrying the given number of failed install attempts for the reasons
+// of the policy: the backoff of the policy, doubled for each failure after the first, up to the maximum backoff.
+func retryBackoff(policy *hivev1.ProvisionRetryPolicy, failures int32) time.Duration {
+	maxBackoff := defaultRetryMaxBackoff
+	if policy.MaxBackoff != nil {
+		maxBackoff = policy.MaxBackoff.Duration
+	}
+	backoff := policy.Backoff.Duration
+	for i := int32(1); i < failures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+// retryFallback returns the fallback of the policy to apply after the given number of failed install attempts for
+// its reasons, or nil if it has none.
+func retryFallback(policy *hivev1.ProvisionRetryPolicy, failures int32) *hivev1.ProvisionRetryFallback {
+	if len(policy.Fallbacks) == 0 || failures < 1 {
+		return nil
+	}
+	if int(failures) > len(policy.Fallbacks) {
+		return &policy.Fallbacks[len(policy.Fallbacks)-1]
+	}
+	return &policy.Fallbacks[failures-1]
+}
+
+// regionSetByRetryFallback returns true if the region of the ClusterDeployment is the region of the retry policy
+// fallback applied to it. The region of the install config is then replaced by the installer.
+func regionSetByRetryFallback(cd *hivev1.ClusterDeployment, region string) bool {
+	retries := cd.Status.ProvisionRetries
+	return retries != nil && retries.Fallback != nil && retries.Fallback.Region != "" && retries.Fallback.Region == region
+}
+
+// applyRetryFallback applies the fallback of the retry policy for the failed install attempts of the cluster, if
+// there is one and it has not been applied yet. It returns true if the ClusterDeployment was updated, in which case
+// no provision should be created until the next reconcile.
+func (r *ReconcileClusterDeployment) applyRetryFallback(cd *hivev1.ClusterDeployment, policy *hivev1.ProvisionRetryPolicy, reason string, logger log.FieldLogger) (bool, error) {
+	failures := retryPolicyFailures(cd, policy)
+	fallback := retryFallback(policy, failures)
+	if fallback == nil || (fallback.Region == "" && fallback.InstanceType == "") {
+		return false, nil
+	}
+	region := getClusterRegion(cd)
+	changed := false
+	if cd.Status.ProvisionRetries.Fallback == nil || !reflect.DeepEqual(*cd.Status.ProvisionRetries.Fallback, *fallback) {
+		switch platform := getClusterPlatform(cd); platform {
+		case constants.PlatformAWS, constants.PlatformAzure, constants.PlatformGCP:
+		default:
+			logger.WithField("platform", platform).Warn("retry policy fallbacks are not supported on this platform")
+			return false, nil
+		}
+		cd.Status.ProvisionRetries.Fallback = fallback.DeepCopy()
+		if fallback.Region != "" && fallback.Region != region && cd.Status.ProvisionRetries.PrevRegion == "" {
+			cd.Status.ProvisionRetries.PrevRegion = region
+		}
+		var changes []string
+		if fallback.Region != "" {
+			changes = append(changes, fmt.Sprintf("in region %s", fallback.Region))
+		}
+		if fallback.InstanceType != "" {
+			changes = append(changes, fmt.Sprintf("with instance type %s", fallback.InstanceType))
+		}
+		cd.Status.Conditions, _ = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.ProvisionRetryPolicyAppliedCondition,
+			corev1.ConditionTrue,
+			retryFallbackAppliedReason,
+			fmt.Sprintf("Retrying %s after %d failed install attempts for %s", strings.Join(changes, " "), failures, reason),
+			controllerutils.UpdateConditionIfReasonOrMessageChange)
+		logger.WithField("region", fallback.Region).WithField("instanceType", fallback.InstanceType).Info("applying retry policy fallback")
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+			return false, err
+		}
+		changed = true
+	}
+	// The region is changed after recording the fallback, which allows the change.
+	if fallback.Region != "" && fallback.Region != region {
+		controllerutils.SetClusterRegion(cd, fallback.Region)
+		if err := r.Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment region")
+			return false, err
+		}
+		logger.WithField("region", fallback.Region).Info("changed cluster region for retry policy fallback")
+		changed = true
+	}
+	poolsChanged, err := r.applyRetryFallbackToMachinePools(cd, fallback, logger)
+	if err != nil {
+		return false, err
+	}
+	return changed || poolsChanged, nil
+}
+
+// applyRetryFallbackToMachinePools applies the retry policy fallback to the MachinePools of the cluster, which would
+// otherwise create the machines of the installed cluster with the instance type that failed, or in zones and subnets
+// of the previous region. It returns true if any MachinePool was updated.
+func (r *ReconcileClusterDeployment) applyRetryFallbackToMachinePools(cd *hivev1.ClusterDeployment, fallback *hivev1.ProvisionRetryFallback, logger log.FieldLogger) (bool, error) {
+	pools := &hivev1.MachinePoolList{}
+	if err := r.List(context.TODO(), pools, client.InNamespace(cd.Namespace)); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list machine pools")
+		return false, err
+	}
+	changed := false
+	for i := range pools.Items {
+		pool := &pools.Items[i]
+		if pool.Spec.ClusterDeploymentRef.Name != cd.Name || pool.DeletionTimestamp != nil {
+			continue
+		}
+		if !applyRetryFallbackToMachinePoolPlatform(&pool.Spec.Platform, fallback) {
+			continue
+		}
+		poolLogger := logger.WithField("machinePool", pool.Name)
+		// The platform of a MachinePool can only be changed with the override annotation, which is restored once the
+		// platform is updated. The pool has no machines yet, so there is nothing to roll out.
+		override, hasOverride := pool.Annotations[constants.OverrideMachinePoolPlatformAnnotation]
+		if override != "true" {
+			if pool.Annotations == nil {
+				pool.Annotations = map[string]string{}
+			}
+			pool.Annotations[constants.OverrideMachinePoolPlatformAnnotation] = "true"
+		}
+		if err := r.Update(context.TODO(), pool); err != nil {
+			poolLogger.WithError(err).Log(controllerutils.LogLevel(err), "failed to apply retry policy fallback to machine pool")
+			return false, err
+		}
+		if override != "true" {
+			if hasOverride {
+				pool.Annotations[constants.OverrideMachinePoolPlatformAnnotation] = override
+			} else {
+				delete(pool.Annotations, constants.OverrideMachinePoolPlatformAnnotation)
+			}
+			if err := r.Update(context.TODO(), pool); err != nil {
+				poolLogger.WithError(err).Log(controllerutils.LogLevel(err), "failed to restore machine pool platform override annotation")
+				return false, err
+			}
+		}
+		poolLogger.WithField("region", fallback.Region).WithField("instanceType", fallback.InstanceType).Info("applied retry policy fallback to machine pool")
+		changed = true
+	}
+	return changed, nil
+}
+
+// applyRetryFallbackToMachinePoolPlatform applies a retry policy fallback to the platform of a MachinePool: the
+// instance type replaces that of the pool, and a region clears the zones and subnets of the previous region. It returns
+// true if the platform was changed.
+func applyRetryFallbackToMachinePoolPlatform(platform *hivev1.MachinePoolPlatform, fallback *hivev1.ProvisionRetryFallback) bool {
+	orig := platform.DeepCopy()
+	switch {
+	case platform.AWS != nil:
+		if fallback.InstanceType != "" {
+			platform.AWS.InstanceType = fallback.InstanceType
+		}
+		if fallback.Region != "" && (len(platform.AWS.Zones) > 0 || len(platform.AWS.Subnets) > 0) {
+			platform.AWS.Zones = nil
+			platform.AWS.Subnets = nil
+		}
+	case platform.Azure != nil:
+		if fallback.InstanceType != "" {
+			platform.Azure.InstanceType = fallback.InstanceType
+		}
+		if fallback.Region != "" && len(platform.Azure.Zones) > 0 {
+			platform.Azure.Zones = nil
+		}
+	case platform.GCP != nil:
+		if fallback.InstanceType != "" {
+			platform.GCP.InstanceType = fallback.InstanceType
+		}
+		if fallback.Region != "" && len(platform.GCP.Zones) > 0 {
+			platform.GCP.Zones = nil
+		}
+	}
+	return !reflect.DeepEqual(orig, platform)
+}
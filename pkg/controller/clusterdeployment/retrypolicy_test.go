@@ -0,0 +1,407 @@
+package clusterdeployment
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	tcp "github.com/openshift/hive/pkg/test/clusterprovision"
+	testmp "github.com/openshift/hive/pkg/test/machinepool"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	cases := []struct {
+		name       string
+		maxBackoff *metav1.Duration
+		failures   int32
+		expected   time.Duration
+	}{
+		{
+			name:     "first failure",
+			failures: 1,
+			expected: 10 * time.Minute,
+		},
+		{
+			name:     "third failure",
+			failures: 3,
+			expected: 40 * time.Minute,
+		},
+		{
+			name:     "default max backoff",
+			failures: 20,
+			expected: 24 * time.Hour,
+		},
+		{
+			name:       "max backoff",
+			maxBackoff: &metav1.Duration{Duration: 30 * time.Minute},
+			failures:   3,
+			expected:   30 * time.Minute,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := &hivev1.ProvisionRetryPolicy{
+				Backoff:    &metav1.Duration{Duration: 10 * time.Minute},
+				MaxBackoff: tc.maxBackoff,
+			}
+			assert.Equal(t, tc.expected, retryBackoff(policy, tc.failures), "unexpected backoff")
+		})
+	}
+}
+
+func TestRetryFallback(t *testing.T) {
+	policy := &hivev1.ProvisionRetryPolicy{
+		Fallbacks: []hivev1.ProvisionRetryFallback{
+			{Region: "us-west-2"},
+			{Region: "us-west-2", InstanceType: "m6i.xlarge"},
+		},
+	}
+	assert.Nil(t, retryFallback(policy, 0), "unexpected fallback before any failure")
+	assert.Equal(t, &policy.Fallbacks[0], retryFallback(policy, 1), "unexpected fallback after first failure")
+	assert.Equal(t, &policy.Fallbacks[1], retryFallback(policy, 2), "unexpected fallback after second failure")
+	assert.Equal(t, &policy.Fallbacks[1], retryFallback(policy, 5), "unexpected fallback once fallbacks are exhausted")
+	assert.Nil(t, retryFallback(&hivev1.ProvisionRetryPolicy{}, 1), "unexpected fallback for policy without fallbacks")
+}
+
+func TestReconcileRetryPolicy(t *testing.T) {
+	// Fake out readProvisionFailedConfig
+	os.Setenv(constants.FailedProvisionConfigFileEnvVar, "fake")
+
+	getCD := func(c client.Client) *hivev1.ClusterDeployment {
+		cd := &hivev1.ClusterDeployment{}
+		require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Name: testName, Namespace: testNamespace}, cd), "could not get ClusterDeployment")
+		return cd
+	}
+	getProvisions := func(c client.Client) []*hivev1.ClusterProvision {
+		provisionList := &hivev1.ClusterProvisionList{}
+		require.NoError(t, c.List(context.TODO(), provisionList), "could not list ClusterProvisions")
+		provisions := make([]*hivev1.ClusterProvision, len(provisionList.Items))
+		for i := range provisionList.Items {
+			provisions[i] = &provisionList.Items[i]
+		}
+		return provisions
+	}
+	withFailure := func(reason string, failureTime time.Time) tcp.Option {
+		return func(provision *hivev1.ClusterProvision) {
+			tcp.WithFailureReason(reason)(provision)
+			provision.Status.Conditions[0].LastTransitionTime = metav1.NewTime(failureTime)
+		}
+	}
+	withFailures := func(cd *hivev1.ClusterDeployment, failures ...hivev1.ProvisionFailureCount) *hivev1.ClusterDeployment {
+		cd.Status.ProvisionRetries = &hivev1.ProvisionRetriesStatus{Failures: failures}
+		return cd
+	}
+	workerPool := func(opts ...testmp.Option) *hivev1.MachinePool {
+		return testmp.Build(append([]testmp.Option{
+			testmp.WithNamespace(testNamespace),
+			testmp.WithPoolNameForClusterDeployment("worker", testName),
+			testmp.WithAWSInstanceType("m5.xlarge"),
+			func(pool *hivev1.MachinePool) {
+				pool.Spec.Platform.AWS.Zones = []string{"us-east-1a"}
+				pool.Spec.Platform.AWS.Subnets = []string{"subnet-0123456789abcdef0"}
+			},
+		}, opts...)...)
+	}
+	getWorkerPool := func(c client.Client) *hivev1.MachinePool {
+		pool := &hivev1.MachinePool{}
+		require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Name: testName + "-worker", Namespace: testNamespace}, pool), "could not get MachinePool")
+		return pool
+	}
+	baseCD := func(cd *hivev1.ClusterDeployment) *hivev1.ClusterDeployment {
+		return testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(cd))
+	}
+	quotaPolicy := hivev1.ProvisionRetryPolicy{
+		Reasons:       []string{"AWSQuotaExceeded", "AWSInsufficientCapacity"},
+		AttemptsLimit: pointer.Int32(3),
+		Backoff:       &metav1.Duration{Duration: 10 * time.Minute},
+		Fallbacks: []hivev1.ProvisionRetryFallback{
+			{Region: "us-west-2"},
+			{Region: "us-west-2", InstanceType: "m6i.xlarge"},
+		},
+	}
+
+	cases := []struct {
+		name                  string
+		existing              []runtime.Object
+		retryReasons          *[]string
+		expectedRequeueAfter  time.Duration
+		expectPendingCreation bool
+		validate              func(*testing.T, client.Client)
+	}{
+		{
+			name: "backoff",
+			existing: []runtime.Object{
+				baseCD(withFailures(testClusterDeploymentWithProvision(),
+					hivev1.ProvisionFailureCount{Reason: "AWSInsufficientCapacity", Count: 1})),
+				testProvision(withFailure("AWSQuotaExceeded", time.Now())),
+			},
+			expectedRequeueAfter: 20 * time.Minute,
+			validate: func(t *testing.T, c client.Client) {
+				cd := getCD(c)
+				assert.NotNil(t, cd.Status.ProvisionRef, "expected failed provision to be current")
+				if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionRetryPolicyAppliedCondition); assert.NotNil(t, cond, "no ProvisionRetryPolicyApplied condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected ProvisionRetryPolicyApplied status")
+					assert.Equal(t, retryScheduledReason, cond.Reason, "unexpected ProvisionRetryPolicyApplied reason")
+					assert.Contains(t, cond.Message, "Install attempt 2 failed for AWSQuotaExceeded", "unexpected ProvisionRetryPolicyApplied message")
+				}
+			},
+		},
+		{
+			name: "backoff elapsed",
+			existing: []runtime.Object{
+				baseCD(testClusterDeploymentWithProvision()),
+				testProvision(withFailure("AWSQuotaExceeded", time.Now().Add(-time.Hour))),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				cd := getCD(c)
+				assert.Nil(t, cd.Status.ProvisionRef, "expected failed provision to be cleared")
+				if assert.NotNil(t, cd.Status.ProvisionRetries, "expected failure to be recorded") {
+					assert.Equal(t, []hivev1.ProvisionFailureCount{{Reason: "AWSQuotaExceeded", Count: 1}},
+						cd.Status.ProvisionRetries.Failures, "unexpected failures")
+				}
+			},
+		},
+		{
+			name: "no retry policy",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := baseCD(testClusterDeploymentWithProvision())
+					cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.ProvisionRetryPolicyAppliedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: retryScheduledReason,
+					})
+					return cd
+				}(),
+				testProvision(withFailure("KubeAPIWaitFailed", time.Now().Add(-time.Hour))),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				cd := getCD(c)
+				assert.Nil(t, cd.Status.ProvisionRetries, "unexpected failures recorded")
+				if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionRetryPolicyAppliedCondition); assert.NotNil(t, cond, "no ProvisionRetryPolicyApplied condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected ProvisionRetryPolicyApplied status")
+					assert.Equal(t, noRetryPolicyReason, cond.Reason, "unexpected ProvisionRetryPolicyApplied reason")
+				}
+			},
+		},
+		{
+			name: "no retry policy after fallback to another region",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := baseCD(withFailures(testClusterDeploymentWithProvision(),
+						hivev1.ProvisionFailureCount{Reason: "AWSQuotaExceeded", Count: 1}))
+					cd.Spec.Platform.AWS.Region = "us-west-2"
+					cd.Labels[hivev1.HiveClusterRegionLabel] = "us-west-2"
+					cd.Status.ProvisionRetries.Fallback = &hivev1.ProvisionRetryFallback{Region: "us-west-2"}
+					cd.Status.ProvisionRetries.PrevRegion = "us-east-1"
+					return cd
+				}(),
+				testProvision(withFailure("KubeAPIWaitFailed", time.Now().Add(-time.Hour))),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				cd := getCD(c)
+				assert.Nil(t, cd.Status.ProvisionRef, "expected failed provision to be cleared")
+				if assert.NotNil(t, cd.Status.ProvisionRetries, "no provision retries") {
+					assert.Equal(t, []hivev1.ProvisionFailureCount{{Reason: "AWSQuotaExceeded", Count: 1}},
+						cd.Status.ProvisionRetries.Failures, "unexpected failures")
+					assert.Empty(t, cd.Status.ProvisionRetries.PrevRegion, "unexpected previous region")
+				}
+			},
+		},
+		{
+			name: "attempts limit reached",
+			existing: []runtime.Object{
+				baseCD(withFailures(testClusterDeployment(),
+					hivev1.ProvisionFailureCount{Reason: "AWSQuotaExceeded", Count: 2},
+					hivev1.ProvisionFailureCount{Reason: "AWSInsufficientCapacity", Count: 1})),
+				testProvision(tcp.WithFailureReason("AWSQuotaExceeded")),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				cd := getCD(c)
+				if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition); assert.NotNil(t, cond, "no ProvisionStopped condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "expected ProvisionStopped to be True")
+					assert.Equal(t, retryPolicyAttemptsLimitReachedReason, cond.Reason, "unexpected ProvisionStopped reason")
+				}
+				assert.Len(t, getProvisions(c), 1, "expected 1 ClusterProvision to exist")
+			},
+		},
+		{
+			name: "retry reason not listed",
+			existing: []runtime.Object{
+				baseCD(withFailures(testClusterDeployment(),
+					hivev1.ProvisionFailureCount{Reason: "AWSQuotaExceeded", Count: 1})),
+				testProvision(tcp.WithFailureReason("AWSQuotaExceeded")),
+			},
+			retryReasons: &[]string{"KubeAPIWaitFailed"},
+			validate: func(t *testing.T, c client.Client) {
+				cd := getCD(c)
+				if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition); assert.NotNil(t, cond, "no ProvisionStopped condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "expected ProvisionStopped to be False")
+				}
+			},
+		},
+		{
+			name: "apply fallback",
+			existing: []runtime.Object{
+				baseCD(withFailures(testClusterDeployment(),
+					hivev1.ProvisionFailureCount{Reason: "AWSQuotaExceeded", Count: 1})),
+				testProvision(tcp.WithFailureReason("AWSQuotaExceeded")),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				cd := getCD(c)
+				assert.Equal(t, "us-west-2", cd.Spec.Platform.AWS.Region, "unexpected region")
+				if assert.NotNil(t, cd.Status.ProvisionRetries, "no provision retries") {
+					assert.Equal(t, &hivev1.ProvisionRetryFallback{Region: "us-west-2"}, cd.Status.ProvisionRetries.Fallback, "unexpected fallback")
+					assert.Equal(t, "us-east-1", cd.Status.ProvisionRetries.PrevRegion, "unexpected previous region")
+				}
+				if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionRetryPolicyAppliedCondition); assert.NotNil(t, cond, "no ProvisionRetryPolicyApplied condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected ProvisionRetryPolicyApplied status")
+					assert.Equal(t, retryFallbackAppliedReason, cond.Reason, "unexpected ProvisionRetryPolicyApplied reason")
+				}
+				assert.Len(t, getProvisions(c), 1, "expected 1 ClusterProvision to exist")
+			},
+		},
+		{
+			name: "fallback applied",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := baseCD(withFailures(testClusterDeployment(),
+						hivev1.ProvisionFailureCount{Reason: "AWSQuotaExceeded", Count: 1}))
+					cd.Spec.Platform.AWS.Region = "us-west-2"
+					cd.Labels[hivev1.HiveClusterRegionLabel] = "us-west-2"
+					cd.Status.ProvisionRetries.Fallback = &hivev1.ProvisionRetryFallback{Region: "us-west-2"}
+					cd.Status.ProvisionRetries.PrevRegion = "us-east-1"
+					return cd
+				}(),
+				testProvision(tcp.WithFailureReason("AWSQuotaExceeded")),
+			},
+			expectPendingCreation: true,
+			validate: func(t *testing.T, c client.Client) {
+				assert.Len(t, getProvisions(c), 2, "expected 2 ClusterProvisions to exist")
+			},
+		},
+		{
+			name: "apply next fallback",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := baseCD(withFailures(testClusterDeployment(),
+						hivev1.ProvisionFailureCount{Reason: "AWSInsufficientCapacity", Count: 2}))
+					cd.Spec.Platform.AWS.Region = "us-west-2"
+					cd.Labels[hivev1.HiveClusterRegionLabel] = "us-west-2"
+					cd.Status.ProvisionRetries.Fallback = &hivev1.ProvisionRetryFallback{Region: "us-west-2"}
+					return cd
+				}(),
+				testProvision(tcp.WithFailureReason("AWSInsufficientCapacity")),
+				workerPool(),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				cd := getCD(c)
+				assert.Equal(t, "us-west-2", cd.Spec.Platform.AWS.Region, "unexpected region")
+				if assert.NotNil(t, cd.Status.ProvisionRetries, "no provision retries") {
+					assert.Equal(t, &hivev1.ProvisionRetryFallback{Region: "us-west-2", InstanceType: "m6i.xlarge"},
+						cd.Status.ProvisionRetries.Fallback, "unexpected fallback")
+					assert.Empty(t, cd.Status.ProvisionRetries.PrevRegion, "unexpected previous region")
+				}
+				pool := getWorkerPool(c)
+				assert.Equal(t, "m6i.xlarge", pool.Spec.Platform.AWS.InstanceType, "unexpected machine pool instance type")
+				assert.Empty(t, pool.Spec.Platform.AWS.Zones, "unexpected machine pool zones")
+				assert.Empty(t, pool.Spec.Platform.AWS.Subnets, "unexpected machine pool subnets")
+				assert.NotContains(t, pool.Annotations, constants.OverrideMachinePoolPlatformAnnotation, "unexpected override annotation")
+				assert.Len(t, getProvisions(c), 1, "expected 1 ClusterProvision to exist")
+			},
+		},
+		{
+			name: "apply fallback to machine pool",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := baseCD(withFailures(testClusterDeployment(),
+						hivev1.ProvisionFailureCount{Reason: "AWSQuotaExceeded", Count: 1}))
+					cd.Spec.Platform.AWS.Region = "us-west-2"
+					cd.Labels[hivev1.HiveClusterRegionLabel] = "us-west-2"
+					cd.Status.ProvisionRetries.Fallback = &hivev1.ProvisionRetryFallback{Region: "us-west-2"}
+					cd.Status.ProvisionRetries.PrevRegion = "us-east-1"
+					return cd
+				}(),
+				testProvision(tcp.WithFailureReason("AWSQuotaExceeded")),
+				workerPool(testmp.WithAnnotations(map[string]string{constants.OverrideMachinePoolPlatformAnnotation: "false"})),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				pool := getWorkerPool(c)
+				assert.Equal(t, "m5.xlarge", pool.Spec.Platform.AWS.InstanceType, "unexpected machine pool instance type")
+				assert.Empty(t, pool.Spec.Platform.AWS.Zones, "unexpected machine pool zones")
+				assert.Empty(t, pool.Spec.Platform.AWS.Subnets, "unexpected machine pool subnets")
+				assert.Equal(t, "false", pool.Annotations[constants.OverrideMachinePoolPlatformAnnotation], "unexpected override annotation")
+				assert.Len(t, getProvisions(c), 1, "expected 1 ClusterProvision to exist")
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := log.WithField("controller", "clusterDeployment")
+			fpConfig := hivev1.FailedProvisionConfig{
+				RetryReasons:  tc.retryReasons,
+				RetryPolicies: []hivev1.ProvisionRetryPolicy{quotaPolicy},
+			}
+			b, err := json.Marshal(fpConfig)
+			require.NoError(t, err, "could not marshal FailedProvisionConfig")
+			readFile = fakeReadFile(string(b))
+
+			existing := append(tc.existing,
+				testInstallConfigSecretAWS(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			)
+			fakeClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build()
+			controllerExpectations := controllerutils.NewExpectations(logger)
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(gomock.NewController(t))
+			rcd := &ReconcileClusterDeployment{
+				Client:                        fakeClient,
+				scheme:                        scheme.GetScheme(),
+				logger:                        logger,
+				expectations:                  controllerExpectations,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				validateCredentialsForClusterDeployment: func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (bool, error) {
+					return true, nil
+				},
+				nodeSelector: &map[string]string{},
+				tolerations:  &[]corev1.Toleration{},
+			}
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: testName, Namespace: testNamespace}}
+
+			result, err := rcd.Reconcile(context.TODO(), request)
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			if tc.expectedRequeueAfter == 0 {
+				assert.Zero(t, result.RequeueAfter, "expected empty requeue after")
+			} else {
+				assert.InDelta(t, tc.expectedRequeueAfter, result.RequeueAfter, float64(10*time.Second), "unexpected requeue after")
+			}
+			assert.Equal(t, tc.expectPendingCreation, !controllerExpectations.SatisfiedExpectations(request.String()),
+				"unexpected pending creation")
+			if tc.validate != nil {
+				tc.validate(t, fakeClient)
+			}
+		})
+	}
+}
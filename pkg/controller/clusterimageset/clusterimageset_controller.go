@@ -0,0 +1,366 @@
+package clusterimageset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/openshift/library-go/pkg/verify"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/controller/clusterdeployment"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ClusterImageSetControllerName
+
+	// graphConfigMapKey is the key of the update graph in the ConfigMap referenced by a channel.
+	graphConfigMapKey = "graph.json"
+	// channelsMetadataKey is the node metadata of an update graph listing the channels of a release.
+	channelsMetadataKey = "io.openshift.upgrades.graph.release.channels"
+
+	defaultArchitecture = "amd64"
+	defaultPollInterval = time.Hour
+	// maxHistory is the number of resolved releases kept in the status of a ClusterImageSet.
+	maxHistory = 10
+	// maxGraphSize limits the size of an update graph read from a graph endpoint.
+	maxGraphSize = 32 << 20
+
+	resolvedReason          = "Resolved"
+	graphUnavailableReason  = "GraphUnavailable"
+	noMatchingReleaseReason = "NoMatchingRelease"
+	noVerifiedReleaseReason = "NoVerifiedRelease"
+	// verificationNotConfiguredReason is set when release image verification is not configured: channels are never
+	// followed without verifying the releases first.
+	verificationNotConfiguredReason = "ReleaseVerificationNotConfigured"
+)
+
+// Add creates a new ClusterImageSet controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileClusterImageSet
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterImageSet {
+	logger := log.WithField("controller", ControllerName)
+	r := &ReconcileClusterImageSet{
+		Client:     controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:     logger,
+		httpClient: &http.Client{Timeout: time.Minute},
+	}
+	verifier, err := clusterdeployment.LoadReleaseImageVerifier(mgr.GetConfig())
+	if err == nil {
+		r.releaseImageVerifier = verifier
+	} else {
+		logger.WithError(err).Error("Release Image verification failed to be configured")
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileClusterImageSet, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("clusterimageset-controller", mgr, controller.Options{
+		Reconciler:              controllerutils.NewDelayingReconciler(r, r.logger),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the spec of ClusterImageSets. Status updates are ignored, as the controller records the
+	// time of every read of the update graph in the status.
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &hivev1.ClusterImageSet{}),
+		&handler.EnqueueRequestForObject{},
+		predicate.GenerationChangedPredicate{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterImageSet{}
+
+// ReconcileClusterImageSet keeps the release image of ClusterImageSets that follow a channel at the latest release of
+// the channel.
+type ReconcileClusterImageSet struct {
+	client.Client
+	logger log.FieldLogger
+
+	httpClient *http.Client
+
+	// releaseImageVerifier, if provided, is used to verify the signatures of releases before they are followed.
+	releaseImageVerifier verify.Interface
+}
+
+// graph is a Cincinnati update graph. Only the nodes of the graph are used.
+type graph struct {
+	Nodes []graphNode `json:"nodes"`
+}
+
+type graphNode struct {
+	Version  string            `json:"version"`
+	Payload  string            `json:"payload"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// release is a release of a channel.
+type release struct {
+	version semver.Version
+	image   string
+}
+
+// Reconcile resolves the latest release of the channel of a ClusterImageSet.
+func (r *ReconcileClusterImageSet) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterImageSet", request.NamespacedName)
+	logger.Debug("reconciling cluster image set")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	imageSet := &hivev1.ClusterImageSet{}
+	if err := r.Get(ctx, request.NamespacedName, imageSet); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("cluster image set not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting ClusterImageSet")
+		return reconcile.Result{}, err
+	}
+	channel := imageSet.Spec.Channel
+	if channel == nil || imageSet.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+	logger = logger.WithField("channel", channel.Name)
+	pollInterval := defaultPollInterval
+	if channel.PollInterval != nil {
+		pollInterval = channel.PollInterval.Duration
+	}
+
+	latest, reason, err := r.resolveChannel(ctx, channel, logger)
+	now := metav1.Now()
+	imageSet.Status.LastCheckedTime = &now
+	if reason == verificationNotConfiguredReason {
+		logger.WithError(err).Error("refusing to follow channel")
+		if statusErr := r.updateStatus(imageSet, corev1.ConditionFalse, reason, err.Error(), logger); statusErr != nil {
+			return reconcile.Result{}, statusErr
+		}
+		return reconcile.Result{}, err
+	}
+	if err != nil {
+		logger.WithError(err).WithField("reason", reason).Warn("could not resolve channel")
+		return reconcile.Result{RequeueAfter: pollInterval},
+			r.updateStatus(imageSet, corev1.ConditionFalse, reason, err.Error(), logger)
+	}
+
+	if imageSet.Spec.ReleaseImage != latest.image {
+		logger.WithField("version", latest.version).WithField("releaseImage", latest.image).
+			Info("following new release of channel")
+		imageSet.Spec.ReleaseImage = latest.image
+		if err := r.Update(ctx, imageSet); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterImageSet release image")
+			return reconcile.Result{}, errors.Wrap(err, "could not update ClusterImageSet release image")
+		}
+		imageSet.Status.LastCheckedTime = &now
+	}
+	version := latest.version.String()
+	imageSet.Status.Version = version
+	if h := imageSet.Status.History; len(h) == 0 || h[0].ReleaseImage != latest.image {
+		imageSet.Status.History = append([]hivev1.ClusterImageSetRelease{{
+			Version:      version,
+			ReleaseImage: latest.image,
+			ResolvedTime: now,
+		}}, h...)
+		if len(imageSet.Status.History) > maxHistory {
+			imageSet.Status.History = imageSet.Status.History[:maxHistory]
+		}
+	}
+	message := fmt.Sprintf("Release %s is the latest release of channel %s", version, channel.Name)
+	return reconcile.Result{RequeueAfter: pollInterval},
+		r.updateStatus(imageSet, corev1.ConditionTrue, resolvedReason, message, logger)
+}
+
+// resolveChannel returns the latest release of the channel that passes signature verification. On failure, the reason
+// for the ChannelResolved condition is returned with the error.
+func (r *ReconcileClusterImageSet) resolveChannel(ctx context.Context, channel *hivev1.ClusterImageSetChannel, logger log.FieldLogger) (*release, string, error) {
+	if r.releaseImageVerifier == nil {
+		return nil, verificationNotConfiguredReason, errors.New("release image verification is not configured, refusing to follow channel")
+	}
+	g, err := r.readGraph(ctx, channel)
+	if err != nil {
+		return nil, graphUnavailableReason, err
+	}
+	releases, err := channelReleases(g, channel)
+	if err != nil {
+		return nil, noMatchingReleaseReason, err
+	}
+	if len(releases) == 0 {
+		return nil, noMatchingReleaseReason, fmt.Errorf("update graph has no releases of channel %s", channel.Name)
+	}
+	for i := range releases {
+		rel := &releases[i]
+		var digest string
+		if index := strings.LastIndex(rel.image, "@"); index != -1 {
+			digest = rel.image[index+1:]
+		}
+		if err := r.releaseImageVerifier.Verify(ctx, digest); err != nil {
+			logger.WithError(err).WithField("version", rel.version).WithField("releaseImage", rel.image).
+				Warn("skipping release that failed verification")
+			continue
+		}
+		return rel, "", nil
+	}
+	return nil, noVerifiedReleaseReason, fmt.Errorf("none of the %d releases of channel %s passed verification", len(releases), channel.Name)
+}
+
+// readGraph reads the update graph of the channel from its graph endpoint or ConfigMap.
+func (r *ReconcileClusterImageSet) readGraph(ctx context.Context, channel *hivev1.ClusterImageSetChannel) (*graph, error) {
+	var data []byte
+	if channel.GraphConfigMapRef != nil {
+		cm := &corev1.ConfigMap{}
+		key := client.ObjectKey{Namespace: controllerutils.GetHiveNamespace(), Name: channel.GraphConfigMapRef.Name}
+		if err := r.Get(ctx, key, cm); err != nil {
+			return nil, errors.Wrapf(err, "could not get update graph ConfigMap %s", key)
+		}
+		graphJSON, ok := cm.Data[graphConfigMapKey]
+		if !ok {
+			return nil, fmt.Errorf("update graph ConfigMap %s has no %s key", key, graphConfigMapKey)
+		}
+		data = []byte(graphJSON)
+	} else {
+		var err error
+		if data, err = r.fetchGraph(ctx, channel); err != nil {
+			return nil, err
+		}
+	}
+	g := &graph{}
+	if err := json.Unmarshal(data, g); err != nil {
+		return nil, errors.Wrap(err, "could not parse update graph")
+	}
+	return g, nil
+}
+
+// fetchGraph queries the graph endpoint of the channel.
+func (r *ReconcileClusterImageSet) fetchGraph(ctx context.Context, channel *hivev1.ClusterImageSetChannel) ([]byte, error) {
+	u, err := url.Parse(channel.GraphURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid update graph URL")
+	}
+	query := u.Query()
+	query.Set("channel", channel.Name)
+	query.Set("arch", channelArchitecture(channel))
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create update graph request")
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not query update graph")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("update graph query returned %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxGraphSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read update graph")
+	}
+	return data, nil
+}
+
+// channelReleases returns the releases in the graph that belong to the channel and are within its version range,
+// latest first. Nodes without channel metadata, as served by graph endpoints queried for a single channel, are assumed
+// to belong to the channel.
+func channelReleases(g *graph, channel *hivev1.ClusterImageSetChannel) ([]release, error) {
+	var versionRange semver.Range
+	if channel.Version != "" {
+		var err error
+		if versionRange, err = semver.ParseRange(channel.Version); err != nil {
+			return nil, errors.Wrap(err, "invalid version range")
+		}
+	}
+	var releases []release
+	for _, node := range g.Nodes {
+		if channels, ok := node.Metadata[channelsMetadataKey]; ok && !inChannel(channels, channel.Name) {
+			continue
+		}
+		version, err := semver.Parse(node.Version)
+		if err != nil || node.Payload == "" {
+			continue
+		}
+		if versionRange != nil && !versionRange(version) {
+			continue
+		}
+		releases = append(releases, release{version: version, image: node.Payload})
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].version.GT(releases[j].version)
+	})
+	return releases, nil
+}
+
+func inChannel(channels, name string) bool {
+	for _, c := range strings.Split(channels, ",") {
+		if strings.TrimSpace(c) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func channelArchitecture(channel *hivev1.ClusterImageSetChannel) string {
+	if channel.Architecture == "" {
+		return defaultArchitecture
+	}
+	return channel.Architecture
+}
+
+// updateStatus sets the ChannelResolved condition of the ClusterImageSet and updates its status.
+func (r *ReconcileClusterImageSet) updateStatus(imageSet *hivev1.ClusterImageSet, status corev1.ConditionStatus, reason, message string, logger log.FieldLogger) error {
+	imageSet.Status.Conditions, _ = controllerutils.SetClusterImageSetConditionWithChangeCheck(
+		imageSet.Status.Conditions,
+		hivev1.ClusterImageSetChannelResolvedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if err := r.Status().Update(context.Background(), imageSet); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterImageSet status")
+		return errors.Wrap(err, "could not update ClusterImageSet status")
+	}
+	return nil
+}
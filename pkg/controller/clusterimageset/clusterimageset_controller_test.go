@@ -0,0 +1,283 @@
+package clusterimageset
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/library-go/pkg/verify"
+	"github.com/openshift/library-go/pkg/verify/store"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+)
+
+const (
+	imageSetName = "openshift-stable"
+
+	release4141 = "quay.io/openshift-release-dev/ocp-release@sha256:4141"
+	release4142 = "quay.io/openshift-release-dev/ocp-release@sha256:4142"
+	release4150 = "quay.io/openshift-release-dev/ocp-release@sha256:4150"
+)
+
+const testGraph = `{
+  "nodes": [
+    {"version": "4.14.1", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:4141",
+     "metadata": {"io.openshift.upgrades.graph.release.channels": "candidate-4.14,stable-4.14"}},
+    {"version": "4.14.2", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:4142",
+     "metadata": {"io.openshift.upgrades.graph.release.channels": "candidate-4.14,stable-4.14"}},
+    {"version": "4.14.3", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:4143",
+     "metadata": {"io.openshift.upgrades.graph.release.channels": "candidate-4.14"}},
+    {"version": "4.15.0", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:4150",
+     "metadata": {"io.openshift.upgrades.graph.release.channels": "stable-4.14,stable-4.15"}}
+  ],
+  "edges": [[0, 1], [1, 2], [1, 3]]
+}`
+
+func TestReconcileClusterImageSet(t *testing.T) {
+	resolvedAt := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name             string
+		channel          *hivev1.ClusterImageSetChannel
+		releaseImage     string
+		history          []hivev1.ClusterImageSetRelease
+		graphStatus      int
+		verified         []string
+		noVerifier       bool
+		expectedImage    string
+		expectedVersion  string
+		expectedHistory  []string
+		expectedStatus   corev1.ConditionStatus
+		expectedReason   string
+		expectNoRequeue  bool
+		expectNoLastTime bool
+		expectErr        bool
+	}{
+		{
+			name:            "latest release of channel",
+			channel:         &hivev1.ClusterImageSetChannel{Name: "stable-4.14"},
+			expectedImage:   release4150,
+			expectedVersion: "4.15.0",
+			expectedHistory: []string{release4150},
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  "Resolved",
+		},
+		{
+			name:            "version range",
+			channel:         &hivev1.ClusterImageSetChannel{Name: "stable-4.14", Version: "4.14.x"},
+			releaseImage:    release4141,
+			history:         []hivev1.ClusterImageSetRelease{{Version: "4.14.1", ReleaseImage: release4141, ResolvedTime: resolvedAt}},
+			expectedImage:   release4142,
+			expectedVersion: "4.14.2",
+			expectedHistory: []string{release4142, release4141},
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  "Resolved",
+		},
+		{
+			name:            "unchanged release",
+			channel:         &hivev1.ClusterImageSetChannel{Name: "stable-4.14", Version: "4.14.x"},
+			releaseImage:    release4142,
+			history:         []hivev1.ClusterImageSetRelease{{Version: "4.14.2", ReleaseImage: release4142, ResolvedTime: resolvedAt}},
+			expectedImage:   release4142,
+			expectedVersion: "4.14.2",
+			expectedHistory: []string{release4142},
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  "Resolved",
+		},
+		{
+			name:            "latest verified release",
+			channel:         &hivev1.ClusterImageSetChannel{Name: "stable-4.14"},
+			verified:        []string{"sha256:4141", "sha256:4142"},
+			expectedImage:   release4142,
+			expectedVersion: "4.14.2",
+			expectedHistory: []string{release4142},
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  "Resolved",
+		},
+		{
+			name:           "no verified release",
+			channel:        &hivev1.ClusterImageSetChannel{Name: "stable-4.14"},
+			releaseImage:   release4141,
+			verified:       []string{},
+			expectedImage:  release4141,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "NoVerifiedRelease",
+		},
+		{
+			name:            "no verifier",
+			channel:         &hivev1.ClusterImageSetChannel{Name: "stable-4.14"},
+			releaseImage:    release4141,
+			noVerifier:      true,
+			expectedImage:   release4141,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  "ReleaseVerificationNotConfigured",
+			expectNoRequeue: true,
+			expectErr:       true,
+		},
+		{
+			name:           "no release in range",
+			channel:        &hivev1.ClusterImageSetChannel{Name: "stable-4.14", Version: "4.16.x"},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "NoMatchingRelease",
+		},
+		{
+			name:           "graph unavailable",
+			channel:        &hivev1.ClusterImageSetChannel{Name: "stable-4.14"},
+			releaseImage:   release4141,
+			graphStatus:    http.StatusServiceUnavailable,
+			expectedImage:  release4141,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "GraphUnavailable",
+		},
+		{
+			name:             "no channel",
+			releaseImage:     release4141,
+			expectedImage:    release4141,
+			expectNoRequeue:  true,
+			expectNoLastTime: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "stable-4.14", req.URL.Query().Get("channel"), "unexpected channel queried")
+				assert.Equal(t, "amd64", req.URL.Query().Get("arch"), "unexpected architecture queried")
+				if test.graphStatus != 0 {
+					w.WriteHeader(test.graphStatus)
+					return
+				}
+				fmt.Fprint(w, testGraph)
+			}))
+			defer server.Close()
+			if test.channel != nil {
+				test.channel.GraphURL = server.URL
+			}
+			imageSet := &hivev1.ClusterImageSet{
+				ObjectMeta: metav1.ObjectMeta{Name: imageSetName},
+				Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: test.releaseImage, Channel: test.channel},
+				Status:     hivev1.ClusterImageSetStatus{History: test.history},
+			}
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(imageSet).Build()
+			r := &ReconcileClusterImageSet{
+				Client:     c,
+				logger:     log.New(),
+				httpClient: server.Client(),
+			}
+			switch {
+			case test.noVerifier:
+			case test.verified != nil:
+				r.releaseImageVerifier = testReleaseVerifier{known: sets.NewString(test.verified...)}
+			default:
+				r.releaseImageVerifier = testReleaseVerifier{known: allReleases}
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: imageSetName}})
+			if test.expectErr {
+				assert.Error(t, err, "expected error from Reconcile")
+			} else {
+				require.NoError(t, err, "unexpected error from Reconcile")
+			}
+			if test.expectNoRequeue {
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue")
+			} else {
+				assert.Equal(t, defaultPollInterval, result.RequeueAfter, "unexpected requeue")
+			}
+
+			imageSet = &hivev1.ClusterImageSet{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: imageSetName}, imageSet))
+			assert.Equal(t, test.expectedImage, imageSet.Spec.ReleaseImage, "unexpected release image")
+			assert.Equal(t, test.expectedVersion, imageSet.Status.Version, "unexpected version")
+			var history []string
+			for _, h := range imageSet.Status.History {
+				history = append(history, h.ReleaseImage)
+			}
+			assert.Equal(t, test.expectedHistory, history, "unexpected history")
+			if test.expectNoLastTime {
+				assert.Nil(t, imageSet.Status.LastCheckedTime, "unexpected last checked time")
+			} else {
+				assert.NotNil(t, imageSet.Status.LastCheckedTime, "expected last checked time")
+			}
+			cond := controllerutils.FindCondition(imageSet.Status.Conditions, hivev1.ClusterImageSetChannelResolvedCondition)
+			if test.expectedReason == "" {
+				assert.Nil(t, cond, "unexpected ChannelResolved condition")
+				return
+			}
+			require.NotNil(t, cond, "expected ChannelResolved condition")
+			assert.Equal(t, test.expectedStatus, cond.Status, "unexpected status of ChannelResolved condition")
+			assert.Equal(t, test.expectedReason, cond.Reason, "unexpected reason of ChannelResolved condition")
+		})
+	}
+}
+
+func TestReconcileClusterImageSet_GraphConfigMap(t *testing.T) {
+	imageSet := &hivev1.ClusterImageSet{
+		ObjectMeta: metav1.ObjectMeta{Name: imageSetName},
+		Spec: hivev1.ClusterImageSetSpec{
+			Channel: &hivev1.ClusterImageSetChannel{
+				Name:              "candidate-4.14",
+				GraphConfigMapRef: &corev1.LocalObjectReference{Name: "update-graph"},
+			},
+		},
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: "update-graph"},
+		Data:       map[string]string{"graph.json": testGraph},
+	}
+	existing := []runtime.Object{imageSet, cm}
+	c := testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build()
+	r := &ReconcileClusterImageSet{Client: c, logger: log.New(), releaseImageVerifier: testReleaseVerifier{known: allReleases}}
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: imageSetName}})
+	require.NoError(t, err, "unexpected error from Reconcile")
+
+	imageSet = &hivev1.ClusterImageSet{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: imageSetName}, imageSet))
+	assert.Equal(t, "quay.io/openshift-release-dev/ocp-release@sha256:4143", imageSet.Spec.ReleaseImage, "unexpected release image")
+	assert.Equal(t, "4.14.3", imageSet.Status.Version, "unexpected version")
+}
+
+// allReleases are the digests of all the releases of the test graph.
+var allReleases = sets.NewString("sha256:4141", "sha256:4142", "sha256:4143", "sha256:4150")
+
+// testReleaseVerifier returns Verify true for only provided known digests.
+type testReleaseVerifier struct {
+	known sets.String
+}
+
+var _ verify.Interface = testReleaseVerifier{}
+
+func (t testReleaseVerifier) Verify(ctx context.Context, releaseDigest string) error {
+	if !t.known.Has(releaseDigest) {
+		return fmt.Errorf("verification did not succeed")
+	}
+	return nil
+}
+
+func (testReleaseVerifier) Signatures() map[string][][]byte {
+	return nil
+}
+
+func (testReleaseVerifier) Verifiers() map[string]openpgp.EntityList {
+	return nil
+}
+
+func (testReleaseVerifier) AddStore(_ store.Store) {
+}
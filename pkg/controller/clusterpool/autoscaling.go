@@ -0,0 +1,219 @@
+package clusterpool
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	defaultAutoscalingWindow  = time.Hour
+	defaultAutoscalingHistory = 7 * 24 * time.Hour
+
+	// claimHistoryBucket is the period over which claims are counted together in the claim history.
+	claimHistoryBucket = time.Hour
+
+	// autoscalingResyncInterval is how often we requeue an autoscaling pool, so that targets are lowered as claims
+	// age out of the window even when nothing else about the pool changes.
+	autoscalingResyncInterval = 5 * time.Minute
+
+	autoscalingReasonPredicted = "PredictedDemand"
+	autoscalingReasonMinimum   = "MinimumSize"
+	autoscalingReasonMaximum   = "MaximumSize"
+)
+
+// setAutoscalingStatus records newly observed claims in the pool's claim history and recomputes the autoscaling
+// targets. The caller is responsible for pushing the changes back to the server.
+// The return indicates whether anything changed.
+func setAutoscalingStatus(clp *hivev1.ClusterPool, claims *claimCollection, now time.Time) bool {
+	if clp.Spec.Autoscaling == nil {
+		changed := clp.Status.Autoscaling != nil
+		clp.Status.Autoscaling = nil
+		return changed
+	}
+	origStatus := clp.Status.Autoscaling.DeepCopy()
+	if clp.Status.Autoscaling == nil {
+		clp.Status.Autoscaling = &hivev1.ClusterPoolAutoscalingStatus{}
+	}
+	status := clp.Status.Autoscaling
+
+	status.ClaimHistory, status.LastClaimTime = recordClaimHistory(status.ClaimHistory, status.LastClaimTime, claims,
+		now.Add(-autoscalingRetention(clp.Spec.Autoscaling)))
+	status.TargetSize, status.TargetRunningCount, status.Reason, status.Message =
+		computeAutoscalingTargets(clp, status.ClaimHistory, now)
+
+	return !reflect.DeepEqual(origStatus, status)
+}
+
+// recordClaimHistory counts the claims created after the last recorded claim in the hour of their creation, and drops
+// the hours that ended before the cutoff. It returns the updated history along with the creation time of the most
+// recent claim counted. Counting claims per hour bounds the size of the history by the retention, however busy the
+// pool is.
+func recordClaimHistory(history []hivev1.ClusterPoolClaimCount, lastClaim *metav1.Time, claims *claimCollection, cutoff time.Time) ([]hivev1.ClusterPoolClaimCount, *metav1.Time) {
+	// The last recorded claim serves as a watermark so that claims are only counted once, even though they stick
+	// around for the life of the cluster. Claims created in the same second as the watermark, but not seen until a
+	// later reconcile, are missed; that is an acceptable error for the purposes of prediction.
+	var watermark time.Time
+	if lastClaim != nil {
+		watermark = lastClaim.Time
+	}
+	var updated []hivev1.ClusterPoolClaimCount
+	for _, c := range history {
+		if c.Hour.Add(claimHistoryBucket).After(cutoff) {
+			updated = append(updated, c)
+		}
+	}
+	for _, claim := range claims.byClaimName {
+		created := claim.CreationTimestamp
+		if !created.Time.After(watermark) || !created.Time.After(cutoff) {
+			continue
+		}
+		updated = countClaim(updated, created.Time)
+		if lastClaim == nil || created.After(lastClaim.Time) {
+			lastClaim = created.DeepCopy()
+		}
+	}
+	return updated, lastClaim
+}
+
+// countClaim adds a claim created at the given time to the count of its hour, keeping the history sorted.
+func countClaim(history []hivev1.ClusterPoolClaimCount, created time.Time) []hivev1.ClusterPoolClaimCount {
+	hour := created.UTC().Truncate(claimHistoryBucket)
+	i := sort.Search(len(history), func(i int) bool { return !history[i].Hour.Time.Before(hour) })
+	if i < len(history) && history[i].Hour.Time.Equal(hour) {
+		history[i].Count++
+		return history
+	}
+	history = append(history, hivev1.ClusterPoolClaimCount{})
+	copy(history[i+1:], history[i:])
+	history[i] = hivev1.ClusterPoolClaimCount{Hour: metav1.NewTime(hour), Count: 1}
+	return history
+}
+
+// computeAutoscalingTargets predicts the number of claims the pool will receive in the upcoming window, and derives
+// the effective Size and RunningCount from it within the configured bounds.
+func computeAutoscalingTargets(clp *hivev1.ClusterPool, history []hivev1.ClusterPoolClaimCount, now time.Time) (int32, int32, string, string) {
+	as := clp.Spec.Autoscaling
+	window := autoscalingWindow(as)
+
+	var demand float64
+	var message string
+	switch as.Mode {
+	case hivev1.ClusterPoolAutoscalingTimeOfDay:
+		days := autoscalingHistoryDays(as)
+		var total float64
+		for d := 1; d <= days; d++ {
+			start := now.AddDate(0, 0, -d)
+			total += countClaimsBetween(history, start, start.Add(window), now)
+		}
+		demand = total / float64(days)
+		message = fmt.Sprintf("averaged %.1f claims in the upcoming %s over the last %d days", demand, window, days)
+	default:
+		demand = countClaimsBetween(history, now.Add(-window), now, now)
+		message = fmt.Sprintf("observed %.1f claims in the last %s", demand, window)
+	}
+	predicted := int32(math.Ceil(demand))
+
+	reason := autoscalingReasonPredicted
+	switch {
+	case predicted < clp.Spec.Size:
+		reason = autoscalingReasonMinimum
+	case predicted > as.MaxSize:
+		reason = autoscalingReasonMaximum
+	}
+	size := clampInt32(predicted, clp.Spec.Size, as.MaxSize)
+
+	runningCount := clp.Spec.RunningCount
+	switch {
+	case poolAlwaysRunning(clp):
+		runningCount = size
+	case as.MaxRunningCount != nil:
+		runningCount = clampInt32(predicted, clp.Spec.RunningCount, *as.MaxRunningCount)
+		if runningCount > size {
+			runningCount = size
+		}
+	}
+
+	return size, runningCount, reason, message
+}
+
+// effectivePoolSize returns the Size and RunningCount the pool should currently maintain, taking autoscaling into
+// account.
+func effectivePoolSize(clp *hivev1.ClusterPool) (int32, int32) {
+	if clp.Spec.Autoscaling != nil && clp.Status.Autoscaling != nil {
+		return clp.Status.Autoscaling.TargetSize, clp.Status.Autoscaling.TargetRunningCount
+	}
+	return clp.Spec.Size, clp.Spec.RunningCount
+}
+
+// clampInt32 bounds v to [lower, upper]. The lower bound wins if the bounds are inverted.
+func clampInt32(v, lower, upper int32) int32 {
+	if v > upper {
+		v = upper
+	}
+	if v < lower {
+		v = lower
+	}
+	return v
+}
+
+// countClaimsBetween returns the number of claims of the history created between start and end. The claims of an hour
+// only partly between them are counted in proportion to the overlap, the hour in progress ending now.
+func countClaimsBetween(history []hivev1.ClusterPoolClaimCount, start, end, now time.Time) float64 {
+	var n float64
+	for _, c := range history {
+		hourStart, hourEnd := c.Hour.Time, c.Hour.Add(claimHistoryBucket)
+		if hourEnd.After(now) {
+			hourEnd = now
+		}
+		if !hourEnd.After(hourStart) {
+			continue
+		}
+		overlapStart, overlapEnd := hourStart, hourEnd
+		if start.After(overlapStart) {
+			overlapStart = start
+		}
+		if end.Before(overlapEnd) {
+			overlapEnd = end
+		}
+		if overlapEnd.After(overlapStart) {
+			n += float64(c.Count) * float64(overlapEnd.Sub(overlapStart)) / float64(hourEnd.Sub(hourStart))
+		}
+	}
+	return n
+}
+
+// autoscalingRetention is how long claim history must be kept for the configured mode.
+func autoscalingRetention(as *hivev1.ClusterPoolAutoscaling) time.Duration {
+	retention := autoscalingWindow(as)
+	if as.Mode == hivev1.ClusterPoolAutoscalingTimeOfDay {
+		if h := time.Duration(autoscalingHistoryDays(as)) * 24 * time.Hour; h > retention {
+			retention = h
+		}
+	}
+	return retention
+}
+
+func autoscalingWindow(as *hivev1.ClusterPoolAutoscaling) time.Duration {
+	if as.Window != nil && as.Window.Duration > 0 {
+		return as.Window.Duration
+	}
+	return defaultAutoscalingWindow
+}
+
+func autoscalingHistoryDays(as *hivev1.ClusterPoolAutoscaling) int {
+	history := defaultAutoscalingHistory
+	if as.History != nil {
+		history = as.History.Duration
+	}
+	if days := int(history / (24 * time.Hour)); days > 0 {
+		return days
+	}
+	return 1
+}
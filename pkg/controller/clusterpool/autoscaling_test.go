@@ -0,0 +1,224 @@
+package clusterpool
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/apis/hive/v1/openstack"
+	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+func TestComputeAutoscalingTargets(t *testing.T) {
+	now := time.Date(2024, 5, 15, 9, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) metav1.Time {
+		return metav1.NewTime(now.Add(-d))
+	}
+	day := 24 * time.Hour
+
+	cases := []struct {
+		name                 string
+		size                 int32
+		runningCount         int32
+		autoscaling          hivev1.ClusterPoolAutoscaling
+		alwaysRunning        bool
+		history              []metav1.Time
+		expectedSize         int32
+		expectedRunningCount int32
+		expectedReason       string
+	}{
+		{
+			name:           "no claims",
+			size:           2,
+			autoscaling:    hivev1.ClusterPoolAutoscaling{MaxSize: 5},
+			expectedSize:   2,
+			expectedReason: autoscalingReasonMinimum,
+		},
+		{
+			name:        "moving average",
+			size:        1,
+			autoscaling: hivev1.ClusterPoolAutoscaling{MaxSize: 5},
+			history: []metav1.Time{
+				ago(2 * time.Hour), ago(50 * time.Minute), ago(30 * time.Minute), ago(time.Minute),
+			},
+			expectedSize:   3,
+			expectedReason: autoscalingReasonPredicted,
+		},
+		{
+			name: "moving average with custom window",
+			size: 1,
+			autoscaling: hivev1.ClusterPoolAutoscaling{
+				MaxSize: 5,
+				Window:  &metav1.Duration{Duration: 3 * time.Hour},
+			},
+			history: []metav1.Time{
+				ago(2 * time.Hour), ago(50 * time.Minute), ago(30 * time.Minute), ago(time.Minute),
+			},
+			expectedSize:   4,
+			expectedReason: autoscalingReasonPredicted,
+		},
+		{
+			name: "moving average counts part of the oldest hour",
+			size: 1,
+			autoscaling: hivev1.ClusterPoolAutoscaling{
+				MaxSize: 5,
+				Window:  &metav1.Duration{Duration: 90 * time.Minute},
+			},
+			history: []metav1.Time{
+				// Half of the hour from 7:00 is in the window
+				ago(100 * time.Minute), ago(100 * time.Minute), ago(100 * time.Minute), ago(100 * time.Minute),
+				ago(30 * time.Minute),
+			},
+			expectedSize:   3,
+			expectedReason: autoscalingReasonPredicted,
+		},
+		{
+			name:        "bounded by max size",
+			size:        1,
+			autoscaling: hivev1.ClusterPoolAutoscaling{MaxSize: 2},
+			history: []metav1.Time{
+				ago(50 * time.Minute), ago(30 * time.Minute), ago(time.Minute),
+			},
+			expectedSize:   2,
+			expectedReason: autoscalingReasonMaximum,
+		},
+		{
+			name:         "running count scaled",
+			size:         1,
+			runningCount: 1,
+			autoscaling:  hivev1.ClusterPoolAutoscaling{MaxSize: 5, MaxRunningCount: pointer.Int32Ptr(2)},
+			history: []metav1.Time{
+				ago(50 * time.Minute), ago(30 * time.Minute), ago(time.Minute),
+			},
+			expectedSize:         3,
+			expectedRunningCount: 2,
+			expectedReason:       autoscalingReasonPredicted,
+		},
+		{
+			name:         "running count not scaled without max",
+			size:         1,
+			runningCount: 1,
+			autoscaling:  hivev1.ClusterPoolAutoscaling{MaxSize: 5},
+			history: []metav1.Time{
+				ago(50 * time.Minute), ago(30 * time.Minute), ago(time.Minute),
+			},
+			expectedSize:         3,
+			expectedRunningCount: 1,
+			expectedReason:       autoscalingReasonPredicted,
+		},
+		{
+			name:          "always running pool",
+			size:          1,
+			runningCount:  1,
+			alwaysRunning: true,
+			autoscaling:   hivev1.ClusterPoolAutoscaling{MaxSize: 5},
+			history: []metav1.Time{
+				ago(50 * time.Minute), ago(30 * time.Minute), ago(time.Minute),
+			},
+			expectedSize:         3,
+			expectedRunningCount: 3,
+			expectedReason:       autoscalingReasonPredicted,
+		},
+		{
+			name: "time of day",
+			autoscaling: hivev1.ClusterPoolAutoscaling{
+				Mode:    hivev1.ClusterPoolAutoscalingTimeOfDay,
+				MaxSize: 10,
+				History: &metav1.Duration{Duration: 2 * day},
+			},
+			history: []metav1.Time{
+				// Yesterday and the day before, in the hour after now's time of day
+				ago(2*day - 10*time.Minute), ago(2*day - 20*time.Minute),
+				ago(day - 10*time.Minute), ago(day - 20*time.Minute), ago(day - 30*time.Minute),
+				// Outside of the upcoming window
+				ago(day + 10*time.Minute), ago(day - 90*time.Minute),
+				// Recent claims are not considered
+				ago(time.Minute),
+			},
+			// (2 + 3) / 2 rounded up
+			expectedSize:   3,
+			expectedReason: autoscalingReasonPredicted,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clp := &hivev1.ClusterPool{
+				Spec: hivev1.ClusterPoolSpec{
+					Size:         tc.size,
+					RunningCount: tc.runningCount,
+					Autoscaling:  &tc.autoscaling,
+				},
+			}
+			if tc.alwaysRunning {
+				clp.Spec.Platform.OpenStack = &openstack.Platform{}
+			}
+			var history []hivev1.ClusterPoolClaimCount
+			for _, created := range tc.history {
+				history = countClaim(history, created.Time)
+			}
+			size, runningCount, reason, message := computeAutoscalingTargets(clp, history, now)
+			assert.Equal(t, tc.expectedSize, size, "unexpected target size")
+			assert.Equal(t, tc.expectedRunningCount, runningCount, "unexpected target running count")
+			assert.Equal(t, tc.expectedReason, reason, "unexpected reason")
+			assert.NotEmpty(t, message, "expected a message")
+		})
+	}
+}
+
+func TestRecordClaimHistory(t *testing.T) {
+	now := time.Date(2024, 5, 15, 9, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) metav1.Time {
+		return metav1.NewTime(now.Add(-d))
+	}
+	claim := func(name string, created metav1.Time) *hivev1.ClusterClaim {
+		return testclaim.FullBuilder(testNamespace, name, scheme.GetScheme()).Build(
+			testclaim.Generic(testgeneric.WithCreationTimestamp(created.Time)),
+		)
+	}
+	claims := &claimCollection{
+		byClaimName: map[string]*hivev1.ClusterClaim{
+			"recorded": claim("recorded", ago(30*time.Minute)),
+			"new1":     claim("new1", ago(5*time.Minute)),
+			"new2":     claim("new2", ago(10*time.Minute)),
+			"expired":  claim("expired", ago(3*time.Hour)),
+		},
+	}
+	history := []hivev1.ClusterPoolClaimCount{
+		{Hour: ago(2 * time.Hour), Count: 2},
+		{Hour: ago(time.Hour), Count: 1},
+	}
+
+	recorded := ago(30 * time.Minute)
+	actual, lastClaim := recordClaimHistory(history, &recorded, claims, now.Add(-time.Hour))
+
+	assert.Equal(t, []hivev1.ClusterPoolClaimCount{{Hour: ago(time.Hour), Count: 3}}, actual, "unexpected history")
+	if assert.NotNil(t, lastClaim, "expected a last claim time") {
+		assert.True(t, lastClaim.Time.Equal(now.Add(-5*time.Minute)), "unexpected last claim time: %v", lastClaim)
+	}
+}
+
+func TestRecordClaimHistoryKeepsWindow(t *testing.T) {
+	now := time.Date(2024, 5, 15, 9, 0, 0, 0, time.UTC)
+	claims := &claimCollection{byClaimName: map[string]*hivev1.ClusterClaim{}}
+	for i := 0; i < 1500; i++ {
+		name := fmt.Sprintf("claim-%d", i)
+		claims.byClaimName[name] = testclaim.FullBuilder(testNamespace, name, scheme.GetScheme()).Build(
+			testclaim.Generic(testgeneric.WithCreationTimestamp(now.Add(-time.Duration(i) * time.Second))),
+		)
+	}
+
+	actual, _ := recordClaimHistory(nil, nil, claims, now.Add(-time.Hour))
+
+	// Claims of the last 25 minutes, and one at the start of the current hour
+	assert.Equal(t, []hivev1.ClusterPoolClaimCount{
+		{Hour: metav1.NewTime(now.Add(-time.Hour)), Count: 1499},
+		{Hour: metav1.NewTime(now), Count: 1},
+	}, actual, "expected every claim within the window to be counted per hour")
+}
@@ -0,0 +1,176 @@
+package clusterpool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// sortClaimQueue orders pending claims in the order they should be assigned clusters: highest priority first; then,
+// if the policy specifies a FairShareLabel, round-robin across the consumers identified by that label; then oldest
+// first.
+func sortClaimQueue(pending []*hivev1.ClusterClaim, policy *hivev1.ClusterPoolClaimPolicy) {
+	byAge := func(claims []*hivev1.ClusterClaim, i, j int) bool {
+		if !claims[i].CreationTimestamp.Equal(&claims[j].CreationTimestamp) {
+			return claims[i].CreationTimestamp.Before(&claims[j].CreationTimestamp)
+		}
+		return claims[i].Name < claims[j].Name
+	}
+
+	// rank is the position of each claim among the pending claims of the same priority and consumer. Serving all
+	// rank-0 claims before any rank-1 claims gives each consumer a turn.
+	rank := map[string]int{}
+	if policy != nil && policy.FairShareLabel != "" {
+		sorted := make([]*hivev1.ClusterClaim, len(pending))
+		copy(sorted, pending)
+		sort.Slice(sorted, func(i, j int) bool { return byAge(sorted, i, j) })
+		type consumerKey struct {
+			priority int32
+			consumer string
+		}
+		seen := map[consumerKey]int{}
+		for _, claim := range sorted {
+			key := consumerKey{priority: claim.Spec.Priority, consumer: claim.Labels[policy.FairShareLabel]}
+			rank[claim.Name] = seen[key]
+			seen[key]++
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		if pending[i].Spec.Priority != pending[j].Spec.Priority {
+			return pending[i].Spec.Priority > pending[j].Spec.Priority
+		}
+		if rank[pending[i].Name] != rank[pending[j].Name] {
+			return rank[pending[i].Name] < rank[pending[j].Name]
+		}
+		return byAge(pending, i, j)
+	})
+}
+
+// preemptClaims deletes assigned claims of lower priority to make room for pending claims, if the pool's ClaimPolicy
+// allows it. Before it is deleted, each preempted claim gets a Preempted condition and a Warning event naming the claim
+// that preempted it. This only happens when the pool has no capacity to create clusters for the pending claims. Claims are
+// only preempted once they have held their cluster for the configured GracePeriod, lowest priority first, then those
+// which have held their cluster the longest.
+func (r *ReconcileClusterPool) preemptClaims(clp *hivev1.ClusterPool, claims *claimCollection, cds *cdCollection, availableCapacity int, logger log.FieldLogger) error {
+	if clp.Spec.ClaimPolicy == nil || clp.Spec.ClaimPolicy.Preemption == nil || availableCapacity > 0 {
+		return nil
+	}
+
+	// Pending claims at the front of the queue will be served by the pool's unassigned clusters, or by the capacity
+	// freed up by clusters already on their way out, including those of claims we have already preempted. Only the
+	// remainder may preempt.
+	supply := len(cds.Unassigned(false)) + len(cds.Deleting()) + len(cds.MarkedForDeletion()) + numReleasing(claims, cds)
+	pending := claims.Unassigned()
+	if len(pending) <= supply {
+		return nil
+	}
+
+	victims := preemptionCandidates(claims, cds, clp.Spec.ClaimPolicy.Preemption.GracePeriod.Duration, time.Now())
+	var errs []error
+	// The contenders are sorted by descending priority, and the victims by ascending priority, so we can stop at
+	// the first contender that doesn't outrank the next victim.
+	for _, contender := range pending[supply:] {
+		if len(victims) == 0 || victims[0].Spec.Priority >= contender.Spec.Priority {
+			break
+		}
+		victim := victims[0]
+		victims = victims[1:]
+		logger := logger.WithFields(log.Fields{
+			"claim":           victim.Name,
+			"claimPriority":   victim.Spec.Priority,
+			"preemptedBy":     contender.Name,
+			"pendingPriority": contender.Spec.Priority,
+		})
+		logger.Info("preempting claim for higher priority claim")
+		msg := fmt.Sprintf("Preempted by ClusterClaim %s of higher priority %d", contender.Name, contender.Spec.Priority)
+		victim.Status.Conditions = controllerutils.SetClusterClaimCondition(
+			victim.Status.Conditions,
+			hivev1.ClusterClaimPreemptedCondition,
+			corev1.ConditionTrue,
+			"Preempted",
+			msg,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if err := r.Status().Update(context.Background(), victim); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of preempted claim")
+			errs = append(errs, err)
+			continue
+		}
+		r.eventRecorder.Event(victim, corev1.EventTypeWarning, "Preempted", msg)
+		if err := r.Delete(context.Background(), victim); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error deleting preempted claim")
+			errs = append(errs, err)
+			continue
+		}
+		metricClaimsPreempted.WithLabelValues(clp.Namespace, clp.Name).Inc()
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// numReleasing returns the number of clusters assigned to claims that are being deleted, such as those we preempted,
+// which have not yet been released. The clusterclaim controller marks such a cluster for removal when it cleans up
+// after the claim; until then it is counted neither as deleting nor as marked for deletion.
+func numReleasing(claims *claimCollection, cds *cdCollection) int {
+	n := 0
+	for _, claim := range claims.byCDName {
+		if claim.DeletionTimestamp == nil {
+			continue
+		}
+		cd := cds.byClaimName[claim.Name]
+		if cd == nil || cd.DeletionTimestamp != nil || controllerutils.IsClusterMarkedForRemoval(cd) {
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// preemptionCandidates returns the assigned claims which have held their clusters for at least the grace period. Claims
+// we preempted but failed to delete come first, so that they are deleted rather than another claim preempted in their
+// place; the rest are sorted by ascending priority, then by the time their clusters were claimed, oldest first.
+func preemptionCandidates(claims *claimCollection, cds *cdCollection, gracePeriod time.Duration, now time.Time) []*hivev1.ClusterClaim {
+	claimedAt := map[string]time.Time{}
+	preempted := map[string]bool{}
+	var candidates []*hivev1.ClusterClaim
+	for _, claim := range claims.byCDName {
+		if claim.DeletionTimestamp != nil {
+			continue
+		}
+		cd := cds.byClaimName[claim.Name]
+		if cd == nil || cd.DeletionTimestamp != nil || cd.Spec.ClusterPoolRef.ClaimedTimestamp == nil {
+			continue
+		}
+		claimed := cd.Spec.ClusterPoolRef.ClaimedTimestamp.Time
+		if now.Sub(claimed) < gracePeriod {
+			continue
+		}
+		claimedAt[claim.Name] = claimed
+		if cond := controllerutils.FindCondition(claim.Status.Conditions, hivev1.ClusterClaimPreemptedCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+			preempted[claim.Name] = true
+		}
+		candidates = append(candidates, claim)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if preempted[candidates[i].Name] != preempted[candidates[j].Name] {
+			return preempted[candidates[i].Name]
+		}
+		if candidates[i].Spec.Priority != candidates[j].Spec.Priority {
+			return candidates[i].Spec.Priority < candidates[j].Spec.Priority
+		}
+		if !claimedAt[candidates[i].Name].Equal(claimedAt[candidates[j].Name]) {
+			return claimedAt[candidates[i].Name].Before(claimedAt[candidates[j].Name])
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates
+}
@@ -0,0 +1,88 @@
+package clusterpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+func TestSortClaimQueue(t *testing.T) {
+	now := time.Now()
+	claim := func(name string, age time.Duration, priority int32, consumer string) *hivev1.ClusterClaim {
+		opts := []testclaim.Option{
+			testclaim.WithPriority(priority),
+			testclaim.Generic(testgeneric.WithCreationTimestamp(now.Add(-age))),
+		}
+		if consumer != "" {
+			opts = append(opts, testclaim.Generic(testgeneric.WithLabel("team", consumer)))
+		}
+		return testclaim.FullBuilder(testNamespace, name, scheme.GetScheme()).Build(opts...)
+	}
+
+	cases := []struct {
+		name          string
+		claims        []*hivev1.ClusterClaim
+		policy        *hivev1.ClusterPoolClaimPolicy
+		expectedOrder []string
+	}{
+		{
+			name: "oldest first",
+			claims: []*hivev1.ClusterClaim{
+				claim("c1", time.Minute, 0, ""),
+				claim("c2", time.Hour, 0, ""),
+				claim("c3", time.Second, 0, ""),
+			},
+			expectedOrder: []string{"c2", "c1", "c3"},
+		},
+		{
+			name: "priority before age",
+			claims: []*hivev1.ClusterClaim{
+				claim("c1", time.Minute, 0, ""),
+				claim("c2", time.Hour, 0, ""),
+				claim("c3", time.Second, 5, ""),
+				claim("c4", time.Second, -1, ""),
+			},
+			expectedOrder: []string{"c3", "c2", "c1", "c4"},
+		},
+		{
+			name: "fair share label ignored without policy",
+			claims: []*hivev1.ClusterClaim{
+				claim("ci1", 4*time.Minute, 0, "ci"),
+				claim("ci2", 3*time.Minute, 0, "ci"),
+				claim("ci3", 2*time.Minute, 0, "ci"),
+				claim("release1", time.Minute, 0, "release"),
+			},
+			expectedOrder: []string{"ci1", "ci2", "ci3", "release1"},
+		},
+		{
+			name: "fair share",
+			claims: []*hivev1.ClusterClaim{
+				claim("ci1", 5*time.Minute, 0, "ci"),
+				claim("ci2", 4*time.Minute, 0, "ci"),
+				claim("ci3", 3*time.Minute, 0, "ci"),
+				claim("release1", 2*time.Minute, 0, "release"),
+				claim("release2", 90*time.Second, 0, "release"),
+				claim("other", time.Minute, 0, ""),
+				claim("urgent", time.Second, 1, "ci"),
+			},
+			policy:        &hivev1.ClusterPoolClaimPolicy{FairShareLabel: "team"},
+			expectedOrder: []string{"urgent", "ci1", "release1", "other", "ci2", "release2", "ci3"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sortClaimQueue(tc.claims, tc.policy)
+			actualOrder := make([]string, len(tc.claims))
+			for i, claim := range tc.claims {
+				actualOrder[i] = claim.Name
+			}
+			assert.Equal(t, tc.expectedOrder, actualOrder, "unexpected queue order")
+		})
+	}
+}
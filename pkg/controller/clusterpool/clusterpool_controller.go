@@ -2,14 +2,13 @@ package clusterpool
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
-	yamlpatch "github.com/krishicks/yaml-patch"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -21,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -58,6 +58,7 @@ var (
 		hivev1.ClusterPoolCapacityAvailableCondition,
 		hivev1.ClusterPoolAllClustersCurrentCondition,
 		hivev1.ClusterPoolInventoryValidCondition,
+		hivev1.ClusterPoolInventoryAvailableCondition,
 		hivev1.ClusterPoolDeletionPossibleCondition,
 	}
 )
@@ -82,9 +83,10 @@ func Add(mgr manager.Manager) error {
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterPool {
 	logger := log.WithField("controller", ControllerName)
 	return &ReconcileClusterPool{
-		Client:       controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
-		logger:       logger,
-		expectations: controllerutils.NewExpectations(logger),
+		Client:        controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:        logger,
+		expectations:  controllerutils.NewExpectations(logger),
+		eventRecorder: mgr.GetEventRecorderFor(ControllerName.String()),
 	}
 }
 
@@ -147,7 +149,7 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterPool, concurrentReconc
 	enqueuePoolForClaim := handler.EnqueueRequestsFromMapFunc(
 		func(ctx context.Context, o client.Object) []reconcile.Request {
 			claim, ok := o.(*hivev1.ClusterClaim)
-			if !ok {
+			if !ok || claim.Spec.ClusterPoolName == "" {
 				return nil
 			}
 			return []reconcile.Request{{
@@ -180,28 +182,33 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterPool, concurrentReconc
 		return err
 	}
 
-	// Watch for changes to ClusterDeploymentCustomizations
+	// Watch for changes to the ClusterImageSets of pools rolling over to new releases
 	if err := c.Watch(
-		source.Kind(mgr.GetCache(), &hivev1.ClusterDeploymentCustomization{}),
-		handler.EnqueueRequestsFromMapFunc(
-			requestsForCDCResources(r.Client, r.logger)),
+		source.Kind(mgr.GetCache(), &hivev1.ClusterImageSet{}),
+		handler.EnqueueRequestsFromMapFunc(requestsForClusterImageSet(r.Client, r.logger)),
 	); err != nil {
 		return err
 	}
 
+	// Watch for changes to the resources which may be listed in ClusterPool Inventories
+	for kind, ik := range inventoryKinds {
+		if err := c.Watch(
+			source.Kind(mgr.GetCache(), ik.object),
+			handler.EnqueueRequestsFromMapFunc(
+				requestsForInventoryResources(r.Client, kind, r.logger)),
+		); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func requestsForCDCResources(c client.Client, logger log.FieldLogger) handler.MapFunc {
+func requestsForInventoryResources(c client.Client, kind hivev1.InventoryEntryKind, logger log.FieldLogger) handler.MapFunc {
 	return func(ctx context.Context, o client.Object) []reconcile.Request {
-		cdc, ok := o.(*hivev1.ClusterDeploymentCustomization)
-		if !ok {
-			return nil
-		}
-
 		cpList := &hivev1.ClusterPoolList{}
 		if err := c.List(context.Background(), cpList, client.InNamespace(o.GetNamespace())); err != nil {
-			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list cluster pools for CDC resource")
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list cluster pools for inventory resource")
 			return nil
 		}
 
@@ -211,7 +218,7 @@ func requestsForCDCResources(c client.Client, logger log.FieldLogger) handler.Ma
 				continue
 			}
 			for _, entry := range cpl.Spec.Inventory {
-				if entry.Name != cdc.Name {
+				if inventoryEntryKind(entry) != kind || entry.Name != o.GetName() {
 					continue
 				}
 				requests = append(requests, reconcile.Request{
@@ -283,6 +290,8 @@ type ReconcileClusterPool struct {
 	logger log.FieldLogger
 	// A TTLCache of ClusterDeployment creates each ClusterPool expects to see
 	expectations controllerutils.ExpectationsInterface
+	// eventRecorder records events on the claims preempted by the pool
+	eventRecorder record.EventRecorder
 }
 
 // Reconcile reads the state of the ClusterPool, checks if we currently have enough ClusterDeployments waiting, and
@@ -345,6 +354,33 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	}
 
 	poolVersion := calculatePoolVersion(clp)
+	if clp.Spec.ReleaseRollover != nil {
+		releaseImage, err := r.poolReleaseImage(clp, logger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if (clp.Status.BaseReleaseImage == "" && releaseImage != "") || clp.Status.ReleaseImage != releaseImage {
+			logger.WithField("releaseImage", releaseImage).Info("pool release image changed")
+			if clp.Status.BaseReleaseImage == "" {
+				clp.Status.BaseReleaseImage = releaseImage
+			}
+			clp.Status.ReleaseImage = releaseImage
+			if err := r.Status().Update(context.Background(), clp); err != nil {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool release image")
+				return reconcile.Result{}, errors.Wrap(err, "could not update ClusterPool release image")
+			}
+		}
+		poolVersion = rolloverPoolVersion(poolVersion, releaseImage, clp.Status.BaseReleaseImage)
+	} else if clp.Status.BaseReleaseImage != "" {
+		// ReleaseRollover was disabled: its base release image is recorded anew if it is enabled again, as the
+		// clusters created in the meantime have the pool version without the release image.
+		clp.Status.BaseReleaseImage = ""
+		clp.Status.ReleaseImage = ""
+		if err := r.Status().Update(context.Background(), clp); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not clear ClusterPool release image")
+			return reconcile.Result{}, errors.Wrap(err, "could not clear ClusterPool release image")
+		}
+	}
 
 	cds, err := getAllClusterDeploymentsForPool(r.Client, clp, poolVersion, logger)
 	if err != nil {
@@ -356,18 +392,26 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
-	cdcs, err := getAllCustomizationsForPool(r.Client, clp, logger)
+	inventory, err := getInventoryForPool(r.Client, clp, logger)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	claims.SyncClusterDeploymentAssignments(r.Client, cds, logger)
 	cds.SyncClaimAssignments(r.Client, claims, logger)
-	if err := cdcs.SyncClusterDeploymentCustomizationAssignments(r.Client, clp, cds, logger); err != nil {
+	if err := inventory.SyncAssignments(r.Client, clp, cds, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.syncHibernationSchedules(clp, cds, logger); err != nil {
+		logger.WithError(err).Error("error syncing hibernation schedules of unclaimed clusters")
 		return reconcile.Result{}, err
 	}
 
-	if setStatusCounts(clp, cds) {
+	statusChanged := setStatusCounts(clp, cds)
+	if setAutoscalingStatus(clp, claims, time.Now()) {
+		statusChanged = true
+	}
+	if statusChanged {
 		if err := r.Status().Update(context.Background(), clp); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool status")
 			return reconcile.Result{}, errors.Wrap(err, "could not update ClusterPool status")
@@ -386,7 +430,21 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 			}).Info("Cannot add more clusters because no capacity available.")
 		}
 	}
-	if err := r.setAvailableCapacityCondition(clp, availableCapacity > 0, logger); err != nil {
+
+	headroom, err := r.getQuotaHeadroom(clp)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not compute cluster quota headroom")
+		return reconcile.Result{}, err
+	}
+	capacityLimitedByQuota := false
+	if headroom.clusters < availableCapacity {
+		availableCapacity = headroom.clusters
+		capacityLimitedByQuota = true
+		if availableCapacity <= 0 {
+			logger.Info("Cannot add more clusters because it would exceed a ClusterQuota.")
+		}
+	}
+	if err := r.setAvailableCapacityCondition(clp, availableCapacity > 0, capacityLimitedByQuota, logger); err != nil {
 		logger.WithError(err).Error("error setting CapacityAvailable condition")
 		return reconcile.Result{}, err
 	}
@@ -396,6 +454,11 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
+	if err := r.preemptClaims(clp, claims, cds, availableCapacity, logger); err != nil {
+		logger.WithError(err).Error("error preempting claims")
+		return reconcile.Result{}, err
+	}
+
 	availableCurrent := math.MaxInt32
 	if clp.Spec.MaxConcurrent != nil {
 		availableCurrent = int(*clp.Spec.MaxConcurrent) - len(cds.Installing()) - len(cds.Deleting())
@@ -417,10 +480,24 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	}
 	availableCurrent -= toDel
 
+	// With autoscaling, the pool's Size and RunningCount are the floors for the computed targets.
+	size, runningCount := effectivePoolSize(clp)
+	if size > headroom.size {
+		logger.WithFields(log.Fields{
+			"Size":    size,
+			"Allowed": headroom.size,
+		}).Info("Limiting pool size to stay within ClusterQuota.")
+		size = headroom.size
+	}
+	// Clusters we resume may not push the running clusters over quota. Those already running are grandfathered.
+	maxRunning := numRunning(cds.Unassigned(false)) + headroom.running
+
 	// drift will indicate how many clusters we need to add or delete to get back to steady state
 	// of the pool's Size. This needs to take into account the clusters we're creating to satisfy
 	// the immediate demand of pending claims.
-	switch drift := len(cds.Unassigned(true)) - int(clp.Spec.Size) - len(claims.Unassigned()); {
+	// How long until the next batch of stale clusters may be rolled over, if a batch is waiting.
+	var rolloverRequeue time.Duration
+	switch drift := len(cds.Unassigned(true)) - int(size) - len(claims.Unassigned()); {
 	// activity quota exceeded, so no action
 	case availableCurrent <= 0:
 		logger.WithFields(log.Fields{
@@ -430,10 +507,8 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	// If too few, create new InstallConfig and ClusterDeployment.
 	case drift < 0 && availableCapacity > 0:
 		toAdd := minIntVarible(-drift, availableCapacity, availableCurrent)
-		if clp.Spec.Inventory != nil {
-			toAdd = minIntVarible(toAdd, len(cdcs.Unassigned()))
-		}
-		if err := r.addClusters(clp, poolVersion, cds, toAdd, cdcs, logger); err != nil {
+		toAdd = minIntVarible(toAdd, inventory.Available())
+		if err := r.addClusters(clp, poolVersion, cds, toAdd, inventory, logger); err != nil {
 			log.WithError(err).Error("error adding clusters")
 			return reconcile.Result{}, err
 		}
@@ -450,6 +525,13 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		if err := r.deleteExcessClusters(cds, toDel, logger); err != nil {
 			return reconcile.Result{}, err
 		}
+	// Pools rolling over to a new release delete stale CDs in batches once all CDs are installed.
+	case drift == 0 && len(cds.Installing()) == 0 && len(cds.Stale()) > 0 && clp.Spec.ReleaseRollover != nil:
+		wait, err := r.rollOverStaleClusters(clp, cds, availableCurrent, logger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		rolloverRequeue = wait
 	// Special case for stale CDs: allow deleting one if all CDs are installed.
 	case drift == 0 && len(cds.Installing()) == 0 && len(cds.Stale()) > 0:
 		toDelete := cds.Stale()[0]
@@ -462,7 +544,7 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		metricStaleClusterDeploymentsDeleted.WithLabelValues(clp.Namespace, clp.Name).Inc()
 	}
 
-	if err := r.reconcileRunningClusters(clp, cds, len(claims.Unassigned()), logger); err != nil {
+	if err := r.reconcileRunningClusters(cds, int(runningCount), len(claims.Unassigned()), maxRunning, logger); err != nil {
 		log.WithError(err).Error("error updating hibernating/running state")
 		return reconcile.Result{}, err
 	}
@@ -480,23 +562,27 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, nil
+	if clp.Spec.Autoscaling != nil && (rolloverRequeue == 0 || autoscalingResyncInterval < rolloverRequeue) {
+		return reconcile.Result{RequeueAfter: autoscalingResyncInterval}, nil
+	}
+	return reconcile.Result{RequeueAfter: rolloverRequeue}, nil
 }
 
 // reconcileRunningClusters ensures the oldest unassigned clusters are set to running, and the
 // remainder are set to hibernating. The number of clusters we set to running is determined by
-// adding the cluster's configured runningCount to the number of unsatisfied claims for which we're
-// spinning up new clusters.
+// adding the pool's (possibly autoscaled) runningCount to the number of unsatisfied claims for which
+// we're spinning up new clusters, up to maxRunning.
 func (r *ReconcileClusterPool) reconcileRunningClusters(
-	clp *hivev1.ClusterPool,
 	cds *cdCollection,
+	poolRunningCount int,
 	extraRunning int,
+	maxRunning int,
 	logger log.FieldLogger,
 ) error {
 	// If we're creating excess clusters to satisfy unassigned claims, add that many
 	// to the runningCount. They'll get snatched up immediately, bringing the number
 	// of running clusters back down to runningCount once the pool reaches steady state.
-	runningCount := int(clp.Spec.RunningCount) + extraRunning
+	runningCount := minIntVarible(poolRunningCount+extraRunning, maxRunning)
 	// Exclude broken clusters
 	cdList := cds.Unassigned(false)
 	// Sort by age, oldest first, for FIFO purposes. Include secondary sort by namespace/name as
@@ -672,7 +758,7 @@ func (r *ReconcileClusterPool) addClusters(
 	poolVersion string,
 	cds *cdCollection,
 	newClusterCount int,
-	cdcs *cdcCollection,
+	inventory *poolInventory,
 	logger log.FieldLogger,
 ) error {
 	logger.WithField("count", newClusterCount).Info("Adding new clusters")
@@ -711,7 +797,7 @@ func (r *ReconcileClusterPool) addClusters(
 	}
 
 	for i := 0; i < newClusterCount; i++ {
-		cd, err := r.createCluster(clp, cloudBuilder, pullSecret, installConfigTemplate, poolVersion, cdcs, logger)
+		cd, err := r.createCluster(clp, cloudBuilder, pullSecret, installConfigTemplate, poolVersion, inventory, logger)
 		if err != nil {
 			return err
 		}
@@ -727,7 +813,7 @@ func (r *ReconcileClusterPool) createCluster(
 	pullSecret string,
 	installConfigTemplate string,
 	poolVersion string,
-	cdcs *cdcCollection,
+	inventory *poolInventory,
 	logger log.FieldLogger,
 ) (*hivev1.ClusterDeployment, error) {
 	var err error
@@ -768,6 +854,10 @@ func (r *ReconcileClusterPool) createCluster(
 		builder.HibernateAfter = &clp.Spec.HibernateAfter.Duration
 	}
 
+	if clp.Spec.HibernationConfig != nil {
+		builder.HibernationSchedule = clp.Spec.HibernationConfig.Schedule.DeepCopy()
+	}
+
 	objs, err := builder.Build()
 	if err != nil {
 		return nil, errors.Wrap(err, "error building resources")
@@ -776,32 +866,31 @@ func (r *ReconcileClusterPool) createCluster(
 	poolKey := types.NamespacedName{Namespace: clp.Namespace, Name: clp.Name}.String()
 	r.expectations.ExpectCreations(poolKey, 1)
 	var cd *hivev1.ClusterDeployment
-	var secret *corev1.Secret
-	var cdPos int
 	// Add the ClusterPoolRef to the ClusterDeployment, and move it to the end of the slice.
-	for i, obj := range objs {
+	for _, obj := range objs {
 		if cdTmp, ok := obj.(*hivev1.ClusterDeployment); ok {
 			cd = cdTmp
-			cdPos = i
 			poolRef := poolReference(clp)
 			cd.Spec.ClusterPoolRef = &poolRef
-			if clp.Spec.Inventory != nil {
-				cdc := cdcs.unassigned[0]
-				if cdcs.reserved[cdc.Name] != nil || cdc.Status.ClusterDeploymentRef != nil || cdc.Status.ClusterPoolRef != nil {
-					return nil, errors.Errorf("ClusterDeploymentCustomization %s is already reserved", cdc.Name)
-				}
-				cd.Spec.ClusterPoolRef.CustomizationRef = &corev1.LocalObjectReference{Name: cdc.Name}
-			}
-		} else if secretTmp := isInstallConfigSecret(obj); secretTmp != nil {
-			secret = secretTmp
 		}
 	}
 
-	if err := r.patchInstallConfig(clp, cd, secret, cdcs, logger); err != nil {
+	// Reserve the inventory entries for the cluster
+	objs, err = inventory.Apply(r.Client, clp, cd, objs, logger)
+	if err != nil {
+		if err := inventory.UpdateConditions(r.Client, clp); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update inventory conditions")
+		}
 		return nil, err
 	}
 
 	// Move the ClusterDeployment to the end of the slice
+	var cdPos int
+	for i, obj := range objs {
+		if _, ok := obj.(*hivev1.ClusterDeployment); ok {
+			cdPos = i
+		}
+	}
 	lastIndex := len(objs) - 1
 	objs[cdPos], objs[lastIndex] = objs[lastIndex], objs[cdPos]
 	// Create the resources.
@@ -825,56 +914,28 @@ func isInstallConfigSecret(obj interface{}) *corev1.Secret {
 	return nil
 }
 
-// patchInstallConfig responsible for applying ClusterDeploymentCustomization and its reservation
-func (r *ReconcileClusterPool) patchInstallConfig(clp *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, secret *corev1.Secret, cdcs *cdcCollection, logger log.FieldLogger) error {
-	if clp.Spec.Inventory == nil {
-		return nil
-	}
-	if cd.Spec.ClusterPoolRef.CustomizationRef == nil {
-		return errors.New("missing customization")
+// syncHibernationSchedules makes the HibernationSchedule of the pool's unclaimed ClusterDeployments match the
+// pool's HibernationConfig.Schedule, so that clusters created before the schedule was changed are claimed with the
+// current schedule. Claimed clusters keep the schedule they were claimed with.
+func (r *ReconcileClusterPool) syncHibernationSchedules(clp *hivev1.ClusterPool, cds *cdCollection, logger log.FieldLogger) error {
+	var schedule *hivev1.HibernationSchedule
+	if clp.Spec.HibernationConfig != nil {
+		schedule = clp.Spec.HibernationConfig.Schedule
 	}
-
-	cdc := &hivev1.ClusterDeploymentCustomization{}
-	if err := r.Client.Get(context.Background(), client.ObjectKey{Namespace: clp.Namespace, Name: cd.Spec.ClusterPoolRef.CustomizationRef.Name}, cdc); err != nil {
-		if apierrors.IsNotFound(err) {
-			return errors.New("missing customization")
+	var errs []error
+	for _, cd := range cds.Unassigned(true) {
+		if reflect.DeepEqual(cd.Spec.HibernationSchedule, schedule) {
+			continue
+		}
+		cdLog := logger.WithField("cluster", cd.Name)
+		cdLog.Info("updating hibernation schedule of unclaimed cluster")
+		cd.Spec.HibernationSchedule = schedule.DeepCopy()
+		if err := r.Update(context.Background(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update hibernation schedule of ClusterDeployment")
+			errs = append(errs, err)
 		}
-		return err
-	}
-
-	newPatch := yamlpatch.Patch{}
-	for _, patch := range cdc.Spec.InstallConfigPatches {
-		var value interface{} = patch.Value
-		newPatch = append(newPatch, yamlpatch.Operation{
-			Op:    yamlpatch.Op(patch.Op),
-			Path:  yamlpatch.OpPath(patch.Path),
-			From:  yamlpatch.OpPath(patch.From),
-			Value: yamlpatch.NewNode(&value),
-		})
-	}
-
-	installConfig, err := newPatch.Apply([]byte(secret.StringData["install-config.yaml"]))
-	if err != nil {
-		cdcs.BrokenBySyntax(r, cdc, fmt.Sprint(err))
-		cdcs.UpdateInventoryValidCondition(r, clp)
-		return err
-	}
-
-	configJson, err := json.Marshal(cdc.Spec)
-	if err != nil {
-		return err
-	}
-
-	if err := cdcs.Reserve(r, cdc, cd.Name, clp.Name); err != nil {
-		return err
-	}
-	if err := cdcs.InstallationPending(r, cdc); err != nil {
-		return err
 	}
-
-	cdc.Status.LastAppliedConfiguration = string(configJson)
-	secret.StringData["install-config.yaml"] = string(installConfig)
-	return nil
+	return utilerrors.NewAggregate(errs)
 }
 
 func (r *ReconcileClusterPool) createRandomNamespace(clp *hivev1.ClusterPool) (*corev1.Namespace, error) {
@@ -972,12 +1033,12 @@ func (r *ReconcileClusterPool) reconcileDeletedPool(pool *hivev1.ClusterPool, lo
 		return nil
 	}
 
-	cdcs, err := getAllCustomizationsForPool(r.Client, pool, logger)
+	inventory, err := getInventoryForPool(r.Client, pool, logger)
 	if err != nil {
 		return err
 	}
 
-	if err := cdcs.RemoveFinalizer(r.Client, pool); err != nil {
+	if err := inventory.RemoveFinalizer(r.Client, pool); err != nil {
 		return err
 	}
 
@@ -1097,12 +1158,18 @@ func (r *ReconcileClusterPool) setMissingDependenciesCondition(pool *hivev1.Clus
 	return nil
 }
 
-func (r *ReconcileClusterPool) setAvailableCapacityCondition(pool *hivev1.ClusterPool, available bool, logger log.FieldLogger) error {
+func (r *ReconcileClusterPool) setAvailableCapacityCondition(pool *hivev1.ClusterPool, available, limitedByQuota bool, logger log.FieldLogger) error {
 	status := corev1.ConditionTrue
 	reason := "Available"
 	message := "There is capacity to add more clusters to the pool."
 	updateConditionCheck := controllerutils.UpdateConditionNever
-	if !available {
+	switch {
+	case !available && limitedByQuota:
+		status = corev1.ConditionFalse
+		reason = "ClusterQuotaExceeded"
+		message = "Adding clusters to the pool would exceed a ClusterQuota."
+		updateConditionCheck = controllerutils.UpdateConditionIfReasonOrMessageChange
+	case !available:
 		status = corev1.ConditionFalse
 		reason = "MaxCapacity"
 		message = fmt.Sprintf("Pool is at maximum capacity of %d waiting and claimed clusters.", *pool.Spec.MaxSize)
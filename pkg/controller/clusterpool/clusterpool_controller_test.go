@@ -20,6 +20,8 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -81,6 +83,10 @@ func TestReconcileClusterPool(t *testing.T) {
 			Status: corev1.ConditionUnknown,
 			Type:   hivev1.ClusterPoolInventoryValidCondition,
 		}),
+		testcp.WithCondition(hivev1.ClusterPoolCondition{
+			Status: corev1.ConditionUnknown,
+			Type:   hivev1.ClusterPoolInventoryAvailableCondition,
+		}),
 		testcp.WithCondition(hivev1.ClusterPoolCondition{
 			Status: corev1.ConditionUnknown,
 			Type:   hivev1.ClusterPoolDeletionPossibleCondition,
@@ -111,6 +117,29 @@ func TestReconcileClusterPool(t *testing.T) {
 
 	nowish := time.Now()
 
+	poolSchedule := &hivev1.HibernationSchedule{
+		RunWindows: []hivev1.HibernationRunWindow{{Start: "08:00", End: "19:00"}},
+	}
+	oldSchedule := &hivev1.HibernationSchedule{
+		RunWindows: []hivev1.HibernationRunWindow{{Start: "09:00", End: "17:00"}},
+	}
+
+	// withClusterQuota returns objs plus a ClusterQuota with the given limits selecting the test namespace.
+	withClusterQuota := func(hard hivev1.ClusterQuotaLimits, objs ...runtime.Object) []runtime.Object {
+		return append(objs,
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: testNamespace, Labels: map[string]string{"tenant": "test"}},
+			},
+			&hivev1.ClusterQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-quota"},
+				Spec: hivev1.ClusterQuotaSpec{
+					NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "test"}},
+					Hard:              hard,
+				},
+			},
+		)
+	}
+
 	tests := []struct {
 		name                               string
 		existing                           []runtime.Object
@@ -128,6 +157,7 @@ func TestReconcileClusterPool(t *testing.T) {
 		expectedCDCurrentStatus            corev1.ConditionStatus
 		expectedInventoryValidStatus       corev1.ConditionStatus
 		expectedInventoryMessage           map[string][]string
+		expectedInventoryAvailableStatus   corev1.ConditionStatus
 		expectedDeletionPossibleCondition  *hivev1.ClusterPoolCondition
 		expectedCDCFinalizers              map[string][]string
 		expectedCDCReason                  map[string]string
@@ -139,12 +169,24 @@ func TestReconcileClusterPool(t *testing.T) {
 		expectedAssignedCDCs               map[string]string
 		expectedRunning                    int
 		expectedLabels                     map[string]string // Tested on all clusters, so will not work if your test has pre-existing cds in the pool.
+		// Map, keyed by ClusterDeployment name, of expected Spec.HibernationSchedule. Not checked if nil.
+		expectedHibernationSchedules map[string]*hivev1.HibernationSchedule
 		// Map, keyed by claim name, of expected Status.Conditions['Pending'].Reason.
 		// (The clusterpool controller always sets this condition's Status to True.)
 		// Not checked if nil.
-		expectedClaimPendingReasons      map[string]string
+		expectedClaimPendingReasons map[string]string
+		// Map, keyed by claim name, of expected Status.QueuePosition. Not checked if nil.
+		expectedClaimQueuePositions map[string]int32
+		// Map, keyed by claim name, of expected Message of the Pending condition. Not checked if nil.
+		expectedClaimPendingMessages     map[string]string
+		expectedEvents                   []string
 		expectedInventoryAssignmentOrder []string
-		expectPoolVersionChanged         bool
+		// reconciles is the number of times each pool is reconciled. Defaults to 1.
+		reconciles               int
+		expectPoolVersionChanged bool
+		// Checked only if the pool has Spec.Autoscaling set.
+		expectedAutoscalingTargetSize         int32
+		expectedAutoscalingTargetRunningCount int32
 	}{
 		{
 			name: "initialize conditions",
@@ -328,6 +370,57 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedPoolVersion:          inventoryPoolVersion,
 			expectError:                  false,
 		},
+		{
+			name: "cp with secret inventory limited by available entries",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(3),
+					testcp.WithInventoryEntries(hivev1.SecretInventoryEntry, "test-creds-1", "test-creds-2"),
+				),
+				testsecret.FullBuilder(testNamespace, "test-creds-1", scheme).Build(),
+				testsecret.FullBuilder(testNamespace, "test-creds-2", scheme).Build(),
+			},
+			expectedTotalClusters:            2,
+			expectedInventoryValidStatus:     corev1.ConditionTrue,
+			expectedInventoryAvailableStatus: corev1.ConditionTrue,
+			expectedPoolVersion:              inventoryPoolVersion,
+		},
+		{
+			name: "cp with secret inventory exhausted",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(2),
+					testcp.WithInventoryEntries(hivev1.SecretInventoryEntry, "test-creds-1"),
+				),
+				testsecret.FullBuilder(testNamespace, "test-creds-1", scheme).GenericOptions(
+					testgeneric.WithAnnotation(constants.InventoryReservedByClusterDeploymentAnnotation, "c1"),
+					testgeneric.WithAnnotation(constants.InventoryReservedByClusterPoolAnnotation, testLeasePoolName),
+				).Build(),
+				unclaimedCDBuilder("c1").Build(
+					testcd.WithPoolVersion(inventoryPoolVersion),
+					testcd.WithInventoryRef(hivev1.SecretInventoryEntry, "test-creds-1"),
+				),
+			},
+			expectedTotalClusters:            1,
+			expectedObservedSize:             1,
+			expectedInventoryValidStatus:     corev1.ConditionTrue,
+			expectedInventoryAvailableStatus: corev1.ConditionFalse,
+			expectedPoolVersion:              inventoryPoolVersion,
+		},
+		{
+			name: "cp with secret inventory and secret doesn't exist is not valid - missing",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithInventoryEntries(hivev1.SecretInventoryEntry, "test-creds-1"),
+				),
+			},
+			expectedTotalClusters:            0,
+			expectedInventoryValidStatus:     corev1.ConditionFalse,
+			expectedInventoryMessage:         map[string][]string{"Missing": {"Secret/test-creds-1"}},
+			expectedInventoryAvailableStatus: corev1.ConditionFalse,
+			expectedPoolVersion:              inventoryPoolVersion,
+		},
 		{
 			name: "cp with inventory - fix cdc reservation",
 			existing: []runtime.Object{
@@ -811,6 +904,32 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedReady: 0,
 			expectedLabels:        map[string]string{"foo": "bar"},
 		},
+		{
+			name: "sync hibernation schedule to unclaimed clusters",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithHibernationSchedule(poolSchedule)),
+				unclaimedCDBuilder("c1").Build(testcd.Running(), testcd.WithHibernationSchedule(oldSchedule)),
+				cdBuilder("c2").Build(
+					testcd.Running(),
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test-claim"),
+					testcd.WithHibernationSchedule(oldSchedule),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithCluster("c2"),
+				),
+			},
+			expectedTotalClusters:  2,
+			expectedObservedSize:   1,
+			expectedObservedReady:  1,
+			expectedAssignedCDs:    1,
+			expectedAssignedClaims: 1,
+			expectedRunning:        1,
+			expectedHibernationSchedules: map[string]*hivev1.HibernationSchedule{
+				"c1": poolSchedule,
+				"c2": oldSchedule,
+			},
+		},
 		{
 			name: "scale up",
 			existing: []runtime.Object{
@@ -1464,6 +1583,179 @@ func TestReconcileClusterPool(t *testing.T) {
 				"test-claim-2": "ClusterAssigned",
 				"test-claim-3": "NoClusters",
 			},
+			expectedClaimQueuePositions: map[string]int32{
+				"test-claim-1": 0,
+				"test-claim-2": 0,
+				"test-claim-3": 1,
+			},
+			expectedClaimPendingMessages: map[string]string{
+				"test-claim-3": "No clusters in pool are ready to be claimed",
+			},
+		},
+		{
+			name: "assign to higher priority claim first",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Running()),
+				testclaim.FullBuilder(testNamespace, "low", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Hour))),
+				),
+				testclaim.FullBuilder(testNamespace, "high", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithPriority(10),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish)),
+				),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     1,
+			expectedObservedReady:    1,
+			expectedAssignedClaims:   1,
+			expectedAssignedCDs:      1,
+			expectedRunning:          2,
+			expectedUnassignedClaims: 1,
+			expectedClaimPendingReasons: map[string]string{
+				"high": "ClusterAssigned",
+				"low":  "NoClusters",
+			},
+			expectedClaimQueuePositions: map[string]int32{
+				"high": 0,
+				"low":  1,
+			},
+		},
+		{
+			name: "pending claims report their position",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(0)),
+				testclaim.FullBuilder(testNamespace, "low", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Hour))),
+				),
+				testclaim.FullBuilder(testNamespace, "high", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithPriority(10),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish)),
+				),
+			},
+			expectedTotalClusters:    2,
+			expectedRunning:          2,
+			expectedUnassignedClaims: 2,
+			expectedClaimQueuePositions: map[string]int32{
+				"high": 1,
+				"low":  2,
+			},
+			// The position is not part of the message, which stays the same as the queue moves.
+			expectedClaimPendingMessages: map[string]string{
+				"high": "No clusters in pool are ready to be claimed",
+				"low":  "No clusters in pool are ready to be claimed",
+			},
+		},
+		{
+			name: "preempt lower priority claim at capacity",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(0),
+					testcp.WithMaxSize(1),
+					testcp.WithClaimPolicy(&hivev1.ClusterPoolClaimPolicy{
+						Preemption: &hivev1.ClusterClaimPreemption{},
+					}),
+				),
+				cdBuilder("c1").Build(
+					testcd.Running(),
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "low"),
+				),
+				testclaim.FullBuilder(testNamespace, "low", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithCluster("c1"),
+				),
+				testclaim.FullBuilder(testNamespace, "high", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithPriority(10),
+				),
+			},
+			expectedCapacityStatus: corev1.ConditionFalse,
+			expectedTotalClusters:  1,
+			expectedAssignedCDs:    1,
+			expectedRunning:        1,
+			// The low priority claim was deleted
+			expectedUnassignedClaims: 1,
+			expectedEvents:           []string{"Warning Preempted Preempted by ClusterClaim high of higher priority 10"},
+		},
+		{
+			name: "preempt only one claim for a pending claim until its cluster is released",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(0),
+					testcp.WithMaxSize(2),
+					testcp.WithClaimPolicy(&hivev1.ClusterPoolClaimPolicy{
+						Preemption: &hivev1.ClusterClaimPreemption{},
+					}),
+				),
+				cdBuilder("c1").Build(
+					testcd.Running(),
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "low1"),
+				),
+				cdBuilder("c2").Build(
+					testcd.Running(),
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "low2"),
+				),
+				// The finalizers keep the preempted claim around, as the clusterclaim controller would until it has
+				// released its cluster.
+				testclaim.FullBuilder(testNamespace, "low1", scheme).GenericOptions(
+					testgeneric.WithFinalizer(testFinalizer),
+				).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithCluster("c1"),
+				),
+				testclaim.FullBuilder(testNamespace, "low2", scheme).GenericOptions(
+					testgeneric.WithFinalizer(testFinalizer),
+				).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithCluster("c2"),
+				),
+				testclaim.FullBuilder(testNamespace, "high", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithPriority(10),
+				),
+			},
+			reconciles:               2,
+			expectedCapacityStatus:   corev1.ConditionFalse,
+			expectedTotalClusters:    2,
+			expectedAssignedCDs:      2,
+			expectedRunning:          2,
+			expectedAssignedClaims:   2,
+			expectedUnassignedClaims: 1,
+			expectedEvents:           []string{"Warning Preempted Preempted by ClusterClaim high of higher priority 10"},
+		},
+		{
+			name: "do not preempt claim within grace period",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(0),
+					testcp.WithMaxSize(1),
+					testcp.WithClaimPolicy(&hivev1.ClusterPoolClaimPolicy{
+						Preemption: &hivev1.ClusterClaimPreemption{GracePeriod: metav1.Duration{Duration: time.Hour}},
+					}),
+				),
+				cdBuilder("c1").Build(
+					testcd.Running(),
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "low"),
+				),
+				testclaim.FullBuilder(testNamespace, "low", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithCluster("c1"),
+				),
+				testclaim.FullBuilder(testNamespace, "high", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithPriority(10),
+				),
+			},
+			expectedCapacityStatus:   corev1.ConditionFalse,
+			expectedTotalClusters:    1,
+			expectedAssignedCDs:      1,
+			expectedRunning:          1,
+			expectedAssignedClaims:   1,
+			expectedUnassignedClaims: 1,
 		},
 		{
 			name: "do not assign to claims for other pools",
@@ -1958,6 +2250,83 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedAssignedClaims:   2,
 			expectedUnassignedClaims: 3,
 		},
+		{
+			name: "autoscaling grows pool with claim demand",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithAutoscaling(&hivev1.ClusterPoolAutoscaling{
+						MaxSize:         3,
+						MaxRunningCount: pointer.Int32Ptr(1),
+					}),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim1", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-10*time.Minute))),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim2", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-5*time.Minute))),
+				),
+			},
+			// Two claims in the last hour set the target size to 2, plus the two pending claims.
+			expectedTotalClusters:                 4,
+			expectedUnassignedClaims:              2,
+			expectedRunning:                       3,
+			expectedAutoscalingTargetSize:         2,
+			expectedAutoscalingTargetRunningCount: 1,
+		},
+		{
+			name: "scale up limited by cluster quota",
+			existing: withClusterQuota(hivev1.ClusterQuotaLimits{ClusterDeployments: pointer.Int32Ptr(3)},
+				initializedPoolBuilder.Build(testcp.WithSize(5)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(),
+			),
+			expectedTotalClusters:  3,
+			expectedObservedSize:   2,
+			expectedCapacityStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "at cluster quota",
+			existing: withClusterQuota(hivev1.ClusterQuotaLimits{ClusterDeployments: pointer.Int32Ptr(2)},
+				initializedPoolBuilder.Build(testcp.WithSize(5)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(),
+			),
+			expectedTotalClusters:  2,
+			expectedObservedSize:   2,
+			expectedCapacityStatus: corev1.ConditionFalse,
+		},
+		{
+			name: "size limited by cluster quota",
+			existing: withClusterQuota(hivev1.ClusterQuotaLimits{ClusterPoolSize: pointer.Int32Ptr(3)},
+				initializedPoolBuilder.Build(
+					testcp.WithSize(5),
+					testcp.Generic(testgeneric.WithCreationTimestamp(nowish)),
+				),
+				// An older pool in the namespace gets its share of the quota first
+				testcp.FullBuilder(testNamespace, "older-pool", scheme).Build(
+					testcp.WithSize(1),
+					testcp.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Hour))),
+				),
+			),
+			expectedTotalClusters:  2,
+			expectedCapacityStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "running limited by cluster quota",
+			existing: withClusterQuota(hivev1.ClusterQuotaLimits{RunningClusterDeployments: pointer.Int32Ptr(2)},
+				initializedPoolBuilder.Build(testcp.WithSize(3), testcp.WithRunningCount(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Running()),
+				unclaimedCDBuilder("c2").Build(),
+				unclaimedCDBuilder("c3").Build(),
+			),
+			expectedTotalClusters: 3,
+			expectedObservedSize:  3,
+			expectedObservedReady: 1,
+			expectedRunning:       2,
+		},
 	}
 
 	for _, test := range tests {
@@ -1995,11 +2364,14 @@ func TestReconcileClusterPool(t *testing.T) {
 			if test.expectedPools != nil {
 				expectedPools = test.expectedPools
 			}
+			recorder := record.NewFakeRecorder(10)
+
 			for _, poolName := range expectedPools {
 				rcp := &ReconcileClusterPool{
-					Client:       fakeClient,
-					logger:       logger,
-					expectations: controllerExpectations,
+					Client:        fakeClient,
+					logger:        logger,
+					expectations:  controllerExpectations,
+					eventRecorder: recorder,
 				}
 
 				reconcileRequest := reconcile.Request{
@@ -2009,11 +2381,17 @@ func TestReconcileClusterPool(t *testing.T) {
 					},
 				}
 
-				_, err := rcp.Reconcile(context.TODO(), reconcileRequest)
-				if test.expectError {
-					assert.Error(t, err, "expected error from reconcile")
-				} else {
-					assert.NoError(t, err, "expected no error from reconcile")
+				reconciles := test.reconciles
+				if reconciles == 0 {
+					reconciles = 1
+				}
+				for i := 0; i < reconciles; i++ {
+					_, err := rcp.Reconcile(context.TODO(), reconcileRequest)
+					if test.expectError {
+						assert.Error(t, err, "expected error from reconcile")
+					} else {
+						assert.NoError(t, err, "expected no error from reconcile")
+					}
 				}
 			}
 
@@ -2028,6 +2406,12 @@ func TestReconcileClusterPool(t *testing.T) {
 			}
 			assert.Equal(t, test.expectedObservedSize, pool.Status.Size, "unexpected observed size")
 			assert.Equal(t, test.expectedObservedReady, pool.Status.Ready, "unexpected observed ready count")
+			if pool.Spec.Autoscaling != nil {
+				if assert.NotNil(t, pool.Status.Autoscaling, "expected autoscaling status") {
+					assert.Equal(t, test.expectedAutoscalingTargetSize, pool.Status.Autoscaling.TargetSize, "unexpected autoscaling target size")
+					assert.Equal(t, test.expectedAutoscalingTargetRunningCount, pool.Status.Autoscaling.TargetRunningCount, "unexpected autoscaling target running count")
+				}
+			}
 			currentPoolVersion := calculatePoolVersion(pool)
 			assert.Equal(
 				t, test.expectPoolVersionChanged, currentPoolVersion != expectedPoolVersion,
@@ -2070,6 +2454,14 @@ func TestReconcileClusterPool(t *testing.T) {
 				}
 			}
 
+			if test.expectedInventoryAvailableStatus != "" {
+				inventoryAvailableCondition := controllerutils.FindCondition(pool.Status.Conditions, hivev1.ClusterPoolInventoryAvailableCondition)
+				if assert.NotNil(t, inventoryAvailableCondition, "did not find InventoryAvailable condition") {
+					assert.Equal(t, test.expectedInventoryAvailableStatus, inventoryAvailableCondition.Status,
+						"unexpected InventoryAvailable condition status %s", inventoryAvailableCondition.Status)
+				}
+			}
+
 			if test.expectedInventoryMessage != nil {
 				inventoryValidCondition := controllerutils.FindCondition(pool.Status.Conditions, hivev1.ClusterPoolInventoryValidCondition)
 				if assert.NotNil(t, inventoryValidCondition, "did not find InventoryValid condition") {
@@ -2136,6 +2528,14 @@ func TestReconcileClusterPool(t *testing.T) {
 					if len(pool.Spec.InstallerEnv) != 0 {
 						assert.Equal(t, pool.Spec.InstallerEnv, cd.Spec.Provisioning.InstallerEnv, "expected InstallerEnv to match")
 					}
+					if pool.Spec.HibernationConfig != nil && poolRef.ClaimName == "" {
+						assert.Equal(t, pool.Spec.HibernationConfig.Schedule, cd.Spec.HibernationSchedule, "expected HibernationSchedule to match")
+					}
+				}
+				if test.expectedHibernationSchedules != nil {
+					if schedule, ok := test.expectedHibernationSchedules[cd.Name]; ok {
+						assert.Equal(t, schedule, cd.Spec.HibernationSchedule, "unexpected HibernationSchedule for cluster %s", cd.Name)
+					}
 				}
 				switch powerState := cd.Spec.PowerState; powerState {
 				case hivev1.ClusterPowerStateRunning:
@@ -2170,6 +2570,19 @@ func TestReconcileClusterPool(t *testing.T) {
 						}
 					}
 				}
+				if test.expectedClaimPendingMessages != nil {
+					if message, ok := test.expectedClaimPendingMessages[claim.Name]; ok {
+						actualCond := controllerutils.FindCondition(claim.Status.Conditions, hivev1.ClusterClaimPendingCondition)
+						if assert.NotNil(t, actualCond, "did not find Pending condition on claim %s", claim.Name) {
+							assert.Equal(t, message, actualCond.Message, "wrong message on Pending condition for claim %s", claim.Name)
+						}
+					}
+				}
+				if test.expectedClaimQueuePositions != nil {
+					if position, ok := test.expectedClaimQueuePositions[claim.Name]; ok {
+						assert.Equal(t, position, claim.Status.QueuePosition, "wrong queue position for claim %s", claim.Name)
+					}
+				}
 				if claim.Spec.Namespace == "" {
 					actualUnassignedClaims++
 				} else {
@@ -2177,6 +2590,13 @@ func TestReconcileClusterPool(t *testing.T) {
 				}
 			}
 			assert.Equal(t, test.expectedAssignedClaims, actualAssignedClaims, "unexpected number of assigned claims")
+
+			close(recorder.Events)
+			var actualEvents []string
+			for event := range recorder.Events {
+				actualEvents = append(actualEvents, event)
+			}
+			assert.Equal(t, test.expectedEvents, actualEvents, "unexpected events")
 			assert.Equal(t, test.expectedUnassignedClaims, actualUnassignedClaims, "unexpected number of unassigned claims")
 
 			cdcs := &hivev1.ClusterDeploymentCustomizationList{}
@@ -0,0 +1,80 @@
+package clusterpool
+
+import (
+	"math"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// quotaHeadroom is how much more a pool may consume under the ClusterQuotas selecting its namespace.
+type quotaHeadroom struct {
+	// clusters is the number of ClusterDeployments the pool may add.
+	clusters int
+	// running is the number of additional clusters the pool may have running.
+	running int
+	// size is the largest Size the pool may maintain.
+	size int32
+}
+
+// getQuotaHeadroom computes the headroom left for the pool by the ClusterQuotas selecting its namespace. Values are
+// math.MaxInt32 when not limited by any quota.
+func (r *ReconcileClusterPool) getQuotaHeadroom(clp *hivev1.ClusterPool) (*quotaHeadroom, error) {
+	headroom := &quotaHeadroom{
+		clusters: math.MaxInt32,
+		running:  math.MaxInt32,
+		size:     math.MaxInt32,
+	}
+	quotas, err := controllerutils.GetClusterQuotasForNamespace(r.Client, clp.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, quota := range quotas {
+		consumption, err := controllerutils.GetClusterQuotaConsumption(r.Client, quota)
+		if err != nil {
+			return nil, err
+		}
+		hard := quota.Spec.Hard
+		if hard.ClusterDeployments != nil {
+			headroom.clusters = minIntVarible(headroom.clusters, int(*hard.ClusterDeployments-consumption.Used.ClusterDeployments))
+		}
+		if hard.RunningClusterDeployments != nil {
+			headroom.running = minIntVarible(headroom.running, int(*hard.RunningClusterDeployments-consumption.Used.RunningClusterDeployments))
+		}
+		if hard.ClusterPoolSize != nil {
+			// Pools are granted their size in order of creation, so that when a quota is overcommitted (e.g. because it
+			// was lowered after the pools were created) it is consistently the newest pools that are cut back.
+			allowed := *hard.ClusterPoolSize
+			for _, pool := range consumption.Pools {
+				if pool.Namespace == clp.Namespace && pool.Name == clp.Name {
+					break
+				}
+				allowed -= controllerutils.ClusterPoolQuotaSize(pool)
+			}
+			if allowed < headroom.size {
+				headroom.size = allowed
+			}
+		}
+	}
+	if headroom.clusters < 0 {
+		headroom.clusters = 0
+	}
+	if headroom.running < 0 {
+		headroom.running = 0
+	}
+	if headroom.size < 0 {
+		headroom.size = 0
+	}
+	return headroom, nil
+}
+
+// numRunning returns the number of clusters in the list that count as running against ClusterQuotas.
+func numRunning(cds []*hivev1.ClusterDeployment) int {
+	n := 0
+	for _, cd := range cds {
+		if controllerutils.IsRunningForClusterQuota(cd) {
+			n++
+		}
+	}
+	return n
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 type claimCollection struct {
@@ -70,13 +71,8 @@ func getAllClaimsForPool(c client.Client, pool *hivev1.ClusterPool, logger log.F
 			claimCol.byCDName[cdName] = ref
 		}
 	}
-	// Sort assignable claims by creationTimestamp for FIFO behavior.
-	sort.Slice(
-		claimCol.unassigned,
-		func(i, j int) bool {
-			return claimCol.unassigned[i].CreationTimestamp.Before(&claimCol.unassigned[j].CreationTimestamp)
-		},
-	)
+	// Sort assignable claims by priority, then creationTimestamp for FIFO behavior.
+	sortClaimQueue(claimCol.unassigned, pool.Spec.ClaimPolicy)
 
 	logger.WithFields(log.Fields{
 		"assignedCount":   len(claimCol.byCDName),
@@ -91,8 +87,8 @@ func (c *claimCollection) ByName(claimName string) *hivev1.ClusterClaim {
 	return c.byClaimName[claimName]
 }
 
-// Unassigned returns a list of claims that are not assigned to clusters yet. The list is sorted by
-// age, oldest first.
+// Unassigned returns a list of claims that are not assigned to clusters yet. The list is sorted in
+// the order the claims should be served: by priority, then by age, oldest first (see sortClaimQueue).
 func (c *claimCollection) Unassigned() []*hivev1.ClusterClaim {
 	return c.unassigned
 }
@@ -113,6 +109,7 @@ func (claims *claimCollection) Assign(c client.Client, claim *hivev1.ClusterClai
 				return err
 			}
 			// Update the status
+			claimi.Status.QueuePosition = 0
 			claimi.Status.Conditions = controllerutils.SetClusterClaimCondition(
 				claimi.Status.Conditions,
 				hivev1.ClusterClaimPendingCondition,
@@ -319,7 +316,7 @@ func getAllClusterDeploymentsForPool(c client.Client, pool *hivev1.ClusterPool,
 			customizationExists = false
 			cdcName := cd.Spec.ClusterPoolRef.CustomizationRef.Name
 			for _, entry := range pool.Spec.Inventory {
-				if cdcName == entry.Name {
+				if isCustomizationEntry(entry) && cdcName == entry.Name {
 					customizationExists = true
 					break
 				}
@@ -645,7 +642,7 @@ type cdcCollection struct {
 // getAllCustomizationsForPool is the constructor for a cdcCollection for all of the
 // ClusterDeploymentCustomizations that are related to specified pool.
 func getAllCustomizationsForPool(c client.Client, pool *hivev1.ClusterPool, logger log.FieldLogger) (*cdcCollection, error) {
-	if pool.Spec.Inventory == nil {
+	if !hasInventoryOfKind(pool, hivev1.ClusterDeploymentCustomizationInventoryEntry) {
 		return &cdcCollection{}, nil
 	}
 	cdcList := &hivev1.ClusterDeploymentCustomizationList{}
@@ -671,6 +668,9 @@ func getAllCustomizationsForPool(c client.Client, pool *hivev1.ClusterPool, logg
 	}
 
 	for _, item := range pool.Spec.Inventory {
+		if !isCustomizationEntry(item) {
+			continue
+		}
 		if cdc, ok := cdcCol.namespace[item.Name]; ok {
 			cdcCol.byCDCName[item.Name] = cdc
 			availability := conditionsv1.FindStatusCondition(cdc.Status.Conditions, conditionsv1.ConditionAvailable)
@@ -888,6 +888,87 @@ func (cdcs *cdcCollection) Unassigned() []*hivev1.ClusterDeploymentCustomization
 	return cdcs.unassigned
 }
 
+func (cdcs *cdcCollection) Available() int {
+	return len(cdcs.unassigned)
+}
+
+// Invalid returns the names of the CDCs which are missing or which failed to produce a cluster.
+func (cdcs *cdcCollection) Invalid() map[string][]string {
+	cloud := []string{}
+	for _, cdc := range cdcs.cloud {
+		cloud = append(cloud, cdc.Name)
+	}
+	syntax := []string{}
+	for _, cdc := range cdcs.syntax {
+		syntax = append(syntax, cdc.Name)
+	}
+	return map[string][]string{
+		inventoryBrokenByCloud:  cloud,
+		inventoryBrokenBySyntax: syntax,
+		inventoryMissing:        append([]string{}, cdcs.missing...),
+	}
+}
+
+// Apply reserves the next unassigned CDC for a ClusterDeployment being created and applies its patches to the
+// install config Secret, the ClusterDeployment, the MachinePools and the manifests among objs. A manifests Secret or
+// ConfigMap generated for the patches is returned.
+func (cdcs *cdcCollection) Apply(c client.Client, pool *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, objs []runtime.Object, logger log.FieldLogger) ([]runtime.Object, error) {
+	if len(cdcs.unassigned) == 0 {
+		return nil, errors.New("no ClusterDeploymentCustomization available")
+	}
+	cdc := cdcs.unassigned[0]
+	if cdcs.reserved[cdc.Name] != nil || cdc.Status.ClusterDeploymentRef != nil || cdc.Status.ClusterPoolRef != nil {
+		return nil, fmt.Errorf("ClusterDeploymentCustomization %s is already reserved", cdc.Name)
+	}
+	cd.Spec.ClusterPoolRef.CustomizationRef = &corev1.LocalObjectReference{Name: cdc.Name}
+
+	var secret *corev1.Secret
+	for _, obj := range objs {
+		if secretTmp := isInstallConfigSecret(obj); secretTmp != nil {
+			secret = secretTmp
+		}
+	}
+	if secret == nil {
+		return nil, errors.New("missing install config")
+	}
+
+	cdc = &hivev1.ClusterDeploymentCustomization{}
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: pool.Namespace, Name: cd.Spec.ClusterPoolRef.CustomizationRef.Name}, cdc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New("missing customization")
+		}
+		return nil, err
+	}
+
+	installConfig, err := patchInstallConfig(secret.StringData["install-config.yaml"], cdc.Spec.InstallConfigPatches)
+	if err != nil {
+		cdcs.BrokenBySyntax(c, cdc, fmt.Sprint(err))
+		return nil, err
+	}
+
+	newObjs, err := applyCustomizationPatches(c, pool, cdc, cd, objs)
+	if err != nil {
+		cdcs.BrokenBySyntax(c, cdc, fmt.Sprint(err))
+		return nil, err
+	}
+
+	configJson, err := json.Marshal(cdc.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cdcs.Reserve(c, cdc, cd.Name, pool.Name); err != nil {
+		return nil, err
+	}
+	if err := cdcs.InstallationPending(c, cdc); err != nil {
+		return nil, err
+	}
+
+	cdc.Status.LastAppliedConfiguration = string(configJson)
+	secret.StringData["install-config.yaml"] = installConfig
+	return newObjs, nil
+}
+
 func (cdcs *cdcCollection) ByName(name string) *hivev1.ClusterDeploymentCustomization {
 	return cdcs.byCDCName[name]
 }
@@ -896,6 +977,9 @@ func (cdcs *cdcCollection) RemoveFinalizer(c client.Client, pool *hivev1.Cluster
 	poolFinalizer := fmt.Sprintf("hive.openshift.io/%s", pool.Name)
 
 	for _, item := range pool.Spec.Inventory {
+		if !isCustomizationEntry(item) {
+			continue
+		}
 		if cdc, ok := cdcs.namespace[item.Name]; ok {
 			controllerutils.DeleteFinalizer(cdc, poolFinalizer)
 			if err := c.Update(context.Background(), cdc); err != nil {
@@ -908,15 +992,14 @@ func (cdcs *cdcCollection) RemoveFinalizer(c client.Client, pool *hivev1.Cluster
 	return nil
 }
 
-// SyncClusterDeploymentCustomizations updates CDCs and related CR status:
+// SyncAssignments updates CDCs and related CR status:
 // - Handle deletion of CDC in the namespace
 // - If there is no CD, but CDC is reserved, then we release the CDC
 // - Make sure that CD <=> CDC links are legit; repair them if not.
 // - Notice a Broken CD => update the CDC's ApplySucceeded condition to BrokenByCloud;
 // - Notice a CD has finished installing => update the CDC's ApplySucceeded condition to Success;
-// - Update ClusterPool InventoryValid condition
-func (cdcs *cdcCollection) SyncClusterDeploymentCustomizationAssignments(c client.Client, pool *hivev1.ClusterPool, cds *cdCollection, logger log.FieldLogger) error {
-	if pool.Spec.Inventory == nil {
+func (cdcs *cdcCollection) SyncAssignments(c client.Client, pool *hivev1.ClusterPool, cds *cdCollection, logger log.FieldLogger) error {
+	if !hasInventoryOfKind(pool, hivev1.ClusterDeploymentCustomizationInventoryEntry) {
 		return nil
 	}
 
@@ -1054,78 +1137,9 @@ func (cdcs *cdcCollection) SyncClusterDeploymentCustomizationAssignments(c clien
 
 	cdcs.Sort()
 
-	// Update ClusterPool InventoryValid condition
-	if err := cdcs.UpdateInventoryValidCondition(c, pool); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (cdcs *cdcCollection) UpdateInventoryValidCondition(c client.Client, pool *hivev1.ClusterPool) error {
-	message := ""
-	status := corev1.ConditionTrue
-	reason := hivev1.InventoryReasonValid
-	if (len(cdcs.syntax) + len(cdcs.cloud) + len(cdcs.missing)) > 0 {
-		// Send the cdcCollection to our custom marshaller that extracts and marshals just the invalid CDCs.
-		var b invalidCDCCollection = invalidCDCCollection(*cdcs)
-		messageByte, err := json.Marshal(&b)
-		if err != nil {
-			return err
-		}
-		message = string(messageByte)
-		status = corev1.ConditionFalse
-		reason = hivev1.InventoryReasonInvalid
-	}
-
-	conditions, changed := controllerutils.SetClusterPoolConditionWithChangeCheck(
-		pool.Status.Conditions,
-		hivev1.ClusterPoolInventoryValidCondition,
-		status,
-		reason,
-		message,
-		controllerutils.UpdateConditionIfReasonOrMessageChange,
-	)
-
-	if changed {
-		pool.Status.Conditions = conditions
-		if err := c.Status().Update(context.TODO(), pool); err != nil {
-			return err
-		}
-	}
-
 	return nil
 }
 
-type invalidCDCCollection cdcCollection
-
-var _ json.Marshaler = &invalidCDCCollection{}
-
-// MarshalJSON cdcs implements the InventoryValid condition message
-func (cdcs *invalidCDCCollection) MarshalJSON() ([]byte, error) {
-	cloud := []string{}
-	for _, cdc := range cdcs.cloud {
-		cloud = append(cloud, cdc.Name)
-	}
-	syntax := []string{}
-	for _, cdc := range cdcs.syntax {
-		syntax = append(syntax, cdc.Name)
-	}
-	sort.Strings(cloud)
-	sort.Strings(syntax)
-	sort.Strings(cdcs.missing)
-
-	return json.Marshal(&struct {
-		BrokenByCloud  []string
-		BrokenBySyntax []string
-		Missing        []string
-	}{
-		BrokenByCloud:  cloud,
-		BrokenBySyntax: syntax,
-		Missing:        cdcs.missing,
-	})
-}
-
 // setCDsCurrentCondition idempotently sets the ClusterDeploymentsCurrent condition on the
 // ClusterPool according to whether all unassigned CDs have the same PoolVersion as the pool.
 func setCDsCurrentCondition(c client.Client, cds *cdCollection, clp *hivev1.ClusterPool, poolVersion string) error {
@@ -1237,7 +1251,7 @@ func ensureClaimAssignment(c client.Client, claim *hivev1.ClusterClaim, claims *
 
 // assignClustersToClaims iterates over unassigned claims and assignable ClusterDeployments, in order (see
 // claimCollection.Unassigned and cdCollection.Assignable), assigning them to each other, stopping when the
-// first of the two lists is exhausted.
+// first of the two lists is exhausted. Claims left pending are told their position in the queue.
 func assignClustersToClaims(c client.Client, claims *claimCollection, cds *cdCollection, logger log.FieldLogger) error {
 	// ensureClaimAssignment modifies claims.unassigned and cds.assignable, so make a copy of the lists.
 	// copy() limits itself to the size of the destination
@@ -1253,18 +1267,26 @@ func assignClustersToClaims(c client.Client, claims *claimCollection, cds *cdCol
 			errs = append(errs, err)
 		}
 	}
-	// If any unassigned claims remain, mark their status accordingly
-	for _, claim := range claims.Unassigned() {
+	// If any unassigned claims remain, mark their status accordingly, including their position in the queue. The
+	// message of the condition does not include the position, so that a claim whose position did not change is not
+	// updated when the queue ahead of it moves.
+	pending := claims.Unassigned()
+	for i, claim := range pending {
 		logger := logger.WithField("claim", claim.Name)
 		logger.Debug("no clusters ready to assign to claim")
-		if conds, statusChanged := controllerutils.SetClusterClaimConditionWithChangeCheck(
+		conds, statusChanged := controllerutils.SetClusterClaimConditionWithChangeCheck(
 			claim.Status.Conditions,
 			hivev1.ClusterClaimPendingCondition,
 			corev1.ConditionTrue,
 			"NoClusters",
 			"No clusters in pool are ready to be claimed",
 			controllerutils.UpdateConditionIfReasonOrMessageChange,
-		); statusChanged {
+		)
+		if position := int32(i + 1); claim.Status.QueuePosition != position {
+			claim.Status.QueuePosition = position
+			statusChanged = true
+		}
+		if statusChanged {
 			claim.Status.Conditions = conds
 			if err := c.Status().Update(context.Background(), claim); err != nil {
 				logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
@@ -0,0 +1,550 @@
+package clusterpool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// Keys of the InventoryValid condition message
+	inventoryBrokenByCloud  = "BrokenByCloud"
+	inventoryBrokenBySyntax = "BrokenBySyntax"
+	inventoryMissing        = "Missing"
+)
+
+// inventoryCollection is the reservation bookkeeping for the entries of one kind in a ClusterPool's Inventory.
+// One entry of each kind in the Inventory is reserved for every ClusterDeployment created by the pool.
+type inventoryCollection interface {
+	// Available returns the number of entries which may be reserved for new ClusterDeployments.
+	Available() int
+	// Invalid returns the names of the entries which cannot be used, keyed by the reason. The reasons are the keys
+	// of the InventoryValid condition message.
+	Invalid() map[string][]string
+	// Apply reserves the next available entry for a ClusterDeployment being created and records the reservation in
+	// the ClusterDeployment. objs are the other resources being created for the cluster, which may be modified.
+	// Any additional resources to be created for the cluster are returned.
+	Apply(c client.Client, pool *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, objs []runtime.Object, logger log.FieldLogger) ([]runtime.Object, error)
+	// SyncAssignments releases entries reserved for ClusterDeployments which no longer exist, repairs reservations
+	// recorded in ClusterDeployments but not in the entries, and maintains the pool's finalizer on the entries.
+	SyncAssignments(c client.Client, pool *hivev1.ClusterPool, cds *cdCollection, logger log.FieldLogger) error
+	// RemoveFinalizer removes the pool's finalizer from all of the entries.
+	RemoveFinalizer(c client.Client, pool *hivev1.ClusterPool) error
+}
+
+// inventoryKind describes how to handle the entries of one kind in a ClusterPool's Inventory.
+type inventoryKind struct {
+	// object is an empty instance of the resource referenced by the entries, used to watch them.
+	object client.Object
+	// collect is the constructor of the inventoryCollection for the entries of this kind in the pool.
+	collect func(c client.Client, pool *hivev1.ClusterPool, logger log.FieldLogger) (inventoryCollection, error)
+}
+
+// inventoryKinds are the supported kinds of ClusterPool Inventory entries.
+var inventoryKinds = map[hivev1.InventoryEntryKind]inventoryKind{
+	hivev1.ClusterDeploymentCustomizationInventoryEntry: {
+		object: &hivev1.ClusterDeploymentCustomization{},
+		collect: func(c client.Client, pool *hivev1.ClusterPool, logger log.FieldLogger) (inventoryCollection, error) {
+			return getAllCustomizationsForPool(c, pool, logger)
+		},
+	},
+	hivev1.SecretInventoryEntry: {
+		object: &corev1.Secret{},
+		collect: resourceInventoryCollector(hivev1.SecretInventoryEntry, func() client.ObjectList { return &corev1.SecretList{} },
+			func(obj client.Object) client.Object {
+				secret := obj.(*corev1.Secret)
+				return &corev1.Secret{Type: secret.Type, Data: secret.Data}
+			},
+			useCredentialsSecret),
+	},
+	hivev1.ConfigMapInventoryEntry: {
+		object: &corev1.ConfigMap{},
+		collect: resourceInventoryCollector(hivev1.ConfigMapInventoryEntry, func() client.ObjectList { return &corev1.ConfigMapList{} },
+			func(obj client.Object) client.Object {
+				cm := obj.(*corev1.ConfigMap)
+				return &corev1.ConfigMap{Data: cm.Data, BinaryData: cm.BinaryData}
+			},
+			useManifestsConfigMap),
+	},
+}
+
+// inventoryEntryKind returns the kind of an inventory entry, defaulting to ClusterDeploymentCustomization.
+func inventoryEntryKind(entry hivev1.InventoryEntry) hivev1.InventoryEntryKind {
+	if entry.Kind == "" {
+		return hivev1.ClusterDeploymentCustomizationInventoryEntry
+	}
+	return entry.Kind
+}
+
+func isCustomizationEntry(entry hivev1.InventoryEntry) bool {
+	return inventoryEntryKind(entry) == hivev1.ClusterDeploymentCustomizationInventoryEntry
+}
+
+// hasInventoryOfKind returns true if the pool's Inventory contains entries of the given kind.
+func hasInventoryOfKind(pool *hivev1.ClusterPool, kind hivev1.InventoryEntryKind) bool {
+	for _, entry := range pool.Spec.Inventory {
+		if inventoryEntryKind(entry) == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// poolInventory is the reservation bookkeeping for all of the kinds of entries in a ClusterPool's Inventory.
+type poolInventory struct {
+	byKind map[hivev1.InventoryEntryKind]inventoryCollection
+	// kinds are the keys of byKind, sorted so that the entries are always applied in the same order.
+	kinds []hivev1.InventoryEntryKind
+}
+
+// getInventoryForPool is the constructor for a poolInventory covering each kind of entry in the pool's Inventory.
+func getInventoryForPool(c client.Client, pool *hivev1.ClusterPool, logger log.FieldLogger) (*poolInventory, error) {
+	inventory := &poolInventory{byKind: map[hivev1.InventoryEntryKind]inventoryCollection{}}
+	for _, entry := range pool.Spec.Inventory {
+		kind := inventoryEntryKind(entry)
+		if _, ok := inventory.byKind[kind]; ok {
+			continue
+		}
+		ik, ok := inventoryKinds[kind]
+		if !ok {
+			return nil, fmt.Errorf("unsupported inventory entry kind %s", kind)
+		}
+		col, err := ik.collect(c, pool, logger)
+		if err != nil {
+			return nil, err
+		}
+		inventory.byKind[kind] = col
+		inventory.kinds = append(inventory.kinds, kind)
+	}
+	sort.Slice(inventory.kinds, func(i, j int) bool { return inventory.kinds[i] < inventory.kinds[j] })
+	return inventory, nil
+}
+
+// Available returns the number of clusters for which entries of every kind can be reserved.
+func (inv *poolInventory) Available() int {
+	available := math.MaxInt32
+	for _, col := range inv.byKind {
+		available = minIntVarible(available, col.Available())
+	}
+	return available
+}
+
+// Apply reserves an entry of each kind for a ClusterDeployment being created, and returns the objects to create for
+// it: the given objects and the copies of the reserved entries. The credentials Secret generated for the pool is
+// dropped from the given objects when a Secret inventory entry replaces it.
+func (inv *poolInventory) Apply(c client.Client, pool *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, objs []runtime.Object, logger log.FieldLogger) ([]runtime.Object, error) {
+	credsSecretName := controllerutils.CredentialsSecretName(cd)
+	var newObjs []runtime.Object
+	for _, kind := range inv.kinds {
+		kindObjs, err := inv.byKind[kind].Apply(c, pool, cd, objs, logger.WithField("inventoryKind", kind))
+		if err != nil {
+			return nil, err
+		}
+		newObjs = append(newObjs, kindObjs...)
+	}
+	credsSecretReplaced := credsSecretName != "" && controllerutils.CredentialsSecretName(cd) != credsSecretName
+	result := make([]runtime.Object, 0, len(objs)+len(newObjs))
+	for _, obj := range objs {
+		if secret, ok := obj.(*corev1.Secret); ok && credsSecretReplaced && secret.Name == credsSecretName {
+			logger.WithField("secret", secret.Name).Debug("dropping credentials secret replaced by inventory")
+			continue
+		}
+		result = append(result, obj)
+	}
+	return append(result, newObjs...), nil
+}
+
+// SyncAssignments syncs the reservations of each kind of entry and updates the inventory conditions of the pool.
+func (inv *poolInventory) SyncAssignments(c client.Client, pool *hivev1.ClusterPool, cds *cdCollection, logger log.FieldLogger) error {
+	for _, kind := range inv.kinds {
+		if err := inv.byKind[kind].SyncAssignments(c, pool, cds, logger.WithField("inventoryKind", kind)); err != nil {
+			return err
+		}
+	}
+	return inv.UpdateConditions(c, pool)
+}
+
+func (inv *poolInventory) RemoveFinalizer(c client.Client, pool *hivev1.ClusterPool) error {
+	for _, kind := range inv.kinds {
+		if err := inv.byKind[kind].RemoveFinalizer(c, pool); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateConditions updates the InventoryValid and InventoryAvailable conditions of a pool with an Inventory.
+func (inv *poolInventory) UpdateConditions(c client.Client, pool *hivev1.ClusterPool) error {
+	if len(inv.kinds) == 0 {
+		return nil
+	}
+
+	invalid := map[string][]string{
+		inventoryBrokenByCloud:  {},
+		inventoryBrokenBySyntax: {},
+		inventoryMissing:        {},
+	}
+	invalidCount := 0
+	var exhausted []string
+	for _, kind := range inv.kinds {
+		col := inv.byKind[kind]
+		for reason, names := range col.Invalid() {
+			for _, name := range names {
+				// Entries other than ClusterDeploymentCustomizations are qualified by their kind
+				if kind != hivev1.ClusterDeploymentCustomizationInventoryEntry {
+					name = fmt.Sprintf("%s/%s", kind, name)
+				}
+				invalid[reason] = append(invalid[reason], name)
+				invalidCount++
+			}
+		}
+		if col.Available() == 0 {
+			exhausted = append(exhausted, string(kind))
+		}
+	}
+
+	message := ""
+	status := corev1.ConditionTrue
+	reason := hivev1.InventoryReasonValid
+	if invalidCount > 0 {
+		for _, names := range invalid {
+			sort.Strings(names)
+		}
+		messageByte, err := json.Marshal(invalid)
+		if err != nil {
+			return err
+		}
+		message = string(messageByte)
+		status = corev1.ConditionFalse
+		reason = hivev1.InventoryReasonInvalid
+	}
+	conditions, validChanged := controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolInventoryValidCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+
+	status = corev1.ConditionTrue
+	reason = hivev1.InventoryReasonAvailable
+	message = "Unreserved entries are available for each kind in the inventory"
+	if len(exhausted) > 0 {
+		status = corev1.ConditionFalse
+		reason = hivev1.InventoryReasonExhausted
+		message = fmt.Sprintf("All inventory entries of kind %s are reserved", strings.Join(exhausted, ", "))
+	}
+	conditions, availableChanged := controllerutils.SetClusterPoolConditionWithChangeCheck(
+		conditions,
+		hivev1.ClusterPoolInventoryAvailableCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+
+	if validChanged || availableChanged {
+		pool.Status.Conditions = conditions
+		if err := c.Status().Update(context.TODO(), pool); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// useCredentialsSecret makes the ClusterDeployment use the named Secret as the credentials of its cloud platform.
+func useCredentialsSecret(cd *hivev1.ClusterDeployment, name string) error {
+	var ref *corev1.LocalObjectReference
+	switch platform := &cd.Spec.Platform; {
+	case platform.AWS != nil:
+		ref = &platform.AWS.CredentialsSecretRef
+	case platform.Azure != nil:
+		ref = &platform.Azure.CredentialsSecretRef
+	case platform.GCP != nil:
+		ref = &platform.GCP.CredentialsSecretRef
+	case platform.OpenStack != nil:
+		ref = &platform.OpenStack.CredentialsSecretRef
+	case platform.VSphere != nil:
+		ref = &platform.VSphere.CredentialsSecretRef
+	case platform.IBMCloud != nil:
+		ref = &platform.IBMCloud.CredentialsSecretRef
+	case platform.Ovirt != nil:
+		ref = &platform.Ovirt.CredentialsSecretRef
+	default:
+		return fmt.Errorf("the platform of ClusterDeployment %s has no credentials Secret", cd.Name)
+	}
+	ref.Name = name
+	return nil
+}
+
+// useManifestsConfigMap makes the ClusterDeployment add the manifests in the named ConfigMap to its install.
+func useManifestsConfigMap(cd *hivev1.ClusterDeployment, name string) error {
+	if cd.Spec.Provisioning == nil {
+		cd.Spec.Provisioning = &hivev1.Provisioning{}
+	}
+	if cd.Spec.Provisioning.ManifestsConfigMapRef != nil || cd.Spec.Provisioning.ManifestsSecretRef != nil {
+		return fmt.Errorf("ClusterDeployment %s already has manifests, which cannot be combined with a ConfigMap inventory entry", cd.Name)
+	}
+	cd.Spec.Provisioning.ManifestsConfigMapRef = &corev1.LocalObjectReference{Name: name}
+	return nil
+}
+
+// resourceInventoryCollection is the reservation bookkeeping for inventory entries referring to plain resources,
+// such as Secrets and ConfigMaps, which have no status of their own. Reservations are recorded in annotations on
+// the resources, and the reserved resource is copied into the namespace of the ClusterDeployment, which is made to
+// use the copy.
+type resourceInventoryCollection struct {
+	kind hivev1.InventoryEntryKind
+	// copyObject returns a new resource with the content of the given one.
+	copyObject func(client.Object) client.Object
+	// use makes the ClusterDeployment refer to the copy of the reserved resource with the given name.
+	use func(cd *hivev1.ClusterDeployment, name string) error
+	// Listed in the pool inventory, exist and are not reserved, in inventory order
+	unassigned []client.Object
+	// Listed in the pool inventory but the resource doesn't exist in the pool namespace
+	missing []string
+	// Reserved for some cluster deployment, by name
+	reserved map[string]client.Object
+	// All the resources listed in the pool inventory that exist, by name
+	byName map[string]client.Object
+	// All the resources of this kind in the namespace, by name
+	namespace map[string]client.Object
+}
+
+var _ inventoryCollection = &resourceInventoryCollection{}
+var _ inventoryCollection = &cdcCollection{}
+
+// resourceInventoryCollector returns the constructor of a resourceInventoryCollection for the given kind.
+func resourceInventoryCollector(kind hivev1.InventoryEntryKind, newList func() client.ObjectList, copyObject func(client.Object) client.Object, use func(*hivev1.ClusterDeployment, string) error) func(client.Client, *hivev1.ClusterPool, log.FieldLogger) (inventoryCollection, error) {
+	return func(c client.Client, pool *hivev1.ClusterPool, logger log.FieldLogger) (inventoryCollection, error) {
+		list := newList()
+		if err := c.List(context.Background(), list, client.InNamespace(pool.Namespace)); err != nil {
+			logger.WithField("namespace", pool.Namespace).WithError(err).Errorf("error listing %ss", kind)
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+
+		col := &resourceInventoryCollection{
+			kind:       kind,
+			copyObject: copyObject,
+			use:        use,
+			unassigned: make([]client.Object, 0),
+			missing:    make([]string, 0),
+			reserved:   make(map[string]client.Object),
+			byName:     make(map[string]client.Object),
+			namespace:  make(map[string]client.Object),
+		}
+		for _, item := range items {
+			obj := item.(client.Object)
+			col.namespace[obj.GetName()] = obj
+		}
+		for _, entry := range pool.Spec.Inventory {
+			if inventoryEntryKind(entry) != kind {
+				continue
+			}
+			obj, ok := col.namespace[entry.Name]
+			if !ok {
+				col.missing = append(col.missing, entry.Name)
+				continue
+			}
+			col.byName[entry.Name] = obj
+			if obj.GetAnnotations()[constants.InventoryReservedByClusterDeploymentAnnotation] != "" {
+				col.reserved[entry.Name] = obj
+			} else if obj.GetDeletionTimestamp() == nil {
+				col.unassigned = append(col.unassigned, obj)
+			}
+		}
+
+		logger.WithFields(log.Fields{
+			"kind":            kind,
+			"unassignedCount": len(col.unassigned),
+			"missingCount":    len(col.missing),
+			"reservedCount":   len(col.reserved),
+		}).Debug("found inventory resources for ClusterPool")
+
+		return col, nil
+	}
+}
+
+func (col *resourceInventoryCollection) Available() int {
+	return len(col.unassigned)
+}
+
+func (col *resourceInventoryCollection) Invalid() map[string][]string {
+	return map[string][]string{inventoryMissing: col.missing}
+}
+
+// Apply reserves the next unassigned resource for a ClusterDeployment being created and returns a copy of it in the
+// ClusterDeployment's namespace, which the ClusterDeployment is made to use.
+func (col *resourceInventoryCollection) Apply(c client.Client, pool *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, objs []runtime.Object, logger log.FieldLogger) ([]runtime.Object, error) {
+	if len(col.unassigned) == 0 {
+		return nil, fmt.Errorf("no %s available in inventory", col.kind)
+	}
+	obj := col.unassigned[0]
+	// Check that the ClusterDeployment can use the resource before reserving it.
+	if err := col.use(cd, obj.GetName()); err != nil {
+		return nil, err
+	}
+	if err := col.Reserve(c, obj, cd.Name, pool.Name); err != nil {
+		return nil, err
+	}
+	logger.WithField("name", obj.GetName()).Info("reserved inventory entry for cluster")
+	cd.Spec.ClusterPoolRef.InventoryRefs = append(cd.Spec.ClusterPoolRef.InventoryRefs, hivev1.InventoryEntry{
+		Kind: col.kind,
+		Name: obj.GetName(),
+	})
+
+	copied := col.copyObject(obj)
+	copied.SetName(obj.GetName())
+	copied.SetNamespace(cd.Namespace)
+	return []runtime.Object{copied}, nil
+}
+
+// Reserve records in the resource that it is reserved for the ClusterDeployment.
+func (col *resourceInventoryCollection) Reserve(c client.Client, obj client.Object, cdName, poolName string) error {
+	annotations := obj.GetAnnotations()
+	if reservedBy := annotations[constants.InventoryReservedByClusterDeploymentAnnotation]; reservedBy != "" {
+		return fmt.Errorf("%s %s already reserved by ClusterDeployment %s", col.kind, obj.GetName(), reservedBy)
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[constants.InventoryReservedByClusterDeploymentAnnotation] = cdName
+	annotations[constants.InventoryReservedByClusterPoolAnnotation] = poolName
+	obj.SetAnnotations(annotations)
+	if err := c.Update(context.Background(), obj); err != nil {
+		return err
+	}
+
+	col.reserved[obj.GetName()] = obj
+	for i, o := range col.unassigned {
+		if o.GetName() == obj.GetName() {
+			col.unassigned = append(col.unassigned[:i], col.unassigned[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Release removes the reservation from the resource, making it available to new clusters.
+func (col *resourceInventoryCollection) Release(c client.Client, obj client.Object) error {
+	annotations := obj.GetAnnotations()
+	delete(annotations, constants.InventoryReservedByClusterDeploymentAnnotation)
+	delete(annotations, constants.InventoryReservedByClusterPoolAnnotation)
+	obj.SetAnnotations(annotations)
+	if err := c.Update(context.Background(), obj); err != nil {
+		return err
+	}
+
+	delete(col.reserved, obj.GetName())
+	if _, ok := col.byName[obj.GetName()]; ok && obj.GetDeletionTimestamp() == nil {
+		col.unassigned = append(col.unassigned, obj)
+	}
+	return nil
+}
+
+// SyncAssignments updates the reservations of the resources:
+// - Handle deletion of resources listed in the inventory
+// - If there is no CD, but the resource is reserved by this pool, then we release the resource
+// - If a CD refers to a resource which isn't reserved, reserve it for the CD.
+func (col *resourceInventoryCollection) SyncAssignments(c client.Client, pool *hivev1.ClusterPool, cds *cdCollection, logger log.FieldLogger) error {
+	poolFinalizer := fmt.Sprintf("hive.openshift.io/%s", pool.Name)
+
+	// Handle deletion of resources in the namespace
+	for _, obj := range col.namespace {
+		_, listed := col.byName[obj.GetName()]
+		hasFinalizer := controllerutils.HasFinalizer(obj, poolFinalizer)
+		isReserved := obj.GetAnnotations()[constants.InventoryReservedByClusterDeploymentAnnotation] != ""
+		if (obj.GetDeletionTimestamp() != nil && !isReserved) || !listed {
+			// We can delete the finalizer for a deleted resource only if it is not reserved
+			if hasFinalizer {
+				controllerutils.DeleteFinalizer(obj, poolFinalizer)
+				if err := c.Update(context.Background(), obj); err != nil {
+					return err
+				}
+			}
+		} else if !hasFinalizer {
+			// Ensure the finalizer is present while the resource is in this pool inventory
+			controllerutils.AddFinalizer(obj, poolFinalizer)
+			if err := c.Update(context.Background(), obj); err != nil {
+				return err
+			}
+		}
+	}
+
+	// If there is no CD, but the resource is reserved by this pool, then we release it
+	for _, obj := range col.reserved {
+		annotations := obj.GetAnnotations()
+		if annotations[constants.InventoryReservedByClusterPoolAnnotation] != pool.Name {
+			continue
+		}
+		if cds.ByName(annotations[constants.InventoryReservedByClusterDeploymentAnnotation]) == nil {
+			logger.WithField("name", obj.GetName()).Info("releasing inventory entry of deleted cluster")
+			if err := col.Release(c, obj); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Make sure the resources referenced by CDs are reserved for them
+	for _, cd := range cds.byCDName {
+		for _, ref := range cd.Spec.ClusterPoolRef.InventoryRefs {
+			if ref.Kind != col.kind {
+				continue
+			}
+			logger := logger.WithFields(log.Fields{
+				"clusterdeployment": cd.Name,
+				"name":              ref.Name,
+			})
+			obj, ok := col.namespace[ref.Name]
+			if !ok {
+				logger.Warning("CD has reference to an inventory entry that doesn't exist, it was forcefully removed or this is a bug")
+				continue
+			}
+			switch reservedBy := obj.GetAnnotations()[constants.InventoryReservedByClusterDeploymentAnnotation]; reservedBy {
+			case cd.Name:
+			case "":
+				if err := col.Reserve(c, obj, cd.Name, pool.Name); err != nil {
+					return err
+				}
+			default:
+				logger.WithField("parallelclusterdeployment", reservedBy).Warning("Another CD has this inventory entry reserved")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (col *resourceInventoryCollection) RemoveFinalizer(c client.Client, pool *hivev1.ClusterPool) error {
+	poolFinalizer := fmt.Sprintf("hive.openshift.io/%s", pool.Name)
+	for _, obj := range col.namespace {
+		if !controllerutils.HasFinalizer(obj, poolFinalizer) {
+			continue
+		}
+		controllerutils.DeleteFinalizer(obj, poolFinalizer)
+		if err := c.Update(context.Background(), obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
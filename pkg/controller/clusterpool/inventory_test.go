@@ -0,0 +1,448 @@
+package clusterpool
+
+import (
+	"context"
+	"testing"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcdc "github.com/openshift/hive/pkg/test/clusterdeploymentcustomization"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testcm "github.com/openshift/hive/pkg/test/configmap"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+func TestResourceInventory(t *testing.T) {
+	scheme := scheme.GetScheme()
+	poolFinalizer := "hive.openshift.io/" + testLeasePoolName
+	reservedBy := func(cdName, poolName string) []testgeneric.Option {
+		return []testgeneric.Option{
+			testgeneric.WithAnnotation(constants.InventoryReservedByClusterDeploymentAnnotation, cdName),
+			testgeneric.WithAnnotation(constants.InventoryReservedByClusterPoolAnnotation, poolName),
+		}
+	}
+	pool := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme).Build(
+		testcp.WithInventoryEntries(hivev1.SecretInventoryEntry, "creds-1", "creds-2", "creds-3"),
+		testcp.WithInventoryEntries(hivev1.ConfigMapInventoryEntry, "vip-1"),
+	)
+
+	cases := []struct {
+		name                string
+		existing            []runtime.Object
+		expectedAvailable   int
+		expectedReservedFor map[string]string
+		expectedFinalizer   map[string]bool
+	}{
+		{
+			name: "entries available",
+			existing: []runtime.Object{
+				testsecret.FullBuilder(testNamespace, "creds-1", scheme).Build(),
+				testsecret.FullBuilder(testNamespace, "creds-2", scheme).Build(),
+				testsecret.FullBuilder(testNamespace, "unlisted", scheme).Build(),
+				testcm.FullBuilder(testNamespace, "vip-1", scheme).Build(),
+			},
+			expectedAvailable:   1,
+			expectedReservedFor: map[string]string{"creds-1": "", "creds-2": "", "vip-1": ""},
+			expectedFinalizer:   map[string]bool{"creds-1": true, "creds-2": true, "unlisted": false},
+		},
+		{
+			name: "release entries of deleted clusters",
+			existing: []runtime.Object{
+				testsecret.FullBuilder(testNamespace, "creds-1", scheme).GenericOptions(reservedBy("gone", testLeasePoolName)...).Build(),
+				testsecret.FullBuilder(testNamespace, "creds-2", scheme).GenericOptions(reservedBy("c1", testLeasePoolName)...).Build(),
+				testcm.FullBuilder(testNamespace, "vip-1", scheme).GenericOptions(reservedBy("gone", testLeasePoolName)...).Build(),
+				testcd.FullBuilder("c1", "c1", scheme).Build(
+					testcd.WithUnclaimedClusterPoolReference(testNamespace, testLeasePoolName),
+					testcd.WithInventoryRef(hivev1.SecretInventoryEntry, "creds-2"),
+				),
+			},
+			expectedAvailable:   1,
+			expectedReservedFor: map[string]string{"creds-1": "", "creds-2": "c1", "vip-1": ""},
+		},
+		{
+			name: "repair reservation",
+			existing: []runtime.Object{
+				testsecret.FullBuilder(testNamespace, "creds-1", scheme).Build(),
+				testcm.FullBuilder(testNamespace, "vip-1", scheme).Build(),
+				testcd.FullBuilder("c1", "c1", scheme).Build(
+					testcd.WithUnclaimedClusterPoolReference(testNamespace, testLeasePoolName),
+					testcd.WithInventoryRef(hivev1.SecretInventoryEntry, "creds-1"),
+					testcd.WithInventoryRef(hivev1.ConfigMapInventoryEntry, "vip-1"),
+				),
+			},
+			expectedAvailable:   0,
+			expectedReservedFor: map[string]string{"creds-1": "c1", "vip-1": "c1"},
+		},
+		{
+			name: "reserved by another pool",
+			existing: []runtime.Object{
+				testsecret.FullBuilder(testNamespace, "creds-1", scheme).GenericOptions(reservedBy("other", "other-pool")...).Build(),
+				testcm.FullBuilder(testNamespace, "vip-1", scheme).Build(),
+			},
+			expectedAvailable:   0,
+			expectedReservedFor: map[string]string{"creds-1": "other", "vip-1": ""},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := testfake.NewFakeClientBuilder().
+				WithIndex(&hivev1.ClusterDeployment{}, cdClusterPoolIndex, indexClusterDeploymentsByClusterPool).
+				WithRuntimeObjects(append(tc.existing, pool.DeepCopy())...).
+				Build()
+			logger := log.WithField("controller", "clusterpool")
+			cds, err := getAllClusterDeploymentsForPool(c, pool, "", logger)
+			require.NoError(t, err, "unexpected error getting cluster deployments")
+			inventory, err := getInventoryForPool(c, pool, logger)
+			require.NoError(t, err, "unexpected error getting inventory")
+			require.NoError(t, inventory.SyncAssignments(c, pool, cds, logger), "unexpected error syncing inventory")
+
+			assert.Equal(t, tc.expectedAvailable, inventory.Available(), "unexpected number of available entries")
+			for name, cdName := range tc.expectedReservedFor {
+				var obj client.Object = &corev1.Secret{}
+				if name == "vip-1" {
+					obj = &corev1.ConfigMap{}
+				}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: name}, obj))
+				assert.Equal(t, cdName, obj.GetAnnotations()[constants.InventoryReservedByClusterDeploymentAnnotation],
+					"unexpected reservation of %s", name)
+			}
+			for name, expected := range tc.expectedFinalizer {
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: name}, secret))
+				if expected {
+					assert.Contains(t, secret.Finalizers, poolFinalizer, "expected finalizer on %s", name)
+				} else {
+					assert.NotContains(t, secret.Finalizers, poolFinalizer, "unexpected finalizer on %s", name)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceInventoryApply(t *testing.T) {
+	scheme := scheme.GetScheme()
+	pool := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme).Build(
+		testcp.WithInventoryEntries(hivev1.SecretInventoryEntry, "creds-1"),
+		testcp.WithInventoryEntries(hivev1.ConfigMapInventoryEntry, "vip-1"),
+	)
+	c := testfake.NewFakeClientBuilder().WithRuntimeObjects(
+		pool,
+		testsecret.FullBuilder(testNamespace, "creds-1", scheme).Build(
+			testsecret.WithDataKeyValue("credentials", []byte("secret")),
+		),
+		testcm.FullBuilder(testNamespace, "vip-1", scheme).Build(
+			testcm.WithDataKeyValue("vip.yaml", "vip: 10.0.0.5"),
+		),
+	).Build()
+	logger := log.WithField("controller", "clusterpool")
+	inventory, err := getInventoryForPool(c, pool, logger)
+	require.NoError(t, err, "unexpected error getting inventory")
+
+	cd := testcd.FullBuilder("c1", "c1", scheme).Build(
+		testcd.WithUnclaimedClusterPoolReference(testNamespace, testLeasePoolName),
+		testcd.WithAWSPlatform(&hivev1aws.Platform{
+			Region:               "us-east-1",
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: "pool-creds"},
+		}),
+	)
+	poolObjs := []runtime.Object{
+		testsecret.FullBuilder("c1", "pool-creds", scheme).Build(),
+		testsecret.FullBuilder("c1", "pull-secret", scheme).Build(),
+		cd,
+	}
+	objs, err := inventory.Apply(c, pool, cd, poolObjs, logger)
+	require.NoError(t, err, "unexpected error applying inventory")
+
+	assert.Equal(t, []hivev1.InventoryEntry{
+		{Kind: hivev1.ConfigMapInventoryEntry, Name: "vip-1"},
+		{Kind: hivev1.SecretInventoryEntry, Name: "creds-1"},
+	}, cd.Spec.ClusterPoolRef.InventoryRefs, "unexpected inventory refs")
+	assert.Equal(t, "creds-1", cd.Spec.Platform.AWS.CredentialsSecretRef.Name, "expected the cluster to use the copied secret")
+	if assert.NotNil(t, cd.Spec.Provisioning, "expected provisioning") &&
+		assert.NotNil(t, cd.Spec.Provisioning.ManifestsConfigMapRef, "expected manifests configmap") {
+		assert.Equal(t, "vip-1", cd.Spec.Provisioning.ManifestsConfigMapRef.Name, "expected the cluster to use the copied configmap")
+	}
+	// The credentials secret generated for the pool is replaced by the copy of the inventory secret.
+	if assert.Len(t, objs, 4, "expected the other objects of the cluster and copies of the configmap and secret") {
+		assert.Equal(t, "pull-secret", objs[0].(*corev1.Secret).Name, "unexpected object kept")
+		assert.Equal(t, cd, objs[1], "expected the cluster to be kept")
+		copiedCM := objs[2].(*corev1.ConfigMap)
+		assert.Equal(t, "c1", copiedCM.Namespace, "unexpected namespace of copied configmap")
+		assert.Equal(t, "vip-1", copiedCM.Name, "unexpected name of copied configmap")
+		assert.Equal(t, "vip: 10.0.0.5", copiedCM.Data["vip.yaml"], "unexpected data in copied configmap")
+		copied := objs[3].(*corev1.Secret)
+		assert.Equal(t, "c1", copied.Namespace, "unexpected namespace of copied secret")
+		assert.Equal(t, "creds-1", copied.Name, "unexpected name of copied secret")
+		assert.Equal(t, []byte("secret"), copied.Data["credentials"], "unexpected data in copied secret")
+	}
+	secret := &corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: "creds-1"}, secret))
+	assert.Equal(t, "c1", secret.Annotations[constants.InventoryReservedByClusterDeploymentAnnotation], "expected secret to be reserved")
+	assert.Equal(t, 0, inventory.Available(), "expected inventory to be exhausted")
+
+	_, err = inventory.Apply(c, pool, cd, nil, logger)
+	assert.Error(t, err, "expected error applying exhausted inventory")
+}
+
+func TestResourceInventoryApplyConflictingManifests(t *testing.T) {
+	scheme := scheme.GetScheme()
+	pool := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme).Build(
+		testcp.WithInventoryEntries(hivev1.ConfigMapInventoryEntry, "vip-1"),
+	)
+	c := testfake.NewFakeClientBuilder().WithRuntimeObjects(pool, testcm.FullBuilder(testNamespace, "vip-1", scheme).Build()).Build()
+	logger := log.WithField("controller", "clusterpool")
+	inventory, err := getInventoryForPool(c, pool, logger)
+	require.NoError(t, err, "unexpected error getting inventory")
+
+	cd := testcd.FullBuilder("c1", "c1", scheme).Build(testcd.WithUnclaimedClusterPoolReference(testNamespace, testLeasePoolName))
+	cd.Spec.Provisioning = &hivev1.Provisioning{ManifestsSecretRef: &corev1.LocalObjectReference{Name: "manifests"}}
+	_, err = inventory.Apply(c, pool, cd, nil, logger)
+	assert.Error(t, err, "expected error applying configmap to cluster with manifests")
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: "vip-1"}, cm))
+	assert.Empty(t, cm.Annotations[constants.InventoryReservedByClusterDeploymentAnnotation], "unexpected reservation of configmap")
+}
+
+func TestCustomizationInventoryApply(t *testing.T) {
+	scheme := scheme.GetScheme()
+	installConfig := "baseDomain: example.com\ncompute:\n- name: worker\n  replicas: 3\n"
+
+	cases := []struct {
+		name      string
+		cdc       *hivev1.ClusterDeploymentCustomization
+		manifests map[string][]byte
+		// configMapManifests, if set, are the manifests of a ConfigMap in the namespace of the pool referenced by the
+		// ClusterDeployment.
+		configMapManifests map[string]string
+		expectErr          bool
+		validate           func(t *testing.T, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, installConfig string, newObjs []runtime.Object)
+		expectedSecret     map[string]string
+	}{
+		{
+			name: "typed install config values",
+			cdc: testcdc.FullBuilder(testNamespace, "test-cdc-1", scheme).Build(
+				testcdc.WithInstallConfigPatch("/compute/0/replicas", "replace", 5),
+				testcdc.WithInstallConfigPatch("/fips", "add", true),
+				testcdc.WithInstallConfigPatch("/networking", "add", map[string]interface{}{
+					"machineNetwork": []map[string]string{{"cidr": "10.1.0.0/16"}},
+				}),
+				testcdc.WithPatch("/baseDomain", "replace", "example.org"),
+			),
+			validate: func(t *testing.T, _ *hivev1.ClusterDeployment, _ *hivev1.MachinePool, installConfig string, _ []runtime.Object) {
+				assert.Contains(t, installConfig, "replicas: 5", "expected numeric replicas")
+				assert.Contains(t, installConfig, "fips: true", "expected boolean fips")
+				assert.Contains(t, installConfig, "cidr: 10.1.0.0/16", "expected machine network object")
+				assert.Contains(t, installConfig, "baseDomain: example.org", "expected string base domain")
+			},
+		},
+		{
+			name: "cluster deployment and machine pool patches",
+			cdc: testcdc.FullBuilder(testNamespace, "test-cdc-1", scheme).Build(
+				testcdc.WithClusterDeploymentPatch("/metadata/labels/topology", "add", "large"),
+				testcdc.WithClusterDeploymentPatch("/spec/platform/aws/region", "replace", "us-west-2"),
+				testcdc.WithMachinePoolPatch("/spec/replicas", "replace", 6),
+				testcdc.WithMachinePoolPatch("/spec/platform/aws/type", "replace", "m6i.2xlarge"),
+			),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, _ string, newObjs []runtime.Object) {
+				assert.Equal(t, "large", cd.Labels["topology"], "expected label on clusterdeployment")
+				assert.Equal(t, "us-west-2", cd.Spec.Platform.AWS.Region, "expected patched region")
+				assert.Equal(t, "c1", cd.Name, "unexpected change to clusterdeployment name")
+				if assert.NotNil(t, pool.Spec.Replicas, "expected replicas on machinepool") {
+					assert.Equal(t, int64(6), *pool.Spec.Replicas, "expected patched replicas")
+				}
+				assert.Equal(t, "m6i.2xlarge", pool.Spec.Platform.AWS.InstanceType, "expected patched instance type")
+				assert.Empty(t, newObjs, "unexpected new objects")
+			},
+		},
+		{
+			name: "manifest patches generate secret",
+			cdc: testcdc.FullBuilder(testNamespace, "test-cdc-1", scheme).Build(
+				testcdc.WithManifestPatch("/99-custom.yaml", "add", "kind: ConfigMap\n"),
+			),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment, _ *hivev1.MachinePool, _ string, newObjs []runtime.Object) {
+				if assert.Len(t, newObjs, 1, "expected manifests secret") {
+					secret := newObjs[0].(*corev1.Secret)
+					assert.Equal(t, "c1-manifests", secret.Name, "unexpected manifests secret name")
+					assert.Equal(t, "c1", secret.Namespace, "unexpected manifests secret namespace")
+					assert.Equal(t, map[string][]byte{"99-custom.yaml": []byte("kind: ConfigMap\n")}, secret.Data, "unexpected manifests")
+					if assert.NotNil(t, cd.Spec.Provisioning.ManifestsSecretRef, "expected manifests secret ref") {
+						assert.Equal(t, secret.Name, cd.Spec.Provisioning.ManifestsSecretRef.Name, "unexpected manifests secret ref")
+					}
+				}
+			},
+		},
+		{
+			name: "manifest patches existing secret",
+			cdc: testcdc.FullBuilder(testNamespace, "test-cdc-1", scheme).Build(
+				testcdc.WithManifestPatch("/existing.yaml", "remove", nil),
+				testcdc.WithManifestPatch("/new.yaml", "add", "new"),
+			),
+			manifests: map[string][]byte{"existing.yaml": []byte("existing")},
+			validate: func(t *testing.T, _ *hivev1.ClusterDeployment, _ *hivev1.MachinePool, _ string, newObjs []runtime.Object) {
+				assert.Empty(t, newObjs, "unexpected new objects")
+			},
+			expectedSecret: map[string]string{"new.yaml": "new"},
+		},
+		{
+			name: "manifest patches configmap",
+			cdc: testcdc.FullBuilder(testNamespace, "test-cdc-1", scheme).Build(
+				testcdc.WithManifestPatch("/existing.yaml", "remove", nil),
+				testcdc.WithManifestPatch("/new.yaml", "add", "new"),
+			),
+			configMapManifests: map[string]string{"existing.yaml": "existing", "kept.yaml": "kept"},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment, _ *hivev1.MachinePool, _ string, newObjs []runtime.Object) {
+				if assert.Len(t, newObjs, 1, "expected manifests configmap") {
+					cm := newObjs[0].(*corev1.ConfigMap)
+					assert.Equal(t, "c1-manifests", cm.Name, "unexpected manifests configmap name")
+					assert.Equal(t, "c1", cm.Namespace, "unexpected manifests configmap namespace")
+					assert.Equal(t, map[string]string{"kept.yaml": "kept", "new.yaml": "new"}, cm.Data, "unexpected manifests")
+					if assert.NotNil(t, cd.Spec.Provisioning.ManifestsConfigMapRef, "expected manifests configmap ref") {
+						assert.Equal(t, cm.Name, cd.Spec.Provisioning.ManifestsConfigMapRef.Name, "unexpected manifests configmap ref")
+					}
+				}
+			},
+		},
+		{
+			name: "manifest patches missing configmap",
+			cdc: testcdc.FullBuilder(testNamespace, "test-cdc-1", scheme).Build(
+				testcdc.WithClusterDeploymentPatch("/spec/provisioning/manifestsConfigMapRef", "add", map[string]string{"name": "missing"}),
+				testcdc.WithManifestPatch("/new.yaml", "add", "new"),
+			),
+			expectErr: true,
+		},
+		{
+			name: "cluster deployment rename",
+			cdc: testcdc.FullBuilder(testNamespace, "test-cdc-1", scheme).Build(
+				testcdc.WithClusterDeploymentPatch("/metadata/name", "replace", "other"),
+			),
+			expectErr: true,
+		},
+		{
+			name: "cluster deployment labels replaced",
+			cdc: testcdc.FullBuilder(testNamespace, "test-cdc-1", scheme).Build(
+				testcdc.WithClusterDeploymentPatch("/metadata/labels", "replace", map[string]string{"topology": "large"}),
+			),
+			expectErr: true,
+		},
+		{
+			name: "cluster deployment pool reference",
+			cdc: testcdc.FullBuilder(testNamespace, "test-cdc-1", scheme).Build(
+				testcdc.WithClusterDeploymentPatch("/spec/clusterPoolRef/poolName", "replace", "other"),
+			),
+			expectErr: true,
+		},
+		{
+			name: "machine pool annotations removed",
+			cdc: testcdc.FullBuilder(testNamespace, "test-cdc-1", scheme).Build(
+				testcdc.WithMachinePoolPatch("/metadata/annotations", "remove", nil),
+			),
+			expectErr: true,
+		},
+		{
+			name: "missing machine pool path",
+			cdc: testcdc.FullBuilder(testNamespace, "test-cdc-1", scheme).Build(
+				testcdc.WithMachinePoolPatch("/spec/missing/path", "replace", 1),
+			),
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme).Build(
+				testcp.WithInventory([]string{"test-cdc-1"}),
+			)
+			existing := []runtime.Object{pool, tc.cdc}
+			if tc.configMapManifests != nil {
+				existing = append(existing, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "pool-manifests"},
+					Data:       tc.configMapManifests,
+				})
+			}
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build()
+			logger := log.WithField("controller", "clusterpool")
+			inventory, err := getInventoryForPool(c, pool, logger)
+			require.NoError(t, err, "unexpected error getting inventory")
+
+			cd := testcd.FullBuilder("c1", "c1", scheme).Build(
+				testcd.WithUnclaimedClusterPoolReference(testNamespace, testLeasePoolName),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.Platform.AWS = &hivev1aws.Platform{Region: "us-east-1"}
+					cd.Spec.Provisioning = &hivev1.Provisioning{}
+				},
+			)
+			installConfigSecret := testsecret.FullBuilder("c1", "c1-install-config", scheme).Build(
+				func(secret *corev1.Secret) {
+					secret.StringData = map[string]string{"install-config.yaml": installConfig}
+				},
+			)
+			replicas := int64(3)
+			mp := &hivev1.MachinePool{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: "c1-worker"},
+				Spec: hivev1.MachinePoolSpec{
+					ClusterDeploymentRef: corev1.LocalObjectReference{Name: "c1"},
+					Name:                 "worker",
+					Replicas:             &replicas,
+					Platform: hivev1.MachinePoolPlatform{
+						AWS: &hivev1aws.MachinePoolPlatform{InstanceType: "m5.xlarge"},
+					},
+				},
+			}
+			objs := []runtime.Object{installConfigSecret, mp, cd}
+			var manifestsSecret *corev1.Secret
+			if tc.manifests != nil {
+				manifestsSecret = testsecret.FullBuilder("c1", "c1-manifests", scheme).Build(func(secret *corev1.Secret) {
+					secret.Data = tc.manifests
+				})
+				cd.Spec.Provisioning.ManifestsSecretRef = &corev1.LocalObjectReference{Name: manifestsSecret.Name}
+				objs = append(objs, manifestsSecret)
+			}
+			if tc.configMapManifests != nil {
+				cd.Spec.Provisioning.ManifestsConfigMapRef = &corev1.LocalObjectReference{Name: "pool-manifests"}
+			}
+
+			allObjs, err := inventory.Apply(c, pool, cd, objs, logger)
+
+			cdc := &hivev1.ClusterDeploymentCustomization{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(tc.cdc), cdc))
+			cond := conditionsv1.FindStatusCondition(cdc.Status.Conditions, hivev1.ApplySucceededCondition)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error applying customization")
+				if assert.NotNil(t, cond, "expected ApplySucceeded condition") {
+					assert.Equal(t, hivev1.CustomizationApplyReasonBrokenSyntax, cond.Reason, "unexpected condition reason")
+				}
+				return
+			}
+			require.NoError(t, err, "unexpected error applying customization")
+			require.Equal(t, objs, allObjs[:len(objs)], "expected the given objects to be kept")
+			newObjs := allObjs[len(objs):]
+			if assert.NotNil(t, cond, "expected ApplySucceeded condition") {
+				assert.Equal(t, hivev1.CustomizationApplyReasonInstallationPending, cond.Reason, "unexpected condition reason")
+			}
+			if tc.validate != nil {
+				tc.validate(t, cd, mp, installConfigSecret.StringData["install-config.yaml"], newObjs)
+			}
+			if tc.expectedSecret != nil {
+				actual := map[string]string{}
+				for k, v := range manifestsSecret.Data {
+					actual[k] = string(v)
+				}
+				assert.Equal(t, tc.expectedSecret, actual, "unexpected manifests")
+			}
+		})
+	}
+}
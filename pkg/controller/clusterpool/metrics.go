@@ -47,6 +47,12 @@ var (
 		Name: "hive_clusterpool_stale_clusterdeployments_deleted",
 		Help: "The number of ClusterDeployments deleted because they no longer match the spec of their ClusterPool.",
 	}, []string{"clusterpool_namespace", "clusterpool_name"})
+	// metricClaimsPreempted tracks the total number of ClusterClaims we delete to make room for
+	// pending claims of higher priority. See ClusterPool.Spec.ClaimPolicy.Preemption.
+	metricClaimsPreempted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_clusterpool_clusterclaims_preempted",
+		Help: "The number of assigned ClusterClaims deleted to make room for pending ClusterClaims of higher priority.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
 	// metricClaimDelaySeconds tracks how long it takes for a claim to be assigned, labeled by
 	// cluster pool.
 	metricClaimDelaySeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
@@ -75,5 +81,6 @@ func init() {
 	metrics.Registry.MustRegister(metricClusterDeploymentsStale)
 	metrics.Registry.MustRegister(metricClusterDeploymentsBroken)
 	metrics.Registry.MustRegister(metricStaleClusterDeploymentsDeleted)
+	metrics.Registry.MustRegister(metricClaimsPreempted)
 	metrics.Registry.MustRegister(metricClaimDelaySeconds)
 }
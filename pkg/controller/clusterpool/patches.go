@@ -0,0 +1,265 @@
+package clusterpool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	yamlpatch "github.com/krishicks/yaml-patch"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// patchValue decodes the value of the patch: its TypedValue, if set, or else its string Value.
+func patchValue(patch hivev1.PatchEntity) (interface{}, error) {
+	if patch.TypedValue == nil || len(patch.TypedValue.Raw) == 0 {
+		return patch.Value, nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(patch.TypedValue.Raw, &value); err != nil {
+		return nil, errors.Wrapf(err, "could not decode value of patch to %s", patch.Path)
+	}
+	return value, nil
+}
+
+// patchInstallConfig applies the patches to the install config YAML.
+func patchInstallConfig(installConfig string, patches []hivev1.PatchEntity) (string, error) {
+	newPatch := yamlpatch.Patch{}
+	for _, patch := range patches {
+		value, err := patchValue(patch)
+		if err != nil {
+			return "", err
+		}
+		newPatch = append(newPatch, yamlpatch.Operation{
+			Op:    yamlpatch.Op(patch.Op),
+			Path:  yamlpatch.OpPath(patch.Path),
+			From:  yamlpatch.OpPath(patch.From),
+			Value: yamlpatch.NewNode(&value),
+		})
+	}
+	patched, err := newPatch.Apply([]byte(installConfig))
+	if err != nil {
+		return "", err
+	}
+	return string(patched), nil
+}
+
+// applyJSONPatches applies the patches to the JSON document.
+func applyJSONPatches(doc []byte, patches []hivev1.PatchEntity) ([]byte, error) {
+	ops := make([]map[string]interface{}, len(patches))
+	for i, patch := range patches {
+		op := map[string]interface{}{
+			"op":   patch.Op,
+			"path": patch.Path,
+		}
+		if patch.From != "" {
+			op["from"] = patch.From
+		}
+		if patch.TypedValue != nil && len(patch.TypedValue.Raw) > 0 {
+			op["value"] = json.RawMessage(patch.TypedValue.Raw)
+		} else {
+			op["value"] = patch.Value
+		}
+		ops[i] = op
+	}
+	opsJSON, err := json.Marshal(ops)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode patches")
+	}
+	jsonPatch, err := jsonpatch.DecodePatch(opsJSON)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode patches")
+	}
+	return jsonPatch.Apply(doc)
+}
+
+// patchObject applies the patches to the object. The name, namespace and clusterPoolRef of the object cannot be
+// changed, nor its labels or annotations replaced as a whole.
+func patchObject(obj runtime.Object, patches []hivev1.PatchEntity) error {
+	if len(patches) == 0 {
+		return nil
+	}
+	for _, patch := range patches {
+		if controllerutils.IsProtectedPatchPath(patch.Path) || (patch.From != "" && controllerutils.IsProtectedPatchPath(patch.From)) {
+			return fmt.Errorf("patch of %s must not change the name, namespace or clusterPoolRef of %T, nor replace its labels or annotations", patch.Path, obj)
+		}
+	}
+	doc, err := json.Marshal(obj)
+	if err != nil {
+		return errors.Wrapf(err, "could not encode %T", obj)
+	}
+	// The labels and annotations can only be patched entry by entry, so make sure there are maps to add entries to.
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(doc, &fields); err != nil {
+		return errors.Wrapf(err, "could not decode %T", obj)
+	}
+	metadata, _ := fields["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+		fields["metadata"] = metadata
+	}
+	for _, key := range []string{"labels", "annotations"} {
+		if _, ok := metadata[key]; !ok {
+			metadata[key] = map[string]interface{}{}
+		}
+	}
+	if doc, err = json.Marshal(fields); err != nil {
+		return errors.Wrapf(err, "could not encode %T", obj)
+	}
+	patched, err := applyJSONPatches(doc, patches)
+	if err != nil {
+		return err
+	}
+	newObj := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(runtime.Object)
+	if err := json.Unmarshal(patched, newObj); err != nil {
+		return errors.Wrapf(err, "patched %T is not valid", obj)
+	}
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(newObj).Elem())
+	return nil
+}
+
+// patchManifests applies the patches to the manifests, represented as an object mapping each file name to its contents.
+func patchManifests(manifests map[string][]byte, patches []hivev1.PatchEntity) (map[string][]byte, error) {
+	files := make(map[string]string, len(manifests))
+	for name, contents := range manifests {
+		files[name] = string(contents)
+	}
+	doc, err := json.Marshal(files)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode manifests")
+	}
+	patched, err := applyJSONPatches(doc, patches)
+	if err != nil {
+		return nil, err
+	}
+	files = map[string]string{}
+	if err := json.Unmarshal(patched, &files); err != nil {
+		return nil, errors.Wrap(err, "patched manifests must map file names to string contents")
+	}
+	result := make(map[string][]byte, len(files))
+	for name, contents := range files {
+		result[name] = []byte(contents)
+	}
+	return result, nil
+}
+
+// applyCustomizationPatches applies the ClusterDeployment, MachinePool and manifest patches of the customization to the
+// objects generated for a ClusterDeployment. If the manifests need to be patched and there is no manifests Secret or
+// ConfigMap among objs, a new manifests Secret or ConfigMap is returned.
+func applyCustomizationPatches(c client.Client, clp *hivev1.ClusterPool, cdc *hivev1.ClusterDeploymentCustomization, cd *hivev1.ClusterDeployment, objs []runtime.Object) ([]runtime.Object, error) {
+	if err := patchObject(cd, cdc.Spec.ClusterDeploymentPatches); err != nil {
+		return nil, errors.Wrap(err, "could not patch ClusterDeployment")
+	}
+	for _, obj := range objs {
+		if pool, ok := obj.(*hivev1.MachinePool); ok {
+			if err := patchObject(pool, cdc.Spec.MachinePoolPatches); err != nil {
+				return nil, errors.Wrapf(err, "could not patch MachinePool %s", pool.Name)
+			}
+		}
+	}
+
+	if len(cdc.Spec.ManifestPatches) == 0 {
+		return nil, nil
+	}
+	if cd.Spec.Provisioning == nil {
+		return nil, errors.New("cannot patch manifests of a ClusterDeployment without provisioning")
+	}
+	if cd.Spec.Provisioning.ManifestsConfigMapRef != nil {
+		return patchManifestsConfigMap(c, clp, cdc, cd, objs)
+	}
+	var manifestsSecret *corev1.Secret
+	if ref := cd.Spec.Provisioning.ManifestsSecretRef; ref != nil {
+		for _, obj := range objs {
+			if secret, ok := obj.(*corev1.Secret); ok && secret.Name == ref.Name {
+				manifestsSecret = secret
+			}
+		}
+		if manifestsSecret == nil {
+			return nil, fmt.Errorf("cannot patch manifests from Secret %s that was not generated for the ClusterDeployment", ref.Name)
+		}
+	}
+	var newObjs []runtime.Object
+	if manifestsSecret == nil {
+		manifestsSecret = &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Secret",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      manifestsName(cd),
+				Namespace: cd.Namespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+		cd.Spec.Provisioning.ManifestsSecretRef = &corev1.LocalObjectReference{Name: manifestsSecret.Name}
+		newObjs = append(newObjs, manifestsSecret)
+	}
+	data, err := patchManifests(manifestsSecret.Data, cdc.Spec.ManifestPatches)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not patch manifests")
+	}
+	manifestsSecret.Data = data
+	return newObjs, nil
+}
+
+// patchManifestsConfigMap applies the manifest patches of the customization to the manifests ConfigMap of the
+// ClusterDeployment. A ConfigMap among objs is patched in place. Otherwise, as the namespace of the ClusterDeployment
+// is created along with it, the ConfigMap is read from the namespace of the pool and a patched copy is returned, to
+// which the ClusterDeployment is pointed. Only the Data of the ConfigMap is patched; its BinaryData is copied as is.
+func patchManifestsConfigMap(c client.Client, clp *hivev1.ClusterPool, cdc *hivev1.ClusterDeploymentCustomization, cd *hivev1.ClusterDeployment, objs []runtime.Object) ([]runtime.Object, error) {
+	ref := cd.Spec.Provisioning.ManifestsConfigMapRef
+	var manifestsConfigMap *corev1.ConfigMap
+	for _, obj := range objs {
+		if cm, ok := obj.(*corev1.ConfigMap); ok && cm.Name == ref.Name {
+			manifestsConfigMap = cm
+		}
+	}
+	var newObjs []runtime.Object
+	if manifestsConfigMap == nil {
+		source := &corev1.ConfigMap{}
+		if err := c.Get(context.Background(), client.ObjectKey{Namespace: clp.Namespace, Name: ref.Name}, source); err != nil {
+			return nil, errors.Wrapf(err, "could not get manifests ConfigMap %s", ref.Name)
+		}
+		manifestsConfigMap = &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ConfigMap",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      manifestsName(cd),
+				Namespace: cd.Namespace,
+			},
+			Data:       source.Data,
+			BinaryData: source.BinaryData,
+		}
+		cd.Spec.Provisioning.ManifestsConfigMapRef = &corev1.LocalObjectReference{Name: manifestsConfigMap.Name}
+		newObjs = append(newObjs, manifestsConfigMap)
+	}
+	manifests := make(map[string][]byte, len(manifestsConfigMap.Data))
+	for name, contents := range manifestsConfigMap.Data {
+		manifests[name] = []byte(contents)
+	}
+	manifests, err := patchManifests(manifests, cdc.Spec.ManifestPatches)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not patch manifests")
+	}
+	manifestsConfigMap.Data = make(map[string]string, len(manifests))
+	for name, contents := range manifests {
+		manifestsConfigMap.Data[name] = string(contents)
+	}
+	return newObjs, nil
+}
+
+// manifestsName is the name of the manifests Secret or ConfigMap generated for the patched manifests of a
+// ClusterDeployment.
+func manifestsName(cd *hivev1.ClusterDeployment) string {
+	return fmt.Sprintf("%s-manifests", cd.Name)
+}
@@ -0,0 +1,114 @@
+package clusterpool
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/davegardnerisme/deephash"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// rolloverPoolVersion extends the pool version with the release image of the ClusterImageSet of a pool with
+// ReleaseRollover configured, so that clusters installed from an older release image are stale. The pool version is
+// left unchanged at the base release image of the pool, as the clusters created before ReleaseRollover was enabled
+// were installed from it.
+func rolloverPoolVersion(poolVersion, releaseImage, baseReleaseImage string) string {
+	if releaseImage == baseReleaseImage {
+		return poolVersion
+	}
+	ba := append([]byte(poolVersion), deephash.Hash(releaseImage)...)
+	return fmt.Sprintf("%x", deephash.Hash(ba))
+}
+
+// poolReleaseImage returns the release image of the ClusterImageSet of the pool. If the ClusterImageSet does not exist
+// or has no release image yet, the release image last recorded in the status of the pool is returned, so that the
+// clusters of the pool are not treated as stale while the ClusterImageSet is unavailable.
+func (r *ReconcileClusterPool) poolReleaseImage(clp *hivev1.ClusterPool, logger log.FieldLogger) (string, error) {
+	imageSet := &hivev1.ClusterImageSet{}
+	switch err := r.Get(context.Background(), client.ObjectKey{Name: clp.Spec.ImageSetRef.Name}, imageSet); {
+	case apierrors.IsNotFound(err):
+		logger.WithField("clusterImageSet", clp.Spec.ImageSetRef.Name).Debug("cluster image set not found")
+		return clp.Status.ReleaseImage, nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster image set")
+		return "", errors.Wrap(err, "could not get ClusterImageSet")
+	case imageSet.Spec.ReleaseImage == "":
+		return clp.Status.ReleaseImage, nil
+	}
+	return imageSet.Spec.ReleaseImage, nil
+}
+
+// rollOverStaleClusters deletes a batch of stale clusters of a pool with ReleaseRollover configured, once the
+// configured interval has passed since the previous batch. If the interval has not yet passed, the time left is
+// returned.
+func (r *ReconcileClusterPool) rollOverStaleClusters(clp *hivev1.ClusterPool, cds *cdCollection, availableCurrent int, logger log.FieldLogger) (time.Duration, error) {
+	rollover := clp.Spec.ReleaseRollover
+	if rollover.Interval != nil && clp.Status.LastRolloverTime != nil {
+		if wait := rollover.Interval.Duration - time.Since(clp.Status.LastRolloverTime.Time); wait > 0 {
+			logger.WithField("wait", wait).Debug("waiting for rollover interval before deleting stale clusters")
+			return wait, nil
+		}
+	}
+	batchSize := 1
+	if rollover.MaxConcurrent != nil {
+		batchSize = int(*rollover.MaxConcurrent)
+	}
+	count := minIntVarible(batchSize, len(cds.Stale()), availableCurrent)
+	if count <= 0 {
+		return 0, nil
+	}
+	// Copy the stale clusters, as deleting a cluster removes it from the collection.
+	toDelete := make([]*hivev1.ClusterDeployment, count)
+	copy(toDelete, cds.Stale())
+	for _, cd := range toDelete {
+		logger := logger.WithField("cluster", cd.Name)
+		logger.Info("deleting stale cluster deployment to roll over pool release")
+		if err := cds.Delete(r.Client, cd.Name); err != nil {
+			logger.WithError(err).Error("error deleting cluster deployment")
+			return 0, err
+		}
+		metricStaleClusterDeploymentsDeleted.WithLabelValues(clp.Namespace, clp.Name).Inc()
+	}
+	now := metav1.Now()
+	clp.Status.LastRolloverTime = &now
+	if err := r.Status().Update(context.Background(), clp); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool rollover time")
+		return 0, errors.Wrap(err, "could not update ClusterPool rollover time")
+	}
+	return 0, nil
+}
+
+// requestsForClusterImageSet returns the pools with ReleaseRollover configured that use the ClusterImageSet.
+func requestsForClusterImageSet(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		cpList := &hivev1.ClusterPoolList{}
+		if err := c.List(ctx, cpList); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list cluster pools for cluster image set")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, cpl := range cpList.Items {
+			if cpl.Spec.ReleaseRollover == nil || cpl.Spec.ImageSetRef.Name != o.GetName() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: cpl.Namespace,
+					Name:      cpl.Name,
+				},
+			})
+		}
+		return requests
+	}
+}
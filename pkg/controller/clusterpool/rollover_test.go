@@ -0,0 +1,249 @@
+package clusterpool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+func TestReconcileClusterPool_ReleaseRollover(t *testing.T) {
+	scheme := scheme.GetScheme()
+	const (
+		oldImage = "quay.io/openshift-release-dev/ocp-release@sha256:old"
+		newImage = "quay.io/openshift-release-dev/ocp-release@sha256:new"
+		// baseImage is the release image of the pool when ReleaseRollover was enabled.
+		baseImage = "quay.io/openshift-release-dev/ocp-release@sha256:base"
+	)
+
+	poolBuilder := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme).
+		GenericOptions(
+			testgeneric.WithFinalizer(finalizer),
+		).
+		Options(
+			testcp.ForAWS(credsSecretName, "us-east-1"),
+			testcp.WithBaseDomain("test-domain"),
+			testcp.WithImageSet(imageSetName),
+			testcp.WithSize(3),
+			func(pool *hivev1.ClusterPool) {
+				pool.Status.Conditions, _ = controllerutils.InitializeClusterPoolConditions(nil, clusterPoolConditions)
+			},
+		)
+	basePoolVersion := calculatePoolVersion(poolBuilder.Build())
+	withStatusReleaseImage := func(image string) testcp.Option {
+		return func(pool *hivev1.ClusterPool) {
+			pool.Status.ReleaseImage = image
+			pool.Status.BaseReleaseImage = baseImage
+		}
+	}
+	withLastRolloverTime := func(t time.Time) testcp.Option {
+		return func(pool *hivev1.ClusterPool) {
+			pool.Status.LastRolloverTime = &metav1.Time{Time: t}
+		}
+	}
+	installedCDs := func(poolVersion string) []runtime.Object {
+		var cds []runtime.Object
+		for _, name := range []string{"c1", "c2", "c3"} {
+			cds = append(cds, testcd.FullBuilder(name, name, scheme).Build(
+				testcd.WithPowerState(hivev1.ClusterPowerStateHibernating),
+				testcd.WithUnclaimedClusterPoolReference(testNamespace, testLeasePoolName),
+				testcd.WithPoolVersion(poolVersion),
+				testcd.Installed(),
+			))
+		}
+		return cds
+	}
+
+	tests := []struct {
+		name                 string
+		pool                 *hivev1.ClusterPool
+		cds                  []runtime.Object
+		imageSetReleaseImage string
+		expectedDeleted      int
+		expectedReleaseImage string
+		expectedBaseImage    string
+		expectRolloverTime   bool
+		expectRequeue        bool
+	}{
+		{
+			name: "release changed",
+			pool: poolBuilder.Build(
+				testcp.WithReleaseRollover(&hivev1.ClusterPoolReleaseRollover{MaxConcurrent: pointer.Int32(2)}),
+				withStatusReleaseImage(oldImage),
+			),
+			cds:                  installedCDs(rolloverPoolVersion(basePoolVersion, oldImage, baseImage)),
+			imageSetReleaseImage: newImage,
+			expectedDeleted:      2,
+			expectedReleaseImage: newImage,
+			expectRolloverTime:   true,
+		},
+		{
+			name: "one cluster at a time by default",
+			pool: poolBuilder.Build(
+				testcp.WithReleaseRollover(&hivev1.ClusterPoolReleaseRollover{}),
+				withStatusReleaseImage(oldImage),
+			),
+			cds:                  installedCDs(rolloverPoolVersion(basePoolVersion, oldImage, baseImage)),
+			imageSetReleaseImage: newImage,
+			expectedDeleted:      1,
+			expectedReleaseImage: newImage,
+			expectRolloverTime:   true,
+		},
+		{
+			name: "interval not elapsed",
+			pool: poolBuilder.Build(
+				testcp.WithReleaseRollover(&hivev1.ClusterPoolReleaseRollover{
+					Interval: &metav1.Duration{Duration: time.Hour},
+				}),
+				withStatusReleaseImage(newImage),
+				withLastRolloverTime(time.Now().Add(-10*time.Minute)),
+			),
+			cds:                  installedCDs(rolloverPoolVersion(basePoolVersion, oldImage, baseImage)),
+			imageSetReleaseImage: newImage,
+			expectedReleaseImage: newImage,
+			expectRolloverTime:   true,
+			expectRequeue:        true,
+		},
+		{
+			name: "interval elapsed",
+			pool: poolBuilder.Build(
+				testcp.WithReleaseRollover(&hivev1.ClusterPoolReleaseRollover{
+					Interval: &metav1.Duration{Duration: time.Hour},
+				}),
+				withStatusReleaseImage(newImage),
+				withLastRolloverTime(time.Now().Add(-2*time.Hour)),
+			),
+			cds:                  installedCDs(rolloverPoolVersion(basePoolVersion, oldImage, baseImage)),
+			imageSetReleaseImage: newImage,
+			expectedDeleted:      1,
+			expectedReleaseImage: newImage,
+			expectRolloverTime:   true,
+		},
+		{
+			name: "release unchanged",
+			pool: poolBuilder.Build(
+				testcp.WithReleaseRollover(&hivev1.ClusterPoolReleaseRollover{MaxConcurrent: pointer.Int32(2)}),
+				withStatusReleaseImage(oldImage),
+			),
+			cds:                  installedCDs(rolloverPoolVersion(basePoolVersion, oldImage, baseImage)),
+			imageSetReleaseImage: oldImage,
+			expectedReleaseImage: oldImage,
+		},
+		{
+			name: "release image of ClusterImageSet not yet resolved",
+			pool: poolBuilder.Build(
+				testcp.WithReleaseRollover(&hivev1.ClusterPoolReleaseRollover{MaxConcurrent: pointer.Int32(2)}),
+				withStatusReleaseImage(oldImage),
+			),
+			cds:                  installedCDs(rolloverPoolVersion(basePoolVersion, oldImage, baseImage)),
+			expectedReleaseImage: oldImage,
+		},
+		{
+			name: "rollover enabled",
+			pool: poolBuilder.Build(
+				testcp.WithReleaseRollover(&hivev1.ClusterPoolReleaseRollover{MaxConcurrent: pointer.Int32(2)}),
+			),
+			cds:                  installedCDs(basePoolVersion),
+			imageSetReleaseImage: oldImage,
+			expectedReleaseImage: oldImage,
+			expectedBaseImage:    oldImage,
+		},
+		{
+			name: "release changed after rollover enabled",
+			pool: poolBuilder.Build(
+				testcp.WithReleaseRollover(&hivev1.ClusterPoolReleaseRollover{MaxConcurrent: pointer.Int32(2)}),
+				withStatusReleaseImage(baseImage),
+			),
+			cds:                  installedCDs(basePoolVersion),
+			imageSetReleaseImage: newImage,
+			expectedDeleted:      2,
+			expectedReleaseImage: newImage,
+			expectRolloverTime:   true,
+		},
+		{
+			name: "rollover disabled",
+			pool: poolBuilder.Build(
+				withStatusReleaseImage(oldImage),
+			),
+			cds:                  installedCDs(basePoolVersion),
+			imageSetReleaseImage: newImage,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			existing := append([]runtime.Object{
+				test.pool,
+				&hivev1.ClusterImageSet{
+					ObjectMeta: metav1.ObjectMeta{Name: imageSetName},
+					Spec: hivev1.ClusterImageSetSpec{
+						ReleaseImage: test.imageSetReleaseImage,
+						Channel:      &hivev1.ClusterImageSetChannel{Name: "stable-4.14"},
+					},
+				},
+				testsecret.FullBuilder(testNamespace, credsSecretName, scheme).
+					Build(testsecret.WithDataKeyValue("dummykey", []byte("dummyval"))),
+			}, test.cds...)
+			fakeClient := testfake.NewFakeClientBuilder().
+				WithIndex(&hivev1.ClusterDeployment{}, cdClusterPoolIndex, indexClusterDeploymentsByClusterPool).
+				WithIndex(&hivev1.ClusterClaim{}, claimClusterPoolIndex, indexClusterClaimsByClusterPool).
+				WithRuntimeObjects(existing...).
+				Build()
+			logger := log.New()
+			rcp := &ReconcileClusterPool{
+				Client:       fakeClient,
+				logger:       logger,
+				expectations: controllerutils.NewExpectations(logger),
+			}
+
+			result, err := rcp.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testLeasePoolName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			if test.expectRequeue {
+				assert.True(t, result.RequeueAfter > 0 && result.RequeueAfter <= 50*time.Minute,
+					"unexpected requeue after %s", result.RequeueAfter)
+			} else {
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue")
+			}
+
+			cds := &hivev1.ClusterDeploymentList{}
+			require.NoError(t, fakeClient.List(context.Background(), cds))
+			deleted := 0
+			for _, cd := range cds.Items {
+				if cd.DeletionTimestamp != nil {
+					deleted++
+				}
+			}
+			deleted += len(test.cds) - len(cds.Items)
+			assert.Equal(t, test.expectedDeleted, deleted, "unexpected number of deleted clusters")
+
+			pool := &hivev1.ClusterPool{}
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testLeasePoolName}, pool))
+			assert.Equal(t, test.expectedReleaseImage, pool.Status.ReleaseImage, "unexpected pool release image")
+			expectedBaseImage := test.expectedBaseImage
+			if expectedBaseImage == "" && pool.Spec.ReleaseRollover != nil {
+				expectedBaseImage = baseImage
+			}
+			assert.Equal(t, expectedBaseImage, pool.Status.BaseReleaseImage, "unexpected pool base release image")
+			assert.Equal(t, test.expectRolloverTime, pool.Status.LastRolloverTime != nil, "unexpected last rollover time")
+		})
+	}
+}
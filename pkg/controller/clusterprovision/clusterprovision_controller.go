@@ -395,7 +395,7 @@ func (r *ReconcileClusterProvision) reconcileSuccessfulJob(instance *hivev1.Clus
 
 func (r *ReconcileClusterProvision) reconcileFailedJob(instance *hivev1.ClusterProvision, job *batchv1.Job, pLog log.FieldLogger) (reconcile.Result, error) {
 	pLog.Info("install job failed")
-	reason, message := r.parseInstallLog(instance.Spec.InstallLog, pLog)
+	reason, message := r.parseInstallLog(instance.Spec.InstallLog, instance.Namespace, pLog)
 	if controllerutils.IsDeadlineExceeded(job) && reason == unknownReason {
 		reason, message = "AttemptDeadlineExceeded", "Install job failed due to deadline being exceeded for the attempt"
 	}
@@ -633,8 +633,10 @@ func (r *ReconcileClusterProvision) logProvisionSuccessFailureMetric(
 		return
 	}
 	timeMetric := metricInstallFailureSeconds
+	result := resultFailure
 	if stage == hivev1.ClusterProvisionStageComplete {
 		timeMetric = metricInstallSuccessSeconds
+		result = resultSuccess
 	}
 	installVersion := constants.MetricLabelDefaultValue
 	// InstallVersion is set by the imageset job. Can be nil if we never ran that (e.g. minimal install mode).
@@ -648,5 +650,36 @@ func (r *ReconcileClusterProvision) logProvisionSuccessFailureMetric(
 		"workers":         r.getWorkers(*cd),
 		"install_attempt": strconv.Itoa(instance.Spec.Attempt),
 	}
-	timeMetric.Observe(cd, fixedLabels, time.Since(instance.CreationTimestamp.Time).Seconds())
+	now := time.Now()
+	timeMetric.Observe(cd, fixedLabels, now.Sub(instance.CreationTimestamp.Time).Seconds())
+
+	var failedAt *time.Time
+	if result == resultFailure {
+		failedAt = &now
+	}
+	for name, seconds := range installPhaseDurations(instance.Status.Timeline, failedAt) {
+		metricInstallPhaseSeconds.Observe(cd, map[string]string{
+			"phase":           string(name),
+			"platform":        fixedLabels["platform"],
+			"region":          fixedLabels["region"],
+			"cluster_version": installVersion,
+			"result":          result,
+		}, seconds)
+	}
+}
+
+// installPhaseDurations returns how long each phase of the installer timeline took, in seconds. If the install
+// failed at failedAt, the phase that was still in progress, which is the one the installer failed in, lasted until
+// then. Otherwise phases that did not complete are left out.
+func installPhaseDurations(timeline []hivev1.ClusterProvisionPhase, failedAt *time.Time) map[hivev1.ClusterProvisionPhaseName]float64 {
+	durations := make(map[hivev1.ClusterProvisionPhaseName]float64, len(timeline))
+	for _, phase := range timeline {
+		switch {
+		case phase.CompletionTime != nil:
+			durations[phase.Name] = phase.CompletionTime.Sub(phase.StartTime.Time).Seconds()
+		case failedAt != nil:
+			durations[phase.Name] = failedAt.Sub(phase.StartTime.Time).Seconds()
+		}
+	}
+	return durations
 }
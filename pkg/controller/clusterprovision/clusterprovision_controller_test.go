@@ -572,3 +572,44 @@ compute:
 		})
 	}
 }
+
+func Test_installPhaseDurations(t *testing.T) {
+	start := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) metav1.Time {
+		return metav1.NewTime(start.Add(time.Duration(minutes) * time.Minute))
+	}
+	completedAt := func(minutes int) *metav1.Time {
+		t := at(minutes)
+		return &t
+	}
+	timeline := []hivev1.ClusterProvisionPhase{
+		{Name: hivev1.ClusterProvisionPhaseInfrastructureCreation, StartTime: at(0), CompletionTime: completedAt(5)},
+		{Name: hivev1.ClusterProvisionPhaseAPIWait, StartTime: at(5)},
+	}
+	failedAt := start.Add(25 * time.Minute)
+	tests := []struct {
+		name     string
+		failedAt *time.Time
+		want     map[hivev1.ClusterProvisionPhaseName]float64
+	}{
+		{
+			name: "in-progress phase left out",
+			want: map[hivev1.ClusterProvisionPhaseName]float64{
+				hivev1.ClusterProvisionPhaseInfrastructureCreation: 300,
+			},
+		},
+		{
+			name:     "in-progress phase lasts until the failure",
+			failedAt: &failedAt,
+			want: map[hivev1.ClusterProvisionPhaseName]float64{
+				hivev1.ClusterProvisionPhaseInfrastructureCreation: 300,
+				hivev1.ClusterProvisionPhaseAPIWait:                1200,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, installPhaseDurations(timeline, tt.failedAt))
+		})
+	}
+}
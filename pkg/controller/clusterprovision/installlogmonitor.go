@@ -2,13 +2,13 @@ package clusterprovision
 
 import (
 	"context"
-	"regexp"
 	"strings"
 
 	"github.com/ghodss/yaml"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
@@ -24,8 +24,10 @@ const (
 	unknownMessage               = "Cluster install failed but no known errors found in logs"
 )
 
-// parseInstallLog parses install log to monitor for known issues.
-func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldLogger) (string, string) {
+// parseInstallLog parses install log to monitor for known issues. Regexes from the
+// install-log-regexes configmap in the namespace of the ClusterProvision override the regexes
+// with the same name from the configmaps in the hive namespace.
+func (r *ReconcileClusterProvision) parseInstallLog(log *string, namespace string, pLog log.FieldLogger) (string, string) {
 	if log == nil {
 		return unknownReason, logMissingMessage
 	}
@@ -58,15 +60,19 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 	if additionalRegexCMErr := r.Get(context.TODO(), types.NamespacedName{Name: additionalRegexConfigMapName, Namespace: controllerutils.GetHiveNamespace()}, additionalRegexCM); additionalRegexCMErr != nil {
 		pLog.WithError(additionalRegexCMErr).Errorf("error loading %s configmap", additionalRegexConfigMapName)
 	} else {
-		additionalRegexesRaw, ok := additionalRegexCM.Data[regexDataEntryName]
-		if !ok {
-			pLog.Errorf("%s configmap does not have a %q data entry", additionalRegexConfigMapName, regexDataEntryName)
-		} else {
-			if additionalRegexesRaw != "" {
-				if err := yaml.Unmarshal([]byte(additionalRegexesRaw), &additionalRegexes); err != nil {
-					pLog.WithError(err).Errorf("cannot unmarshal data from %s configmap", regexConfigMapName)
-				}
-			}
+		additionalRegexes = unmarshalRegexConfigMap(additionalRegexCM, pLog)
+	}
+
+	// Load the namespace's own regex configmap, which most namespaces won't have.
+	namespaceRegexes := []installLogRegex{}
+	if namespace != controllerutils.GetHiveNamespace() {
+		namespaceRegexCM := &corev1.ConfigMap{}
+		switch err := r.Get(context.TODO(), types.NamespacedName{Name: regexConfigMapName, Namespace: namespace}, namespaceRegexCM); {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			pLog.WithError(err).Errorf("error loading %s configmap from namespace %s", regexConfigMapName, namespace)
+		default:
+			namespaceRegexes = unmarshalRegexConfigMap(namespaceRegexCM, pLog)
 		}
 	}
 
@@ -79,26 +85,29 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 	}
 
 	// Scan log contents for known errors
-	combinedRegexes := append(regexes, additionalRegexes...)
-	for _, ilr := range combinedRegexes {
-		ilrLog := pLog.WithField("regexName", ilr.Name)
-		ilrLog.Debug("parsing regex entry")
-		for _, ss := range ilr.SearchRegexStrings {
-			// Make the expression case insensitive.
-			// NOTE: This works correctly on a regex that already has flaggage. E.g. "(?i)(?s)..."
-			// is equivalent to "(?is)..."
-			ss = "(?i)" + ss
-			ssLog := ilrLog.WithField("searchString", ss)
-			ssLog.Debug("matching search string")
-			switch match, err := regexp.Match(ss, []byte(*log)); {
-			case err != nil:
-				ssLog.WithError(err).Error("unable to compile regex")
-			case match:
-				pLog.WithField("reason", ilr.InstallFailingReason).Info("found known install failure string")
-				return ilr.InstallFailingReason, ilr.InstallFailingMessage
-			}
-		}
+	classifier := newInstallLogClassifier(pLog, namespaceRegexes, regexes, additionalRegexes)
+	if reason, message, found := classifier.classify(*log, pLog); found {
+		return reason, message
 	}
 
 	return unknownReason, *log
 }
+
+// unmarshalRegexConfigMap returns the regexes from an optional regex configmap. Errors are logged, and
+// result in no regexes.
+func unmarshalRegexConfigMap(cm *corev1.ConfigMap, pLog log.FieldLogger) []installLogRegex {
+	regexes := []installLogRegex{}
+	regexesRaw, ok := cm.Data[regexDataEntryName]
+	if !ok {
+		pLog.Errorf("%s configmap does not have a %q data entry", cm.Name, regexDataEntryName)
+		return regexes
+	}
+	if regexesRaw == "" {
+		return regexes
+	}
+	if err := yaml.Unmarshal([]byte(regexesRaw), &regexes); err != nil {
+		pLog.WithError(err).Errorf("cannot unmarshal data from %s configmap", cm.Name)
+		return []installLogRegex{}
+	}
+	return regexes
+}
@@ -1,11 +1,14 @@
 package clusterprovision
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
+	"github.com/ghodss/yaml"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -76,6 +79,7 @@ func TestParseInstallLog(t *testing.T) {
 	tests := []struct {
 		name            string
 		log             *string
+		namespace       string
 		existing        []runtime.Object
 		expectedReason  string
 		expectedMessage *string
@@ -156,9 +160,17 @@ func TestParseInstallLog(t *testing.T) {
 			expectedReason: "TooManyRoute53Zones",
 		},
 		{
-			name:           "Generic ResourceLimitExceeded",
-			log:            pointer.String(genericLimitExceeded),
-			expectedReason: "FallbackResourceLimitExceeded",
+			name:            "Generic ResourceLimitExceeded",
+			log:             pointer.String(genericLimitExceeded),
+			expectedReason:  "FallbackResourceLimitExceeded",
+			expectedMessage: pointer.String("Unknown resource limit exceeded (GenericLimitExceeded) - couldn't parse a specific resource type"),
+		},
+		{
+			name:            "Generic QuotaExceeded",
+			log:             pointer.String(gcpSSDQuotaLog),
+			existing:        []runtime.Object{buildFallbackOnlyRegexConfigMap(t)},
+			expectedReason:  "FallbackQuotaExceeded",
+			expectedMessage: pointer.String("Unknown quota exceeded (SSD_TOTAL_GB) - couldn't parse a specific resource type"),
 		},
 		{
 			name:           "Credentials are invalid",
@@ -274,6 +286,198 @@ func TestParseInstallLog(t *testing.T) {
 			},
 			expectedReason: "KubeAPIWaitTimeoutRegexes",
 		},
+		{
+			name: "additionalRegexes take precedence over fallback regexes",
+			log:  pointer.String(genericLimitExceeded),
+			existing: []runtime.Object{
+				buildRegexConfigMap(),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      additionalRegexConfigMapName,
+						Namespace: constants.DefaultHiveNamespace,
+					},
+					Data: map[string]string{
+						"regexes": `
+- name: GenericLimitExceeded
+  searchRegexStrings:
+  - "GenericLimitExceeded"
+  installFailingReason: GenericLimitExceeded
+  installFailingMessage: Generic limit exceeded
+`,
+					},
+				},
+			},
+			expectedReason:  "GenericLimitExceeded",
+			expectedMessage: pointer.String("Generic limit exceeded"),
+		},
+		{
+			name: "higher priority regexes take precedence",
+			log:  pointer.String(kubeAPIWaitTimeoutLog),
+			existing: []runtime.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      regexConfigMapName,
+						Namespace: constants.DefaultHiveNamespace,
+					},
+					Data: map[string]string{
+						"regexes": `
+- name: KubeAPIWaitTimeout
+  searchRegexStrings:
+  - "waiting for Kubernetes API: context deadline exceeded"
+  installFailingReason: KubeAPIWaitTimeout
+  installFailingMessage: Timeout waiting for the Kubernetes API to begin responding
+- name: KubeAPIWait
+  searchRegexStrings:
+  - "waiting for Kubernetes API"
+  installFailingReason: KubeAPIWait
+  installFailingMessage: Failed waiting for the Kubernetes API
+  priority: 1
+`,
+					},
+				},
+			},
+			expectedReason: "KubeAPIWait",
+		},
+		{
+			name: "extract fields into message",
+			log:  pointer.String(awsEC2QuotaExceeded),
+			existing: []runtime.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      regexConfigMapName,
+						Namespace: constants.DefaultHiveNamespace,
+					},
+					Data: map[string]string{
+						"regexes": `
+- name: AWSEC2QuotaExceeded
+  searchRegexStrings:
+  - "MissingQuota\\): (?P<quota>ec2/[A-Z0-9-]+) is not available in (?P<region>[a-z0-9-]+)"
+  installFailingReason: AWSEC2QuotaExceeded
+  installFailingMessage: AWS EC2 quota ${quota} exceeded in ${region}
+`,
+					},
+				},
+			},
+			expectedReason:  "AWSEC2QuotaExceeded",
+			expectedMessage: pointer.String("AWS EC2 quota ec2/L-1234A56B exceeded in us-east-1"),
+		},
+		{
+			name: "match within search window",
+			log:  pointer.String(route53Timeout),
+			existing: []runtime.Object{
+				buildWindowedRegexConfigMap(3),
+			},
+			expectedReason:  "Route53Timeout",
+			expectedMessage: pointer.String("Timeout waiting for the Route53 hosted zone aws_route53_zone to be created"),
+		},
+		{
+			name: "no match outside search window",
+			log:  pointer.String(route53Timeout),
+			existing: []runtime.Object{
+				buildWindowedRegexConfigMap(2),
+			},
+			expectedReason:  unknownReason,
+			expectedMessage: pointer.String(route53Timeout),
+		},
+		{
+			name:      "namespace regexes add reasons",
+			log:       pointer.String(noMatchLog),
+			namespace: testNamespace,
+			existing: []runtime.Object{
+				buildRegexConfigMap(),
+				buildNamespaceRegexConfigMap(`
+- name: TeamExample
+  searchRegexStrings:
+  - "an (?P<what>example) of something"
+  installFailingReason: TeamExample
+  installFailingMessage: Found an ${what} in the log
+`),
+			},
+			expectedReason:  "TeamExample",
+			expectedMessage: pointer.String("Found an example in the log"),
+		},
+		{
+			name:      "namespace regexes replace hive regexes by name",
+			log:       pointer.String(dnsAlreadyExistsLog),
+			namespace: testNamespace,
+			existing: []runtime.Object{
+				buildRegexConfigMap(),
+				buildNamespaceRegexConfigMap(`
+- name: DNSAlreadyExists
+  searchRegexStrings:
+  - "Tried to create resource record set \\[name='(?P<record>[^']*)'"
+  installFailingReason: DNSAlreadyExists
+  installFailingMessage: DNS record ${record} already exists, delete it from the hosted zone of the team
+`),
+			},
+			expectedReason:  "DNSAlreadyExists",
+			expectedMessage: pointer.String("DNS record api.jh-stg-2405-2.n6b3.s1.devshift.org. already exists, delete it from the hosted zone of the team"),
+		},
+		{
+			name:      "namespace regex without search strings disables hive regex",
+			log:       pointer.String(dnsAlreadyExistsLog),
+			namespace: testNamespace,
+			existing: []runtime.Object{
+				buildRegexConfigMap(),
+				buildNamespaceRegexConfigMap(`
+- name: DNSAlreadyExists
+  installFailingReason: DNSAlreadyExists
+`),
+			},
+			expectedReason:  unknownReason,
+			expectedMessage: pointer.String(dnsAlreadyExistsLog),
+		},
+		{
+			name:      "namespace regexes are searched before hive regexes of equal priority",
+			log:       pointer.String(dnsAlreadyExistsLog),
+			namespace: testNamespace,
+			existing: []runtime.Object{
+				buildRegexConfigMap(),
+				buildNamespaceRegexConfigMap(`
+- name: TeamDNSAlreadyExists
+  searchRegexStrings:
+  - "already exists"
+  installFailingReason: TeamDNSAlreadyExists
+  installFailingMessage: DNS record already exists, delete it from the hosted zone of the team
+`),
+			},
+			expectedReason:  "TeamDNSAlreadyExists",
+			expectedMessage: pointer.String("DNS record already exists, delete it from the hosted zone of the team"),
+		},
+		{
+			name:      "hive regexes of higher priority are searched before namespace regexes",
+			log:       pointer.String(dnsAlreadyExistsLog),
+			namespace: testNamespace,
+			existing: []runtime.Object{
+				buildRegexConfigMap(),
+				buildNamespaceRegexConfigMap(`
+- name: TeamDNSAlreadyExists
+  searchRegexStrings:
+  - "already exists"
+  installFailingReason: TeamDNSAlreadyExists
+  installFailingMessage: DNS record already exists, delete it from the hosted zone of the team
+  priority: -2
+`),
+			},
+			expectedReason:  "DNSAlreadyExists",
+			expectedMessage: pointer.String("DNS record already exists"),
+		},
+		{
+			name:      "namespace regexes in other namespaces are ignored",
+			log:       pointer.String(noMatchLog),
+			namespace: "other-namespace",
+			existing: []runtime.Object{
+				buildRegexConfigMap(),
+				buildNamespaceRegexConfigMap(`
+- name: TeamDNSAlreadyExists
+  searchRegexStrings:
+  - "an example of something"
+  installFailingReason: TeamExample
+  installFailingMessage: Found an example in the log
+`),
+			},
+			expectedReason: unknownReason,
+		},
 		{
 			name:           "no log",
 			expectedReason: unknownReason,
@@ -484,7 +688,11 @@ func TestParseInstallLog(t *testing.T) {
 				Client: fakeClient,
 				scheme: scheme.GetScheme(),
 			}
-			reason, message := r.parseInstallLog(test.log, log.WithFields(log.Fields{}))
+			namespace := test.namespace
+			if namespace == "" {
+				namespace = testNamespace
+			}
+			reason, message := r.parseInstallLog(test.log, namespace, log.WithFields(log.Fields{}))
 			assert.Equal(t, test.expectedReason, reason, "unexpected reason")
 			if test.expectedMessage != nil {
 				assert.Equal(t, *test.expectedMessage, message)
@@ -495,6 +703,51 @@ func TestParseInstallLog(t *testing.T) {
 	}
 }
 
+func TestSearchWindows(t *testing.T) {
+	cases := []struct {
+		name     string
+		text     string
+		size     int
+		expected []string
+	}{
+		{
+			name:     "fewer lines than window",
+			text:     "one\ntwo",
+			size:     3,
+			expected: []string{"one\ntwo"},
+		},
+		{
+			name:     "as many lines as window",
+			text:     "one\ntwo\nthree",
+			size:     3,
+			expected: []string{"one\ntwo\nthree"},
+		},
+		{
+			name:     "sliding window",
+			text:     "one\ntwo\nthree\nfour",
+			size:     2,
+			expected: []string{"one\ntwo", "two\nthree", "three\nfour"},
+		},
+		{
+			name:     "single line windows",
+			text:     "one\n\nthree",
+			size:     1,
+			expected: []string{"one", "", "three"},
+		},
+		{
+			name:     "trailing newline",
+			text:     "one\ntwo\n",
+			size:     2,
+			expected: []string{"one\ntwo", "two\n"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, searchWindows(tc.text, lineOffsets(tc.text), tc.size), "unexpected search windows")
+		})
+	}
+}
+
 // buildRegexConfigMap reads the install log regexes configmap from within config/configmaps/install-log-regexes-configmap.yaml
 func buildRegexConfigMap() runtime.Object {
 	scheme := scheme.GetScheme()
@@ -509,3 +762,57 @@ func buildRegexConfigMap() runtime.Object {
 	}
 	return obj
 }
+
+// buildFallbackOnlyRegexConfigMap builds an install log regexes configmap with only the fallback regexes of the
+// install log regexes configmap.
+func buildFallbackOnlyRegexConfigMap(t *testing.T) runtime.Object {
+	regexes := []installLogRegex{}
+	require.NoError(t, yaml.Unmarshal([]byte(buildRegexConfigMap().(*corev1.ConfigMap).Data[regexDataEntryName]), &regexes))
+	fallbacks := []installLogRegex{}
+	for _, ilr := range regexes {
+		if ilr.Priority < 0 {
+			fallbacks = append(fallbacks, ilr)
+		}
+	}
+	raw, err := yaml.Marshal(fallbacks)
+	require.NoError(t, err)
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      regexConfigMapName,
+			Namespace: constants.DefaultHiveNamespace,
+		},
+		Data: map[string]string{regexDataEntryName: string(raw)},
+	}
+}
+
+// buildNamespaceRegexConfigMap builds an install log regexes configmap in the test namespace with the given regexes.
+func buildNamespaceRegexConfigMap(regexes string) runtime.Object {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      regexConfigMapName,
+			Namespace: testNamespace,
+		},
+		Data: map[string]string{regexDataEntryName: regexes},
+	}
+}
+
+// buildWindowedRegexConfigMap builds an install log regexes configmap with a regex matching the route53Timeout log
+// across its lines.
+func buildWindowedRegexConfigMap(searchWindowLines int) runtime.Object {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      regexConfigMapName,
+			Namespace: constants.DefaultHiveNamespace,
+		},
+		Data: map[string]string{
+			regexDataEntryName: fmt.Sprintf(`
+- name: Route53Timeout
+  searchRegexStrings:
+  - "(?s)error waiting for Route53 Hosted Zone.*timeout while waiting.*in resource \"(?P<resource>[a-z0-9_]+)\""
+  installFailingReason: Route53Timeout
+  installFailingMessage: Timeout waiting for the Route53 hosted zone ${resource} to be created
+  searchWindowLines: %d
+`, searchWindowLines),
+		},
+	}
+}
@@ -1,5 +1,12 @@
 package clusterprovision
 
+import (
+	"regexp"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+)
+
 // installLogRegex is a struct that represents all the data we use to scan for certain
 // search strings in install logs. These structs are serialized as yaml and stored/read from
 // the install-log-regexes ConfigMap.
@@ -8,6 +15,8 @@ type installLogRegex struct {
 	Name string `json:"name"`
 
 	// SearchRegexStrings are the regex strings we will search for.
+	// Named capture groups, e.g. "(?P<code>[A-Za-z.]*LimitExceeded)", can be referenced in the
+	// InstallFailingMessage as ${code}.
 	SearchRegexStrings []string `json:"searchRegexStrings"`
 
 	// InstallFailingReason is the single word CamelCase reason we report for this failure in conditions, metrics and logs.
@@ -15,4 +24,144 @@ type installLogRegex struct {
 
 	// InstallFailingMessage is the user friendly sentence we report for this failure and conditions, metrics and logs.
 	InstallFailingMessage string `json:"installFailingMessage"`
+
+	// Priority orders the regexes: regexes with a higher priority are searched for first. Regexes with
+	// the same priority are searched for in the order in which they are listed. Defaults to 0.
+	Priority int `json:"priority,omitempty"`
+
+	// SearchWindowLines, when set, limits each match to this many consecutive lines of the install log,
+	// so that a regex using the (?s) flag can match an error spread across neighbouring lines without
+	// matching unrelated lines further away. By default regexes are matched against the whole log.
+	SearchWindowLines int `json:"searchWindowLines,omitempty"`
+}
+
+// installLogClassifier finds the reason for an install failure by matching the install log against
+// installLogRegexes, in order of precedence.
+type installLogClassifier struct {
+	regexes []compiledInstallLogRegex
+}
+
+type compiledInstallLogRegex struct {
+	installLogRegex
+	searchRegexes []*regexp.Regexp
+}
+
+// newInstallLogClassifier compiles the given lists of regexes, which are listed in order of
+// precedence. A regex replaces the regexes with the same name in the lists that follow it. Search
+// strings that cannot be compiled are logged and skipped.
+func newInstallLogClassifier(pLog log.FieldLogger, regexLists ...[]installLogRegex) *installLogClassifier {
+	c := &installLogClassifier{}
+	names := map[string]bool{}
+	for _, regexes := range regexLists {
+		listNames := map[string]bool{}
+		for _, ilr := range regexes {
+			if names[ilr.Name] {
+				pLog.WithField("regexName", ilr.Name).Debug("regex entry is overridden")
+				continue
+			}
+			listNames[ilr.Name] = true
+			compiled := compiledInstallLogRegex{installLogRegex: ilr}
+			for _, ss := range ilr.SearchRegexStrings {
+				// Make the expression case insensitive.
+				// NOTE: This works correctly on a regex that already has flaggage. E.g. "(?i)(?s)..."
+				// is equivalent to "(?is)..."
+				ss = "(?i)" + ss
+				re, err := regexp.Compile(ss)
+				if err != nil {
+					pLog.WithField("regexName", ilr.Name).WithField("searchString", ss).WithError(err).Error("unable to compile regex")
+					continue
+				}
+				compiled.searchRegexes = append(compiled.searchRegexes, re)
+			}
+			c.regexes = append(c.regexes, compiled)
+		}
+		for name := range listNames {
+			names[name] = true
+		}
+	}
+	sort.SliceStable(c.regexes, func(i, j int) bool {
+		return c.regexes[i].Priority > c.regexes[j].Priority
+	})
+	return c
+}
+
+// classify returns the reason and message of the first regex found in the install log. The message
+// has the values of the named capture groups of the search string that matched expanded into it.
+func (c *installLogClassifier) classify(installLog string, pLog log.FieldLogger) (reason, message string, found bool) {
+	var lineStarts []int
+	for _, ilr := range c.regexes {
+		ilrLog := pLog.WithField("regexName", ilr.Name)
+		ilrLog.Debug("parsing regex entry")
+		searchTexts := []string{installLog}
+		if ilr.SearchWindowLines > 0 {
+			if lineStarts == nil {
+				lineStarts = lineOffsets(installLog)
+			}
+			searchTexts = searchWindows(installLog, lineStarts, ilr.SearchWindowLines)
+		}
+		for _, re := range ilr.searchRegexes {
+			ssLog := ilrLog.WithField("searchString", re.String())
+			ssLog.Debug("matching search string")
+			for _, text := range searchTexts {
+				match := re.FindStringSubmatchIndex(text)
+				if match == nil {
+					continue
+				}
+				message := ilr.InstallFailingMessage
+				if fields := extractFields(re, text, match); len(fields) > 0 {
+					ssLog = ssLog.WithFields(fields)
+					message = string(re.ExpandString(nil, message, text, match))
+				}
+				ssLog.WithField("reason", ilr.InstallFailingReason).Info("found known install failure string")
+				return ilr.InstallFailingReason, message, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// lineOffsets returns the offset of the start of each line of the text.
+func lineOffsets(text string) []int {
+	offsets := []int{0}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
+// searchWindows returns the texts of each run of size consecutive lines, given the offsets of the start of the lines
+// of the text. The windows are slices of the text rather than copies of its lines, so building them takes no more
+// than a pass over the lines however large the window.
+func searchWindows(text string, lineStarts []int, size int) []string {
+	if len(lineStarts) <= size {
+		return []string{text}
+	}
+	windows := make([]string, 0, len(lineStarts)-size+1)
+	for i := 0; i+size <= len(lineStarts); i++ {
+		end := len(text)
+		if i+size < len(lineStarts) {
+			// Leave out the newline ending the last line of the window.
+			end = lineStarts[i+size] - 1
+		}
+		windows = append(windows, text[lineStarts[i]:end])
+	}
+	return windows
+}
+
+// extractFields returns the values of the named capture groups of a match. Groups that did not
+// participate in the match have empty values.
+func extractFields(re *regexp.Regexp, text string, match []int) log.Fields {
+	fields := log.Fields{}
+	for i, name := range re.SubexpNames() {
+		switch {
+		case name == "":
+		case match[2*i] < 0:
+			fields[name] = ""
+		default:
+			fields[name] = text[match[2*i]:match[2*i+1]]
+		}
+	}
+	return fields
 }
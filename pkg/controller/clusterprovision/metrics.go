@@ -16,6 +16,7 @@ var (
 
 	metricInstallFailureSeconds hivemetrics.HistogramVecWithDynamicLabels
 	metricInstallSuccessSeconds hivemetrics.HistogramVecWithDynamicLabels
+	metricInstallPhaseSeconds   hivemetrics.HistogramVecWithDynamicLabels
 )
 
 func registerMetrics(mConfig *metricsconfig.MetricsConfig, log log.FieldLogger) {
@@ -56,9 +57,19 @@ func registerMetrics(mConfig *metricsconfig.MetricsConfig, log log.FieldLogger)
 		[]string{"platform", "region", "cluster_version", "workers", "install_attempt"},
 		mapClusterTypeLabelToValue,
 	)
+	metricInstallPhaseSeconds = *hivemetrics.NewHistogramVecWithDynamicLabels(
+		&prometheus.HistogramOpts{
+			Name:    "hive_cluster_provision_install_phase_duration_seconds",
+			Help:    "Time taken by each phase of the installer, observed when the cluster provision finishes. The phase a failed provision failed in is observed up to the failure.",
+			Buckets: []float64{60, 120, 300, 600, 900, 1200, 1800, 2700, 3600},
+		},
+		[]string{"phase", "platform", "region", "cluster_version", "result"},
+		mapClusterTypeLabelToValue,
+	)
 
 	metricInstallErrors.Register()
 	metricClusterProvisionsTotal.Register()
 	metricInstallFailureSeconds.Register()
 	metricInstallSuccessSeconds.Register()
+	metricInstallPhaseSeconds.Register()
 }
@@ -0,0 +1,151 @@
+package clusterquota
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ClusterQuotaControllerName
+)
+
+// Add creates a new ClusterQuota Controller and adds it to the Manager with default RBAC. The Manager will set fields
+// on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	return &ReconcileClusterQuota{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewDelayingReconciler(r, log.WithField("controller", ControllerName)),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterQuotas
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.ClusterQuota{}), &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Any change to a ClusterDeployment, ClusterPool or Namespace may affect the usage of any quota. There are
+	// typically few quotas, so rather than working out which quotas select the object we requeue all of them.
+	allQuotas := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+		quotaList := &hivev1.ClusterQuotaList{}
+		if err := mgr.GetClient().List(ctx, quotaList); err != nil {
+			log.WithField("controller", ControllerName).WithError(err).Error("failed to list ClusterQuotas")
+			return nil
+		}
+		requests := make([]reconcile.Request, len(quotaList.Items))
+		for i, quota := range quotaList.Items {
+			requests[i].NamespacedName = types.NamespacedName{Name: quota.Name}
+		}
+		return requests
+	})
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.ClusterDeployment{}), allQuotas); err != nil {
+		return err
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.ClusterPool{}), allQuotas); err != nil {
+		return err
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.Namespace{}), allQuotas); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterQuota{}
+
+// ReconcileClusterQuota reconciles a ClusterQuota object for the purpose of reporting its usage.
+type ReconcileClusterQuota struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// Reconcile computes the usage of a ClusterQuota and records it in the status.
+func (r *ReconcileClusterQuota) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterQuota", request.NamespacedName)
+	logger.Info("reconciling cluster quota")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	quota := &hivev1.ClusterQuota{}
+	switch err := r.Get(context.Background(), request.NamespacedName, quota); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("cluster quota not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Error("error reading cluster quota")
+		return reconcile.Result{}, err
+	}
+
+	if quota.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	consumption, err := controllerutils.GetClusterQuotaConsumption(r, quota)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not compute cluster quota usage")
+		return reconcile.Result{}, err
+	}
+
+	status := hivev1.ClusterQuotaStatus{
+		Used:       consumption.Used,
+		Namespaces: int32(consumption.Namespaces.Len()),
+	}
+	if reflect.DeepEqual(status, quota.Status) {
+		return reconcile.Result{}, nil
+	}
+	quota.Status = status
+	logger.WithFields(log.Fields{
+		"clusterDeployments":        status.Used.ClusterDeployments,
+		"runningClusterDeployments": status.Used.RunningClusterDeployments,
+		"clusterPoolSize":           status.Used.ClusterPoolSize,
+	}).Info("updating cluster quota usage")
+	if err := r.Status().Update(context.Background(), quota); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster quota status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
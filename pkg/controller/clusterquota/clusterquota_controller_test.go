@@ -0,0 +1,158 @@
+package clusterquota
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testnamespace "github.com/openshift/hive/pkg/test/namespace"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	quotaName    = "test-quota"
+	tenantLabel  = "tenant"
+	tenantName   = "team-a"
+	namespaceA1  = "team-a-1"
+	namespaceA2  = "team-a-2"
+	namespaceB   = "team-b"
+	poolNSPrefix = "pool-cluster-"
+)
+
+func TestReconcileClusterQuota(t *testing.T) {
+	scheme := scheme.GetScheme()
+
+	tenantNamespace := func(name, tenant string) runtime.Object {
+		return testnamespace.FullBuilder(name, scheme).GenericOptions(testgeneric.WithLabel(tenantLabel, tenant)).Build()
+	}
+	cd := func(namespace, name string, opts ...testcd.Option) runtime.Object {
+		return testcd.FullBuilder(namespace, name, scheme).Build(opts...)
+	}
+	pool := func(namespace, name string, opts ...testcp.Option) runtime.Object {
+		return testcp.FullBuilder(namespace, name, scheme).Build(opts...)
+	}
+
+	cases := []struct {
+		name               string
+		existing           []runtime.Object
+		existingStatus     hivev1.ClusterQuotaStatus
+		expectedStatus     hivev1.ClusterQuotaStatus
+		expectStatusUpdate bool
+	}{
+		{
+			name: "no namespaces selected",
+			existing: []runtime.Object{
+				tenantNamespace(namespaceB, "team-b"),
+				cd(namespaceB, "cd1"),
+			},
+			expectedStatus: hivev1.ClusterQuotaStatus{},
+		},
+		{
+			name: "usage across selected namespaces",
+			existing: []runtime.Object{
+				tenantNamespace(namespaceA1, tenantName),
+				tenantNamespace(namespaceA2, tenantName),
+				tenantNamespace(namespaceB, "team-b"),
+				cd(namespaceA1, "cd1"),
+				cd(namespaceA1, "cd2", testcd.WithPowerState(hivev1.ClusterPowerStateHibernating)),
+				cd(namespaceA2, "cd3", testcd.WithPowerState(hivev1.ClusterPowerStateRunning)),
+				cd(namespaceB, "cd4"),
+				pool(namespaceA1, "pool1", testcp.WithSize(2)),
+				pool(namespaceA2, "pool2", testcp.WithSize(1), testcp.WithAutoscaling(&hivev1.ClusterPoolAutoscaling{MaxSize: 4})),
+				pool(namespaceB, "pool3", testcp.WithSize(5)),
+			},
+			expectedStatus: hivev1.ClusterQuotaStatus{
+				Used: hivev1.ClusterQuotaUsage{
+					ClusterDeployments:        3,
+					RunningClusterDeployments: 2,
+					ClusterPoolSize:           6,
+				},
+				Namespaces: 2,
+			},
+			expectStatusUpdate: true,
+		},
+		{
+			name: "pool clusters counted against pool namespace",
+			existing: []runtime.Object{
+				tenantNamespace(namespaceA1, tenantName),
+				tenantNamespace(poolNSPrefix+"1", ""),
+				tenantNamespace(poolNSPrefix+"2", ""),
+				cd(poolNSPrefix+"1", "cd1", testcd.WithUnclaimedClusterPoolReference(namespaceA1, "pool1")),
+				cd(poolNSPrefix+"2", "cd2",
+					testcd.WithClusterPoolReference(namespaceB, "pool2", "claim"),
+					testcd.WithPowerState(hivev1.ClusterPowerStateHibernating),
+				),
+			},
+			expectedStatus: hivev1.ClusterQuotaStatus{
+				Used: hivev1.ClusterQuotaUsage{
+					ClusterDeployments:        1,
+					RunningClusterDeployments: 1,
+				},
+				Namespaces: 1,
+			},
+			expectStatusUpdate: true,
+		},
+		{
+			name: "status unchanged",
+			existing: []runtime.Object{
+				tenantNamespace(namespaceA1, tenantName),
+				cd(namespaceA1, "cd1"),
+			},
+			existingStatus: hivev1.ClusterQuotaStatus{
+				Used:       hivev1.ClusterQuotaUsage{ClusterDeployments: 1, RunningClusterDeployments: 1},
+				Namespaces: 1,
+			},
+			expectedStatus: hivev1.ClusterQuotaStatus{
+				Used:       hivev1.ClusterQuotaUsage{ClusterDeployments: 1, RunningClusterDeployments: 1},
+				Namespaces: 1,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			quota := &hivev1.ClusterQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            quotaName,
+					ResourceVersion: "1",
+				},
+				Spec: hivev1.ClusterQuotaSpec{
+					NamespaceSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{tenantLabel: tenantName},
+					},
+					Hard: hivev1.ClusterQuotaLimits{ClusterDeployments: pointer.Int32Ptr(10)},
+				},
+				Status: tc.existingStatus,
+			}
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(append(tc.existing, quota)...).Build()
+			r := &ReconcileClusterQuota{
+				Client: c,
+				logger: log.WithField("controller", "clusterquota"),
+			}
+
+			_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: quotaName}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			actual := &hivev1.ClusterQuota{}
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: quotaName}, actual), "could not get quota")
+			assert.Equal(t, tc.expectedStatus, actual.Status, "unexpected quota status")
+			if tc.expectStatusUpdate {
+				assert.NotEqual(t, "1", actual.ResourceVersion, "expected status to be updated")
+			} else {
+				assert.Equal(t, "1", actual.ResourceVersion, "expected no status update")
+			}
+		})
+	}
+}
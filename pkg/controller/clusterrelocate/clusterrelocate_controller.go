@@ -219,6 +219,17 @@ func (r *ReconcileClusterRelocate) Reconcile(ctx context.Context, request reconc
 		currentRelocateName = ""
 	}
 
+	// Roll back the relocate when the ClusterRelocate has been cancelled while the relocation is still outgoing. A
+	// relocation that has completed is left alone since the destination cluster now manages the cluster.
+	if relocateStatus == hivev1.RelocateOutgoing {
+		switch cr, err := r.getRelocate(currentRelocateName, logger); {
+		case err != nil:
+			return reconcile.Result{}, err
+		case cr != nil && cr.Spec.Cancel:
+			return r.rollBackRelocate(cd, cr, logger)
+		}
+	}
+
 	if cd.DeletionTimestamp != nil {
 		// Stop relocating if the ClusterDeployment was deleted prior to completing the relocation
 		if relocateStatus == hivev1.RelocateOutgoing {
@@ -226,6 +237,10 @@ func (r *ReconcileClusterRelocate) Reconcile(ctx context.Context, request reconc
 			if err := r.stopRelocating(cd, currentRelocateName, logger); err != nil {
 				return reconcile.Result{}, errors.Wrap(err, "failed to stop relocating")
 			}
+			if err := r.updateClusterStatus(currentRelocateName, cd, hivev1.FailedClusterRelocatePhase,
+				"relocation aborted because the ClusterDeployment was deleted", nil, logger); err != nil {
+				return reconcile.Result{}, err
+			}
 		} else {
 			logger.Debug("skipping deleted clusterdeployment")
 		}
@@ -234,7 +249,7 @@ func (r *ReconcileClusterRelocate) Reconcile(ctx context.Context, request reconc
 
 	// Skip any copying actions for ClusterDeployment that has already been relocated
 	if relocateStatus == hivev1.RelocateComplete {
-		return r.finishRelocateCompletion(cd, currentRelocateName, logger)
+		return r.finishRelocateCompletion(cd, currentRelocateName, nil, logger)
 	}
 
 	desiredRelocates, err := r.findMatchingRelocates(cd, logger)
@@ -260,61 +275,50 @@ func (r *ReconcileClusterRelocate) reconcileSingleMatch(cd *hivev1.ClusterDeploy
 		if err := r.stopRelocating(cd, oldRelocateName, logger); err != nil {
 			return reconcile.Result{}, err
 		}
+		if err := r.updateClusterStatus(oldRelocateName, cd, hivev1.FailedClusterRelocatePhase,
+			fmt.Sprintf("relocation aborted because the ClusterDeployment matches ClusterRelocate %s", desiredRelocate.Name),
+			nil, logger); err != nil {
+			return reconcile.Result{}, err
+		}
 		recordMetricForAbortedRelocate(oldRelocateName, "new_match")
 	}
 
 	logger = logger.WithField("clusterRelocate", desiredRelocate.Name)
 
-	kubeconfigSecret := &corev1.Secret{}
-	if err := r.Get(
-		context.Background(),
-		client.ObjectKey{
-			Namespace: desiredRelocate.Spec.KubeconfigSecretRef.Namespace,
-			Name:      desiredRelocate.Spec.KubeconfigSecretRef.Name,
-		},
-		kubeconfigSecret,
-	); err != nil {
-		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get kubeconfig secret")
-		r.setRelocationFailedCondition(
-			cd,
-			corev1.ConditionTrue,
-			"MissingKubeconfigSecret",
-			fmt.Sprintf("missing kubeconfig secret for destination cluster: %v", err),
-			logger,
-		)
-		// return the error getting the kubeconfig secret rather than the update error
-		return reconcile.Result{}, errors.Wrap(err, "failed to get kubeconfig secret")
+	if desiredRelocate.Spec.Cancel {
+		return r.reconcileCancelled(cd, desiredRelocate, logger)
 	}
 
-	destClient, err := r.remoteClusterAPIClientBuilder(kubeconfigSecret).Build()
+	if desiredRelocate.Spec.DryRun {
+		return r.reconcileDryRun(cd, oldRelocateStatus, oldRelocateName, desiredRelocate, logger)
+	}
+
+	destClient, err := r.destinationClient(cd, desiredRelocate, logger)
 	if err != nil {
-		logger.WithError(err).Warn("could not create a client for the destination cluster")
-		r.setRelocationFailedCondition(
-			cd,
-			corev1.ConditionTrue,
-			"NoConnection",
-			fmt.Sprintf("could not connect to destination cluster: %v", err),
-			logger,
-		)
-		// return the error making the remote connection rather than the update error
-		return reconcile.Result{}, errors.Wrap(err, "could not create a client for the destination cluster")
+		return reconcile.Result{}, err
 	}
 
 	switch proceed, completed, err := r.checkForExistingClusterDeployment(cd, destClient, logger); {
 	case err != nil:
 		return reconcile.Result{}, err
 	case completed:
-		return r.finishRelocateCompletion(cd, desiredRelocate.Name, logger)
+		return r.finishRelocateCompletion(cd, desiredRelocate.Name, nil, logger)
 	case !proceed:
-		return reconcile.Result{}, nil
+		return reconcile.Result{}, r.updateClusterStatus(desiredRelocate.Name, cd, hivev1.FailedClusterRelocatePhase,
+			"the ClusterDeployment in the destination cluster does not match the one being relocated", nil, logger)
 	}
 
 	if err := r.setRelocateAnnotation(cd, desiredRelocate.Name, hivev1.RelocateOutgoing, logger); err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "could not set relocate status to outgoing")
 	}
 
+	if err := r.updateClusterStatus(desiredRelocate.Name, cd, hivev1.CopyingClusterRelocatePhase, "", []string{}, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Copy resources to destination cluster
-	if err := r.copy(cd, destClient, logger); err != nil {
+	copied, err := r.copy(cd, desiredRelocate.Name, destClient, logger)
+	if err != nil {
 		r.setRelocationFailedCondition(
 			cd,
 			corev1.ConditionTrue,
@@ -322,11 +326,71 @@ func (r *ReconcileClusterRelocate) reconcileSingleMatch(cd *hivev1.ClusterDeploy
 			err.Error(),
 			logger,
 		)
+		if copied == nil {
+			copied = []string{}
+		}
+		r.updateClusterStatus(desiredRelocate.Name, cd, hivev1.FailedClusterRelocatePhase, err.Error(), copied, logger)
 		// return the move error rather than the update error
 		return reconcile.Result{}, err
 	}
 
-	return r.finishRelocateCompletion(cd, desiredRelocate.Name, logger)
+	return r.finishRelocateCompletion(cd, desiredRelocate.Name, copied, logger)
+}
+
+// destinationClient builds a client for the destination cluster of the ClusterRelocate. Failures are recorded on the
+// ClusterDeployment and in the status of the ClusterRelocate.
+func (r *ReconcileClusterRelocate) destinationClient(cd *hivev1.ClusterDeployment, cr *hivev1.ClusterRelocate, logger log.FieldLogger) (client.Client, error) {
+	kubeconfigSecret := &corev1.Secret{}
+	if err := r.Get(
+		context.Background(),
+		client.ObjectKey{
+			Namespace: cr.Spec.KubeconfigSecretRef.Namespace,
+			Name:      cr.Spec.KubeconfigSecretRef.Name,
+		},
+		kubeconfigSecret,
+	); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get kubeconfig secret")
+		message := fmt.Sprintf("missing kubeconfig secret for destination cluster: %v", err)
+		r.setRelocationFailedCondition(cd, corev1.ConditionTrue, "MissingKubeconfigSecret", message, logger)
+		r.updateClusterStatus(cr.Name, cd, hivev1.FailedClusterRelocatePhase, message, nil, logger)
+		// return the error getting the kubeconfig secret rather than the update error
+		return nil, errors.Wrap(err, "failed to get kubeconfig secret")
+	}
+
+	destClient, err := r.remoteClusterAPIClientBuilder(kubeconfigSecret).Build()
+	if err != nil {
+		logger.WithError(err).Warn("could not create a client for the destination cluster")
+		message := fmt.Sprintf("could not connect to destination cluster: %v", err)
+		r.setRelocationFailedCondition(cd, corev1.ConditionTrue, "NoConnection", message, logger)
+		r.updateClusterStatus(cr.Name, cd, hivev1.FailedClusterRelocatePhase, message, nil, logger)
+		// return the error making the remote connection rather than the update error
+		return nil, errors.Wrap(err, "could not create a client for the destination cluster")
+	}
+	return destClient, nil
+}
+
+// reconcileDryRun records the resources that would be copied to the destination cluster in the status of the
+// ClusterRelocate without relocating the ClusterDeployment. An in-progress relocate for the same ClusterRelocate is
+// aborted.
+func (r *ReconcileClusterRelocate) reconcileDryRun(cd *hivev1.ClusterDeployment, oldRelocateStatus hivev1.RelocateStatus, oldRelocateName string, desiredRelocate *hivev1.ClusterRelocate, logger log.FieldLogger) (reconcile.Result, error) {
+	if oldRelocateStatus == hivev1.RelocateOutgoing && oldRelocateName == desiredRelocate.Name {
+		logger.Warn("aborting relocation since clusterrelocate is in dry-run mode")
+		if err := r.stopRelocating(cd, oldRelocateName, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		recordMetricForAbortedRelocate(oldRelocateName, "dry_run")
+	}
+
+	objects, err := r.objectsToCopy(cd, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	resources := make([]string, len(objects))
+	for i, obj := range objects {
+		resources[i] = resourceName(obj)
+	}
+	logger.WithField("resources", resources).Info("dry run: clusterdeployment would be relocated")
+	return reconcile.Result{}, r.updateClusterStatus(desiredRelocate.Name, cd, hivev1.PlannedClusterRelocatePhase, "", resources, logger)
 }
 
 // setRelocateAnnotation sets the relocate annotation on the ClusterDeployment as well as on the child DNSZone, if there
@@ -389,7 +453,9 @@ func (r *ReconcileClusterRelocate) clearRelocateAnnotation(cd *hivev1.ClusterDep
 	return nil
 }
 
-func (r *ReconcileClusterRelocate) finishRelocateCompletion(cd *hivev1.ClusterDeployment, relocateName string, logger log.FieldLogger) (reconcile.Result, error) {
+// finishRelocateCompletion marks the relocate as complete and deletes the ClusterDeployment from the source cluster. If
+// copied is nil, the resources previously recorded as copied in the ClusterRelocate status are kept.
+func (r *ReconcileClusterRelocate) finishRelocateCompletion(cd *hivev1.ClusterDeployment, relocateName string, copied []string, logger log.FieldLogger) (reconcile.Result, error) {
 	if err := r.setRelocateAnnotation(cd, relocateName, hivev1.RelocateComplete, logger); err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "could not set relocate status to complete")
 	}
@@ -404,6 +470,10 @@ func (r *ReconcileClusterRelocate) finishRelocateCompletion(cd *hivev1.ClusterDe
 		return reconcile.Result{}, err
 	}
 
+	if err := r.updateClusterStatus(relocateName, cd, hivev1.CompletedClusterRelocatePhase, "", copied, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Delete the ClusterDeployment since it has been successfully relocated to a new Hive instance
 	if err := r.Delete(context.Background(), cd); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete relocated clusterdeployment")
@@ -442,10 +512,24 @@ func (r *ReconcileClusterRelocate) stopRelocating(cd *hivev1.ClusterDeployment,
 		return nil
 	}
 	logger.WithField("clusterRelocate", currentRelocateName).Info("stopping relocation")
-	// TODO: Attempt to clean up resources already relocated
+	// Resources already copied are left in the destination cluster. Cancelling the ClusterRelocate removes them.
 	return r.clearRelocateAnnotation(cd, logger)
 }
 
+// getRelocate gets the ClusterRelocate with the given name. The return is nil if the ClusterRelocate does not exist.
+func (r *ReconcileClusterRelocate) getRelocate(name string, logger log.FieldLogger) (*hivev1.ClusterRelocate, error) {
+	cr := &hivev1.ClusterRelocate{}
+	switch err := r.Get(context.Background(), client.ObjectKey{Name: name}, cr); {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		logger.WithError(err).WithField("clusterRelocate", name).Log(controllerutils.LogLevel(err), "failed to get clusterrelocate")
+		return nil, errors.Wrap(err, "failed to get clusterrelocate")
+	default:
+		return cr, nil
+	}
+}
+
 // reconcileNoSingleMatch reconciles a ClusterDeployment that does not match with exactly one ClusterRelocate.
 // Any in-progress relocates will be aborted.
 func (r *ReconcileClusterRelocate) reconcileNoSingleMatch(cd *hivev1.ClusterDeployment, currentRelocateName string, desiredRelocates []*hivev1.ClusterRelocate, logger log.FieldLogger) (reconcile.Result, error) {
@@ -479,6 +563,15 @@ func (r *ReconcileClusterRelocate) reconcileNoSingleMatch(cd *hivev1.ClusterDepl
 	if err := r.setRelocationFailedCondition(cd, status, reason, message, logger); err != nil {
 		return reconcile.Result{}, err
 	}
+	failedRelocates := sets.NewString(names...)
+	if currentRelocateName != "" {
+		failedRelocates.Insert(currentRelocateName)
+	}
+	for _, name := range failedRelocates.List() {
+		if err := r.updateClusterStatus(name, cd, hivev1.FailedClusterRelocatePhase, message, nil, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
 	recordMetricForAbortedRelocate(currentRelocateName, abortedReason)
 	return reconcile.Result{}, nil
 }
@@ -532,85 +625,105 @@ func (r *ReconcileClusterRelocate) checkForExistingClusterDeployment(cd *hivev1.
 	return
 }
 
-func (r *ReconcileClusterRelocate) copy(cd *hivev1.ClusterDeployment, destClient client.Client, logger log.FieldLogger) error {
-	// create namespace
+// copy copies the ClusterDeployment and its dependent resources to the destination cluster. The names of the resources
+// that were copied are returned, even when the copy fails partway. They are also recorded in the status of the
+// ClusterRelocate as they are copied, so that the relocation can be rolled back if it is interrupted.
+func (r *ReconcileClusterRelocate) copy(cd *hivev1.ClusterDeployment, relocateName string, destClient client.Client, logger log.FieldLogger) ([]string, error) {
+	// create namespace, recording that the relocate created it so that a roll back removes it
 	switch err := destClient.Create(context.Background(), &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: cd.Namespace,
+			Name:        cd.Namespace,
+			Annotations: map[string]string{constants.RelocateCreatedNamespaceAnnotation: relocateName},
 		},
 	}); {
 	case apierrors.IsAlreadyExists(err):
 		logger.Info("namespace already exists in destination cluster")
 	case err != nil:
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to create namespace in destination cluster")
-		return errors.Wrap(err, "failed to create namespace in destination cluster")
+		return nil, errors.Wrap(err, "failed to create namespace in destination cluster")
 	default:
 		logger.Info("namespace created")
 	}
 
-	// copy dependent resources
+	objects, err := r.objectsToCopy(cd, logger)
+	if err != nil {
+		return nil, err
+	}
+	copied := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		logger := logger.WithField("type", reflect.TypeOf(obj)).WithField("resource", obj.GetName())
+		// The ClusterDeployment is copied last and must not already exist in the destination cluster.
+		_, isClusterDeployment := obj.(*hivev1.ClusterDeployment)
+		if err := r.copyResource(obj, destClient, isClusterDeployment, logger); err != nil {
+			return copied, errors.Wrapf(err, "could not copy %T resource %q", obj, obj.GetName())
+		}
+		copied = append(copied, resourceName(obj))
+		if isClusterDeployment {
+			// The status is updated once the relocation completes.
+			continue
+		}
+		if err := r.updateClusterStatus(relocateName, cd, hivev1.CopyingClusterRelocatePhase, "", copied, logger); err != nil {
+			return copied, err
+		}
+	}
+	return copied, nil
+}
+
+// objectsToCopy gets the resources to copy to the destination cluster, in the order in which they are copied. The
+// dependent resources come first, followed by the DNSZone, if there is one, and then the ClusterDeployment.
+func (r *ReconcileClusterRelocate) objectsToCopy(cd *hivev1.ClusterDeployment, logger log.FieldLogger) ([]client.Object, error) {
+	var objects []client.Object
 	for _, t := range typesToCopy() {
-		if err := r.copyResources(cd, destClient, t.(client.ObjectList), logger); err != nil {
-			return errors.Wrapf(err, "failed to copy %T", t)
+		objs, err := r.resourcesToCopy(cd, t.(client.ObjectList), logger)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get %T to copy", t)
 		}
+		objects = append(objects, objs...)
 	}
 
-	// copy dnszone
 	dnsZone, err := r.dnsZone(cd, logger)
 	if err != nil {
-		return errors.Wrap(err, "could not get DNSZone")
+		return nil, errors.Wrap(err, "could not get DNSZone")
 	}
 	if dnsZone != nil {
-		logger = logger.WithField("type", reflect.TypeOf(dnsZone)).WithField("resource", dnsZone.Name)
-		if err := r.copyResource(dnsZone, destClient, false, logger); err != nil {
-			return errors.Wrap(err, "failed to copy dnszone")
-		}
-	}
-
-	// copy clusterdeployment
-	{
-		logger := logger.WithField("type", reflect.TypeOf(cd)).WithField("resource", cd.Name)
-		if err := r.copyResource(cd, destClient, true, logger); err != nil {
-			return errors.Wrap(err, "failed to copy clusterdeployment")
-		}
+		objects = append(objects, dnsZone)
 	}
 
-	return nil
+	return append(objects, cd), nil
 }
 
-// copyResources copies all of the resources of the given object type in the namespace of the ClusterDeployment to the
-// destination cluster
-func (r *ReconcileClusterRelocate) copyResources(cd *hivev1.ClusterDeployment, destClient client.Client, objectList client.ObjectList, logger log.FieldLogger) error {
+// resourcesToCopy gets all of the resources of the given object type in the namespace of the ClusterDeployment that
+// should be copied to the destination cluster
+func (r *ReconcileClusterRelocate) resourcesToCopy(cd *hivev1.ClusterDeployment, objectList client.ObjectList, logger log.FieldLogger) ([]client.Object, error) {
 	logger = logger.WithField("type", reflect.TypeOf(objectList))
 	if err := r.List(context.Background(), objectList, client.InNamespace(cd.Namespace)); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list resources")
-		return errors.Wrapf(err, "failed to list %T", objectList)
+		return nil, errors.Wrapf(err, "failed to list %T", objectList)
 	}
 	objs, err := meta.ExtractList(objectList)
 	if err != nil {
 		logger.WithError(err).Error("could not extract resources from list")
-		return errors.Wrapf(err, "could not extract resources from %T", objectList)
+		return nil, errors.Wrapf(err, "could not extract resources from %T", objectList)
 	}
+	var objects []client.Object
 	for _, obj := range objs {
 		logger := logger.WithField("type", reflect.TypeOf(obj))
-		objMeta, err := meta.Accessor(obj)
-		if err != nil {
-			logger.WithError(err).Error("could not get object meta")
-			return errors.Wrapf(err, "could not get object meta for %T", obj)
+		clientObj, ok := obj.(client.Object)
+		if !ok {
+			logger.Error("resource is not a client object")
+			return nil, errors.Errorf("could not get object meta for %T", obj)
 		}
-		logger = logger.WithField("resource", objMeta.GetName())
+		logger = logger.WithField("resource", clientObj.GetName())
 		switch ignore, err := r.ignoreResource(obj, logger); {
 		case err != nil:
-			return errors.Wrap(err, "could not determine whether to ignore resource")
+			return nil, errors.Wrap(err, "could not determine whether to ignore resource")
 		case ignore:
 			logger.Info("resource will not be copied since it is a resource that should be ignored")
 			continue
 		}
-		if err := r.copyResource(obj, destClient, false, logger); err != nil {
-			return errors.Wrapf(err, "could not copy %T resource %q", obj, objMeta.GetName())
-		}
+		objects = append(objects, clientObj)
 	}
-	return nil
+	return objects, nil
 }
 
 func (r *ReconcileClusterRelocate) copyResource(obj runtime.Object, destClient client.Client, failIfExists bool, logger log.FieldLogger) error {
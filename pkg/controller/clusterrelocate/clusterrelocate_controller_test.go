@@ -84,6 +84,9 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 	)
 	jobBuilder := testjob.FullBuilder(namespace, "test-job", scheme)
 	namespaceBuilder := testnamespace.FullBuilder(namespace, scheme)
+	createdNamespaceBuilder := namespaceBuilder.GenericOptions(
+		testgeneric.WithAnnotation(constants.RelocateCreatedNamespaceAnnotation, crName),
+	)
 
 	cases := []struct {
 		name                string
@@ -107,7 +110,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				crBuilder.Build(),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -211,7 +214,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				secretBuilder.Build(testsecret.WithDataKeyValue("test-key", []byte("test-data"))),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -236,7 +239,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -269,7 +272,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -293,7 +296,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				secretBuilder.Build(testsecret.WithDataKeyValue("test-key", []byte("test-data"))),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -314,7 +317,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				secretBuilder.Build(testsecret.WithDataKeyValue("test-key", []byte("other-data"))),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -339,7 +342,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				mpBuilder.Build(),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -365,7 +368,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -389,7 +392,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -426,7 +429,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -467,7 +470,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				cmBuilder.Build(),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -493,7 +496,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				cmBuilder.Build(testcm.WithDataKeyValue("test-key", "test-data")),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -511,7 +514,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				mpBuilder.Build(),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -529,7 +532,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				ssBuilder.Build(),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -547,7 +550,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				sipBuilder.Build(),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -565,7 +568,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				dnsZoneBuilder.Build(),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -585,7 +588,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				dnsZoneBuilder.Build(testdnszone.Generic(testgeneric.WithName("other-dnszone"))),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -602,7 +605,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				jobBuilder.Build(),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -747,7 +750,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				),
 			},
 			expectedResources: []client.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -1255,6 +1258,645 @@ func TestReconcileClusterRelocate_Reconcile_RelocateStatus(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterRelocate_Reconcile_ClusterRelocateStatus(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := scheme.GetScheme()
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).GenericOptions(
+		testgeneric.WithLabel(labelKey, labelValue),
+	).Options(
+		func(cd *hivev1.ClusterDeployment) { cd.Spec.ManageDNS = true },
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.RelocationFailedCondition,
+			Status: corev1.ConditionUnknown,
+		}),
+	)
+	crBuilder := testcr.FullBuilder(crName, scheme).Options(
+		testcr.WithKubeconfigSecret(kubeconfigNamespace, kubeconfigName),
+		testcr.WithClusterDeploymentSelector(labelKey, labelValue),
+	)
+	dnsZoneBuilder := testdnszone.FullBuilder(namespace, controllerutils.DNSZoneName(cdName), scheme)
+	secretBuilder := testsecret.FullBuilder(namespace, "test-secret", scheme)
+	mpBuilder := testmp.FullBuilder(namespace, "test-pool", cdName, scheme)
+
+	allResources := []string{
+		"Secret/test-secret",
+		"MachinePool/" + cdName + "-test-pool",
+		"DNSZone/" + controllerutils.DNSZoneName(cdName),
+		"ClusterDeployment/" + cdName,
+	}
+
+	type clusterStatus struct {
+		relocate  string
+		name      string
+		phase     hivev1.ClusterRelocatePhase
+		resources []string
+	}
+
+	cases := []struct {
+		name                    string
+		cd                      *hivev1.ClusterDeployment
+		missingKubeconfigSecret bool
+		srcResources            []runtime.Object
+		destResources           []runtime.Object
+		expectedError           bool
+		expectDeleted           bool
+		expectRelocateCleared   bool
+		expectedClusterStatuses []clusterStatus
+		expectedCounts          map[string][3]int32
+	}{
+		{
+			name: "completed",
+			cd:   cdBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(),
+			},
+			expectDeleted: true,
+			expectedClusterStatuses: []clusterStatus{
+				{relocate: crName, name: cdName, phase: hivev1.CompletedClusterRelocatePhase, resources: allResources},
+			},
+			expectedCounts: map[string][3]int32{crName: {1, 1, 0}},
+		},
+		{
+			name: "other clusters kept",
+			cd:   cdBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(
+					testcr.WithClusterStatus(hivev1.ClusterRelocateClusterStatus{
+						Namespace: namespace,
+						Name:      "other-cd",
+						Phase:     hivev1.FailedClusterRelocatePhase,
+					}),
+				),
+			},
+			expectDeleted: true,
+			expectedClusterStatuses: []clusterStatus{
+				{relocate: crName, name: "other-cd", phase: hivev1.FailedClusterRelocatePhase},
+				{relocate: crName, name: cdName, phase: hivev1.CompletedClusterRelocatePhase, resources: allResources},
+			},
+			expectedCounts: map[string][3]int32{crName: {2, 1, 1}},
+		},
+		{
+			name: "dry run",
+			cd:   cdBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(testcr.WithDryRun()),
+			},
+			expectRelocateCleared: true,
+			expectedClusterStatuses: []clusterStatus{
+				{relocate: crName, name: cdName, phase: hivev1.PlannedClusterRelocatePhase, resources: allResources},
+			},
+			expectedCounts: map[string][3]int32{crName: {1, 0, 0}},
+		},
+		{
+			name: "dry run aborts in-progress relocate",
+			cd: cdBuilder.Build(
+				testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+			),
+			srcResources: []runtime.Object{
+				crBuilder.Build(testcr.WithDryRun()),
+			},
+			expectRelocateCleared: true,
+			expectedClusterStatuses: []clusterStatus{
+				{relocate: crName, name: cdName, phase: hivev1.PlannedClusterRelocatePhase, resources: allResources},
+			},
+			expectedCounts: map[string][3]int32{crName: {1, 0, 0}},
+		},
+		{
+			name:                    "missing kubeconfig secret",
+			cd:                      cdBuilder.Build(),
+			missingKubeconfigSecret: true,
+			srcResources: []runtime.Object{
+				crBuilder.Build(),
+			},
+			expectedError: true,
+			expectedClusterStatuses: []clusterStatus{
+				{relocate: crName, name: cdName, phase: hivev1.FailedClusterRelocatePhase},
+			},
+			expectedCounts: map[string][3]int32{crName: {1, 0, 1}},
+		},
+		{
+			name: "clusterdeployment mismatch",
+			cd: cdBuilder.Build(func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.BaseDomain = "test-domain"
+			}),
+			srcResources: []runtime.Object{
+				crBuilder.Build(),
+			},
+			destResources: []runtime.Object{
+				cdBuilder.Build(func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.BaseDomain = "other-domain"
+				}),
+			},
+			expectedClusterStatuses: []clusterStatus{
+				{relocate: crName, name: cdName, phase: hivev1.FailedClusterRelocatePhase},
+			},
+			expectedCounts: map[string][3]int32{crName: {1, 0, 1}},
+		},
+		{
+			name: "multiple relocates",
+			cd: cdBuilder.Build(
+				testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+			),
+			srcResources: []runtime.Object{
+				crBuilder.Build(),
+				crBuilder.Build(
+					testcr.Generic(testgeneric.WithName("other-relocate")),
+				),
+			},
+			expectRelocateCleared: true,
+			expectedClusterStatuses: []clusterStatus{
+				{relocate: crName, name: cdName, phase: hivev1.FailedClusterRelocatePhase},
+				{relocate: "other-relocate", name: cdName, phase: hivev1.FailedClusterRelocatePhase},
+			},
+			expectedCounts: map[string][3]int32{crName: {1, 0, 1}, "other-relocate": {1, 0, 1}},
+		},
+		{
+			name: "clusterdeployment deleted while outgoing",
+			cd: cdBuilder.Build(
+				testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+				testcd.Generic(testgeneric.WithFinalizer("test-finalizer")),
+				testcd.Generic(testgeneric.Deleted()),
+			),
+			srcResources: []runtime.Object{
+				crBuilder.Build(),
+			},
+			expectRelocateCleared: true,
+			expectedClusterStatuses: []clusterStatus{
+				{relocate: crName, name: cdName, phase: hivev1.FailedClusterRelocatePhase},
+			},
+			expectedCounts: map[string][3]int32{crName: {1, 0, 1}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.srcResources = append(tc.srcResources,
+				tc.cd,
+				dnsZoneBuilder.Build(),
+				secretBuilder.Build(),
+				mpBuilder.Build(),
+			)
+			kubeconfigSecret := testsecret.FullBuilder(kubeconfigNamespace, "test-kubeconfig", scheme).Build(
+				testsecret.WithDataKeyValue("kubeconfig", []byte("some-kubeconfig-data")),
+			)
+			if !tc.missingKubeconfigSecret {
+				tc.srcResources = append(tc.srcResources, kubeconfigSecret)
+			}
+			srcClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(tc.srcResources...).Build()
+			destClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(tc.destResources...).Build()
+
+			mockCtrl := gomock.NewController(t)
+
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockRemoteClientBuilder.EXPECT().Build().Return(destClient, nil).AnyTimes()
+
+			reconciler := &ReconcileClusterRelocate{
+				Client: srcClient,
+				logger: logger,
+				remoteClusterAPIClientBuilder: func(secret *corev1.Secret) remoteclient.Builder {
+					return mockRemoteClientBuilder
+				},
+			}
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      cdName,
+					Namespace: namespace,
+				},
+			})
+			if tc.expectedError {
+				require.Error(t, err, "expected error during reconcile")
+			} else {
+				require.NoError(t, err, "unexpected error during reconcile")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			err = srcClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd)
+			if tc.expectDeleted {
+				assert.True(t, apierrors.IsNotFound(err), "expected clusterdeployment to be deleted")
+			} else {
+				require.NoError(t, err, "unexpected error fetching clusterdeployment")
+			}
+			if tc.expectRelocateCleared {
+				assert.NotContains(t, cd.Annotations, constants.RelocateAnnotation, "unexpected relocate annotation on clusterdeployment")
+				err = destClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: cdName}, &hivev1.ClusterDeployment{})
+				assert.True(t, apierrors.IsNotFound(err), "expected no clusterdeployment in destination cluster")
+			}
+
+			for _, expected := range tc.expectedClusterStatuses {
+				cr := &hivev1.ClusterRelocate{}
+				err := srcClient.Get(context.Background(), client.ObjectKey{Name: expected.relocate}, cr)
+				require.NoError(t, err, "unexpected error fetching clusterrelocate")
+				var actual *hivev1.ClusterRelocateClusterStatus
+				for i, c := range cr.Status.Clusters {
+					if c.Namespace == namespace && c.Name == expected.name {
+						actual = &cr.Status.Clusters[i]
+					}
+				}
+				if assert.NotNil(t, actual, "missing cluster status for %s in %s", expected.name, expected.relocate) {
+					assert.Equal(t, expected.phase, actual.Phase, "unexpected phase")
+					assert.Equal(t, expected.resources, actual.Resources, "unexpected resources")
+					if expected.phase == hivev1.FailedClusterRelocatePhase && expected.name == cdName {
+						assert.NotEmpty(t, actual.Message, "expected message for failed relocation")
+					}
+				}
+			}
+			for relocateName, counts := range tc.expectedCounts {
+				cr := &hivev1.ClusterRelocate{}
+				err := srcClient.Get(context.Background(), client.ObjectKey{Name: relocateName}, cr)
+				require.NoError(t, err, "unexpected error fetching clusterrelocate")
+				assert.Equal(t, counts[0], cr.Status.MatchedClusters, "unexpected matched clusters")
+				assert.Equal(t, counts[1], cr.Status.CompletedClusters, "unexpected completed clusters")
+				assert.Equal(t, counts[2], cr.Status.FailedClusters, "unexpected failed clusters")
+			}
+		})
+	}
+}
+
+func TestReconcileClusterRelocate_Reconcile_Cancel(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := scheme.GetScheme()
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).GenericOptions(
+		testgeneric.WithLabel(labelKey, labelValue),
+	).Options(
+		func(cd *hivev1.ClusterDeployment) { cd.Spec.ManageDNS = true },
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.RelocationFailedCondition,
+			Status: corev1.ConditionTrue,
+			Reason: "MoveFailed",
+		}),
+	)
+	crBuilder := testcr.FullBuilder(crName, scheme).Options(
+		testcr.WithKubeconfigSecret(kubeconfigNamespace, kubeconfigName),
+		testcr.WithClusterDeploymentSelector(labelKey, labelValue),
+		testcr.WithCancel(),
+	)
+	dnsZoneBuilder := testdnszone.FullBuilder(namespace, controllerutils.DNSZoneName(cdName), scheme)
+	secretBuilder := testsecret.FullBuilder(namespace, "test-secret", scheme)
+	mpBuilder := testmp.FullBuilder(namespace, "test-pool", cdName, scheme)
+	namespaceBuilder := testnamespace.FullBuilder(namespace, scheme)
+
+	cases := []struct {
+		name                string
+		cd                  *hivev1.ClusterDeployment
+		srcResources        []runtime.Object
+		destResources       []runtime.Object
+		expectedError       bool
+		expectDeleted       bool
+		expectRolledBack    bool
+		expectedRelocate    string
+		expectedPhase       hivev1.ClusterRelocatePhase
+		expectedDetached    []client.Object
+		unexpectedResources []client.Object
+		expectedResources   []client.Object
+	}{
+		{
+			name: "outgoing",
+			cd: cdBuilder.Build(
+				testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+			),
+			srcResources: []runtime.Object{
+				crBuilder.Build(withCopied("Secret/test-secret", "MachinePool/"+mpBuilder.Build().Name, "DNSZone/"+controllerutils.DNSZoneName(cdName))),
+				dnsZoneBuilder.Build(
+					testdnszone.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+				),
+			},
+			destResources: []runtime.Object{
+				secretBuilder.Build(),
+				mpBuilder.Build(),
+				dnsZoneBuilder.Build(
+					testdnszone.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
+					testdnszone.Generic(testgeneric.WithFinalizer(hivev1.FinalizerDNSZone)),
+				),
+			},
+			expectRolledBack: true,
+			expectedDetached: []client.Object{
+				dnsZoneBuilder.Build(),
+			},
+			unexpectedResources: []client.Object{
+				secretBuilder.Build(),
+				mpBuilder.Build(),
+			},
+		},
+		{
+			name: "cancelled after completion",
+			cd: cdBuilder.Build(
+				testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateComplete)),
+			),
+			srcResources: []runtime.Object{
+				crBuilder.Build(),
+				dnsZoneBuilder.Build(
+					testdnszone.Generic(withRelocateAnnotation(crName, hivev1.RelocateComplete)),
+				),
+			},
+			destResources: []runtime.Object{
+				secretBuilder.Build(),
+				mpBuilder.Build(),
+				dnsZoneBuilder.Build(
+					testdnszone.Generic(testgeneric.WithFinalizer(hivev1.FinalizerDNSZone)),
+				),
+				cdBuilder.Build(
+					testcd.Generic(testgeneric.WithFinalizer(hivev1.FinalizerDeprovision)),
+				),
+			},
+			expectDeleted: true,
+			expectedPhase: hivev1.CompletedClusterRelocatePhase,
+			expectedResources: []client.Object{
+				secretBuilder.Build(),
+				mpBuilder.Build(),
+				dnsZoneBuilder.Build(),
+				cdBuilder.Build(),
+			},
+		},
+		{
+			name: "failed with copied resources",
+			cd:   cdBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(
+					testcr.WithClusterStatus(hivev1.ClusterRelocateClusterStatus{
+						Namespace: namespace,
+						Name:      cdName,
+						Phase:     hivev1.FailedClusterRelocatePhase,
+						Resources: []string{"Secret/test-secret"},
+					}),
+				),
+			},
+			destResources: []runtime.Object{
+				secretBuilder.Build(),
+			},
+			expectRolledBack: true,
+			unexpectedResources: []client.Object{
+				secretBuilder.Build(),
+			},
+		},
+		{
+			name: "namespace created by relocate",
+			cd:   cdBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(withCopied("Secret/test-secret")),
+			},
+			destResources: []runtime.Object{
+				namespaceBuilder.Build(
+					testnamespace.Generic(testgeneric.WithAnnotation(constants.RelocateCreatedNamespaceAnnotation, crName)),
+				),
+				secretBuilder.Build(),
+			},
+			expectRolledBack: true,
+			unexpectedResources: []client.Object{
+				namespaceBuilder.Build(),
+				secretBuilder.Build(),
+			},
+		},
+		{
+			name: "namespace created by other relocate",
+			cd:   cdBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(withCopied("Secret/test-secret")),
+			},
+			destResources: []runtime.Object{
+				namespaceBuilder.Build(
+					testnamespace.Generic(testgeneric.WithAnnotation(constants.RelocateCreatedNamespaceAnnotation, "other-relocate")),
+				),
+				secretBuilder.Build(),
+			},
+			expectRolledBack: true,
+			unexpectedResources: []client.Object{
+				secretBuilder.Build(),
+			},
+			expectedResources: []client.Object{
+				namespaceBuilder.Build(),
+			},
+		},
+		{
+			name: "namespace not created by relocate",
+			cd:   cdBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(withCopied("Secret/test-secret")),
+			},
+			destResources: []runtime.Object{
+				namespaceBuilder.Build(),
+				secretBuilder.Build(),
+			},
+			expectRolledBack: true,
+			unexpectedResources: []client.Object{
+				secretBuilder.Build(),
+			},
+			expectedResources: []client.Object{
+				namespaceBuilder.Build(),
+			},
+		},
+		{
+			name: "not relocating",
+			cd:   cdBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(),
+			},
+			destResources: []runtime.Object{
+				secretBuilder.Build(),
+			},
+			expectedResources: []client.Object{
+				secretBuilder.Build(),
+			},
+		},
+		{
+			name: "outgoing with copied clusterdeployment",
+			cd: cdBuilder.Build(
+				testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+				testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: "test-infra", ClusterID: "test-cluster-id"}),
+			),
+			srcResources: []runtime.Object{
+				crBuilder.Build(withCopied("Secret/test-secret")),
+			},
+			destResources: []runtime.Object{
+				secretBuilder.Build(),
+				cdBuilder.Build(
+					testcd.Generic(testgeneric.WithFinalizer(hivev1.FinalizerDeprovision)),
+					testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: "test-infra", ClusterID: "test-cluster-id"}),
+				),
+			},
+			expectRolledBack: true,
+			expectedDetached: []client.Object{
+				cdBuilder.Build(),
+			},
+			unexpectedResources: []client.Object{
+				secretBuilder.Build(),
+			},
+		},
+		{
+			name: "resources not recorded as copied",
+			cd: cdBuilder.Build(
+				testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+			),
+			srcResources: []runtime.Object{
+				crBuilder.Build(withCopied("Secret/test-secret")),
+			},
+			destResources: []runtime.Object{
+				secretBuilder.Build(),
+				mpBuilder.Build(),
+			},
+			expectRolledBack: true,
+			unexpectedResources: []client.Object{
+				secretBuilder.Build(),
+			},
+			expectedResources: []client.Object{
+				mpBuilder.Build(),
+			},
+		},
+		{
+			name: "mismatched clusterdeployment in destination",
+			cd: cdBuilder.Build(
+				testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+				testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: "test-infra", ClusterID: "test-cluster-id"}),
+			),
+			srcResources: []runtime.Object{
+				crBuilder.Build(withCopied("Secret/test-secret", "ClusterDeployment/"+cdName)),
+			},
+			destResources: []runtime.Object{
+				secretBuilder.Build(),
+				cdBuilder.Build(
+					testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: "other-infra", ClusterID: "other-cluster-id"}),
+				),
+			},
+			expectedError:    true,
+			expectedRelocate: fmt.Sprintf("%s/%s", crName, hivev1.RelocateOutgoing),
+			expectedPhase:    hivev1.FailedClusterRelocatePhase,
+			expectedResources: []client.Object{
+				secretBuilder.Build(),
+				cdBuilder.Build(),
+			},
+		},
+		{
+			name: "relocate not cancelled",
+			cd: cdBuilder.Build(
+				testcd.Generic(withRelocateAnnotation("other-relocate", hivev1.RelocateComplete)),
+			),
+			srcResources: []runtime.Object{
+				crBuilder.Build(testcr.Generic(testgeneric.WithName("other-relocate")), func(cr *hivev1.ClusterRelocate) {
+					cr.Spec.Cancel = false
+				}),
+			},
+			destResources: []runtime.Object{
+				secretBuilder.Build(),
+				cdBuilder.Build(),
+			},
+			expectDeleted: true,
+			expectedResources: []client.Object{
+				secretBuilder.Build(),
+				cdBuilder.Build(),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.srcResources = append(tc.srcResources,
+				tc.cd,
+				secretBuilder.Build(),
+				mpBuilder.Build(),
+				testsecret.FullBuilder(kubeconfigNamespace, kubeconfigName, scheme).Build(
+					testsecret.WithDataKeyValue("kubeconfig", []byte("some-kubeconfig-data")),
+				),
+			)
+			srcClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(tc.srcResources...).Build()
+			destClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(tc.destResources...).Build()
+
+			mockCtrl := gomock.NewController(t)
+
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockRemoteClientBuilder.EXPECT().Build().Return(destClient, nil).AnyTimes()
+
+			reconciler := &ReconcileClusterRelocate{
+				Client: srcClient,
+				logger: logger,
+				remoteClusterAPIClientBuilder: func(secret *corev1.Secret) remoteclient.Builder {
+					return mockRemoteClientBuilder
+				},
+			}
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      cdName,
+					Namespace: namespace,
+				},
+			})
+			if tc.expectedError {
+				require.Error(t, err, "expected error during reconcile")
+			} else {
+				require.NoError(t, err, "unexpected error during reconcile")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			err = srcClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd)
+			if tc.expectDeleted {
+				assert.True(t, apierrors.IsNotFound(err), "expected clusterdeployment to be deleted from source cluster")
+			} else {
+				require.NoError(t, err, "expected clusterdeployment to remain in source cluster")
+			}
+			if tc.expectedRelocate != "" {
+				assert.Equal(t, tc.expectedRelocate, cd.Annotations[constants.RelocateAnnotation], "unexpected relocate annotation on clusterdeployment")
+			} else if tc.expectRolledBack {
+				assert.NotContains(t, cd.Annotations, constants.RelocateAnnotation, "unexpected relocate annotation on clusterdeployment")
+				dnsZone := &hivev1.DNSZone{}
+				if err := srcClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: controllerutils.DNSZoneName(cdName)}, dnsZone); err == nil {
+					assert.NotContains(t, dnsZone.Annotations, constants.RelocateAnnotation, "unexpected relocate annotation on dnszone")
+				}
+				cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.RelocationFailedCondition)
+				if assert.NotNil(t, cond, "missing relocation failed condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected condition status")
+					assert.Equal(t, "RelocationRolledBack", cond.Reason, "unexpected condition reason")
+				}
+			}
+
+			expectedPhase := tc.expectedPhase
+			if tc.expectRolledBack {
+				expectedPhase = hivev1.RolledBackClusterRelocatePhase
+			}
+			if expectedPhase != "" {
+				cr := &hivev1.ClusterRelocate{}
+				err := srcClient.Get(context.Background(), client.ObjectKey{Name: crName}, cr)
+				require.NoError(t, err, "unexpected error fetching clusterrelocate")
+				if assert.Len(t, cr.Status.Clusters, 1, "expected status for clusterdeployment") {
+					assert.Equal(t, expectedPhase, cr.Status.Clusters[0].Phase, "unexpected phase")
+				}
+			}
+
+			for _, obj := range tc.expectedDetached {
+				destObj := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+				err := destClient.Get(context.Background(), client.ObjectKeyFromObject(obj), destObj)
+				if assert.NoError(t, err, "expected finalizer to keep detached object in destination cluster") {
+					assert.NotNil(t, destObj.GetDeletionTimestamp(), "expected detached object to be deleted")
+					assert.Equal(t, fmt.Sprintf("%s/%s", crName, hivev1.RelocateComplete), destObj.GetAnnotations()[constants.RelocateAnnotation],
+						"expected detached object to be marked as relocated")
+				}
+			}
+			for _, obj := range tc.unexpectedResources {
+				destObj := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+				err := destClient.Get(context.Background(), client.ObjectKeyFromObject(obj), destObj)
+				assert.True(t, apierrors.IsNotFound(err), "expected %T %s to be removed from destination cluster", obj, obj.GetName())
+			}
+			for _, obj := range tc.expectedResources {
+				destObj := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+				err := destClient.Get(context.Background(), client.ObjectKeyFromObject(obj), destObj)
+				if assert.NoError(t, err, "expected %T %s to remain in destination cluster", obj, obj.GetName()) {
+					assert.Nil(t, destObj.GetDeletionTimestamp(), "unexpected deletion of object in destination cluster")
+				}
+			}
+		})
+	}
+}
+
+// withCopied records the resources as copied to the destination cluster in the status of the ClusterRelocate.
+func withCopied(resources ...string) testcr.Option {
+	return testcr.WithClusterStatus(hivev1.ClusterRelocateClusterStatus{
+		Namespace: namespace,
+		Name:      cdName,
+		Phase:     hivev1.CopyingClusterRelocatePhase,
+		Resources: resources,
+	})
+}
+
 func withRelocateAnnotation(clusterRelocateName string, status hivev1.RelocateStatus) testgeneric.Option {
 	return testgeneric.WithAnnotation(
 		constants.RelocateAnnotation,
@@ -0,0 +1,211 @@
+package clusterrelocate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// reconcileCancelled reconciles a ClusterDeployment that matches a cancelled ClusterRelocate but that is not
+// relocating. A relocate that failed after copying resources to the destination cluster is rolled back. Otherwise,
+// there is nothing to do.
+func (r *ReconcileClusterRelocate) reconcileCancelled(cd *hivev1.ClusterDeployment, cr *hivev1.ClusterRelocate, logger log.FieldLogger) (reconcile.Result, error) {
+	if c := clusterStatus(cr, cd); c != nil {
+		switch c.Phase {
+		case hivev1.CopyingClusterRelocatePhase, hivev1.FailedClusterRelocatePhase:
+			if len(c.Resources) > 0 {
+				return r.rollBackRelocate(cd, cr, logger)
+			}
+		}
+	}
+	logger.Debug("not relocating clusterdeployment since clusterrelocate is cancelled")
+	return reconcile.Result{}, nil
+}
+
+// rollBackRelocate reverses the relocation of the ClusterDeployment. The resources copied to the destination cluster
+// are removed, and the relocate annotation is cleared so that the source cluster resumes reconciling the
+// ClusterDeployment.
+func (r *ReconcileClusterRelocate) rollBackRelocate(cd *hivev1.ClusterDeployment, cr *hivev1.ClusterRelocate, logger log.FieldLogger) (reconcile.Result, error) {
+	logger = logger.WithField("clusterRelocate", cr.Name)
+	logger.Info("rolling back relocation since clusterrelocate is cancelled")
+
+	destClient, err := r.destinationClient(cd, cr, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.removeCopies(cd, cr, destClient, logger); err != nil {
+		r.setRelocationFailedCondition(cd, corev1.ConditionTrue, "RollBackFailed", err.Error(), logger)
+		r.updateClusterStatus(cr.Name, cd, hivev1.FailedClusterRelocatePhase, err.Error(), nil, logger)
+		// return the roll back error rather than the update error
+		return reconcile.Result{}, err
+	}
+
+	if err := r.clearRelocateAnnotation(cd, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.setRelocationFailedCondition(
+		cd,
+		corev1.ConditionFalse,
+		"RelocationRolledBack",
+		"relocation cancelled and rolled back",
+		logger,
+	); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.updateClusterStatus(cr.Name, cd, hivev1.RolledBackClusterRelocatePhase, "", []string{}, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	recordMetricForAbortedRelocate(cr.Name, "cancelled")
+	return reconcile.Result{}, nil
+}
+
+// removeCopies removes the copies of the ClusterDeployment and its dependent resources from the destination cluster.
+// Only the resources recorded as copied in the status of the ClusterRelocate are removed, along with the
+// ClusterDeployment if it is a copy of the one being rolled back, and the namespace if it was created by the
+// ClusterRelocate. The ClusterDeployment and DNSZone in the destination
+// cluster are marked as relocated before they are deleted so that the destination cluster does not destroy the cloud
+// resources of the cluster when removing them.
+func (r *ReconcileClusterRelocate) removeCopies(cd *hivev1.ClusterDeployment, cr *hivev1.ClusterRelocate, destClient client.Client, logger log.FieldLogger) error {
+	copied := sets.NewString()
+	if c := clusterStatus(cr, cd); c != nil {
+		copied.Insert(c.Resources...)
+	}
+
+	destCD := &hivev1.ClusterDeployment{}
+	switch err := destClient.Get(context.Background(), client.ObjectKeyFromObject(cd), destCD); {
+	case apierrors.IsNotFound(err):
+		logger.Info("clusterdeployment absent in destination cluster")
+		destCD = nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get clusterdeployment in destination cluster")
+		return errors.Wrap(err, "failed to get clusterdeployment in destination cluster")
+	case !isCopyOf(destCD, cd, copied):
+		// The ClusterDeployment in the destination cluster is for a separate cluster, so neither it nor the resources in
+		// its namespace were copied from the source cluster.
+		logger.Warn("clusterdeployment in destination cluster does not match the one being rolled back")
+		return errors.New("the ClusterDeployment in the destination cluster does not match the one being rolled back")
+	}
+
+	objects, err := r.objectsToCopy(cd, logger)
+	if err != nil {
+		return err
+	}
+
+	// Remove the ClusterDeployment first. The copied MachinePools cannot be removed from the destination cluster until the
+	// ClusterDeployment is gone.
+	if destCD != nil {
+		if err := detachAndDelete(destCD, cr.Name, destClient, logger); err != nil {
+			return err
+		}
+	}
+	for _, obj := range objects {
+		if !copied.Has(resourceName(obj)) {
+			continue
+		}
+		switch obj.(type) {
+		case *hivev1.ClusterDeployment:
+			// The ClusterDeployment has already been removed.
+		case *hivev1.DNSZone:
+			destDNSZone := &hivev1.DNSZone{}
+			switch err := destClient.Get(context.Background(), client.ObjectKeyFromObject(obj), destDNSZone); {
+			case apierrors.IsNotFound(err):
+				logger.Info("dnszone absent in destination cluster")
+			case err != nil:
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get dnszone in destination cluster")
+				return errors.Wrap(err, "failed to get dnszone in destination cluster")
+			default:
+				if err := detachAndDelete(destDNSZone, cr.Name, destClient, logger); err != nil {
+					return err
+				}
+			}
+		default:
+			destObj := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+			destObj.SetNamespace(obj.GetNamespace())
+			destObj.SetName(obj.GetName())
+			if err := deleteCopy(destObj, destClient, logger); err != nil {
+				return err
+			}
+		}
+	}
+	return removeCreatedNamespace(cd.Namespace, cr.Name, destClient, logger)
+}
+
+// removeCreatedNamespace deletes the namespace from the destination cluster if it was created by the ClusterRelocate.
+// A namespace that already existed in the destination cluster is left in place.
+func removeCreatedNamespace(name, relocateName string, destClient client.Client, logger log.FieldLogger) error {
+	ns := &corev1.Namespace{}
+	switch err := destClient.Get(context.Background(), client.ObjectKey{Name: name}, ns); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("namespace absent in destination cluster")
+		return nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get namespace in destination cluster")
+		return errors.Wrap(err, "failed to get namespace in destination cluster")
+	}
+	if ns.Annotations[constants.RelocateCreatedNamespaceAnnotation] != relocateName {
+		logger.Debug("namespace in destination cluster not created by clusterrelocate")
+		return nil
+	}
+	if ns.DeletionTimestamp != nil {
+		return nil
+	}
+	return deleteCopy(ns, destClient, logger)
+}
+
+// isCopyOf returns true if the ClusterDeployment in the destination cluster is a copy of the ClusterDeployment. The
+// copy of an installed cluster has the same infra ID and cluster ID. A ClusterDeployment that has not been installed
+// has no such identity, so it must have been recorded as copied in the status of the ClusterRelocate.
+func isCopyOf(destCD, cd *hivev1.ClusterDeployment, copied sets.String) bool {
+	src, dest := cd.Spec.ClusterMetadata, destCD.Spec.ClusterMetadata
+	if src == nil || dest == nil {
+		return src == nil && dest == nil && copied.Has(resourceName(cd))
+	}
+	return src.InfraID == dest.InfraID && src.ClusterID == dest.ClusterID
+}
+
+// detachAndDelete marks the resource in the destination cluster as relocated and then deletes it. Marking the resource
+// as relocated stops the destination cluster from running the finalizers that would destroy the cloud resources.
+func detachAndDelete(obj hivev1.MetaRuntimeObject, relocateName string, destClient client.Client, logger log.FieldLogger) error {
+	if obj.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	if controllerutils.SetRelocateAnnotation(obj, relocateName, hivev1.RelocateComplete) {
+		if err := destClient.Update(context.Background(), obj); err != nil {
+			logger.WithError(err).WithField("resource", obj.GetName()).
+				Log(controllerutils.LogLevel(err), "could not detach resource in destination cluster")
+			return errors.Wrapf(err, "could not detach %T resource %q in destination cluster", obj, obj.GetName())
+		}
+	}
+	return deleteCopy(obj, destClient, logger)
+}
+
+// deleteCopy deletes the copy of the resource from the destination cluster.
+func deleteCopy(obj client.Object, destClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("type", fmt.Sprintf("%T", obj)).WithField("resource", obj.GetName())
+	switch err := destClient.Delete(context.Background(), obj); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("resource absent in destination cluster")
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete resource from destination cluster")
+		return errors.Wrapf(err, "could not delete %T resource %q from destination cluster", obj, obj.GetName())
+	default:
+		logger.Info("resource deleted from destination cluster")
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package clusterrelocate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// resourceName is the name used for the resource in the status of the ClusterRelocate.
+func resourceName(obj client.Object) string {
+	return fmt.Sprintf("%s/%s", reflect.TypeOf(obj).Elem().Name(), obj.GetName())
+}
+
+// clusterStatus returns the entry for the ClusterDeployment in the status of the ClusterRelocate, or nil if there is
+// none.
+func clusterStatus(cr *hivev1.ClusterRelocate, cd *hivev1.ClusterDeployment) *hivev1.ClusterRelocateClusterStatus {
+	for i, c := range cr.Status.Clusters {
+		if c.Namespace == cd.Namespace && c.Name == cd.Name {
+			return &cr.Status.Clusters[i]
+		}
+	}
+	return nil
+}
+
+// updateClusterStatus records the relocation status of the ClusterDeployment in the status of the ClusterRelocate.
+// If resources is nil, the resources previously recorded for the ClusterDeployment are kept. An empty, non-nil
+// resources clears them.
+func (r *ReconcileClusterRelocate) updateClusterStatus(relocateName string, cd *hivev1.ClusterDeployment, phase hivev1.ClusterRelocatePhase, message string, resources []string, logger log.FieldLogger) error {
+	if relocateName == "" {
+		return nil
+	}
+	logger = logger.WithField("clusterRelocate", relocateName).WithField("phase", phase)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cr := &hivev1.ClusterRelocate{}
+		if err := r.Get(context.Background(), client.ObjectKey{Name: relocateName}, cr); err != nil {
+			return err
+		}
+		if !setClusterStatus(&cr.Status, cd, phase, message, resources) {
+			return nil
+		}
+		return r.Status().Update(context.Background(), cr)
+	})
+	switch {
+	case apierrors.IsNotFound(err):
+		logger.Debug("clusterrelocate not found; not recording cluster status")
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update clusterrelocate status")
+		return errors.Wrap(err, "failed to update clusterrelocate status")
+	}
+	return nil
+}
+
+// setClusterStatus sets the entry for the ClusterDeployment in the ClusterRelocate status and recomputes the cluster
+// counts. The return value is true if the status changed.
+func setClusterStatus(status *hivev1.ClusterRelocateStatus, cd *hivev1.ClusterDeployment, phase hivev1.ClusterRelocatePhase, message string, resources []string) bool {
+	var entry *hivev1.ClusterRelocateClusterStatus
+	for i, c := range status.Clusters {
+		if c.Namespace == cd.Namespace && c.Name == cd.Name {
+			entry = &status.Clusters[i]
+			break
+		}
+	}
+	if entry == nil {
+		status.Clusters = append(status.Clusters, hivev1.ClusterRelocateClusterStatus{
+			Namespace: cd.Namespace,
+			Name:      cd.Name,
+		})
+		entry = &status.Clusters[len(status.Clusters)-1]
+	}
+	switch {
+	case resources == nil:
+		resources = entry.Resources
+	case len(resources) == 0:
+		// Match the round-tripped form of an empty list so that the status does not appear to change.
+		resources = nil
+	}
+	if entry.Phase == phase && entry.Message == message && reflect.DeepEqual(entry.Resources, resources) {
+		return false
+	}
+	if entry.Phase != phase {
+		entry.LastTransitionTime = metav1.Now()
+	}
+	entry.Phase = phase
+	entry.Message = message
+	entry.Resources = resources
+
+	status.MatchedClusters = int32(len(status.Clusters))
+	status.CompletedClusters = 0
+	status.FailedClusters = 0
+	for _, c := range status.Clusters {
+		switch c.Phase {
+		case hivev1.CompletedClusterRelocatePhase:
+			status.CompletedClusters++
+		case hivev1.FailedClusterRelocatePhase:
+			status.FailedClusters++
+		}
+	}
+	return true
+}
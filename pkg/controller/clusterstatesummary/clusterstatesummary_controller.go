@@ -0,0 +1,245 @@
+package clusterstatesummary
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ClusterStateSummaryControllerName
+
+	// summaryInterval is how often summaries are recalculated. ClusterStates change too often to recalculate
+	// summaries on every change, and are themselves only refreshed every few minutes.
+	summaryInterval = 5 * time.Minute
+)
+
+// Add creates a new ClusterStateSummary controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileClusterStateSummary
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterStateSummary {
+	return &ReconcileClusterStateSummary{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileClusterStateSummary, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("clusterstatesummary-controller", mgr, controller.Options{
+		Reconciler:              controllerutils.NewDelayingReconciler(r, r.logger),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the spec of ClusterStateSummaries. The summaries are otherwise recalculated periodically.
+	return c.Watch(
+		source.Kind(mgr.GetCache(), &hivev1.ClusterStateSummary{}),
+		&handler.EnqueueRequestForObject{},
+		predicate.GenerationChangedPredicate{},
+	)
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterStateSummary{}
+
+// ReconcileClusterStateSummary aggregates ClusterStates into ClusterStateSummaries.
+type ReconcileClusterStateSummary struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// Reconcile recalculates the summary of the ClusterStates of the clusters selected by a ClusterStateSummary.
+func (r *ReconcileClusterStateSummary) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterStateSummary", request.NamespacedName)
+	logger.Debug("reconciling cluster state summary")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	summary := &hivev1.ClusterStateSummary{}
+	switch err := r.Get(ctx, request.NamespacedName, summary); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("cluster state summary not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting ClusterStateSummary")
+		return reconcile.Result{}, err
+	}
+	if summary.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&summary.Spec.ClusterDeploymentSelector)
+	if err != nil {
+		// The selector will not become valid until the spec changes, which triggers a new reconcile.
+		logger.WithError(err).Error("invalid clusterDeploymentSelector")
+		return reconcile.Result{}, nil
+	}
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.List(ctx, cdList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments")
+		return reconcile.Result{}, err
+	}
+	stateList := &hivev1.ClusterStateList{}
+	if err := r.List(ctx, stateList); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterStates")
+		return reconcile.Result{}, err
+	}
+
+	status := summarize(cdList.Items, stateList.Items, summary.Spec.GroupByLabels)
+	status.LastUpdated = summary.Status.LastUpdated
+	if !reflect.DeepEqual(status, summary.Status) {
+		now := metav1.Now()
+		status.LastUpdated = &now
+		summary.Status = status
+		if err := r.Status().Update(ctx, summary); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update cluster state summary status")
+			return reconcile.Result{}, err
+		}
+		logger.WithField("clusters", status.Clusters).Info("updated cluster state summary")
+	}
+	return reconcile.Result{RequeueAfter: summaryInterval}, nil
+}
+
+// summarize counts the clusters on which each cluster operator is unhealthy, overall and by the values of the
+// groupBy labels of the ClusterDeployments. Only ClusterDeployments that have a ClusterState are counted.
+func summarize(cds []hivev1.ClusterDeployment, states []hivev1.ClusterState, groupBy []string) hivev1.ClusterStateSummaryStatus {
+	selected := make(map[types.NamespacedName]*hivev1.ClusterDeployment, len(cds))
+	for i := range cds {
+		if cds[i].DeletionTimestamp != nil {
+			continue
+		}
+		selected[types.NamespacedName{Namespace: cds[i].Namespace, Name: cds[i].Name}] = &cds[i]
+	}
+
+	total := newOperatorCounter()
+	groups := map[string]*groupCounter{}
+	for i := range states {
+		state := &states[i]
+		cd, ok := selected[types.NamespacedName{Namespace: state.Namespace, Name: state.Name}]
+		if !ok {
+			continue
+		}
+		total.add(state)
+		if len(groupBy) == 0 {
+			continue
+		}
+		groupLabels := make(map[string]string, len(groupBy))
+		for _, key := range groupBy {
+			groupLabels[key] = cd.Labels[key]
+		}
+		groupKey := labels.Set(groupLabels).String()
+		group, ok := groups[groupKey]
+		if !ok {
+			group = &groupCounter{labels: groupLabels, operatorCounter: newOperatorCounter()}
+			groups[groupKey] = group
+		}
+		group.add(state)
+	}
+
+	status := hivev1.ClusterStateSummaryStatus{
+		Clusters:  total.clusters,
+		Operators: total.summaries(),
+	}
+	groupKeys := make([]string, 0, len(groups))
+	for key := range groups {
+		groupKeys = append(groupKeys, key)
+	}
+	sort.Strings(groupKeys)
+	for _, key := range groupKeys {
+		group := groups[key]
+		status.Groups = append(status.Groups, hivev1.ClusterStateSummaryGroup{
+			Labels:    group.labels,
+			Clusters:  group.clusters,
+			Operators: group.summaries(),
+		})
+	}
+	return status
+}
+
+// operatorCounter counts clusters, and the clusters on which each cluster operator is unhealthy.
+type operatorCounter struct {
+	clusters  int32
+	operators map[string]*hivev1.ClusterOperatorSummary
+}
+
+type groupCounter struct {
+	*operatorCounter
+	labels map[string]string
+}
+
+func newOperatorCounter() *operatorCounter {
+	return &operatorCounter{operators: map[string]*hivev1.ClusterOperatorSummary{}}
+}
+
+func (c *operatorCounter) add(state *hivev1.ClusterState) {
+	c.clusters++
+	for i := range state.Status.ClusterOperators {
+		op := &state.Status.ClusterOperators[i]
+		degraded, unavailable, progressing := controllerutils.ClusterOperatorHealth(op)
+		if !degraded && !unavailable && !progressing {
+			continue
+		}
+		summary, ok := c.operators[op.Name]
+		if !ok {
+			summary = &hivev1.ClusterOperatorSummary{Name: op.Name}
+			c.operators[op.Name] = summary
+		}
+		if degraded {
+			summary.Degraded++
+		}
+		if unavailable {
+			summary.Unavailable++
+		}
+		if progressing {
+			summary.Progressing++
+		}
+	}
+}
+
+// summaries returns the counts of the unhealthy cluster operators, ordered by name.
+func (c *operatorCounter) summaries() []hivev1.ClusterOperatorSummary {
+	if len(c.operators) == 0 {
+		return nil
+	}
+	summaries := make([]hivev1.ClusterOperatorSummary, 0, len(c.operators))
+	for _, summary := range c.operators {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+	return summaries
+}
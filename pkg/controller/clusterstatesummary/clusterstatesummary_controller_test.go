@@ -0,0 +1,163 @@
+package clusterstatesummary
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcs "github.com/openshift/hive/pkg/test/clusterstate"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	testSummaryName = "prod"
+	fleetLabel      = "fleet"
+	regionLabel     = "region"
+)
+
+func TestReconcileClusterStateSummary(t *testing.T) {
+	lastUpdated := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+
+	tests := []struct {
+		name              string
+		spec              hivev1.ClusterStateSummarySpec
+		status            hivev1.ClusterStateSummaryStatus
+		expectedStatus    hivev1.ClusterStateSummaryStatus
+		expectLastUpdated *metav1.Time
+	}{
+		{
+			name: "all clusters",
+			expectedStatus: hivev1.ClusterStateSummaryStatus{
+				Clusters: 4,
+				Operators: []hivev1.ClusterOperatorSummary{
+					{Name: "dns", Unavailable: 1},
+					{Name: "ingress", Degraded: 3, Unavailable: 1, Progressing: 1},
+				},
+			},
+		},
+		{
+			name: "selected clusters",
+			spec: hivev1.ClusterStateSummarySpec{
+				ClusterDeploymentSelector: metav1.LabelSelector{MatchLabels: map[string]string{fleetLabel: "prod"}},
+			},
+			expectedStatus: hivev1.ClusterStateSummaryStatus{
+				Clusters: 3,
+				Operators: []hivev1.ClusterOperatorSummary{
+					{Name: "dns", Unavailable: 1},
+					{Name: "ingress", Degraded: 2, Unavailable: 1, Progressing: 1},
+				},
+			},
+		},
+		{
+			name: "grouped clusters",
+			spec: hivev1.ClusterStateSummarySpec{
+				ClusterDeploymentSelector: metav1.LabelSelector{MatchLabels: map[string]string{fleetLabel: "prod"}},
+				GroupByLabels:             []string{regionLabel},
+			},
+			expectedStatus: hivev1.ClusterStateSummaryStatus{
+				Clusters: 3,
+				Operators: []hivev1.ClusterOperatorSummary{
+					{Name: "dns", Unavailable: 1},
+					{Name: "ingress", Degraded: 2, Unavailable: 1, Progressing: 1},
+				},
+				Groups: []hivev1.ClusterStateSummaryGroup{
+					{
+						Labels:   map[string]string{regionLabel: ""},
+						Clusters: 1,
+					},
+					{
+						Labels:   map[string]string{regionLabel: "us-east-1"},
+						Clusters: 2,
+						Operators: []hivev1.ClusterOperatorSummary{
+							{Name: "dns", Unavailable: 1},
+							{Name: "ingress", Degraded: 2, Unavailable: 1, Progressing: 1},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unchanged summary",
+			spec: hivev1.ClusterStateSummarySpec{
+				ClusterDeploymentSelector: metav1.LabelSelector{MatchLabels: map[string]string{fleetLabel: "staging"}},
+			},
+			status: hivev1.ClusterStateSummaryStatus{
+				LastUpdated: &lastUpdated,
+				Clusters:    1,
+				Operators:   []hivev1.ClusterOperatorSummary{{Name: "ingress", Degraded: 1}},
+			},
+			expectedStatus: hivev1.ClusterStateSummaryStatus{
+				Clusters:  1,
+				Operators: []hivev1.ClusterOperatorSummary{{Name: "ingress", Degraded: 1}},
+			},
+			expectLastUpdated: &lastUpdated,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scheme := scheme.GetScheme()
+			summary := &hivev1.ClusterStateSummary{
+				ObjectMeta: metav1.ObjectMeta{Name: testSummaryName},
+				Spec:       test.spec,
+				Status:     test.status,
+			}
+			existing := []runtime.Object{
+				summary,
+				testcd.FullBuilder("ns1", "c1", scheme).Build(
+					testcd.WithLabel(fleetLabel, "prod"), testcd.WithLabel(regionLabel, "us-east-1")),
+				testcs.FullBuilder("ns1", "c1", scheme).Build(testcs.WithOperators(
+					testcs.OperatorState("ingress", configv1.ConditionTrue, configv1.ConditionFalse, configv1.ConditionFalse),
+					testcs.OperatorState("dns", configv1.ConditionFalse, configv1.ConditionFalse, configv1.ConditionFalse),
+				)),
+				testcd.FullBuilder("ns2", "c2", scheme).Build(
+					testcd.WithLabel(fleetLabel, "prod"), testcd.WithLabel(regionLabel, "us-east-1")),
+				testcs.FullBuilder("ns2", "c2", scheme).Build(testcs.WithOperators(
+					testcs.OperatorState("ingress", configv1.ConditionTrue, configv1.ConditionTrue, configv1.ConditionTrue),
+					testcs.OperatorState("dns", configv1.ConditionFalse, configv1.ConditionTrue, configv1.ConditionFalse),
+				)),
+				testcd.FullBuilder("ns3", "c3", scheme).Build(testcd.WithLabel(fleetLabel, "prod")),
+				testcs.FullBuilder("ns3", "c3", scheme).Build(testcs.WithOperators(
+					testcs.OperatorState("ingress", configv1.ConditionFalse, configv1.ConditionTrue, configv1.ConditionFalse),
+				)),
+				testcd.FullBuilder("ns4", "c4", scheme).Build(testcd.WithLabel(fleetLabel, "staging")),
+				testcs.FullBuilder("ns4", "c4", scheme).Build(testcs.WithOperators(
+					testcs.OperatorState("ingress", configv1.ConditionTrue, configv1.ConditionTrue, configv1.ConditionFalse),
+				)),
+				// No ClusterState yet
+				testcd.FullBuilder("ns5", "c5", scheme).Build(testcd.WithLabel(fleetLabel, "prod")),
+			}
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build()
+			r := &ReconcileClusterStateSummary{Client: c, logger: log.New()}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: testSummaryName}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			assert.Equal(t, summaryInterval, result.RequeueAfter, "unexpected requeue")
+
+			summary = &hivev1.ClusterStateSummary{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: testSummaryName}, summary))
+			if test.expectLastUpdated != nil {
+				if assert.NotNil(t, summary.Status.LastUpdated, "expected last updated time") {
+					assert.True(t, test.expectLastUpdated.Equal(summary.Status.LastUpdated), "unexpected last updated time")
+				}
+			} else {
+				assert.NotNil(t, summary.Status.LastUpdated, "expected last updated time")
+			}
+			summary.Status.LastUpdated = nil
+			assert.Equal(t, test.expectedStatus, summary.Status, "unexpected status")
+		})
+	}
+}
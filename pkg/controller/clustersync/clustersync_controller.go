@@ -25,9 +25,11 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 	"sigs.k8s.io/yaml"
@@ -96,6 +98,13 @@ var (
 			Buckets: []float64{60, 300, 600, 1200, 1800, 2400, 3000, 3600},
 		},
 	)
+
+	metricDriftDetected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_syncset_drift_detected",
+		Help: "Counter incremented for each resource found to have drifted from its syncset in a target cluster, labeled by kind of syncset and by SelectorSyncSet name or SyncSet metrics group.",
+	},
+		[]string{"kind", "name"},
+	)
 )
 
 func init() {
@@ -104,6 +113,7 @@ func init() {
 	metrics.Registry.MustRegister(metricResourcesApplied)
 	metrics.Registry.MustRegister(metricTimeToApplySyncSetResource)
 	metrics.Registry.MustRegister(metricTimeToApplySyncSets)
+	metrics.Registry.MustRegister(metricDriftDetected)
 }
 
 // Add creates a new clustersync Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -199,10 +209,21 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterSync, concurrentReconc
 		return err
 	}
 
-	// Watch for changes to SelectorSyncSets
+	// Watch for changes to SelectorSyncSets. Updates which only report the progress of a rollout are ignored, since
+	// each one would otherwise reconcile every cluster the SelectorSyncSet selects.
 	if err := c.Watch(
 		source.Kind(mgr.GetCache(), &hivev1.SelectorSyncSet{}),
-		handler.EnqueueRequestsFromMapFunc(requestsForSelectorSyncSet(r.Client, r.logger))); err != nil {
+		handler.EnqueueRequestsFromMapFunc(requestsForSelectorSyncSet(r.Client, r.logger)),
+		predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldSSS, okOld := e.ObjectOld.(*hivev1.SelectorSyncSet)
+				newSSS, okNew := e.ObjectNew.(*hivev1.SelectorSyncSet)
+				if !okOld || !okNew {
+					return true
+				}
+				return controllerutils.SelectorSyncSetReleaseChanged(oldSSS, newSSS)
+			},
+		}); err != nil {
 		return err
 	}
 
@@ -401,27 +422,31 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 	needToDoFullReapply := needToCreateLease || needToRenew
 	recobsrv.SetOutcome(hivemetrics.ReconcileOutcomeFullSync)
 
+	snapshots := newHealthSnapshotStore(r.Client, cd)
+
 	// Apply SyncSets
-	syncStatusesForSyncSets, syncSetsNeedRequeue := r.applySyncSets(
+	syncStatusesForSyncSets, syncSetsNeedRequeue, syncSetsNeedHealthChecks := r.applySyncSets(
 		cd,
 		"SyncSet",
 		syncSets,
 		clusterSync.Status.SyncSets,
 		needToDoFullReapply,
 		false, // no need to report SelectorSyncSet metrics if we're reconciling non-selector SyncSets
+		snapshots,
 		resourceHelper,
 		logger,
 	)
 	clusterSync.Status.SyncSets = syncStatusesForSyncSets
 
 	// Apply SelectorSyncSets
-	syncStatusesForSelectorSyncSets, selectorSyncSetsNeedRequeue := r.applySyncSets(
+	syncStatusesForSelectorSyncSets, selectorSyncSetsNeedRequeue, selectorSyncSetsNeedHealthChecks := r.applySyncSets(
 		cd,
 		"SelectorSyncSet",
 		selectorSyncSets,
 		clusterSync.Status.SelectorSyncSets,
 		needToDoFullReapply,
 		clusterSync.Status.FirstSuccessTime == nil, // only report SelectorSyncSet metrics if we haven't reached first success
+		snapshots,
 		resourceHelper,
 		logger,
 	)
@@ -468,8 +493,11 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 	}
 
 	result := reconcile.Result{Requeue: true, RequeueAfter: r.timeUntilRenew(lease)}
-	if syncSetsNeedRequeue || selectorSyncSetsNeedRequeue {
+	switch {
+	case syncSetsNeedRequeue || selectorSyncSetsNeedRequeue:
 		result.RequeueAfter = 0
+	case (syncSetsNeedHealthChecks || selectorSyncSetsNeedHealthChecks) && result.RequeueAfter > healthCheckPollInterval:
+		result.RequeueAfter = healthCheckPollInterval
 	}
 	return result, nil
 }
@@ -481,9 +509,10 @@ func (r *ReconcileClusterSync) applySyncSets(
 	syncStatuses []hiveintv1alpha1.SyncStatus,
 	needToDoFullReapply bool,
 	reportSelectorSyncSetMetrics bool,
+	snapshots *healthSnapshotStore,
 	resourceHelper resource.Helper,
 	logger log.FieldLogger,
-) (newSyncStatuses []hiveintv1alpha1.SyncStatus, requeue bool) {
+) (newSyncStatuses []hiveintv1alpha1.SyncStatus, requeue bool, pollHealthChecks bool) {
 	// Sort the syncsets to a consistent ordering. This prevents thrashing in the ClusterSync status due to the order
 	// of the syncset status changing from one reconcile to the next.
 	sort.Slice(syncSets, func(i, j int) bool {
@@ -524,6 +553,10 @@ func (r *ReconcileClusterSync) applySyncSets(
 				newSyncStatus.LastTransitionTime = metav1.Now()
 			}
 			newSyncStatuses = append(newSyncStatuses, newSyncStatus)
+		} else if oldSyncStatus.HealthCheck != nil {
+			if err := snapshots.remove(syncSetType, oldSyncStatus.Name); err != nil {
+				logger.WithError(err).WithField(syncSetType, oldSyncStatus.Name).Warn("could not remove health snapshot")
+			}
 		}
 	}
 
@@ -531,13 +564,79 @@ func (r *ReconcileClusterSync) applySyncSets(
 		logger := logger.WithField(syncSetType, syncSet.AsMetaObject().GetName())
 		oldSyncStatus, indexOfOldStatus := getOldSyncStatus(syncSet, syncStatuses)
 
+		// Leave the cluster as it is until the rollout of the current generation of the syncset reaches it
+		if !syncSet.IsReleasedToCluster(cd) {
+			logger.Debug("skipping apply of syncset since its rollout has not reached this cluster")
+			if indexOfOldStatus >= 0 {
+				newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
+			}
+			continue
+		}
+
+		// Follow up on the health checks of the current generation of the syncset
+		if healthCheck := oldSyncStatus.HealthCheck; healthCheck != nil && !syncSet.GetSpec().DryRun &&
+			len(syncSet.GetSpec().HealthChecks) > 0 &&
+			oldSyncStatus.ObservedGeneration == syncSet.AsMetaObject().GetGeneration() {
+			rollBack := false
+			switch healthCheck.Phase {
+			case hiveintv1alpha1.PendingSyncHealthCheckPhase:
+				oldSyncStatus.HealthCheck, rollBack = r.checkSyncSetHealth(syncSetType, syncSet, cd, healthCheck, snapshots, resourceHelper, logger)
+				if oldSyncStatus.HealthCheck.Phase == hiveintv1alpha1.PendingSyncHealthCheckPhase && !rollBack {
+					pollHealthChecks = true
+				}
+			case hiveintv1alpha1.RolledBackSyncHealthCheckPhase:
+				// Keep the cluster at the rolled back generation, reapplying it when it is time to do a full re-apply
+				if !needToDoFullReapply {
+					logger.Debug("skipping apply of syncset since it has been rolled back")
+					newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
+					continue
+				}
+				rollBack = true
+			case hiveintv1alpha1.UnhealthySyncHealthCheckPhase:
+				logger.Debug("skipping apply of syncset since its health checks did not pass")
+				newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
+				continue
+			}
+			if rollBack {
+				newSyncStatus, syncSetNeedsRequeue := r.rollBackSyncSet(syncSetType, syncSet, oldSyncStatus, oldSyncStatus.HealthCheck, snapshots, resourceHelper, logger)
+				if syncSetNeedsRequeue {
+					requeue = true
+				}
+				newSyncStatuses = append(newSyncStatuses, newSyncStatus)
+				continue
+			}
+		}
+
+		// Check the resources for drift when it is time to reapply an up-to-date syncset
+		checkedForDrift := false
+		var driftedResources []hiveintv1alpha1.DriftedResource
+		if driftDetection := syncSet.GetSpec().DriftDetection; needToDoFullReapply && !syncSet.GetSpec().DryRun &&
+			(driftDetection == hivev1.CorrectSyncSetDriftDetection || driftDetection == hivev1.AuditSyncSetDriftDetection) &&
+			indexOfOldStatus >= 0 && oldSyncStatus.Result == hiveintv1alpha1.SuccessSyncSetResult &&
+			oldSyncStatus.ObservedGeneration == syncSet.AsMetaObject().GetGeneration() {
+			var err error
+			driftedResources, err = r.detectDrift(syncSetType, syncSet, cd, resourceHelper, logger)
+			if err != nil {
+				logger.WithError(err).Warn("could not check all resources for drift")
+			}
+			checkedForDrift = true
+			if driftDetection == hivev1.AuditSyncSetDriftDetection {
+				logger.Debug("skipping apply of syncset since drift detection is in audit mode")
+				now := metav1.Now()
+				oldSyncStatus.DriftedResources = driftedResources
+				oldSyncStatus.LastDriftCheckTime = &now
+				newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
+				continue
+			}
+		}
+
 		// Determine if the syncset needs to be applied
 		switch {
 		case needToDoFullReapply:
 			logger.Debug("applying syncset because it is time to do a full re-apply")
 		case indexOfOldStatus < 0:
 			logger.Debug("applying syncset because the syncset is new")
-		case oldSyncStatus.Result != hiveintv1alpha1.SuccessSyncSetResult:
+		case oldSyncStatus.Result != hiveintv1alpha1.SuccessSyncSetResult && oldSyncStatus.Result != hiveintv1alpha1.DryRunSyncSetResult:
 			logger.Debug("applying syncset because the last attempt to apply failed")
 		case oldSyncStatus.ObservedGeneration != syncSet.AsMetaObject().GetGeneration():
 			logger.Debug("applying syncset because the syncset generation has changed")
@@ -547,6 +646,15 @@ func (r *ReconcileClusterSync) applySyncSets(
 			continue
 		}
 
+		if syncSet.GetSpec().DryRun {
+			newSyncStatus, syncSetNeedsRequeue := r.dryRunSyncSet(syncSet, cd, oldSyncStatus, resourceHelper, logger)
+			if syncSetNeedsRequeue {
+				requeue = true
+			}
+			newSyncStatuses = append(newSyncStatuses, newSyncStatus)
+			continue
+		}
+
 		// Apply the syncset
 		resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, cd, resourceHelper, logger)
 		newSyncStatus := hiveintv1alpha1.SyncStatus{
@@ -571,6 +679,20 @@ func (r *ReconcileClusterSync) applySyncSets(
 			requeue = true
 		}
 
+		// Start the health checks when a new generation of the syncset has been applied
+		if len(syncSet.GetSpec().HealthChecks) > 0 {
+			switch {
+			case oldSyncStatus.HealthCheck != nil && oldSyncStatus.ObservedGeneration == newSyncStatus.ObservedGeneration:
+				newSyncStatus.HealthCheck = oldSyncStatus.HealthCheck
+			case newSyncStatus.Result == hiveintv1alpha1.SuccessSyncSetResult:
+				newSyncStatus.HealthCheck = &hiveintv1alpha1.SyncHealthCheckStatus{
+					Phase:     hiveintv1alpha1.PendingSyncHealthCheckPhase,
+					StartTime: metav1.Now(),
+				}
+				pollHealthChecks = true
+			}
+		}
+
 		if indexOfOldStatus >= 0 {
 			// Delete any resources that were included in the syncset previously but are no longer included now.
 			remainingResources, err := deleteFromTargetCluster(
@@ -595,11 +717,25 @@ func (r *ReconcileClusterSync) applySyncSets(
 			newSyncStatus.FirstSuccessTime = oldSyncStatus.FirstSuccessTime
 		}
 
+		// Keep reporting the drift found by the last check of this generation of the syncset
+		if oldSyncStatus.ObservedGeneration == newSyncStatus.ObservedGeneration {
+			newSyncStatus.DriftedResources = oldSyncStatus.DriftedResources
+			newSyncStatus.LastDriftCheckTime = oldSyncStatus.LastDriftCheckTime
+		}
+
 		// Update the last transition time if there were any changes to the sync status.
 		if !reflect.DeepEqual(oldSyncStatus, newSyncStatus) {
 			newSyncStatus.LastTransitionTime = metav1.Now()
 		}
 
+		// Report the drift that was corrected by this apply. This is done after updating the last transition time
+		// since a drift check alone does not change the sync status.
+		if checkedForDrift {
+			now := metav1.Now()
+			newSyncStatus.DriftedResources = driftedResources
+			newSyncStatus.LastDriftCheckTime = &now
+		}
+
 		// Set the FirstSuccessTime if this is the first success. Also, observe the apply-duration metric.
 		if newSyncStatus.Result == hiveintv1alpha1.SuccessSyncSetResult && oldSyncStatus.FirstSuccessTime == nil {
 			now := metav1.Now()
@@ -667,7 +803,7 @@ func (r *ReconcileClusterSync) applySyncSet(
 	requeue bool,
 	returnErr error,
 ) {
-	resources, referencesToResources, decodeErr := decodeResources(syncSet, cd, logger)
+	resources, referencesToResources, decodeErr := decodeResources(syncSet, cd, r.Client, logger)
 	referencesToSecrets := referencesToSecrets(syncSet)
 	resourcesInSyncSet = append(referencesToResources, referencesToSecrets...)
 	if decodeErr != nil {
@@ -675,16 +811,7 @@ func (r *ReconcileClusterSync) applySyncSet(
 		return
 	}
 
-	applyFn := resourceHelper.Apply
-	applyFnMetricsLabel := labelApply
-	switch syncSet.GetSpec().ApplyBehavior {
-	case hivev1.CreateOrUpdateSyncSetApplyBehavior:
-		applyFn = resourceHelper.CreateOrUpdate
-		applyFnMetricsLabel = labelCreateOrUpdate
-	case hivev1.CreateOnlySyncSetApplyBehavior:
-		applyFn = resourceHelper.Create
-		applyFnMetricsLabel = labelCreateOnly
-	}
+	applyFn, applyFnMetricsLabel := applyFnForSyncSet(syncSet, resourceHelper)
 
 	// Apply Resources
 	for i, resource := range resources {
@@ -718,7 +845,117 @@ func (r *ReconcileClusterSync) applySyncSet(
 	return
 }
 
-func decodeResources(syncSet CommonSyncSet, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (
+// applyFnForSyncSet returns the function to use to apply the resources of the syncset according to its apply
+// behavior, along with the label for the apply metrics.
+func applyFnForSyncSet(syncSet CommonSyncSet, resourceHelper resource.Helper) (func(obj []byte) (resource.ApplyResult, error), string) {
+	switch syncSet.GetSpec().ApplyBehavior {
+	case hivev1.CreateOrUpdateSyncSetApplyBehavior:
+		return resourceHelper.CreateOrUpdate, labelCreateOrUpdate
+	case hivev1.CreateOnlySyncSetApplyBehavior:
+		return resourceHelper.Create, labelCreateOnly
+	default:
+		return resourceHelper.Apply, labelApply
+	}
+}
+
+// dryRunFnForSyncSet returns the function to use to dry-run the apply of the resources of the syncset according to
+// its apply behavior, matching applyFnForSyncSet.
+func dryRunFnForSyncSet(syncSet CommonSyncSet, resourceHelper resource.Helper) func(obj []byte) (resource.ApplyResult, []string, error) {
+	switch syncSet.GetSpec().ApplyBehavior {
+	case hivev1.CreateOrUpdateSyncSetApplyBehavior:
+		return resourceHelper.DryRunCreateOrUpdate
+	case hivev1.CreateOnlySyncSetApplyBehavior:
+		return resourceHelper.DryRunCreate
+	default:
+		return resourceHelper.DryRunApply
+	}
+}
+
+// dryRunSyncSet computes the changes that applying the resources of the syncset would make to the target cluster
+// without changing anything in the cluster. Resources that were applied before the syncset was put into dry-run mode
+// are retained in ResourcesToDelete so that they are still cleaned up if the syncset is later removed.
+func (r *ReconcileClusterSync) dryRunSyncSet(
+	syncSet CommonSyncSet,
+	cd *hivev1.ClusterDeployment,
+	oldSyncStatus hiveintv1alpha1.SyncStatus,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) (newSyncStatus hiveintv1alpha1.SyncStatus, requeue bool) {
+	newSyncStatus = hiveintv1alpha1.SyncStatus{
+		Name:               syncSet.AsMetaObject().GetName(),
+		ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
+		ResourcesToDelete:  oldSyncStatus.ResourcesToDelete,
+		Result:             hiveintv1alpha1.DryRunSyncSetResult,
+		LastTransitionTime: oldSyncStatus.LastTransitionTime,
+		FirstSuccessTime:   oldSyncStatus.FirstSuccessTime,
+	}
+	resources, references, err := decodeResources(syncSet, cd, r.Client, logger)
+	if err != nil {
+		newSyncStatus.Result = hiveintv1alpha1.FailureSyncSetResult
+		newSyncStatus.FailureMessage = err.Error()
+	}
+	dryRunFn := dryRunFnForSyncSet(syncSet, resourceHelper)
+	var failed int
+	for i, u := range resources {
+		dryRunResult := dryRunResource(u, references[i], dryRunFn, logger.WithField("resourceIndex", i))
+		if dryRunResult.FailureMessage != "" {
+			failed++
+		}
+		newSyncStatus.DryRunResults = append(newSyncStatus.DryRunResults, dryRunResult)
+	}
+	if failed > 0 {
+		requeue = true
+		newSyncStatus.Result = hiveintv1alpha1.FailureSyncSetResult
+		if newSyncStatus.FailureMessage != "" {
+			newSyncStatus.FailureMessage += "\n"
+		}
+		newSyncStatus.FailureMessage += fmt.Sprintf("dry-run apply failed for %d of %d resources", failed, len(resources))
+	}
+	if !reflect.DeepEqual(oldSyncStatus, newSyncStatus) {
+		newSyncStatus.LastTransitionTime = metav1.Now()
+	}
+	logger.WithField("failed", failed).Info("syncset dry-run completed")
+	return
+}
+
+func dryRunResource(
+	u *unstructured.Unstructured,
+	reference hiveintv1alpha1.SyncResourceReference,
+	dryRunFn func(obj []byte) (resource.ApplyResult, []string, error),
+	logger log.FieldLogger,
+) hiveintv1alpha1.DryRunResult {
+	dryRunResult := hiveintv1alpha1.DryRunResult{SyncResourceReference: reference}
+	// Inject the hive managed label as applyToTargetCluster would, so that it does not show up as a change.
+	labels := u.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	labels[constants.HiveManagedLabel] = "true"
+	u.SetLabels(labels)
+	bytes, err := json.Marshal(u)
+	if err != nil {
+		dryRunResult.FailureMessage = errors.Wrap(err, "failed to marshal resource").Error()
+		return dryRunResult
+	}
+	result, changedFields, err := dryRunFn(bytes)
+	if err != nil {
+		logger.WithError(err).Warn("dry-run apply of resource failed")
+		dryRunResult.FailureMessage = err.Error()
+		return dryRunResult
+	}
+	switch result {
+	case resource.CreatedApplyResult:
+		dryRunResult.Action = hiveintv1alpha1.CreateDryRunAction
+	case resource.ConfiguredApplyResult:
+		dryRunResult.Action = hiveintv1alpha1.UpdateDryRunAction
+		dryRunResult.ChangedFields = changedFields
+	default:
+		dryRunResult.Action = hiveintv1alpha1.NoneDryRunAction
+	}
+	return dryRunResult
+}
+
+func decodeResources(syncSet CommonSyncSet, cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (
 	resources []*unstructured.Unstructured, references []hiveintv1alpha1.SyncResourceReference, returnErr error,
 ) {
 	var decodeErrors []error
@@ -731,7 +968,7 @@ func decodeResources(syncSet CommonSyncSet, cd *hivev1.ClusterDeployment, logger
 		}
 		// Apply templates, if enabled
 		if syncSet.GetSpec().EnableResourceTemplates {
-			if err := processParameters(u, cd, logger); err != nil {
+			if err := processParameters(u, cd, c, logger); err != nil {
 				logger.WithField("resourceIndex", i).WithError(err).Warn("error parameterizing object")
 				decodeErrors = append(decodeErrors, errors.Wrapf(err, "failed to parameterize resource %d", i))
 				continue
@@ -1010,7 +1247,7 @@ func setFailedCondition(clusterSync *hiveintv1alpha1.ClusterSync) {
 func getFailingSyncSets(syncStatuses []hiveintv1alpha1.SyncStatus) []string {
 	var failures []string
 	for _, status := range syncStatuses {
-		if status.Result != hiveintv1alpha1.SuccessSyncSetResult {
+		if status.Result == hiveintv1alpha1.FailureSyncSetResult {
 			failures = append(failures, status.Name)
 		}
 	}
@@ -1022,16 +1259,22 @@ func (r *ReconcileClusterSync) setFirstSuccessTime(syncStatuses []hiveintv1alpha
 		return
 	}
 	lastSuccessTime := &metav1.Time{}
+	applied := 0
 	for _, status := range syncStatuses {
+		// Syncsets in dry-run mode apply nothing, so do not hold up the first success of the others
+		if status.Result == hiveintv1alpha1.DryRunSyncSetResult {
+			continue
+		}
 		if status.FirstSuccessTime == nil {
 			return
 		}
 		if status.FirstSuccessTime.Time.After(lastSuccessTime.Time) {
 			lastSuccessTime = status.FirstSuccessTime
 		}
+		applied++
 	}
-	// When len(syncStatuses) == 0, meaning there are no syncsets which apply to the cluster, we will use now as the last success time
-	if len(syncStatuses) == 0 {
+	// When there are no syncsets to apply to the cluster, we will use now as the last success time
+	if applied == 0 {
 		now := metav1.Now()
 		lastSuccessTime = &now
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,6 +31,7 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 	"github.com/openshift/hive/pkg/resource"
@@ -74,6 +77,7 @@ type reconcileTest struct {
 
 	expectUnchangedLeaseRenewTime bool
 	expectRequeue                 bool
+	expectHealthCheckPoll         bool
 	expectNoWorkDone              bool
 }
 
@@ -149,6 +153,8 @@ func (rt *reconcileTest) run(t *testing.T) {
 	assert.True(t, result.Requeue, "expected requeue to be true")
 	if rt.expectRequeue {
 		assert.Zero(t, result.RequeueAfter, "unexpected requeue after")
+	} else if rt.expectHealthCheckPoll {
+		assert.Equal(t, healthCheckPollInterval, result.RequeueAfter, "expected requeue after to be the health check poll interval")
 	} else {
 		var minRequeueAfter, maxRequeueAfter float64
 		if rt.expectUnchangedLeaseRenewTime {
@@ -235,6 +241,16 @@ func areSyncStatusesEqual(t *testing.T, syncSetType string, expectedStatuses, ac
 				*expectedStatuses[i].FirstSuccessTime = *actualStatuses[i].FirstSuccessTime
 			}
 		}
+		if expectedStatus.LastDriftCheckTime != nil && expectedStatus.LastDriftCheckTime.IsZero() && actualStatuses[i].LastDriftCheckTime != nil {
+			actual := actualStatuses[i].LastDriftCheckTime
+			hiveassert.BetweenTimes(t, actual.Time, startTime, endTime, "expected %s status %d to have LastDriftCheckTime of now", syncSetType, i)
+			*expectedStatuses[i].LastDriftCheckTime = *actual
+		}
+		if expectedStatus.HealthCheck != nil && expectedStatus.HealthCheck.StartTime.IsZero() && actualStatuses[i].HealthCheck != nil {
+			actual := actualStatuses[i].HealthCheck.StartTime
+			hiveassert.BetweenTimes(t, actual.Time, startTime, endTime, "expected %s status %d to have health check StartTime of now", syncSetType, i)
+			expectedStatuses[i].HealthCheck.StartTime = actual
+		}
 	}
 	assert.Equalf(t, expectedStatuses, actualStatuses, "unexpected %s statuses", syncSetType)
 }
@@ -533,6 +549,612 @@ func TestReconcileClusterSync_ApplyAllTypes(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_DryRun(t *testing.T) {
+	resourceToCreate := testConfigMap("dest-namespace", "dest-name")
+	resourceToUpdate := testConfigMap("dest-namespace", "dest-name-2")
+	cases := []struct {
+		name                  string
+		existingSyncStatus    *hiveintv1alpha1.SyncStatus
+		updateErr             error
+		expectedSyncStatus    hiveintv1alpha1.SyncStatus
+		expectRequeue         bool
+		expectedFailedMessage string
+	}{
+		{
+			name: "new syncset",
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withNoFirstSuccessTime(),
+				withDryRunResult(),
+				withDryRunResults(
+					hiveintv1alpha1.DryRunResult{
+						SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name"),
+						Action:                hiveintv1alpha1.CreateDryRunAction,
+					},
+					hiveintv1alpha1.DryRunResult{
+						SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name-2"),
+						Action:                hiveintv1alpha1.UpdateDryRunAction,
+						ChangedFields:         []string{"data.foo"},
+					},
+				),
+			),
+		},
+		{
+			name: "previously applied resources are not deleted",
+			existingSyncStatus: func() *hiveintv1alpha1.SyncStatus {
+				s := buildSyncStatus("test-syncset",
+					withTransitionInThePast(),
+					withFirstSuccessTimeInThePast(),
+					withResourcesToDelete(testConfigMapRef("dest-namespace", "removed")),
+				)
+				s.ObservedGeneration = 0
+				return &s
+			}(),
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withFirstSuccessTimeInThePast(),
+				withResourcesToDelete(testConfigMapRef("dest-namespace", "removed")),
+				withDryRunResult(),
+				withDryRunResults(
+					hiveintv1alpha1.DryRunResult{
+						SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name"),
+						Action:                hiveintv1alpha1.CreateDryRunAction,
+					},
+					hiveintv1alpha1.DryRunResult{
+						SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name-2"),
+						Action:                hiveintv1alpha1.UpdateDryRunAction,
+						ChangedFields:         []string{"data.foo"},
+					},
+				),
+			),
+		},
+		{
+			name:      "dry-run failure",
+			updateErr: errors.New("test dry-run error"),
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withNoFirstSuccessTime(),
+				withFailureResult("dry-run apply failed for 1 of 2 resources"),
+				withDryRunResults(
+					hiveintv1alpha1.DryRunResult{
+						SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name"),
+						Action:                hiveintv1alpha1.CreateDryRunAction,
+					},
+					hiveintv1alpha1.DryRunResult{
+						SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name-2"),
+						FailureMessage:        "test dry-run error",
+					},
+				),
+			),
+			expectRequeue:         true,
+			expectedFailedMessage: "SyncSet test-syncset is failing",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			scheme := scheme.GetScheme()
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithDryRun(true),
+				testsyncset.WithResources(resourceToCreate, resourceToUpdate),
+				testsyncset.WithSecrets(testSecretMapping("test-secret", "dest-namespace", "dest-name")),
+				testsyncset.WithPatches(hivev1.SyncObjectPatch{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Namespace:  "dest-namespace",
+					Name:       "dest-name",
+					Patch:      "test-patch",
+					PatchType:  "merge",
+				}),
+			)
+			clusterSync := clusterSyncBuilder(scheme).Build()
+			if tc.existingSyncStatus != nil {
+				clusterSync = clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(*tc.existingSyncStatus))
+			}
+			existing := []runtime.Object{
+				cdBuilder(scheme).Build(),
+				clusterSync,
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+			}
+			rt := newReconcileTest(mockCtrl, existing...)
+			rt.mockResourceHelper.EXPECT().DryRunApply(newApplyMatcher(resourceToCreate)).
+				Return(resource.CreatedApplyResult, nil, nil)
+			if tc.updateErr != nil {
+				rt.mockResourceHelper.EXPECT().DryRunApply(newApplyMatcher(resourceToUpdate)).
+					Return(resource.ApplyResult(""), nil, tc.updateErr)
+			} else {
+				rt.mockResourceHelper.EXPECT().DryRunApply(newApplyMatcher(resourceToUpdate)).
+					Return(resource.ConfiguredApplyResult, []string{"data.foo"}, nil)
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{tc.expectedSyncStatus}
+			rt.expectRequeue = tc.expectRequeue
+			rt.expectedFailedMessage = tc.expectedFailedMessage
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_DryRunApplyBehavior(t *testing.T) {
+	existingResource := testConfigMap("dest-namespace", "dest-name")
+	cases := []struct {
+		applyBehavior  hivev1.SyncSetApplyBehavior
+		expectedAction hiveintv1alpha1.DryRunAction
+	}{
+		{
+			applyBehavior:  hivev1.ApplySyncSetApplyBehavior,
+			expectedAction: hiveintv1alpha1.UpdateDryRunAction,
+		},
+		{
+			applyBehavior:  hivev1.CreateOnlySyncSetApplyBehavior,
+			expectedAction: hiveintv1alpha1.NoneDryRunAction,
+		},
+		{
+			applyBehavior:  hivev1.CreateOrUpdateSyncSetApplyBehavior,
+			expectedAction: hiveintv1alpha1.UpdateDryRunAction,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.applyBehavior), func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			scheme := scheme.GetScheme()
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithDryRun(true),
+				testsyncset.WithApplyBehavior(tc.applyBehavior),
+				testsyncset.WithResources(existingResource),
+			)
+			existing := []runtime.Object{
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+			}
+			rt := newReconcileTest(mockCtrl, existing...)
+			// The resource exists in the cluster with a different content, so it is only left alone by CreateOnly.
+			dryRunResult := hiveintv1alpha1.DryRunResult{
+				SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name"),
+				Action:                tc.expectedAction,
+			}
+			switch tc.applyBehavior {
+			case hivev1.ApplySyncSetApplyBehavior:
+				rt.mockResourceHelper.EXPECT().DryRunApply(newApplyMatcher(existingResource)).
+					Return(resource.ConfiguredApplyResult, []string{"data.foo"}, nil)
+				dryRunResult.ChangedFields = []string{"data.foo"}
+			case hivev1.CreateOnlySyncSetApplyBehavior:
+				rt.mockResourceHelper.EXPECT().DryRunCreate(newApplyMatcher(existingResource)).
+					Return(resource.UnchangedApplyResult, nil, nil)
+			case hivev1.CreateOrUpdateSyncSetApplyBehavior:
+				rt.mockResourceHelper.EXPECT().DryRunCreateOrUpdate(newApplyMatcher(existingResource)).
+					Return(resource.ConfiguredApplyResult, []string{"data.foo"}, nil)
+				dryRunResult.ChangedFields = []string{"data.foo"}
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+				withNoFirstSuccessTime(),
+				withDryRunResult(),
+				withDryRunResults(dryRunResult),
+			)}
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_HealthChecks(t *testing.T) {
+	resourceToApply := testConfigMap("dest-namespace", "dest-name")
+	healthyResource := testConfigMap("dest-namespace", "healthy-name")
+	recentStart := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	expiredStart := metav1.NewTime(time.Now().Add(-20 * time.Minute).Truncate(time.Second))
+	unhealthyMessage := "Deployment dest-namespace/test-deployment condition Available is False, expected True"
+	cases := []struct {
+		name               string
+		existingSyncStatus hiveintv1alpha1.SyncStatus
+		existingSnapshot   bool
+		// largeResources makes the resources of the syncset too large to record in a health snapshot
+		largeResources          bool
+		deploymentAvailable     *bool
+		expectApply             bool
+		expectRollBack          bool
+		expectedSyncStatus      hiveintv1alpha1.SyncStatus
+		expectedSnapshotVersion int64
+		expectHealthCheckPoll   bool
+		expectedFailedMessage   string
+	}{
+		{
+			name: "new generation starts health checks",
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			),
+			expectApply: true,
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withFirstSuccessTimeInThePast(),
+				withHealthCheck(hiveintv1alpha1.PendingSyncHealthCheckPhase, metav1.Time{}, ""),
+			),
+			expectHealthCheckPoll: true,
+		},
+		{
+			name: "pending health checks pass",
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withHealthCheck(hiveintv1alpha1.PendingSyncHealthCheckPhase, recentStart, ""),
+			),
+			deploymentAvailable: pointer.BoolPtr(true),
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withHealthCheck(hiveintv1alpha1.HealthySyncHealthCheckPhase, recentStart, ""),
+			),
+			expectedSnapshotVersion: 2,
+		},
+		{
+			name: "pending health checks pass with resources too large to record",
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withHealthCheck(hiveintv1alpha1.PendingSyncHealthCheckPhase, recentStart, ""),
+			),
+			existingSnapshot:    true,
+			largeResources:      true,
+			deploymentAvailable: pointer.BoolPtr(true),
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withHealthCheck(hiveintv1alpha1.HealthySyncHealthCheckPhase, recentStart, "resources are too large to record for roll back"),
+			),
+		},
+		{
+			name: "pending health checks fail",
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withHealthCheck(hiveintv1alpha1.PendingSyncHealthCheckPhase, recentStart, ""),
+			),
+			existingSnapshot:    true,
+			deploymentAvailable: pointer.BoolPtr(false),
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withHealthCheck(hiveintv1alpha1.PendingSyncHealthCheckPhase, recentStart, unhealthyMessage),
+			),
+			expectedSnapshotVersion: 1,
+			expectHealthCheckPoll:   true,
+		},
+		{
+			name: "health checks time out and roll back",
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withHealthCheck(hiveintv1alpha1.PendingSyncHealthCheckPhase, expiredStart, ""),
+			),
+			existingSnapshot:    true,
+			deploymentAvailable: pointer.BoolPtr(false),
+			expectRollBack:      true,
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withFirstSuccessTimeInThePast(),
+				withFailureResult("health checks did not pass within 10m0s; rolled back to generation 1: "+unhealthyMessage),
+				withHealthCheck(hiveintv1alpha1.RolledBackSyncHealthCheckPhase, expiredStart, unhealthyMessage),
+				withRolledBackToGeneration(1),
+			),
+			expectedSnapshotVersion: 1,
+			expectedFailedMessage:   "SyncSet test-syncset is failing",
+		},
+		{
+			name: "health checks time out without healthy generation",
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withHealthCheck(hiveintv1alpha1.PendingSyncHealthCheckPhase, expiredStart, ""),
+			),
+			deploymentAvailable: pointer.BoolPtr(false),
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withFirstSuccessTimeInThePast(),
+				withFailureResult("health checks did not pass within 10m0s and there is no healthy generation to roll back to: "+unhealthyMessage),
+				withHealthCheck(hiveintv1alpha1.UnhealthySyncHealthCheckPhase, expiredStart, unhealthyMessage),
+			),
+			expectedFailedMessage: "SyncSet test-syncset is failing",
+		},
+		{
+			name: "rolled back syncset is not reapplied",
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withFailureResult("health checks did not pass"),
+				withHealthCheck(hiveintv1alpha1.RolledBackSyncHealthCheckPhase, expiredStart, unhealthyMessage),
+				withRolledBackToGeneration(1),
+			),
+			existingSnapshot: true,
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withFailureResult("health checks did not pass"),
+				withHealthCheck(hiveintv1alpha1.RolledBackSyncHealthCheckPhase, expiredStart, unhealthyMessage),
+				withRolledBackToGeneration(1),
+			),
+			expectedSnapshotVersion: 1,
+			expectedFailedMessage:   "SyncSet test-syncset is failing",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			scheme := scheme.GetScheme()
+			resourceToApply := resourceToApply
+			if tc.largeResources {
+				resourceToApply = resourceToApply.DeepCopy()
+				resourceToApply.Data = map[string]string{"large": strings.Repeat("x", corev1.MaxSecretSize)}
+			}
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(2),
+				testsyncset.WithResources(resourceToApply),
+				testsyncset.WithHealthChecks(hivev1.SyncSetHealthCheck{
+					APIVersion:    "apps/v1",
+					Kind:          "Deployment",
+					Namespace:     "dest-namespace",
+					Name:          "test-deployment",
+					ConditionType: "Available",
+				}),
+			)
+			existing := []runtime.Object{
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(tc.existingSyncStatus)),
+				buildSyncLease(time.Now().Add(-time.Hour)),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+			}
+			if tc.existingSnapshot {
+				existing = append(existing, testHealthSnapshotSecret(t, 1, healthyResource))
+			}
+			rt := newReconcileTest(mockCtrl, existing...)
+			if tc.deploymentAvailable != nil {
+				deployment := &unstructured.Unstructured{}
+				deployment.SetAPIVersion("apps/v1")
+				deployment.SetKind("Deployment")
+				deployment.SetNamespace("dest-namespace")
+				deployment.SetName("test-deployment")
+				status := "False"
+				if *tc.deploymentAvailable {
+					status = "True"
+				}
+				unstructured.SetNestedSlice(deployment.Object, []interface{}{
+					map[string]interface{}{"type": "Available", "status": status},
+				}, "status", "conditions")
+				rt.mockResourceHelper.EXPECT().Get("apps/v1", "Deployment", "dest-namespace", "test-deployment").
+					Return(deployment, nil)
+			}
+			if tc.expectApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).
+					Return(resource.CreatedApplyResult, nil)
+			}
+			if tc.expectRollBack {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(healthyResource)).
+					Return(resource.ConfiguredApplyResult, nil)
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{tc.expectedSyncStatus}
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.expectHealthCheckPoll = tc.expectHealthCheckPoll
+			rt.expectedFailedMessage = tc.expectedFailedMessage
+			rt.run(t)
+
+			secret := &corev1.Secret{}
+			err := rt.c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: healthSnapshotSecretName(testCDName, "SyncSet", "test-syncset")}, secret)
+			if tc.expectedSnapshotVersion == 0 {
+				assert.True(t, apierrors.IsNotFound(err), "expected no health snapshot secret")
+				return
+			}
+			require.NoError(t, err, "unexpected error getting health snapshot secret")
+			snapshot := &healthSnapshot{}
+			require.NoError(t, json.Unmarshal(secret.Data[healthSnapshotSecretKey], snapshot), "could not decode health snapshot")
+			assert.Equal(t, tc.expectedSnapshotVersion, snapshot.Generation, "unexpected health snapshot generation")
+			if assert.Len(t, snapshot.Resources, 1, "unexpected number of resources in health snapshot") {
+				assert.Equal(t, testConfigMapRef("dest-namespace", map[int64]string{1: "healthy-name", 2: "dest-name"}[tc.expectedSnapshotVersion]),
+					snapshot.references()[0], "unexpected resource in health snapshot")
+			}
+		})
+	}
+}
+
+func TestReconcileClusterSync_DriftDetection(t *testing.T) {
+	resourceToEdit := testConfigMap("dest-namespace", "dest-name")
+	resourceToDelete := testConfigMap("dest-namespace", "dest-name-2")
+	drifted := []hiveintv1alpha1.DriftedResource{
+		{
+			SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name"),
+			ChangedFields:         []string{"data.foo"},
+		},
+		{
+			SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name-2"),
+			Missing:               true,
+		},
+	}
+	cases := []struct {
+		name               string
+		driftDetection     hivev1.SyncSetDriftDetection
+		applyBehavior      hivev1.SyncSetApplyBehavior
+		existingSyncStatus hiveintv1alpha1.SyncStatus
+		noDrift            bool
+		expectDriftCheck   bool
+		expectApply        bool
+		expectedSyncStatus hiveintv1alpha1.SyncStatus
+	}{
+		{
+			name:           "correct mode reports and corrects drift",
+			driftDetection: hivev1.CorrectSyncSetDriftDetection,
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			),
+			expectDriftCheck: true,
+			expectApply:      true,
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withDriftedResources(drifted...),
+				withLastDriftCheckTime(metav1.Time{}),
+			),
+		},
+		{
+			name:           "correct mode without drift",
+			driftDetection: hivev1.CorrectSyncSetDriftDetection,
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withDriftedResources(drifted...),
+				withLastDriftCheckTime(timeInThePast),
+			),
+			noDrift:          true,
+			expectDriftCheck: true,
+			expectApply:      true,
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withLastDriftCheckTime(metav1.Time{}),
+			),
+		},
+		{
+			// A CreateOnly resource that exists is never updated, so only missing resources have drifted.
+			name:           "create only correct mode reports and corrects missing resources",
+			driftDetection: hivev1.CorrectSyncSetDriftDetection,
+			applyBehavior:  hivev1.CreateOnlySyncSetApplyBehavior,
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			),
+			expectDriftCheck: true,
+			expectApply:      true,
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withDriftedResources(drifted[1]),
+				withLastDriftCheckTime(metav1.Time{}),
+			),
+		},
+		{
+			name:           "audit mode reports drift without correcting",
+			driftDetection: hivev1.AuditSyncSetDriftDetection,
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			),
+			expectDriftCheck: true,
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withDriftedResources(drifted...),
+				withLastDriftCheckTime(metav1.Time{}),
+			),
+		},
+		{
+			name:           "audit mode applies new generation",
+			driftDetection: hivev1.AuditSyncSetDriftDetection,
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(0),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withDriftedResources(drifted...),
+				withLastDriftCheckTime(timeInThePast),
+			),
+			expectApply: true,
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withFirstSuccessTimeInThePast(),
+			),
+		},
+		{
+			name: "no drift detection",
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			),
+			expectApply: true,
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			scheme := scheme.GetScheme()
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithDriftDetection(tc.driftDetection),
+				testsyncset.WithApplyBehavior(tc.applyBehavior),
+				testsyncset.WithResources(resourceToEdit, resourceToDelete),
+			)
+			existing := []runtime.Object{
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(tc.existingSyncStatus)),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+			}
+			rt := newReconcileTest(mockCtrl, existing...)
+			switch {
+			case tc.applyBehavior == hivev1.CreateOnlySyncSetApplyBehavior:
+				if tc.expectDriftCheck {
+					rt.mockResourceHelper.EXPECT().DryRunCreate(newApplyMatcher(resourceToEdit)).
+						Return(resource.UnchangedApplyResult, nil, nil)
+					rt.mockResourceHelper.EXPECT().DryRunCreate(newApplyMatcher(resourceToDelete)).
+						Return(resource.CreatedApplyResult, nil, nil)
+				}
+				if tc.expectApply {
+					rt.mockResourceHelper.EXPECT().Create(newApplyMatcher(resourceToEdit)).
+						Return(resource.UnchangedApplyResult, nil)
+					rt.mockResourceHelper.EXPECT().Create(newApplyMatcher(resourceToDelete)).
+						Return(resource.CreatedApplyResult, nil)
+				}
+			case tc.expectDriftCheck:
+				if tc.noDrift {
+					rt.mockResourceHelper.EXPECT().DryRunApply(newApplyMatcher(resourceToEdit)).
+						Return(resource.UnchangedApplyResult, nil, nil)
+					rt.mockResourceHelper.EXPECT().DryRunApply(newApplyMatcher(resourceToDelete)).
+						Return(resource.UnchangedApplyResult, nil, nil)
+				} else {
+					rt.mockResourceHelper.EXPECT().DryRunApply(newApplyMatcher(resourceToEdit)).
+						Return(resource.ConfiguredApplyResult, []string{"data.foo"}, nil)
+					rt.mockResourceHelper.EXPECT().DryRunApply(newApplyMatcher(resourceToDelete)).
+						Return(resource.CreatedApplyResult, nil, nil)
+				}
+			}
+			if tc.expectApply && tc.applyBehavior != hivev1.CreateOnlySyncSetApplyBehavior {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToEdit)).
+					Return(resource.ConfiguredApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToDelete)).
+					Return(resource.CreatedApplyResult, nil)
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{tc.expectedSyncStatus}
+			rt.run(t)
+		})
+	}
+}
+
 func TestReconcileClusterSync_Reapply(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -1708,6 +2330,84 @@ func TestReconcileClusterSync_IgnoreNotApplicableSyncSets(t *testing.T) {
 	rt.run(t)
 }
 
+func TestReconcileClusterSync_SelectorSyncSetRollout(t *testing.T) {
+	key := controllerutils.SelectorSyncSetRolloutKey("test-selectorsyncset", testNamespace, testCDName)
+	cases := []struct {
+		name        string
+		rollout     *hivev1.SelectorSyncSetRolloutStatus
+		expectApply bool
+	}{
+		{
+			name: "rollout not started",
+		},
+		{
+			name: "rollout of previous generation",
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              hivev1.CompleteSelectorSyncSetRolloutPhase,
+			},
+		},
+		{
+			name: "cluster not yet released",
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				ReleasedKeyLimit:   key - 1,
+			},
+		},
+		{
+			name: "cluster released",
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				ReleasedKeyLimit:   key,
+			},
+			expectApply: true,
+		},
+		{
+			name: "rollout complete",
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.CompleteSelectorSyncSetRolloutPhase,
+				ReleasedKeyLimit:   -1,
+			},
+			expectApply: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			scheme := scheme.GetScheme()
+			resourceToApply := testConfigMap("dest-namespace", "dest-name")
+			selectorSyncSet := testselectorsyncset.FullBuilder("test-selectorsyncset", scheme).Build(
+				testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+				testselectorsyncset.WithGeneration(2),
+				testselectorsyncset.WithResources(resourceToApply),
+				testselectorsyncset.WithRolloutStrategy(&hivev1.SelectorSyncSetRolloutStrategy{WaveSize: intstr.FromInt(1)}),
+				testselectorsyncset.WithRolloutStatus(tc.rollout),
+			)
+			oldSyncStatus := buildSyncStatus("test-selectorsyncset", withTransitionInThePast(), withFirstSuccessTimeInThePast())
+			rt := newReconcileTest(mockCtrl,
+				cdBuilder(scheme).Build(testcd.WithLabel("test-label-key", "test-label-value")),
+				clusterSyncBuilder(scheme).Build(testcs.WithSelectorSyncSetStatus(oldSyncStatus)),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				selectorSyncSet,
+			)
+			expectedSyncStatus := oldSyncStatus
+			if tc.expectApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+				expectedSyncStatus = buildSyncStatus("test-selectorsyncset", withFirstSuccessTimeInThePast())
+				expectedSyncStatus.ObservedGeneration = 2
+			}
+			rt.expectedSelectorSyncSetStatuses = []hiveintv1alpha1.SyncStatus{expectedSyncStatus}
+			rt.run(t)
+		})
+	}
+}
+
 func TestReconcileClusterSync_ApplySecretForSelectorSyncSet(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	scheme := scheme.GetScheme()
@@ -2321,6 +3021,22 @@ type applyMatcher struct {
 	resource *unstructured.Unstructured
 }
 
+func testHealthSnapshotSecret(t *testing.T, generation int64, resources ...hivev1.MetaRuntimeObject) *corev1.Secret {
+	snapshot := &healthSnapshot{Generation: generation}
+	for _, r := range resources {
+		resourceAsJSON, err := json.Marshal(r)
+		require.NoError(t, err, "could not marshal resource to JSON")
+		u := &unstructured.Unstructured{}
+		require.NoError(t, json.Unmarshal(resourceAsJSON, u), "could not unmarshal as unstructured")
+		snapshot.Resources = append(snapshot.Resources, u)
+	}
+	data, err := json.Marshal(snapshot)
+	require.NoError(t, err, "could not marshal health snapshot")
+	return testsecret.FullBuilder(testNamespace, healthSnapshotSecretName(testCDName, "SyncSet", "test-syncset"), scheme.GetScheme()).Build(
+		testsecret.WithDataKeyValue(healthSnapshotSecretKey, data),
+	)
+}
+
 func newApplyMatcher(resource hivev1.MetaRuntimeObject) gomock.Matcher {
 	resourceAsJSON, err := json.Marshal(resource)
 	if err != nil {
@@ -2491,6 +3207,46 @@ func withTransitionInThePast() syncStatusOption {
 	}
 }
 
+func withDryRunResult() syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.Result = hiveintv1alpha1.DryRunSyncSetResult
+	}
+}
+
+func withDryRunResults(dryRunResults ...hiveintv1alpha1.DryRunResult) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.DryRunResults = dryRunResults
+	}
+}
+
+func withHealthCheck(phase hiveintv1alpha1.SyncHealthCheckPhase, startTime metav1.Time, message string) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.HealthCheck = &hiveintv1alpha1.SyncHealthCheckStatus{
+			Phase:     phase,
+			StartTime: startTime,
+			Message:   message,
+		}
+	}
+}
+
+func withRolledBackToGeneration(generation int64) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.HealthCheck.RolledBackToGeneration = generation
+	}
+}
+
+func withDriftedResources(driftedResources ...hiveintv1alpha1.DriftedResource) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.DriftedResources = driftedResources
+	}
+}
+
+func withLastDriftCheckTime(lastDriftCheckTime metav1.Time) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.LastDriftCheckTime = &lastDriftCheckTime
+	}
+}
+
 func withNoFirstSuccessTime() syncStatusOption {
 	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
 		syncStatus.FirstSuccessTime = nil
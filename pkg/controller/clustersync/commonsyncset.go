@@ -5,6 +5,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 // CommonSyncSet is an interface for interacting with SyncSets and SelectorSyncSets in a generic way.
@@ -17,6 +18,9 @@ type CommonSyncSet interface {
 
 	// GetSpec gets the common spec of the syncset
 	GetSpec() *hivev1.SyncSetCommonSpec
+
+	// IsReleasedToCluster returns true if the current generation of the syncset may be applied to the cluster
+	IsReleasedToCluster(cd *hivev1.ClusterDeployment) bool
 }
 
 // SyncSetAsCommon is a SyncSet typed as a CommonSyncSet
@@ -34,6 +38,10 @@ func (s *SyncSetAsCommon) GetSpec() *hivev1.SyncSetCommonSpec {
 	return &s.Spec.SyncSetCommonSpec
 }
 
+func (s *SyncSetAsCommon) IsReleasedToCluster(cd *hivev1.ClusterDeployment) bool {
+	return true
+}
+
 // SelectorSyncSetAsCommon is a SelectorSyncSet typed as a CommonSyncSet
 type SelectorSyncSetAsCommon hivev1.SelectorSyncSet
 
@@ -48,3 +56,7 @@ func (s *SelectorSyncSetAsCommon) AsMetaObject() metav1.Object {
 func (s *SelectorSyncSetAsCommon) GetSpec() *hivev1.SyncSetCommonSpec {
 	return &s.Spec.SyncSetCommonSpec
 }
+
+func (s *SelectorSyncSetAsCommon) IsReleasedToCluster(cd *hivev1.ClusterDeployment) bool {
+	return controllerutils.IsSelectorSyncSetReleasedToCluster((*hivev1.SelectorSyncSet)(s), cd)
+}
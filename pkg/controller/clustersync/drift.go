@@ -0,0 +1,66 @@
+package clustersync
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// detectDrift compares the resources of the syncset with their live state in the target cluster by way of a
+// dry run of the apply used to sync them, according to the apply behavior of the syncset. A resource has drifted if
+// applying it would create or modify it, so resources of a CreateOnly syncset have drifted only if they are missing.
+func (r *ReconcileClusterSync) detectDrift(
+	syncSetType string,
+	syncSet CommonSyncSet,
+	cd *hivev1.ClusterDeployment,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) ([]hiveintv1alpha1.DriftedResource, error) {
+	resources, references, err := decodeResources(syncSet, cd, r.Client, logger)
+	allErrs := []error{}
+	if err != nil {
+		allErrs = append(allErrs, err)
+	}
+	dryRunFn := dryRunFnForSyncSet(syncSet, resourceHelper)
+	var driftedResources []hiveintv1alpha1.DriftedResource
+	for i, u := range resources {
+		dryRunResult := dryRunResource(u, references[i], dryRunFn, logger.WithField("resourceIndex", i))
+		switch {
+		case dryRunResult.FailureMessage != "":
+			allErrs = append(allErrs, fmt.Errorf("failed to check resource %d for drift: %s", i, dryRunResult.FailureMessage))
+		case dryRunResult.Action == hiveintv1alpha1.CreateDryRunAction:
+			driftedResources = append(driftedResources, hiveintv1alpha1.DriftedResource{
+				SyncResourceReference: references[i],
+				Missing:               true,
+			})
+		case dryRunResult.Action == hiveintv1alpha1.UpdateDryRunAction:
+			driftedResources = append(driftedResources, hiveintv1alpha1.DriftedResource{
+				SyncResourceReference: references[i],
+				ChangedFields:         dryRunResult.ChangedFields,
+			})
+		}
+	}
+	if len(driftedResources) > 0 {
+		logger.WithField("driftedResources", len(driftedResources)).Info("detected drift of syncset resources")
+		metricDriftDetected.WithLabelValues(syncSetType, driftMetricName(syncSet)).Add(float64(len(driftedResources)))
+	}
+	return driftedResources, utilerrors.NewAggregate(allErrs)
+}
+
+// driftMetricName is the name to use in the drift metric for the syncset. Since SyncSets are usually specific to a
+// single cluster, they are grouped by their metrics group annotation to keep the cardinality of the metric low.
+func driftMetricName(syncSet CommonSyncSet) string {
+	if syncSet.AsMetaObject().GetNamespace() == "" {
+		return syncSet.AsMetaObject().GetName()
+	}
+	if syncSetGroup := syncSet.AsMetaObject().GetAnnotations()[constants.SyncSetMetricsGroupAnnotation]; syncSetGroup != "" {
+		return syncSetGroup
+	}
+	return "none"
+}
@@ -0,0 +1,319 @@
+package clustersync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	defaultHealthCheckTimeout = 10 * time.Minute
+	// healthCheckPollInterval is how often pending health checks are evaluated.
+	healthCheckPollInterval = 30 * time.Second
+	// healthSnapshotSecretSuffix is appended to the names of the ClusterDeployment and the syncset to form the name of
+	// the secret holding the resources of the last healthy generation of the syncset.
+	healthSnapshotSecretSuffix = "health-snapshot"
+	// healthSnapshotSecretKey is the key of the health snapshot in its secret.
+	healthSnapshotSecretKey = "snapshot"
+)
+
+// errHealthSnapshotTooLarge is returned when the resources of a syncset do not fit in a secret.
+var errHealthSnapshotTooLarge = errors.New("health snapshot is too large to be stored in a secret")
+
+// healthSnapshot is the set of resources of the last generation of a syncset that passed its health checks on a
+// cluster. It is what gets reapplied when a later generation fails its health checks.
+type healthSnapshot struct {
+	Generation int64                        `json:"generation"`
+	Resources  []*unstructured.Unstructured `json:"resources"`
+}
+
+func (s *healthSnapshot) references() []hiveintv1alpha1.SyncResourceReference {
+	references := make([]hiveintv1alpha1.SyncResourceReference, len(s.Resources))
+	for i, u := range s.Resources {
+		references[i] = hiveintv1alpha1.SyncResourceReference{
+			APIVersion: u.GetAPIVersion(),
+			Kind:       u.GetKind(),
+			Namespace:  u.GetNamespace(),
+			Name:       u.GetName(),
+		}
+	}
+	return references
+}
+
+// healthSnapshotStore holds the health snapshots of the syncsets of a cluster. Each snapshot is kept in its own secret
+// in the namespace of the ClusterDeployment, since the resources may themselves contain sensitive data, and so that
+// the snapshots of all syncsets of a cluster do not have to fit in a single secret. Changes are written right away.
+type healthSnapshotStore struct {
+	client client.Client
+	cd     *hivev1.ClusterDeployment
+}
+
+func newHealthSnapshotStore(c client.Client, cd *hivev1.ClusterDeployment) *healthSnapshotStore {
+	return &healthSnapshotStore{client: c, cd: cd}
+}
+
+func healthSnapshotSecretName(cdName, syncSetType, syncSetName string) string {
+	return apihelpers.GetName(cdName, fmt.Sprintf("%s-%s-%s", strings.ToLower(syncSetType), syncSetName, healthSnapshotSecretSuffix),
+		validation.DNS1123SubdomainMaxLength)
+}
+
+func (s *healthSnapshotStore) get(syncSetType, syncSetName string) (*healthSnapshot, error) {
+	secret := &corev1.Secret{}
+	name := types.NamespacedName{Namespace: s.cd.Namespace, Name: healthSnapshotSecretName(s.cd.Name, syncSetType, syncSetName)}
+	switch err := s.client.Get(context.Background(), name, secret); {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "could not get health snapshot secret")
+	}
+	data, ok := secret.Data[healthSnapshotSecretKey]
+	if !ok {
+		return nil, nil
+	}
+	snapshot := &healthSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, errors.Wrap(err, "could not decode health snapshot")
+	}
+	return snapshot, nil
+}
+
+// set records the snapshot of the syncset, replacing any earlier one. Returns errHealthSnapshotTooLarge if the
+// snapshot does not fit in a secret.
+func (s *healthSnapshotStore) set(syncSetType, syncSetName string, snapshot *healthSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "could not encode health snapshot")
+	}
+	if len(data) > corev1.MaxSecretSize {
+		return errHealthSnapshotTooLarge
+	}
+	secret := &corev1.Secret{}
+	name := types.NamespacedName{Namespace: s.cd.Namespace, Name: healthSnapshotSecretName(s.cd.Name, syncSetType, syncSetName)}
+	switch err := s.client.Get(context.Background(), name, secret); {
+	case apierrors.IsNotFound(err):
+		secret.Namespace = name.Namespace
+		secret.Name = name.Name
+		secret.Labels = map[string]string{constants.ClusterDeploymentNameLabel: s.cd.Name}
+		ownerRef := metav1.NewControllerRef(s.cd, s.cd.GroupVersionKind())
+		ownerRef.Controller = nil
+		secret.OwnerReferences = []metav1.OwnerReference{*ownerRef}
+		secret.Data = map[string][]byte{healthSnapshotSecretKey: data}
+		return errors.Wrap(s.client.Create(context.Background(), secret), "could not create health snapshot secret")
+	case err != nil:
+		return errors.Wrap(err, "could not get health snapshot secret")
+	}
+	secret.Data = map[string][]byte{healthSnapshotSecretKey: data}
+	return errors.Wrap(s.client.Update(context.Background(), secret), "could not update health snapshot secret")
+}
+
+func (s *healthSnapshotStore) remove(syncSetType, syncSetName string) error {
+	secret := &corev1.Secret{}
+	secret.Namespace = s.cd.Namespace
+	secret.Name = healthSnapshotSecretName(s.cd.Name, syncSetType, syncSetName)
+	if err := s.client.Delete(context.Background(), secret); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "could not delete health snapshot secret")
+	}
+	return nil
+}
+
+func healthCheckTimeout(syncSet CommonSyncSet) time.Duration {
+	if timeout := syncSet.GetSpec().HealthCheckTimeout; timeout != nil {
+		return timeout.Duration
+	}
+	return defaultHealthCheckTimeout
+}
+
+// evaluateHealthChecks returns a description of each health check that does not pass on the target cluster.
+func evaluateHealthChecks(healthChecks []hivev1.SyncSetHealthCheck, resourceHelper resource.Helper, logger log.FieldLogger) []string {
+	var failures []string
+	for _, check := range healthChecks {
+		expectedStatus := check.ConditionStatus
+		if expectedStatus == "" {
+			expectedStatus = corev1.ConditionTrue
+		}
+		object := check.Name
+		if check.Namespace != "" {
+			object = check.Namespace + "/" + check.Name
+		}
+		object = fmt.Sprintf("%s %s", check.Kind, object)
+		u, err := resourceHelper.Get(check.APIVersion, check.Kind, check.Namespace, check.Name)
+		if err != nil {
+			logger.WithError(err).WithField("object", object).Debug("could not get object for health check")
+			failures = append(failures, fmt.Sprintf("%s: %v", object, err))
+			continue
+		}
+		status, found := conditionStatus(u, check.ConditionType)
+		switch {
+		case !found:
+			failures = append(failures, fmt.Sprintf("%s has no %s condition", object, check.ConditionType))
+		case status != string(expectedStatus):
+			failures = append(failures, fmt.Sprintf("%s condition %s is %s, expected %s", object, check.ConditionType, status, expectedStatus))
+		}
+	}
+	return failures
+}
+
+// conditionStatus finds the status of the condition of the given type in status.conditions of the object.
+func conditionStatus(u *unstructured.Unstructured, conditionType string) (string, bool) {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != conditionType {
+			continue
+		}
+		status, _ := cond["status"].(string)
+		return status, true
+	}
+	return "", false
+}
+
+// checkSyncSetHealth evaluates the pending health checks of the current generation of the syncset. When they pass,
+// the resources of the syncset are recorded as the health snapshot to roll back to. Returns whether the health checks
+// have timed out.
+func (r *ReconcileClusterSync) checkSyncSetHealth(
+	syncSetType string,
+	syncSet CommonSyncSet,
+	cd *hivev1.ClusterDeployment,
+	healthCheck *hiveintv1alpha1.SyncHealthCheckStatus,
+	snapshots *healthSnapshotStore,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) (newHealthCheck *hiveintv1alpha1.SyncHealthCheckStatus, timedOut bool) {
+	newHealthCheck = healthCheck.DeepCopy()
+	failures := evaluateHealthChecks(syncSet.GetSpec().HealthChecks, resourceHelper, logger)
+	if len(failures) > 0 {
+		newHealthCheck.Message = strings.Join(failures, "; ")
+		timedOut = time.Since(healthCheck.StartTime.Time) >= healthCheckTimeout(syncSet)
+		logger.WithField("timedOut", timedOut).Infof("health checks have not passed: %s", newHealthCheck.Message)
+		return
+	}
+	resources, _, err := decodeResources(syncSet, cd, r.Client, logger)
+	if err == nil {
+		err = snapshots.set(syncSetType, syncSet.AsMetaObject().GetName(), &healthSnapshot{
+			Generation: syncSet.AsMetaObject().GetGeneration(),
+			Resources:  resources,
+		})
+	}
+	if errors.Is(err, errHealthSnapshotTooLarge) {
+		// Do not leave an older generation around to be rolled back to in place of this one
+		if err = snapshots.remove(syncSetType, syncSet.AsMetaObject().GetName()); err == nil {
+			logger.Warn("health checks passed, but the resources are too large to record for roll back")
+			newHealthCheck.Phase = hiveintv1alpha1.HealthySyncHealthCheckPhase
+			newHealthCheck.Message = "resources are too large to record for roll back"
+			return
+		}
+	}
+	if err != nil {
+		logger.WithError(err).Warn("could not record health snapshot")
+		newHealthCheck.Message = fmt.Sprintf("could not record health snapshot: %v", err)
+		return
+	}
+	logger.Info("health checks passed")
+	newHealthCheck.Phase = hiveintv1alpha1.HealthySyncHealthCheckPhase
+	newHealthCheck.Message = ""
+	return
+}
+
+// rollBackSyncSet reapplies the resources of the last generation of the syncset that passed its health checks. In
+// the Sync apply mode, resources that are not part of that generation are deleted. If there is no healthy generation
+// to roll back to, the cluster is left as it is.
+func (r *ReconcileClusterSync) rollBackSyncSet(
+	syncSetType string,
+	syncSet CommonSyncSet,
+	oldSyncStatus hiveintv1alpha1.SyncStatus,
+	healthCheck *hiveintv1alpha1.SyncHealthCheckStatus,
+	snapshots *healthSnapshotStore,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) (newSyncStatus hiveintv1alpha1.SyncStatus, requeue bool) {
+	newSyncStatus = hiveintv1alpha1.SyncStatus{
+		Name:               syncSet.AsMetaObject().GetName(),
+		ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
+		ResourcesToDelete:  oldSyncStatus.ResourcesToDelete,
+		Result:             hiveintv1alpha1.FailureSyncSetResult,
+		LastTransitionTime: oldSyncStatus.LastTransitionTime,
+		FirstSuccessTime:   oldSyncStatus.FirstSuccessTime,
+		HealthCheck:        healthCheck.DeepCopy(),
+	}
+	defer func() {
+		if !reflect.DeepEqual(oldSyncStatus, newSyncStatus) {
+			newSyncStatus.LastTransitionTime = metav1.Now()
+		}
+	}()
+	timeout := healthCheckTimeout(syncSet)
+
+	snapshot, err := snapshots.get(syncSetType, syncSet.AsMetaObject().GetName())
+	if err != nil {
+		logger.WithError(err).Warn("could not read health snapshot")
+		newSyncStatus.FailureMessage = err.Error()
+		setRollBackPending(newSyncStatus.HealthCheck)
+		return newSyncStatus, true
+	}
+	if snapshot == nil {
+		logger.Warn("health checks did not pass and there is no healthy generation to roll back to")
+		newSyncStatus.FailureMessage = fmt.Sprintf("health checks did not pass within %v and there is no healthy generation to roll back to: %s", timeout, healthCheck.Message)
+		newSyncStatus.HealthCheck.Phase = hiveintv1alpha1.UnhealthySyncHealthCheckPhase
+		return newSyncStatus, false
+	}
+
+	logger = logger.WithField("rollBackToGeneration", snapshot.Generation)
+	logger.Info("rolling back syncset since health checks did not pass")
+	applyFn, applyFnMetricsLabel := applyFnForSyncSet(syncSet, resourceHelper)
+	for i, u := range snapshot.Resources {
+		if err := applyToTargetCluster(u, applyFnMetricsLabel, applyFn, logger.WithField("resourceIndex", i)); err != nil {
+			newSyncStatus.FailureMessage = errors.Wrapf(err, "failed to roll back resource %d", i).Error()
+			setRollBackPending(newSyncStatus.HealthCheck)
+			return newSyncStatus, true
+		}
+	}
+	newSyncStatus.FailureMessage = fmt.Sprintf("health checks did not pass within %v; rolled back to generation %d: %s", timeout, snapshot.Generation, healthCheck.Message)
+	if syncSet.GetSpec().ResourceApplyMode == hivev1.SyncResourceApplyMode {
+		snapshotResources := snapshot.references()
+		remainingResources, err := deleteFromTargetCluster(
+			oldSyncStatus.ResourcesToDelete,
+			func(r hiveintv1alpha1.SyncResourceReference) bool {
+				return !containsResource(snapshotResources, r)
+			},
+			resourceHelper,
+			logger,
+		)
+		if err != nil {
+			requeue = true
+			newSyncStatus.FailureMessage += "\n" + err.Error()
+		}
+		newSyncStatus.ResourcesToDelete = mergeResources(snapshotResources, remainingResources)
+		sort.Slice(newSyncStatus.ResourcesToDelete, func(i, j int) bool {
+			return orderResources(newSyncStatus.ResourcesToDelete[i], newSyncStatus.ResourcesToDelete[j])
+		})
+	}
+	newSyncStatus.HealthCheck.Phase = hiveintv1alpha1.RolledBackSyncHealthCheckPhase
+	newSyncStatus.HealthCheck.RolledBackToGeneration = snapshot.Generation
+	return newSyncStatus, requeue
+}
+
+// setRollBackPending marks the health checks as pending again so that a roll back that could not be completed is
+// retried. Since the health checks have already timed out, the roll back is attempted on the next reconcile.
+func setRollBackPending(healthCheck *hiveintv1alpha1.SyncHealthCheckStatus) {
+	healthCheck.Phase = hiveintv1alpha1.PendingSyncHealthCheckPhase
+	healthCheck.RolledBackToGeneration = 0
+}
@@ -2,26 +2,80 @@ package clustersync
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 )
 
+// templateData is the read-only view of a ClusterDeployment which is the data object ("dot") of SyncSet resource
+// templates.
+type templateData struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+	BaseDomain  string
+	Platform    string
+	Region      string
+	InfraID     string
+	ClusterID   string
+	APIURL      string
+}
+
+func newTemplateData(cd *hivev1.ClusterDeployment) *templateData {
+	data := &templateData{
+		Name:        cd.Name,
+		Namespace:   cd.Namespace,
+		Labels:      map[string]string{},
+		Annotations: map[string]string{},
+		BaseDomain:  cd.Spec.BaseDomain,
+		Platform:    cd.Labels[hivev1.HiveClusterPlatformLabel],
+		Region:      cd.Labels[hivev1.HiveClusterRegionLabel],
+		APIURL:      cd.Status.APIURL,
+	}
+	// Copy the maps so templates can't modify the ClusterDeployment
+	for k, v := range cd.Labels {
+		data.Labels[k] = v
+	}
+	for k, v := range cd.Annotations {
+		data.Annotations[k] = v
+	}
+	if cd.Spec.ClusterMetadata != nil {
+		data.InfraID = cd.Spec.ClusterMetadata.InfraID
+		data.ClusterID = cd.Spec.ClusterMetadata.ClusterID
+	}
+	return data
+}
+
 // processParameters modifies `u`, appling text/template parameters found in string values therein.
-func processParameters(u *unstructured.Unstructured, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
-	resourceParamTemplate := template.New("resourceParams").Funcs(
+// Templates are executed against a templateData for `cd`, and may read Secrets and ConfigMaps in the
+// namespace of `cd` via `c`.
+func processParameters(u *unstructured.Unstructured, cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
+	resourceParamTemplate := template.New("resourceParams").Funcs(templateFuncs).Funcs(
 		template.FuncMap{
-			"fromCDLabel": fromCDLabel(cd),
+			"fromCDLabel":      fromCDLabel(cd),
+			"fromCDAnnotation": fromCDAnnotation(cd),
+			"fromSecret":       fromSecret(c, cd.Namespace),
+			"fromConfigMap":    fromConfigMap(c, cd.Namespace),
 		},
 	)
+	data := newTemplateData(cd)
 	for k, v := range u.Object {
-		newVal, err := applyTemplate(resourceParamTemplate, v)
+		newVal, err := applyTemplate(resourceParamTemplate, v, data)
 		if err != nil {
 			return errors.Wrapf(err, "Failed to apply template to value %#v", v)
 		}
@@ -43,13 +97,103 @@ func fromCDLabel(cd *hivev1.ClusterDeployment) func(string) string {
 	}
 }
 
-// applyTemplate recursively parses and executes `t` against the string values found within `v`.
-// The template is executed with a `nil` data object -- i.e. templates referring to `.` ("dot")
-// will not work. This really exists only to support invoking functions in the template's FuncMap.
-// We expect `v` to be a descendant of an Unstructured.Object, and thus limited to types
+// fromCDAnnotation is like fromCDLabel, but for annotations.
+func fromCDAnnotation(cd *hivev1.ClusterDeployment) func(string) string {
+	return func(annotationKey string) string {
+		if cd.Annotations == nil {
+			return ""
+		}
+		return cd.Annotations[annotationKey]
+	}
+}
+
+// fromSecret produces a text/template-suitable func accepting the name of a Secret in `namespace`
+// and a key within its data. The (decoded) value of the key is returned by the func. It is an
+// error if the Secret or the key does not exist.
+func fromSecret(c client.Client, namespace string) func(string, string) (string, error) {
+	return func(name, key string) (string, error) {
+		secret := &corev1.Secret{}
+		if err := c.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+			return "", errors.Wrapf(err, "failed to get Secret %s", name)
+		}
+		value, ok := secret.Data[key]
+		if !ok {
+			return "", fmt.Errorf("key %q not found in Secret %s", key, name)
+		}
+		return string(value), nil
+	}
+}
+
+// fromConfigMap is like fromSecret, but for ConfigMaps.
+func fromConfigMap(c client.Client, namespace string) func(string, string) (string, error) {
+	return func(name, key string) (string, error) {
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, cm); err != nil {
+			return "", errors.Wrapf(err, "failed to get ConfigMap %s", name)
+		}
+		if value, ok := cm.Data[key]; ok {
+			return value, nil
+		}
+		if value, ok := cm.BinaryData[key]; ok {
+			return string(value), nil
+		}
+		return "", fmt.Errorf("key %q not found in ConfigMap %s", key, name)
+	}
+}
+
+// templateFuncs are string functions available to SyncSet resource templates. Names, argument
+// order and results follow the sprig library, so that the string being operated on is last and may
+// be piped: {{ .Name | trimPrefix "prod-" | upper }}
+// Unlike sprig, b64dec fails the template on invalid input instead of returning the error message.
+var templateFuncs = template.FuncMap{
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"split": func(sep, s string) map[string]string {
+		parts := strings.Split(s, sep)
+		m := make(map[string]string, len(parts))
+		for i, part := range parts {
+			m["_"+strconv.Itoa(i)] = part
+		}
+		return m
+	},
+	"splitList": func(sep, s string) []string { return strings.Split(s, sep) },
+	"join":      func(sep string, elems []string) string { return strings.Join(elems, sep) },
+	"quote":     func(s string) string { return fmt.Sprintf("%q", s) },
+	"b64enc":    func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	"b64dec": func(s string) (string, error) {
+		b, err := base64.StdEncoding.DecodeString(s)
+		return string(b), err
+	},
+	// trunc keeps the first n bytes of the string, or the last -n bytes if n is negative.
+	"trunc": func(n int, s string) string {
+		switch {
+		case n < 0 && len(s)+n > 0:
+			return s[len(s)+n:]
+		case n >= 0 && len(s) > n:
+			return s[:n]
+		}
+		return s
+	},
+	"default": func(def, s string) string {
+		if s == "" {
+			return def
+		}
+		return s
+	},
+}
+
+// applyTemplate recursively parses and executes `t` against the string values found within `v`,
+// with `data` as the data object ("dot"). We expect `v` to be a descendant of an Unstructured.Object, and thus limited to types
 // string, float, int, bool, []interface{}, or map[string]interface{} (where the list/map
 // interface{} values are similarly limited, recursively).
-func applyTemplate(t *template.Template, v interface{}) (interface{}, error) {
+func applyTemplate(t *template.Template, v interface{}, data interface{}) (interface{}, error) {
 	ival := reflect.ValueOf(v)
 	switch ival.Kind() {
 	case reflect.String:
@@ -66,11 +210,11 @@ func applyTemplate(t *template.Template, v interface{}) (interface{}, error) {
 			return nil, errors.Wrapf(err, "failed to parse template string %q", sval)
 		}
 		buf := new(bytes.Buffer)
-		err = parsed.Execute(buf, nil)
+		err = parsed.Execute(buf, data)
 		return buf.String(), errors.Wrapf(err, "failed to execute template on string %q", sval)
 	case reflect.Array, reflect.Slice:
 		for i := 0; i < ival.Len(); i++ {
-			newVal, err := applyTemplate(t, ival.Index(i).Interface())
+			newVal, err := applyTemplate(t, ival.Index(i).Interface(), data)
 			if err != nil {
 				return nil, err
 			}
@@ -78,7 +222,7 @@ func applyTemplate(t *template.Template, v interface{}) (interface{}, error) {
 		}
 	case reflect.Map:
 		for _, k := range ival.MapKeys() {
-			newVal, err := applyTemplate(t, ival.MapIndex(k).Interface())
+			newVal, err := applyTemplate(t, ival.MapIndex(k).Interface(), data)
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,134 @@
+package clustersync
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcm "github.com/openshift/hive/pkg/test/configmap"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+func TestProcessParameters(t *testing.T) {
+	scheme := scheme.GetScheme()
+	cd := testcd.FullBuilder(testNamespace, testCDName, scheme).
+		GenericOptions(
+			testgeneric.WithLabel(hivev1.HiveClusterPlatformLabel, "aws"),
+			testgeneric.WithLabel(hivev1.HiveClusterRegionLabel, "us-east-1"),
+			testgeneric.WithAnnotation("example.com/owner", "team-a"),
+		).
+		Build(
+			testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: "test-infra-id", ClusterID: "test-cluster-id"}),
+			func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.BaseDomain = "example.com"
+				cd.Status.APIURL = "https://api.test-cluster-deployment.example.com:6443"
+			},
+		)
+	existing := []runtime.Object{
+		cd,
+		testsecret.FullBuilder(testNamespace, "test-secret", scheme).Build(
+			testsecret.WithDataKeyValue("password", []byte("hunter2")),
+		),
+		testcm.FullBuilder(testNamespace, "test-configmap", scheme).Build(
+			testcm.WithDataKeyValue("vip", "10.0.0.5"),
+		),
+		testsecret.FullBuilder("other-namespace", "other-secret", scheme).Build(
+			testsecret.WithDataKeyValue("password", []byte("hunter3")),
+		),
+	}
+
+	cases := []struct {
+		name          string
+		value         string
+		expectedValue string
+		expectErr     bool
+	}{
+		{
+			name:          "no template",
+			value:         "plain",
+			expectedValue: "plain",
+		},
+		{
+			name:          "cluster deployment fields",
+			value:         "{{ .Namespace }}/{{ .Name }} {{ .BaseDomain }} {{ .Platform }} {{ .Region }} {{ .InfraID }} {{ .ClusterID }} {{ .APIURL }}",
+			expectedValue: "test-namespace/test-cluster-deployment example.com aws us-east-1 test-infra-id test-cluster-id https://api.test-cluster-deployment.example.com:6443",
+		},
+		{
+			name:          "labels and annotations",
+			value:         `{{ index .Labels "hive.openshift.io/cluster-region" }} {{ fromCDAnnotation "example.com/owner" }} {{ fromCDAnnotation "missing" }}`,
+			expectedValue: "us-east-1 team-a ",
+		},
+		{
+			name:          "from secret",
+			value:         `{{ fromSecret "test-secret" "password" }}`,
+			expectedValue: "hunter2",
+		},
+		{
+			name:          "from configmap",
+			value:         `{{ fromConfigMap "test-configmap" "vip" }}`,
+			expectedValue: "10.0.0.5",
+		},
+		{
+			name:      "secret in other namespace",
+			value:     `{{ fromSecret "other-secret" "password" }}`,
+			expectErr: true,
+		},
+		{
+			name:      "missing secret key",
+			value:     `{{ fromSecret "test-secret" "missing" }}`,
+			expectErr: true,
+		},
+		{
+			name:          "string functions",
+			value:         `{{ .Name | trimPrefix "test-" | upper }} {{ .Region | replace "-" "_" }} {{ .Name | trunc 4 }} {{ fromCDLabel "missing" | default "none" }} {{ "abc" | b64enc }}`,
+			expectedValue: "CLUSTER-DEPLOYMENT us_east_1 test none YWJj",
+		},
+		{
+			name:          "split",
+			value:         `{{ $parts := .Region | split "-" }}{{ $parts._0 }} {{ $parts._2 }} {{ .Region | splitList "-" | join "." }}`,
+			expectedValue: "us 1 us.east.1",
+		},
+		{
+			name:          "trunc from end",
+			value:         `{{ .Name | trunc -10 }} {{ .Name | trunc -100 }}`,
+			expectedValue: "deployment test-cluster-deployment",
+		},
+		{
+			name:      "invalid base64",
+			value:     `{{ "not base64!" | b64dec }}`,
+			expectErr: true,
+		},
+		{
+			name:          "conditional",
+			value:         `{{ if hasPrefix "us-" .Region }}us{{ else }}other{{ end }}`,
+			expectedValue: "us",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build()
+			u := &unstructured.Unstructured{Object: map[string]interface{}{
+				"data": map[string]interface{}{
+					"value": tc.value,
+				},
+			}}
+			err := processParameters(u, cd, c, log.WithField("test", tc.name))
+			if tc.expectErr {
+				assert.Error(t, err, "expected error processing parameters")
+				return
+			}
+			require.NoError(t, err, "unexpected error processing parameters")
+			actual, _, _ := unstructured.NestedString(u.Object, "data", "value")
+			assert.Equal(t, tc.expectedValue, actual, "unexpected templated value")
+		})
+	}
+}
@@ -0,0 +1,645 @@
+package clusterupgrade
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.ClusterUpgradeControllerName
+
+	clusterVersionObjectName = "version"
+
+	// clusterVersionFailingCondition is the ClusterVersion condition set by the cluster-version operator when it
+	// cannot make progress.
+	clusterVersionFailingCondition configv1.ClusterStatusConditionType = "Failing"
+
+	// resyncInterval is how often the clusters of an upgrade in progress are checked.
+	resyncInterval = time.Minute
+	defaultTimeout = 3 * time.Hour
+
+	invalidSpecReason             = "InvalidSpec"
+	noTargetsReason               = "NoTargets"
+	upgradingReason               = "Upgrading"
+	maintenanceWindowClosedReason = "MaintenanceWindowClosed"
+	tooManyFailuresReason         = "TooManyFailures"
+	completeReason                = "Complete"
+	noFailuresReason              = "NoFailures"
+	clustersFailedReason          = "ClustersFailed"
+
+	// maxFailedClustersListed limits the number of failed clusters named in the Failed condition.
+	maxFailedClustersListed = 5
+)
+
+// Add creates a new ClusterUpgrade controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileClusterUpgrade
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterUpgrade {
+	r := &ReconcileClusterUpgrade{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileClusterUpgrade, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("clusterupgrade-controller", mgr, controller.Options{
+		Reconciler:              controllerutils.NewDelayingReconciler(r, r.logger),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterUpgrades
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.ClusterUpgrade{}), &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for ClusterDeployments that start or stop being targets of ClusterUpgrades. The progress of upgrades is
+	// checked periodically, so other changes to ClusterDeployments are ignored.
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &hivev1.ClusterDeployment{}),
+		handler.EnqueueRequestsFromMapFunc(requestsForClusterDeployment(r.Client, r.logger)),
+		predicate.Or(predicate.LabelChangedPredicate{}, predicate.GenerationChangedPredicate{}),
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// requestsForClusterDeployment returns the ClusterUpgrades selecting the ClusterDeployment.
+func requestsForClusterDeployment(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		upgrades := &hivev1.ClusterUpgradeList{}
+		if err := c.List(ctx, upgrades); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list ClusterUpgrades for ClusterDeployment")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, upgrade := range upgrades.Items {
+			selector, err := metav1.LabelSelectorAsSelector(&upgrade.Spec.ClusterDeploymentSelector)
+			if err != nil || !selector.Matches(labels.Set(o.GetLabels())) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: upgrade.Name}})
+		}
+		return requests
+	}
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterUpgrade{}
+
+// ReconcileClusterUpgrade upgrades the clusters selected by ClusterUpgrades.
+type ReconcileClusterUpgrade struct {
+	client.Client
+	logger log.FieldLogger
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+}
+
+// upgradeTarget is a cluster selected by a ClusterUpgrade.
+type upgradeTarget struct {
+	cd     *hivev1.ClusterDeployment
+	status hivev1.ClusterUpgradeClusterStatus
+	// remoteClient and clusterVersion are set when the cluster was reachable.
+	remoteClient   client.Client
+	clusterVersion *configv1.ClusterVersion
+}
+
+// Reconcile checks the progress of the upgrade of each cluster selected by a ClusterUpgrade, and starts upgrades of
+// further clusters as the concurrency limit and maintenance window allow.
+func (r *ReconcileClusterUpgrade) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterUpgrade", request.NamespacedName)
+	logger.Debug("reconciling cluster upgrade")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	upgrade := &hivev1.ClusterUpgrade{}
+	switch err := r.Get(ctx, request.NamespacedName, upgrade); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("cluster upgrade not found")
+		metricClusters.DeletePartialMatch(map[string]string{"clusterupgrade": request.Name})
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting ClusterUpgrade")
+		return reconcile.Result{}, err
+	}
+	if upgrade.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+	origStatus := upgrade.Status.DeepCopy()
+
+	if err := validateSpec(&upgrade.Spec); err != nil {
+		logger.WithError(err).Warn("invalid cluster upgrade")
+		upgrade.Status.ObservedGeneration = upgrade.Generation
+		r.setConditions(upgrade, corev1.ConditionFalse, invalidSpecReason, err.Error())
+		return reconcile.Result{}, r.updateStatus(upgrade, origStatus, logger)
+	}
+
+	if upgrade.Status.ObservedGeneration != upgrade.Generation {
+		logger.WithField("generation", upgrade.Generation).Info("starting upgrade of new generation")
+		upgrade.Status.ObservedGeneration = upgrade.Generation
+		upgrade.Status.Phase = hivev1.ProgressingClusterUpgradePhase
+		upgrade.Status.Clusters = nil
+	}
+
+	targets, err := r.getTargets(upgrade, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	now := time.Now()
+	for _, t := range targets {
+		r.observeCluster(upgrade, t, now, logger)
+	}
+
+	counts := map[hivev1.ClusterUpgradeClusterState]int{}
+	for _, t := range targets {
+		counts[t.status.State]++
+	}
+
+	inWindow, nextWindow := maintenanceWindowOpen(upgrade.Spec.MaintenanceWindow, now)
+	var requeueAfter time.Duration
+	switch {
+	case len(targets) == 0:
+		// Clusters becoming installed or being labeled trigger a reconcile.
+		upgrade.Status.Phase = hivev1.ProgressingClusterUpgradePhase
+	case counts[hivev1.UpgradedClusterUpgradeClusterState] == len(targets):
+		if upgrade.Status.Phase != hivev1.CompleteClusterUpgradePhase {
+			logger.WithField("clusters", len(targets)).Info("all clusters upgraded")
+		}
+		upgrade.Status.Phase = hivev1.CompleteClusterUpgradePhase
+	case counts[hivev1.FailedClusterUpgradeClusterState] > int(upgrade.Spec.MaxFailures):
+		if upgrade.Status.Phase != hivev1.HaltedClusterUpgradePhase {
+			logger.WithField("failed", counts[hivev1.FailedClusterUpgradeClusterState]).
+				Warn("halting upgrade since too many clusters failed")
+		}
+		upgrade.Status.Phase = hivev1.HaltedClusterUpgradePhase
+	case !inWindow:
+		upgrade.Status.Phase = hivev1.WaitingClusterUpgradePhase
+		requeueAfter = resyncInterval
+		if counts[hivev1.UpgradingClusterUpgradeClusterState] == 0 && nextWindow > 0 {
+			requeueAfter = nextWindow
+		}
+	default:
+		upgrade.Status.Phase = hivev1.ProgressingClusterUpgradePhase
+		requeueAfter = resyncInterval
+		maxConcurrent := resolveMaxConcurrent(upgrade.Spec.MaxConcurrent, len(targets))
+		for _, t := range targets {
+			if counts[hivev1.UpgradingClusterUpgradeClusterState] >= maxConcurrent {
+				break
+			}
+			if t.status.State != hivev1.PendingClusterUpgradeClusterState {
+				continue
+			}
+			// Pending clusters are only probed once they could be upgraded.
+			if !r.probeCluster(upgrade, t, now, logger) {
+				continue
+			}
+			if t.status.State != hivev1.PendingClusterUpgradeClusterState {
+				// The cluster already runs or is upgrading to the desired release.
+				counts[hivev1.PendingClusterUpgradeClusterState]--
+				counts[t.status.State]++
+				continue
+			}
+			r.startUpgrade(upgrade, t, logger)
+			counts[hivev1.PendingClusterUpgradeClusterState]--
+			counts[t.status.State]++
+			if counts[hivev1.FailedClusterUpgradeClusterState] > int(upgrade.Spec.MaxFailures) {
+				logger.WithField("failed", counts[hivev1.FailedClusterUpgradeClusterState]).
+					Warn("halting upgrade since too many clusters failed")
+				upgrade.Status.Phase = hivev1.HaltedClusterUpgradePhase
+				requeueAfter = 0
+				break
+			}
+		}
+	}
+
+	upgrade.Status.TargetClusters = int32(len(targets))
+	upgrade.Status.UpgradingClusters = int32(counts[hivev1.UpgradingClusterUpgradeClusterState])
+	upgrade.Status.UpgradedClusters = int32(counts[hivev1.UpgradedClusterUpgradeClusterState])
+	upgrade.Status.FailedClusters = int32(counts[hivev1.FailedClusterUpgradeClusterState])
+	upgrade.Status.Clusters = make([]hivev1.ClusterUpgradeClusterStatus, len(targets))
+	for i, t := range targets {
+		upgrade.Status.Clusters[i] = t.status
+	}
+	for _, state := range []hivev1.ClusterUpgradeClusterState{
+		hivev1.PendingClusterUpgradeClusterState,
+		hivev1.UpgradingClusterUpgradeClusterState,
+		hivev1.UpgradedClusterUpgradeClusterState,
+		hivev1.FailedClusterUpgradeClusterState,
+		hivev1.ConflictClusterUpgradeClusterState,
+	} {
+		metricClusters.WithLabelValues(upgrade.Name, string(state)).Set(float64(counts[state]))
+	}
+
+	status, reason, message := progressingCondition(upgrade, counts)
+	r.setConditions(upgrade, status, reason, message)
+	if err := r.updateStatus(upgrade, origStatus, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// validateSpec returns an error if the ClusterUpgrade cannot be carried out.
+func validateSpec(spec *hivev1.ClusterUpgradeSpec) error {
+	if spec.DesiredUpdate.Version == "" && spec.DesiredUpdate.Image == "" {
+		return errors.New("desiredUpdate must specify a version or an image")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(&spec.ClusterDeploymentSelector); err != nil {
+		return errors.Wrap(err, "invalid clusterDeploymentSelector")
+	}
+	if w := spec.MaintenanceWindow; w != nil {
+		if err := controllerutils.ValidateHibernationSchedule(maintenanceSchedule(w)); err != nil {
+			return errors.Wrap(err, "invalid maintenanceWindow")
+		}
+	}
+	return nil
+}
+
+// getTargets returns the installed clusters selected by the ClusterUpgrade, ordered by namespace and name, with the
+// state of their upgrade last recorded in the status. Clusters whose upgrade has not been started are in Conflict
+// while they are also selected by an older ClusterUpgrade which is not complete.
+func (r *ReconcileClusterUpgrade) getTargets(upgrade *hivev1.ClusterUpgrade, logger log.FieldLogger) ([]*upgradeTarget, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&upgrade.Spec.ClusterDeploymentSelector)
+	if err != nil {
+		return nil, err
+	}
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.Background(), cdList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments")
+		return nil, err
+	}
+	others, err := r.olderIncompleteUpgrades(upgrade, logger)
+	if err != nil {
+		return nil, err
+	}
+	previous := map[types.NamespacedName]hivev1.ClusterUpgradeClusterStatus{}
+	for _, s := range upgrade.Status.Clusters {
+		previous[types.NamespacedName{Namespace: s.Namespace, Name: s.Name}] = s
+	}
+	var targets []*upgradeTarget
+	for i := range cdList.Items {
+		cd := &cdList.Items[i]
+		if cd.DeletionTimestamp != nil || !cd.Spec.Installed {
+			continue
+		}
+		status, ok := previous[types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}]
+		if !ok {
+			status = hivev1.ClusterUpgradeClusterStatus{
+				Namespace: cd.Namespace,
+				Name:      cd.Name,
+				State:     hivev1.PendingClusterUpgradeClusterState,
+			}
+		}
+		switch status.State {
+		case hivev1.PendingClusterUpgradeClusterState, hivev1.ConflictClusterUpgradeClusterState:
+			status.State, status.Message = hivev1.PendingClusterUpgradeClusterState, ""
+			for _, other := range others {
+				if other.selector.Matches(labels.Set(cd.Labels)) {
+					status.State = hivev1.ConflictClusterUpgradeClusterState
+					status.Message = fmt.Sprintf("Cluster is also selected by ClusterUpgrade %s, which is not complete", other.name)
+					if other.phase == hivev1.HaltedClusterUpgradePhase {
+						// A halted ClusterUpgrade never completes by itself, so say how to unblock the cluster.
+						status.Message = fmt.Sprintf("Cluster is also selected by ClusterUpgrade %s, which is halted. Change or delete ClusterUpgrade %s to upgrade this cluster",
+							other.name, other.name)
+					}
+					break
+				}
+			}
+		}
+		targets = append(targets, &upgradeTarget{cd: cd, status: status})
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].cd.Namespace != targets[j].cd.Namespace {
+			return targets[i].cd.Namespace < targets[j].cd.Namespace
+		}
+		return targets[i].cd.Name < targets[j].cd.Name
+	})
+	return targets, nil
+}
+
+// selectingUpgrade is a ClusterUpgrade selecting clusters.
+type selectingUpgrade struct {
+	name     string
+	selector labels.Selector
+	phase    hivev1.ClusterUpgradePhase
+}
+
+// olderIncompleteUpgrades returns the ClusterUpgrades created before the given one, which are not complete.
+func (r *ReconcileClusterUpgrade) olderIncompleteUpgrades(upgrade *hivev1.ClusterUpgrade, logger log.FieldLogger) ([]selectingUpgrade, error) {
+	upgrades := &hivev1.ClusterUpgradeList{}
+	if err := r.List(context.Background(), upgrades); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterUpgrades")
+		return nil, err
+	}
+	var older []selectingUpgrade
+	for i := range upgrades.Items {
+		other := &upgrades.Items[i]
+		if other.Name == upgrade.Name || other.DeletionTimestamp != nil || other.Status.Phase == hivev1.CompleteClusterUpgradePhase {
+			continue
+		}
+		if !other.CreationTimestamp.Before(&upgrade.CreationTimestamp) &&
+			!(other.CreationTimestamp.Equal(&upgrade.CreationTimestamp) && other.Name < upgrade.Name) {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&other.Spec.ClusterDeploymentSelector)
+		if err != nil {
+			continue
+		}
+		older = append(older, selectingUpgrade{name: other.Name, selector: selector, phase: other.Status.Phase})
+	}
+	return older, nil
+}
+
+// observeCluster updates the state of the upgrade of a cluster that is upgrading. Pending clusters are not probed
+// until they could be upgraded, and the other states are final until the ClusterUpgrade changes.
+func (r *ReconcileClusterUpgrade) observeCluster(upgrade *hivev1.ClusterUpgrade, t *upgradeTarget, now time.Time, logger log.FieldLogger) {
+	if t.status.State != hivev1.UpgradingClusterUpgradeClusterState {
+		return
+	}
+	r.probeCluster(upgrade, t, now, logger)
+}
+
+// probeCluster updates the state of the upgrade of the cluster from its ClusterVersion. Clusters that are paused,
+// relocating or unreachable keep their previous state. Returns whether the ClusterVersion was retrieved.
+func (r *ReconcileClusterUpgrade) probeCluster(upgrade *hivev1.ClusterUpgrade, t *upgradeTarget, now time.Time, logger log.FieldLogger) bool {
+	logger = logger.WithField("cluster", types.NamespacedName{Namespace: t.cd.Namespace, Name: t.cd.Name})
+	if controllerutils.IsClusterPausedOrRelocating(t.cd, logger) {
+		t.status.Message = "Cluster is paused or relocating"
+		return false
+	}
+	if t.cd.Spec.PowerState == hivev1.ClusterPowerStateHibernating {
+		t.status.Message = "Cluster is hibernating"
+		return false
+	}
+	remoteClient, unreachable, _ := remoteclient.ConnectToRemoteCluster(t.cd, r.remoteClusterAPIClientBuilder(t.cd), r.Client, logger)
+	if unreachable {
+		t.status.Message = "Cluster is unreachable"
+		return false
+	}
+	cv := &configv1.ClusterVersion{}
+	if err := remoteClient.Get(context.Background(), types.NamespacedName{Name: clusterVersionObjectName}, cv); err != nil {
+		logger.WithError(err).Warn("could not get remote ClusterVersion")
+		t.status.Message = fmt.Sprintf("Could not get ClusterVersion: %v", err)
+		return false
+	}
+	t.remoteClient = remoteClient
+	t.clusterVersion = cv
+	t.status.Version = cv.Status.Desired.Version
+
+	desired := upgrade.Spec.DesiredUpdate
+	completedAt := completedUpdate(cv, desired)
+	switch {
+	case completedAt != nil:
+		if t.status.State == hivev1.UpgradingClusterUpgradeClusterState {
+			logger.WithField("version", t.status.Version).Info("cluster upgraded")
+			metricClusterUpgrades.WithLabelValues(upgrade.Name, "succeeded").Inc()
+			if t.status.StartedTime != nil {
+				metricClusterUpgradeDuration.WithLabelValues(upgrade.Name).
+					Observe(completedAt.Sub(t.status.StartedTime.Time).Seconds())
+			}
+		}
+		t.status.State = hivev1.UpgradedClusterUpgradeClusterState
+		t.status.CompletedTime = completedAt
+		t.status.Message = fmt.Sprintf("Cluster runs version %s", t.status.Version)
+	case t.status.State == hivev1.UpgradingClusterUpgradeClusterState || updateRequested(cv, desired):
+		if t.status.StartedTime == nil {
+			// The upgrade was requested by someone else; monitor it as if we had started it.
+			t.status.StartedTime = &metav1.Time{Time: now}
+		}
+		t.status.State = hivev1.UpgradingClusterUpgradeClusterState
+		t.status.Message = clusterVersionMessage(cv, configv1.OperatorProgressing)
+		timeout := defaultTimeout
+		if upgrade.Spec.Timeout != nil {
+			timeout = upgrade.Spec.Timeout.Duration
+		}
+		if now.Sub(t.status.StartedTime.Time) > timeout {
+			t.status.State = hivev1.FailedClusterUpgradeClusterState
+			t.status.Message = fmt.Sprintf("Upgrade did not complete within %s", timeout)
+			if failing := clusterVersionMessage(cv, clusterVersionFailingCondition); failing != "" {
+				t.status.Message += ": " + failing
+			}
+			logger.WithField("timeout", timeout).Warn("cluster failed to upgrade")
+			metricClusterUpgrades.WithLabelValues(upgrade.Name, "failed").Inc()
+		}
+	default:
+		t.status.Message = ""
+	}
+	return true
+}
+
+// startUpgrade sets the desired update of the ClusterVersion of the cluster. The cluster fails to upgrade if its
+// ClusterVersion cannot be updated, so that the other clusters are not held up by it.
+func (r *ReconcileClusterUpgrade) startUpgrade(upgrade *hivev1.ClusterUpgrade, t *upgradeTarget, logger log.FieldLogger) {
+	logger = logger.WithField("cluster", types.NamespacedName{Namespace: t.cd.Namespace, Name: t.cd.Name})
+	desired := upgrade.Spec.DesiredUpdate
+	cv := t.clusterVersion
+	cv.Spec.DesiredUpdate = &configv1.Update{
+		Version: desired.Version,
+		Image:   desired.Image,
+	}
+	if err := t.remoteClient.Update(context.Background(), cv); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not set desired update of remote ClusterVersion")
+		metricClusterUpgrades.WithLabelValues(upgrade.Name, "failed").Inc()
+		t.status.State = hivev1.FailedClusterUpgradeClusterState
+		t.status.Message = fmt.Sprintf("Could not set desired update of ClusterVersion: %v", err)
+		return
+	}
+	logger.WithField("fromVersion", t.status.Version).WithField("version", desired.Version).
+		WithField("image", desired.Image).Info("started cluster upgrade")
+	metricClusterUpgrades.WithLabelValues(upgrade.Name, "started").Inc()
+	now := metav1.Now()
+	t.status.State = hivev1.UpgradingClusterUpgradeClusterState
+	t.status.StartedTime = &now
+	t.status.Message = "Upgrade started"
+}
+
+// completedUpdate returns when the cluster completed its update to the desired release, or nil if the cluster does not
+// run the desired release.
+func completedUpdate(cv *configv1.ClusterVersion, desired hivev1.ClusterUpgradeRelease) *metav1.Time {
+	if len(cv.Status.History) == 0 {
+		return nil
+	}
+	latest := cv.Status.History[0]
+	if latest.State != configv1.CompletedUpdate || !releaseMatches(latest.Version, latest.Image, desired) {
+		return nil
+	}
+	if latest.CompletionTime != nil {
+		return latest.CompletionTime
+	}
+	return &metav1.Time{Time: time.Now()}
+}
+
+// updateRequested returns true if the desired update of the ClusterVersion is the desired release.
+func updateRequested(cv *configv1.ClusterVersion, desired hivev1.ClusterUpgradeRelease) bool {
+	update := cv.Spec.DesiredUpdate
+	return update != nil && releaseMatches(update.Version, update.Image, desired)
+}
+
+func releaseMatches(version, image string, desired hivev1.ClusterUpgradeRelease) bool {
+	return (desired.Version == "" || desired.Version == version) && (desired.Image == "" || desired.Image == image)
+}
+
+// clusterVersionMessage returns the message of the condition of the ClusterVersion if the condition is true.
+func clusterVersionMessage(cv *configv1.ClusterVersion, conditionType configv1.ClusterStatusConditionType) string {
+	for _, c := range cv.Status.Conditions {
+		if c.Type == conditionType && c.Status == configv1.ConditionTrue {
+			return c.Message
+		}
+	}
+	return ""
+}
+
+// maintenanceWindowOpen returns whether upgrades may be started at the given time and, if not, how long until they
+// may be.
+func maintenanceWindowOpen(window *hivev1.ClusterUpgradeMaintenanceWindow, now time.Time) (bool, time.Duration) {
+	if window == nil {
+		return true, 0
+	}
+	state, _, next, err := controllerutils.EvaluateHibernationSchedule(maintenanceSchedule(window), now)
+	if err != nil {
+		return false, 0
+	}
+	if state == hivev1.ClusterPowerStateRunning {
+		return true, 0
+	}
+	if next == nil {
+		return false, 0
+	}
+	return false, next.Time.Sub(now)
+}
+
+// maintenanceSchedule expresses the maintenance window as a schedule whose run windows are the maintenance windows, so
+// that it can be evaluated like a hibernation schedule.
+func maintenanceSchedule(window *hivev1.ClusterUpgradeMaintenanceWindow) *hivev1.HibernationSchedule {
+	return &hivev1.HibernationSchedule{TimeZone: window.TimeZone, RunWindows: window.Windows}
+}
+
+func resolveMaxConcurrent(maxConcurrent *intstr.IntOrString, targets int) int {
+	if maxConcurrent == nil {
+		return 1
+	}
+	n, err := intstr.GetScaledValueFromIntOrPercent(maxConcurrent, targets, true)
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
+}
+
+// progressingCondition returns the status, reason and message of the Progressing condition of the ClusterUpgrade.
+func progressingCondition(upgrade *hivev1.ClusterUpgrade, counts map[hivev1.ClusterUpgradeClusterState]int) (corev1.ConditionStatus, string, string) {
+	s := upgrade.Status
+	switch s.Phase {
+	case hivev1.CompleteClusterUpgradePhase:
+		return corev1.ConditionFalse, completeReason, fmt.Sprintf("All %d clusters upgraded", s.TargetClusters)
+	case hivev1.HaltedClusterUpgradePhase:
+		return corev1.ConditionFalse, tooManyFailuresReason,
+			fmt.Sprintf("Halted: %d clusters failed to upgrade, more than the %d allowed", s.FailedClusters, upgrade.Spec.MaxFailures)
+	case hivev1.WaitingClusterUpgradePhase:
+		return corev1.ConditionTrue, maintenanceWindowClosedReason,
+			fmt.Sprintf("%d of %d clusters upgraded, %d upgrading; waiting for the maintenance window to start further upgrades",
+				s.UpgradedClusters, s.TargetClusters, s.UpgradingClusters)
+	}
+	if s.TargetClusters == 0 {
+		return corev1.ConditionTrue, noTargetsReason, "No installed clusters match the clusterDeploymentSelector"
+	}
+	return corev1.ConditionTrue, upgradingReason,
+		fmt.Sprintf("%d of %d clusters upgraded, %d upgrading, %d pending, %d selected by another ClusterUpgrade",
+			s.UpgradedClusters, s.TargetClusters, s.UpgradingClusters, counts[hivev1.PendingClusterUpgradeClusterState],
+			counts[hivev1.ConflictClusterUpgradeClusterState])
+}
+
+// setConditions sets the Progressing condition as given, and the Failed condition from the failed clusters.
+func (r *ReconcileClusterUpgrade) setConditions(upgrade *hivev1.ClusterUpgrade, status corev1.ConditionStatus, reason, message string) {
+	upgrade.Status.Conditions, _ = controllerutils.SetClusterUpgradeConditionWithChangeCheck(
+		upgrade.Status.Conditions,
+		hivev1.ClusterUpgradeProgressingCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	var failed []string
+	for _, c := range upgrade.Status.Clusters {
+		if c.State == hivev1.FailedClusterUpgradeClusterState {
+			failed = append(failed, fmt.Sprintf("%s/%s", c.Namespace, c.Name))
+		}
+	}
+	failedStatus, failedReason, failedMessage := corev1.ConditionFalse, noFailuresReason, "No clusters failed to upgrade"
+	if len(failed) > 0 {
+		failedStatus, failedReason = corev1.ConditionTrue, clustersFailedReason
+		failedMessage = fmt.Sprintf("%d clusters failed to upgrade: ", len(failed))
+		if len(failed) > maxFailedClustersListed {
+			failedMessage += strings.Join(failed[:maxFailedClustersListed], ", ") + ", ..."
+		} else {
+			failedMessage += strings.Join(failed, ", ")
+		}
+	}
+	upgrade.Status.Conditions, _ = controllerutils.SetClusterUpgradeConditionWithChangeCheck(
+		upgrade.Status.Conditions,
+		hivev1.ClusterUpgradeFailedCondition,
+		failedStatus,
+		failedReason,
+		failedMessage,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+}
+
+func (r *ReconcileClusterUpgrade) updateStatus(upgrade *hivev1.ClusterUpgrade, origStatus *hivev1.ClusterUpgradeStatus, logger log.FieldLogger) error {
+	if reflect.DeepEqual(origStatus, &upgrade.Status) {
+		return nil
+	}
+	if err := r.Status().Update(context.Background(), upgrade); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update cluster upgrade status")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,596 @@
+package clusterupgrade
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	testUpgradeName = "fleet-4.14.3"
+	testNamespace   = "clusters"
+	fleetLabel      = "fleet"
+	currentVersion  = "4.14.2"
+	desiredVersion  = "4.14.3"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+// testCluster describes a selected cluster and the ClusterVersion of the remote cluster.
+type testCluster struct {
+	name        string
+	unreachable bool
+	unselected  bool
+	// version is the completed version of the cluster.
+	version string
+	// desiredVersion is the desired update of the ClusterVersion, if any.
+	desiredVersion string
+	failing        string
+	// notProbed means that the ClusterVersion of the cluster must not be retrieved.
+	notProbed bool
+	// updateFails means that updating the ClusterVersion of the cluster fails.
+	updateFails bool
+}
+
+func TestReconcileClusterUpgrade(t *testing.T) {
+	upgradeStarted := metav1.NewTime(time.Now().Add(-30 * time.Minute))
+	longAgo := metav1.NewTime(time.Now().Add(-4 * time.Hour))
+	closedDay := hivev1.Weekday(time.Now().UTC().AddDate(0, 0, 3).Weekday().String())
+	otherUpgrade := func(name string, created metav1.Time, phase hivev1.ClusterUpgradePhase) *hivev1.ClusterUpgrade {
+		return &hivev1.ClusterUpgrade{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: created},
+			Spec: hivev1.ClusterUpgradeSpec{
+				ClusterDeploymentSelector: metav1.LabelSelector{MatchLabels: map[string]string{fleetLabel: "prod"}},
+				DesiredUpdate:             hivev1.ClusterUpgradeRelease{Version: currentVersion},
+			},
+			Status: hivev1.ClusterUpgradeStatus{Phase: phase},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		spec              func(*hivev1.ClusterUpgradeSpec)
+		otherUpgrades     []*hivev1.ClusterUpgrade
+		clusters          []testCluster
+		statusClusters    []hivev1.ClusterUpgradeClusterStatus
+		expectedPhase     hivev1.ClusterUpgradePhase
+		expectedStates    map[string]hivev1.ClusterUpgradeClusterState
+		expectedMessages  map[string]string
+		expectedUpdated   []string
+		expectedCondition corev1.ConditionStatus
+		expectedReason    string
+		expectFailed      bool
+		expectRequeue     time.Duration
+		expectLongRequeue bool
+	}{
+		{
+			name: "one cluster at a time by default",
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion},
+				{name: "c2", version: currentVersion},
+			},
+			expectedPhase: hivev1.ProgressingClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.UpgradingClusterUpgradeClusterState,
+				"c2": hivev1.PendingClusterUpgradeClusterState,
+			},
+			expectedUpdated:   []string{"c1"},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    upgradingReason,
+			expectRequeue:     resyncInterval,
+		},
+		{
+			name: "percentage of clusters at a time",
+			spec: func(spec *hivev1.ClusterUpgradeSpec) {
+				maxConcurrent := intstr.FromString("100%")
+				spec.MaxConcurrent = &maxConcurrent
+			},
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion},
+				{name: "c2", version: currentVersion},
+				{name: "c3", version: currentVersion, unselected: true},
+			},
+			expectedPhase: hivev1.ProgressingClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.UpgradingClusterUpgradeClusterState,
+				"c2": hivev1.UpgradingClusterUpgradeClusterState,
+			},
+			expectedUpdated:   []string{"c1", "c2"},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    upgradingReason,
+			expectRequeue:     resyncInterval,
+		},
+		{
+			name: "unreachable cluster is skipped",
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion, unreachable: true},
+				{name: "c2", version: currentVersion},
+			},
+			expectedPhase: hivev1.ProgressingClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.PendingClusterUpgradeClusterState,
+				"c2": hivev1.UpgradingClusterUpgradeClusterState,
+			},
+			expectedUpdated:   []string{"c2"},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    upgradingReason,
+			expectRequeue:     resyncInterval,
+		},
+		{
+			name: "pending clusters are not probed at the concurrency limit",
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion, desiredVersion: desiredVersion},
+				{name: "c2", version: desiredVersion, notProbed: true},
+			},
+			statusClusters: []hivev1.ClusterUpgradeClusterStatus{
+				{Namespace: testNamespace, Name: "c1", State: hivev1.UpgradingClusterUpgradeClusterState, StartedTime: &upgradeStarted},
+			},
+			expectedPhase: hivev1.ProgressingClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.UpgradingClusterUpgradeClusterState,
+				"c2": hivev1.PendingClusterUpgradeClusterState,
+			},
+			expectedUpdated:   []string{"c1"},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    upgradingReason,
+			expectRequeue:     resyncInterval,
+		},
+		{
+			name: "pending cluster already at desired version",
+			clusters: []testCluster{
+				{name: "c1", version: desiredVersion},
+				{name: "c2", version: currentVersion},
+			},
+			expectedPhase: hivev1.ProgressingClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.UpgradedClusterUpgradeClusterState,
+				"c2": hivev1.UpgradingClusterUpgradeClusterState,
+			},
+			expectedUpdated:   []string{"c2"},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    upgradingReason,
+			expectRequeue:     resyncInterval,
+		},
+		{
+			name: "clusters selected by older incomplete upgrade",
+			otherUpgrades: []*hivev1.ClusterUpgrade{
+				otherUpgrade("older", longAgo, hivev1.ProgressingClusterUpgradePhase),
+			},
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion, notProbed: true},
+				{name: "c2", version: currentVersion, notProbed: true},
+			},
+			expectedPhase: hivev1.ProgressingClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.ConflictClusterUpgradeClusterState,
+				"c2": hivev1.ConflictClusterUpgradeClusterState,
+			},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    upgradingReason,
+			expectRequeue:     resyncInterval,
+		},
+		{
+			name: "clusters selected by older halted upgrade",
+			otherUpgrades: []*hivev1.ClusterUpgrade{
+				otherUpgrade("older", longAgo, hivev1.HaltedClusterUpgradePhase),
+			},
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion, notProbed: true},
+			},
+			expectedPhase: hivev1.ProgressingClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.ConflictClusterUpgradeClusterState,
+			},
+			expectedMessages: map[string]string{
+				"c1": "Cluster is also selected by ClusterUpgrade older, which is halted. Change or delete ClusterUpgrade older to upgrade this cluster",
+			},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    upgradingReason,
+			expectRequeue:     resyncInterval,
+		},
+		{
+			name: "clusters selected by older complete or newer upgrades",
+			otherUpgrades: []*hivev1.ClusterUpgrade{
+				otherUpgrade("older", longAgo, hivev1.CompleteClusterUpgradePhase),
+				otherUpgrade("newer", metav1.NewTime(time.Now().Add(time.Hour)), hivev1.ProgressingClusterUpgradePhase),
+			},
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion},
+			},
+			statusClusters: []hivev1.ClusterUpgradeClusterStatus{
+				{Namespace: testNamespace, Name: "c1", State: hivev1.ConflictClusterUpgradeClusterState},
+			},
+			expectedPhase: hivev1.ProgressingClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.UpgradingClusterUpgradeClusterState,
+			},
+			expectedUpdated:   []string{"c1"},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    upgradingReason,
+			expectRequeue:     resyncInterval,
+		},
+		{
+			name: "no clusters selected",
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion, unselected: true},
+			},
+			expectedPhase:     hivev1.ProgressingClusterUpgradePhase,
+			expectedStates:    map[string]hivev1.ClusterUpgradeClusterState{},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    noTargetsReason,
+		},
+		{
+			name: "cluster upgraded starts next",
+			clusters: []testCluster{
+				{name: "c1", version: desiredVersion, desiredVersion: desiredVersion},
+				{name: "c2", version: currentVersion},
+			},
+			statusClusters: []hivev1.ClusterUpgradeClusterStatus{
+				{Namespace: testNamespace, Name: "c1", State: hivev1.UpgradingClusterUpgradeClusterState, StartedTime: &upgradeStarted},
+			},
+			expectedPhase: hivev1.ProgressingClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.UpgradedClusterUpgradeClusterState,
+				"c2": hivev1.UpgradingClusterUpgradeClusterState,
+			},
+			expectedUpdated:   []string{"c1", "c2"},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    upgradingReason,
+			expectRequeue:     resyncInterval,
+		},
+		{
+			name: "all clusters upgraded",
+			clusters: []testCluster{
+				{name: "c1", version: desiredVersion, desiredVersion: desiredVersion},
+				{name: "c2", version: desiredVersion, desiredVersion: desiredVersion},
+			},
+			statusClusters: []hivev1.ClusterUpgradeClusterStatus{
+				{Namespace: testNamespace, Name: "c1", State: hivev1.UpgradedClusterUpgradeClusterState},
+				{Namespace: testNamespace, Name: "c2", State: hivev1.UpgradingClusterUpgradeClusterState, StartedTime: &upgradeStarted},
+			},
+			expectedPhase: hivev1.CompleteClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.UpgradedClusterUpgradeClusterState,
+				"c2": hivev1.UpgradedClusterUpgradeClusterState,
+			},
+			expectedUpdated:   []string{"c1", "c2"},
+			expectedCondition: corev1.ConditionFalse,
+			expectedReason:    completeReason,
+		},
+		{
+			name: "upgrade timed out",
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion, desiredVersion: desiredVersion, failing: "Cluster operator etcd is degraded"},
+				{name: "c2", version: currentVersion},
+			},
+			statusClusters: []hivev1.ClusterUpgradeClusterStatus{
+				{Namespace: testNamespace, Name: "c1", State: hivev1.UpgradingClusterUpgradeClusterState, StartedTime: &longAgo},
+			},
+			expectedPhase: hivev1.HaltedClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.FailedClusterUpgradeClusterState,
+				"c2": hivev1.PendingClusterUpgradeClusterState,
+			},
+			expectedUpdated:   []string{"c1"},
+			expectedCondition: corev1.ConditionFalse,
+			expectedReason:    tooManyFailuresReason,
+			expectFailed:      true,
+		},
+		{
+			name: "failures within limit",
+			spec: func(spec *hivev1.ClusterUpgradeSpec) {
+				spec.MaxFailures = 1
+			},
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion, desiredVersion: desiredVersion},
+				{name: "c2", version: currentVersion},
+			},
+			statusClusters: []hivev1.ClusterUpgradeClusterStatus{
+				{Namespace: testNamespace, Name: "c1", State: hivev1.UpgradingClusterUpgradeClusterState, StartedTime: &longAgo},
+			},
+			expectedPhase: hivev1.ProgressingClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.FailedClusterUpgradeClusterState,
+				"c2": hivev1.UpgradingClusterUpgradeClusterState,
+			},
+			expectedUpdated:   []string{"c1", "c2"},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    upgradingReason,
+			expectFailed:      true,
+			expectRequeue:     resyncInterval,
+		},
+		{
+			name: "cluster failing to start upgrade",
+			spec: func(spec *hivev1.ClusterUpgradeSpec) {
+				spec.MaxFailures = 1
+			},
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion, updateFails: true},
+				{name: "c2", version: currentVersion},
+			},
+			expectedPhase: hivev1.ProgressingClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.FailedClusterUpgradeClusterState,
+				"c2": hivev1.UpgradingClusterUpgradeClusterState,
+			},
+			expectedUpdated:   []string{"c2"},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    upgradingReason,
+			expectFailed:      true,
+			expectRequeue:     resyncInterval,
+		},
+		{
+			name: "too many clusters failing to start upgrade",
+			spec: func(spec *hivev1.ClusterUpgradeSpec) {
+				maxConcurrent := intstr.FromString("100%")
+				spec.MaxConcurrent = &maxConcurrent
+			},
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion, updateFails: true},
+				{name: "c2", version: currentVersion, notProbed: true},
+			},
+			expectedPhase: hivev1.HaltedClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.FailedClusterUpgradeClusterState,
+				"c2": hivev1.PendingClusterUpgradeClusterState,
+			},
+			expectedCondition: corev1.ConditionFalse,
+			expectedReason:    tooManyFailuresReason,
+			expectFailed:      true,
+		},
+		{
+			name: "maintenance window closed",
+			spec: func(spec *hivev1.ClusterUpgradeSpec) {
+				spec.MaintenanceWindow = &hivev1.ClusterUpgradeMaintenanceWindow{
+					Windows: []hivev1.HibernationRunWindow{{Days: []hivev1.Weekday{closedDay}, Start: "00:00", End: "01:00"}},
+				}
+			},
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion},
+			},
+			expectedPhase: hivev1.WaitingClusterUpgradePhase,
+			expectedStates: map[string]hivev1.ClusterUpgradeClusterState{
+				"c1": hivev1.PendingClusterUpgradeClusterState,
+			},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    maintenanceWindowClosedReason,
+			expectLongRequeue: true,
+		},
+		{
+			name: "no desired release",
+			spec: func(spec *hivev1.ClusterUpgradeSpec) {
+				spec.DesiredUpdate = hivev1.ClusterUpgradeRelease{}
+			},
+			clusters: []testCluster{
+				{name: "c1", version: currentVersion},
+			},
+			expectedStates:    map[string]hivev1.ClusterUpgradeClusterState{},
+			expectedCondition: corev1.ConditionFalse,
+			expectedReason:    invalidSpecReason,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scheme := scheme.GetScheme()
+			upgrade := &hivev1.ClusterUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: testUpgradeName, Generation: 1, CreationTimestamp: metav1.Now()},
+				Spec: hivev1.ClusterUpgradeSpec{
+					ClusterDeploymentSelector: metav1.LabelSelector{MatchLabels: map[string]string{fleetLabel: "prod"}},
+					DesiredUpdate:             hivev1.ClusterUpgradeRelease{Version: desiredVersion},
+				},
+				Status: hivev1.ClusterUpgradeStatus{ObservedGeneration: 1, Clusters: test.statusClusters},
+			}
+			if test.spec != nil {
+				test.spec(&upgrade.Spec)
+			}
+			existing := []runtime.Object{upgrade}
+			for _, other := range test.otherUpgrades {
+				existing = append(existing, other)
+			}
+			remoteClients := map[string]client.Client{}
+			mockCtrl := gomock.NewController(t)
+			builders := map[string]remoteclient.Builder{}
+			for _, c := range test.clusters {
+				existing = append(existing, testClusterDeployment(scheme, c))
+				remoteClients[c.name] = testRemoteClient(c)
+				builder := remoteclientmock.NewMockBuilder(mockCtrl)
+				if c.notProbed {
+					builder.EXPECT().Build().Times(0)
+				} else {
+					builder.EXPECT().Build().Return(remoteClients[c.name], nil).AnyTimes()
+				}
+				builders[c.name] = builder
+			}
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build()
+			r := &ReconcileClusterUpgrade{
+				Client: c,
+				logger: log.New(),
+				remoteClusterAPIClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					return builders[cd.Name]
+				},
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: testUpgradeName}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			if test.expectLongRequeue {
+				assert.True(t, result.RequeueAfter > 24*time.Hour, "unexpected requeue after %s", result.RequeueAfter)
+			} else {
+				assert.Equal(t, test.expectRequeue, result.RequeueAfter, "unexpected requeue")
+			}
+
+			upgrade = &hivev1.ClusterUpgrade{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: testUpgradeName}, upgrade))
+			assert.Equal(t, test.expectedPhase, upgrade.Status.Phase, "unexpected phase")
+			states := map[string]hivev1.ClusterUpgradeClusterState{}
+			messages := map[string]string{}
+			for _, s := range upgrade.Status.Clusters {
+				states[s.Name] = s.State
+				messages[s.Name] = s.Message
+			}
+			assert.Equal(t, test.expectedStates, states, "unexpected cluster states")
+			for name, message := range test.expectedMessages {
+				assert.Equal(t, message, messages[name], "unexpected message of %s", name)
+			}
+			assert.Equal(t, int32(len(test.expectedStates)), upgrade.Status.TargetClusters, "unexpected number of target clusters")
+
+			var updated []string
+			for _, tc := range test.clusters {
+				if tc.updateFails {
+					assert.Contains(t, messages[tc.name], "update rejected", "expected update error in message of %s", tc.name)
+				}
+				cv := &configv1.ClusterVersion{}
+				require.NoError(t, remoteClients[tc.name].Get(context.Background(), client.ObjectKey{Name: clusterVersionObjectName}, cv))
+				if cv.Spec.DesiredUpdate != nil {
+					assert.Equal(t, desiredVersion, cv.Spec.DesiredUpdate.Version, "unexpected desired update of %s", tc.name)
+					updated = append(updated, tc.name)
+				}
+			}
+			assert.Equal(t, test.expectedUpdated, updated, "unexpected clusters with desired update")
+
+			cond := controllerutils.FindCondition(upgrade.Status.Conditions, hivev1.ClusterUpgradeProgressingCondition)
+			require.NotNil(t, cond, "expected Progressing condition")
+			assert.Equal(t, test.expectedCondition, cond.Status, "unexpected status of Progressing condition")
+			assert.Equal(t, test.expectedReason, cond.Reason, "unexpected reason of Progressing condition")
+			failedCond := controllerutils.FindCondition(upgrade.Status.Conditions, hivev1.ClusterUpgradeFailedCondition)
+			require.NotNil(t, failedCond, "expected Failed condition")
+			if test.expectFailed {
+				assert.Equal(t, corev1.ConditionTrue, failedCond.Status, "unexpected status of Failed condition")
+				assert.Contains(t, failedCond.Message, testNamespace+"/c1", "expected failed cluster in message")
+			} else {
+				assert.Equal(t, corev1.ConditionFalse, failedCond.Status, "unexpected status of Failed condition")
+			}
+		})
+	}
+}
+
+func TestReconcileClusterUpgrade_NewGeneration(t *testing.T) {
+	upgrade := &hivev1.ClusterUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: testUpgradeName, Generation: 2},
+		Spec: hivev1.ClusterUpgradeSpec{
+			ClusterDeploymentSelector: metav1.LabelSelector{MatchLabels: map[string]string{fleetLabel: "prod"}},
+			DesiredUpdate:             hivev1.ClusterUpgradeRelease{Version: "4.14.4"},
+		},
+		Status: hivev1.ClusterUpgradeStatus{
+			ObservedGeneration: 1,
+			Phase:              hivev1.HaltedClusterUpgradePhase,
+			Clusters: []hivev1.ClusterUpgradeClusterStatus{
+				{Namespace: testNamespace, Name: "c1", State: hivev1.FailedClusterUpgradeClusterState},
+			},
+		},
+	}
+	scheme := scheme.GetScheme()
+	cluster := testCluster{name: "c1", version: currentVersion, desiredVersion: desiredVersion}
+	remoteClient := testRemoteClient(cluster)
+	mockCtrl := gomock.NewController(t)
+	builder := remoteclientmock.NewMockBuilder(mockCtrl)
+	builder.EXPECT().Build().Return(remoteClient, nil)
+	c := testfake.NewFakeClientBuilder().WithRuntimeObjects(upgrade, testClusterDeployment(scheme, cluster)).Build()
+	r := &ReconcileClusterUpgrade{
+		Client:                        c,
+		logger:                        log.New(),
+		remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return builder },
+	}
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: testUpgradeName}})
+	require.NoError(t, err, "unexpected error from Reconcile")
+
+	upgrade = &hivev1.ClusterUpgrade{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: testUpgradeName}, upgrade))
+	assert.Equal(t, int64(2), upgrade.Status.ObservedGeneration, "unexpected observed generation")
+	assert.Equal(t, hivev1.ProgressingClusterUpgradePhase, upgrade.Status.Phase, "unexpected phase")
+	require.Len(t, upgrade.Status.Clusters, 1, "unexpected number of clusters")
+	assert.Equal(t, hivev1.UpgradingClusterUpgradeClusterState, upgrade.Status.Clusters[0].State, "unexpected cluster state")
+	cv := &configv1.ClusterVersion{}
+	require.NoError(t, remoteClient.Get(context.Background(), client.ObjectKey{Name: clusterVersionObjectName}, cv))
+	require.NotNil(t, cv.Spec.DesiredUpdate, "expected desired update")
+	assert.Equal(t, "4.14.4", cv.Spec.DesiredUpdate.Version, "unexpected desired update")
+}
+
+func testClusterDeployment(typer runtime.ObjectTyper, c testCluster) *hivev1.ClusterDeployment {
+	fleet := "prod"
+	if c.unselected {
+		fleet = "staging"
+	}
+	unreachable := corev1.ConditionFalse
+	if c.unreachable {
+		unreachable = corev1.ConditionTrue
+	}
+	return testcd.FullBuilder(testNamespace, c.name, typer).Build(
+		testcd.WithLabel(fleetLabel, fleet),
+		testcd.Installed(),
+		testcd.WithClusterMetadata(&hivev1.ClusterMetadata{
+			InfraID:                  c.name + "-infra",
+			AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: c.name + "-kubeconfig"},
+		}),
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.UnreachableCondition,
+			Status: unreachable,
+		}),
+	)
+}
+
+func testRemoteClient(c testCluster) client.Client {
+	cv := &configv1.ClusterVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterVersionObjectName},
+		Status: configv1.ClusterVersionStatus{
+			Desired: configv1.Release{Version: c.version},
+			History: []configv1.UpdateHistory{{
+				State:          configv1.CompletedUpdate,
+				Version:        c.version,
+				CompletionTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			}},
+		},
+	}
+	if c.desiredVersion != "" {
+		cv.Spec.DesiredUpdate = &configv1.Update{Version: c.desiredVersion}
+		if c.desiredVersion != c.version {
+			cv.Status.Desired.Version = c.desiredVersion
+			cv.Status.History = append([]configv1.UpdateHistory{{
+				State:   configv1.PartialUpdate,
+				Version: c.desiredVersion,
+			}}, cv.Status.History...)
+		}
+	}
+	if c.failing != "" {
+		cv.Status.Conditions = append(cv.Status.Conditions, configv1.ClusterOperatorStatusCondition{
+			Type:    clusterVersionFailingCondition,
+			Status:  configv1.ConditionTrue,
+			Message: c.failing,
+		})
+	}
+	builder := testfake.NewFakeClientBuilder().WithRuntimeObjects(cv)
+	if c.updateFails {
+		builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(context.Context, client.WithWatch, client.Object, ...client.UpdateOption) error {
+				return errors.New("update rejected")
+			},
+		})
+	}
+	return builder.Build()
+}
@@ -0,0 +1,32 @@
+package clusterupgrade
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// metricClusters tracks the target clusters of each ClusterUpgrade by the state of their upgrade.
+	metricClusters = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterupgrade_clusters",
+		Help: "The number of clusters targeted by a ClusterUpgrade, by the state of their upgrade.",
+	}, []string{"clusterupgrade", "state"})
+	// metricClusterUpgrades tracks the upgrades of individual clusters started by ClusterUpgrades, and their outcomes.
+	metricClusterUpgrades = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_clusterupgrade_cluster_upgrades_total",
+		Help: "The number of cluster upgrades started, succeeded and failed for a ClusterUpgrade.",
+	}, []string{"clusterupgrade", "result"})
+	// metricClusterUpgradeDuration tracks how long clusters took to upgrade.
+	metricClusterUpgradeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "hive_clusterupgrade_cluster_upgrade_duration_seconds",
+		Help:    "Distribution of the time taken by clusters to upgrade to the desired release of a ClusterUpgrade.",
+		Buckets: []float64{900, 1800, 2700, 3600, 5400, 7200, 10800, 14400},
+	}, []string{"clusterupgrade"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricClusters)
+	metrics.Registry.MustRegister(metricClusterUpgrades)
+	metrics.Registry.MustRegister(metricClusterUpgradeDuration)
+}
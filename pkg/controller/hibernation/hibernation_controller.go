@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -110,6 +111,9 @@ type hibernationReconciler struct {
 	csrUtil csrHelper
 
 	remoteClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// clock is used to evaluate hibernation schedules
+	clock clock.PassiveClock
 }
 
 // NewReconciler returns a new Reconciler
@@ -119,6 +123,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *hi
 		Client:  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		logger:  logger,
 		csrUtil: &csrUtility{},
+		clock:   clock.RealClock{},
 	}
 	r.remoteClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
@@ -245,6 +250,26 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, r.updateClusterDeploymentStatus(cd, cdLog)
 	}
 
+	// Apply scheduled power state transitions. Like HibernateAfter, the schedule has no effect on pool clusters
+	// until they are claimed.
+	if cd.Spec.HibernationSchedule != nil && !isUnclaimedPoolCluster(cd) {
+		specChanged, scheduleRequeueAfter, err := r.applyHibernationSchedule(cd, cdLog)
+		if err != nil || specChanged {
+			// The spec update will trigger another reconcile
+			return reconcile.Result{}, err
+		}
+		if scheduleRequeueAfter > 0 {
+			defer func() {
+				requeueNow := result.Requeue && result.RequeueAfter <= 0
+				if returnErr == nil && !requeueNow && (result.RequeueAfter <= 0 || scheduleRequeueAfter < result.RequeueAfter) {
+					cdLog.Infof("cluster will reconcile due to hibernation schedule in: %v", scheduleRequeueAfter)
+					result.RequeueAfter = scheduleRequeueAfter
+					result.Requeue = true
+				}
+			}()
+		}
+	}
+
 	shouldHibernate := cd.Spec.PowerState == hivev1.ClusterPowerStateHibernating
 	// set readyToHibernate if hibernate after is ready to kick in hibernation
 	var readyToHibernate bool
@@ -257,13 +282,7 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		// - The last time the cluster resumed (status.conditions[Hibernating].lastTransitionTime if not hibernating (but see TODO))
 		// BUT pool clusters wait until they're claimed for HibernateAfter to have effect.
 		poolRef := cd.Spec.ClusterPoolRef
-		isUnclaimedPoolCluster := poolRef != nil && poolRef.PoolName != "" &&
-			// Upgrade note: If we hit this code path on a CD that was claimed before upgrading to
-			// where we introduced ClaimedTimestamp, then that CD was Hibernating when it was claimed
-			// (because that's the same time we introduced ClusterPool.RunningCount) so it's safe to
-			// just use installed/last-resumed as the baseline for hibernateAfter.
-			(poolRef.ClaimName == "" || poolRef.ClaimedTimestamp == nil)
-		if !isUnclaimedPoolCluster {
+		if !isUnclaimedPoolCluster(cd) {
 			hibernateAfterDur := cd.Spec.HibernateAfter.Duration
 			hibLog := cdLog.WithField("hibernateAfter", hibernateAfterDur)
 
@@ -848,6 +867,17 @@ func isNodeReady(node *corev1.Node) bool {
 	return false
 }
 
+// isUnclaimedPoolCluster returns true if the ClusterDeployment belongs to a ClusterPool and has not been claimed.
+func isUnclaimedPoolCluster(cd *hivev1.ClusterDeployment) bool {
+	poolRef := cd.Spec.ClusterPoolRef
+	return poolRef != nil && poolRef.PoolName != "" &&
+		// Upgrade note: If we hit this code path on a CD that was claimed before upgrading to
+		// where we introduced ClaimedTimestamp, then that CD was Hibernating when it was claimed
+		// (because that's the same time we introduced ClusterPool.RunningCount) so it's safe to
+		// just use installed/last-resumed as the baseline for hibernateAfter.
+		(poolRef.ClaimName == "" || poolRef.ClaimedTimestamp == nil)
+}
+
 // shouldStopMachines decides if machines should be stopped
 func shouldStopMachines(cd *hivev1.ClusterDeployment, hibernatingCondition *hivev1.ClusterDeploymentCondition) bool {
 	if cd.Spec.PowerState != hivev1.ClusterPowerStateHibernating {
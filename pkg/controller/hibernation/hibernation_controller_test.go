@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+	clocktesting "k8s.io/utils/clock/testing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -1268,6 +1269,133 @@ func TestHibernateAfter(t *testing.T) {
 	}
 }
 
+func TestHibernationSchedule(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := scheme.GetScheme()
+
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	// window returns a schedule with a single daily window starting and ending at the given offsets from now.
+	window := func(startOffset, endOffset time.Duration) *hivev1.HibernationSchedule {
+		return &hivev1.HibernationSchedule{
+			RunWindows: []hivev1.HibernationRunWindow{{
+				Start: now.Add(startOffset).Format("15:04"),
+				End:   now.Add(endOffset).Format("15:04"),
+			}},
+		}
+	}
+	insideWindow := window(-2*time.Hour, 2*time.Hour)
+	outsideWindow := window(2*time.Hour, 4*time.Hour)
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithClusterVersion("4.4.9"),
+		testcd.InstalledTimestamp(now.Add(-48*time.Hour)),
+		testcd.WithAnnotation(constants.HiveFakeClusterAnnotation, "true"),
+	)
+	csBuilder := testcs.FullBuilder(namespace, cdName, scheme).Options(
+		testcs.WithFirstSuccessTime(now.Add(-10 * time.Hour)),
+	)
+
+	tests := []struct {
+		name string
+		cd   *hivev1.ClusterDeployment
+
+		expectedPowerState     hivev1.ClusterPowerState
+		expectStatus           bool
+		expectNextPowerState   hivev1.ClusterPowerState
+		expectRequeueAtMost    time.Duration
+		expectNoScheduleStatus bool
+	}{
+		{
+			name: "resume at start of window",
+			cd: cdBuilder.Build(
+				testcd.WithPowerState(hivev1.ClusterPowerStateHibernating),
+				testcd.WithHibernationSchedule(insideWindow)),
+			expectedPowerState:   hivev1.ClusterPowerStateRunning,
+			expectStatus:         true,
+			expectNextPowerState: hivev1.ClusterPowerStateHibernating,
+		},
+		{
+			name:                 "hibernate at end of window",
+			cd:                   cdBuilder.Build(testcd.WithHibernationSchedule(outsideWindow)),
+			expectedPowerState:   hivev1.ClusterPowerStateHibernating,
+			expectStatus:         true,
+			expectNextPowerState: hivev1.ClusterPowerStateRunning,
+		},
+		{
+			name: "manual override of applied transition is preserved",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := cdBuilder.Build(
+					testcd.WithPowerState(hivev1.ClusterPowerStateRunning),
+					testcd.WithHibernationSchedule(outsideWindow))
+				cd.Status.HibernationSchedule = &hivev1.HibernationScheduleStatus{
+					LastTransitionTime: &metav1.Time{Time: now},
+				}
+				return cd
+			}(),
+			expectedPowerState:   hivev1.ClusterPowerStateRunning,
+			expectStatus:         true,
+			expectNextPowerState: hivev1.ClusterPowerStateRunning,
+			expectRequeueAtMost:  2 * time.Hour,
+		},
+		{
+			name: "unclaimed pool cluster ignores schedule",
+			cd: cdBuilder.Build(
+				testcd.WithPowerState(hivev1.ClusterPowerStateRunning),
+				testcd.WithUnclaimedClusterPoolReference(namespace, "test-pool"),
+				testcd.WithHibernationSchedule(outsideWindow)),
+			expectedPowerState:     hivev1.ClusterPowerStateRunning,
+			expectNoScheduleStatus: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockActuator := mock.NewMockHibernationActuator(ctrl)
+			mockActuator.EXPECT().CanHandle(gomock.Any()).AnyTimes().Return(true)
+			actuators = []HibernationActuator{mockActuator}
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(test.cd, csBuilder.Build()).Build()
+
+			reconciler := hibernationReconciler{
+				Client: c,
+				logger: log.WithField("controller", "hibernation"),
+				remoteClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					return remoteclientmock.NewMockBuilder(ctrl)
+				},
+				csrUtil: mock.NewMockcsrHelper(ctrl),
+				clock:   clocktesting.NewFakePassiveClock(now),
+			}
+			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			if test.expectRequeueAtMost != 0 {
+				assert.Greater(t, result.RequeueAfter, time.Duration(0), "expected requeue for next transition")
+				assert.LessOrEqual(t, result.RequeueAfter, test.expectRequeueAtMost, "requeue after too large")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			err = c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd)
+			require.NoError(t, err, "error looking up ClusterDeployment")
+			assert.Equal(t, test.expectedPowerState, cd.Spec.PowerState, "unexpected PowerState")
+			if test.expectNoScheduleStatus {
+				assert.Nil(t, cd.Status.HibernationSchedule, "expected no hibernation schedule status")
+			}
+			if test.expectStatus {
+				if assert.NotNil(t, cd.Status.HibernationSchedule, "expected hibernation schedule status") {
+					assert.NotNil(t, cd.Status.HibernationSchedule.LastTransitionTime, "expected last transition time")
+					assert.NotNil(t, cd.Status.HibernationSchedule.NextTransitionTime, "expected next transition time")
+					assert.Equal(t, test.expectNextPowerState, cd.Status.HibernationSchedule.NextPowerState, "unexpected next power state")
+				}
+			}
+		})
+	}
+}
+
 func hibernatingCondition(status corev1.ConditionStatus, reason string, lastTransitionAgo time.Duration) hivev1.ClusterDeploymentCondition {
 	return hivev1.ClusterDeploymentCondition{
 		Type:               hivev1.ClusterHibernatingCondition,
@@ -0,0 +1,86 @@
+package hibernation
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// applyHibernationSchedule drives Spec.PowerState from the ClusterDeployment's HibernationSchedule. A scheduled
+// transition is applied once, when its time has passed, so the PowerState may still be changed manually between
+// transitions. Returns whether the ClusterDeployment spec was updated, and the time until the next scheduled
+// transition (zero if there is none).
+func (r *hibernationReconciler) applyHibernationSchedule(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (bool, time.Duration, error) {
+	now := r.clock.Now()
+	_, last, next, err := controllerutils.EvaluateHibernationSchedule(cd.Spec.HibernationSchedule, now)
+	if err != nil {
+		// The webhook should prevent this; there is nothing to do until the schedule is fixed.
+		logger.WithError(err).Warn("unable to evaluate hibernation schedule")
+		return false, 0, nil
+	}
+
+	status := &hivev1.HibernationScheduleStatus{}
+	if cd.Status.HibernationSchedule != nil {
+		status.LastTransitionTime = cd.Status.HibernationSchedule.LastTransitionTime
+	}
+
+	specChanged := false
+	if last != nil && (status.LastTransitionTime == nil || status.LastTransitionTime.Time.Before(last.Time)) {
+		if powerStateOrDefault(cd.Spec.PowerState) != last.PowerState {
+			logger.WithField("powerState", last.PowerState).WithField("scheduledAt", last.Time).
+				Info("applying scheduled power state transition")
+			cd.Spec.PowerState = last.PowerState
+			if err := r.Update(context.TODO(), cd); err != nil {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "error applying scheduled power state")
+				return false, 0, err
+			}
+			specChanged = true
+		}
+		status.LastTransitionTime = &metav1.Time{Time: last.Time}
+	}
+
+	var requeueAfter time.Duration
+	if next != nil {
+		status.NextTransitionTime = &metav1.Time{Time: next.Time}
+		status.NextPowerState = next.PowerState
+		requeueAfter = next.Time.Sub(now)
+	}
+
+	if !scheduleStatusEqual(cd.Status.HibernationSchedule, status) {
+		cd.Status.HibernationSchedule = status
+		if err := r.updateClusterDeploymentStatus(cd, logger); err != nil {
+			return specChanged, 0, err
+		}
+	}
+	return specChanged, requeueAfter, nil
+}
+
+// powerStateOrDefault returns the given Spec.PowerState, treating the empty value as Running.
+func powerStateOrDefault(powerState hivev1.ClusterPowerState) hivev1.ClusterPowerState {
+	if powerState == "" {
+		return hivev1.ClusterPowerStateRunning
+	}
+	return powerState
+}
+
+// scheduleStatusEqual compares HibernationScheduleStatuses, ignoring the time zone of their timestamps.
+func scheduleStatusEqual(a, b *hivev1.HibernationScheduleStatus) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	timeEqual := func(x, y *metav1.Time) bool {
+		if x == nil || y == nil {
+			return x == y
+		}
+		return x.Time.Equal(y.Time)
+	}
+	return timeEqual(a.LastTransitionTime, b.LastTransitionTime) &&
+		timeEqual(a.NextTransitionTime, b.NextTransitionTime) &&
+		a.NextPowerState == b.NextPowerState
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/apis/hive/v1/metricsconfig"
+)
+
+// newOrphanedCloudResourcesMetric returns the metric summing the orphaned cloud resources of clusters. The clusters
+// are grouped by the AdditionalClusterDeploymentLabels of the metrics config.
+func newOrphanedCloudResourcesMetric(mConfig *metricsconfig.MetricsConfig) *GaugeVecWithDynamicLabels {
+	return NewGaugeVecWithDynamicLabels(&prometheus.GaugeOpts{
+		Name: "hive_cluster_deployments_orphaned_cloud_resources",
+		Help: "Number of orphaned cloud resources of the clusters, as found by the cloud resource inventory.",
+	}, []string{"platform"}, GetOptionalClusterTypeLabels(mConfig))
+}
+
+// calculateOrphanedCloudResourceMetrics reports the orphaned cloud resources of the clusters.
+func (mc *Calculator) calculateOrphanedCloudResourceMetrics(cds []hivev1.ClusterDeployment) {
+	mc.metricOrphanedCloudResources.ObserveSums(sumOrphanedCloudResources(cds, mc.metricOrphanedCloudResources))
+}
+
+// sumOrphanedCloudResources sums the orphaned cloud resources of the clusters by the labels the metric would be
+// observed with. ClusterDeployments whose cloud resources have not been inventoried are ignored.
+func sumOrphanedCloudResources(cds []hivev1.ClusterDeployment, metric *GaugeVecWithDynamicLabels) map[string]*gaugeSum {
+	sums := map[string]*gaugeSum{}
+	for i := range cds {
+		cd := &cds[i]
+		if cd.Status.CloudResources == nil {
+			continue
+		}
+		metric.Add(sums, cd, map[string]string{"platform": cd.Labels[hivev1.HiveClusterPlatformLabel]},
+			float64(cd.Status.CloudResources.OrphanCount))
+	}
+	return sums
+}
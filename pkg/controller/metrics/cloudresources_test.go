@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestSumOrphanedCloudResources(t *testing.T) {
+	created := metav1.Time{Time: time.Now().Add(-24 * time.Hour)}
+	cds := []hivev1.ClusterDeployment{
+		testClusterDeploymentWithOrphans("a", "managed", created, "aws", 3),
+		testClusterDeploymentWithOrphans("b", "managed", created, "aws", 0),
+		testClusterDeploymentWithOrphans("c", "managed", created, "azure", 1),
+		testClusterDeploymentWithOrphans("d", "unmanaged", created, "aws", 2),
+		// Not inventoried
+		testClusterDeployment("e", "managed", created, true),
+	}
+
+	metric := NewGaugeVecWithDynamicLabels(&prometheus.GaugeOpts{Name: "test"}, []string{"platform"},
+		map[string]string{"cluster_type": hivev1.HiveClusterTypeLabel})
+
+	sums := sumOrphanedCloudResources(cds, metric)
+
+	actual := map[[2]string]float64{}
+	for _, sum := range sums {
+		key := [2]string{sum.fixedLabels["platform"], GetLabelValue(sum.obj, hivev1.HiveClusterTypeLabel)}
+		actual[key] = sum.value
+	}
+	assert.Equal(t, map[[2]string]float64{
+		{"aws", "managed"}:   3,
+		{"azure", "managed"}: 1,
+		{"aws", "unmanaged"}: 2,
+	}, actual, "unexpected sums")
+}
+
+func testClusterDeploymentWithOrphans(name, clusterType string, created metav1.Time, platform string, orphans int32) hivev1.ClusterDeployment {
+	cd := testClusterDeployment(name, clusterType, created, true)
+	cd.Labels[hivev1.HiveClusterPlatformLabel] = platform
+	cd.Status.CloudResources = &hivev1.CloudResourcesStatus{
+		OrphanCount:  orphans,
+		LastScanTime: created,
+	}
+	return cd
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/apis/hive/v1/metricsconfig"
+)
+
+// newClusterHourlyCostMetric returns the metric summing the estimated hourly cost of clusters. The clusters are
+// grouped by the AdditionalClusterDeploymentLabels of the metrics config.
+func newClusterHourlyCostMetric(mConfig *metricsconfig.MetricsConfig) *GaugeVecWithDynamicLabels {
+	return NewGaugeVecWithDynamicLabels(&prometheus.GaugeOpts{
+		Name: "hive_cluster_deployments_hourly_cost",
+		Help: "Estimated hourly cost of the installed clusters, according to the cluster-price-table ConfigMap.",
+	}, []string{"currency", "clusterpool_namespacedname"}, GetOptionalClusterTypeLabels(mConfig))
+}
+
+// newClusterCumulativeCostMetric returns the metric summing the estimated cumulative cost of clusters. The clusters
+// are grouped by the AdditionalClusterDeploymentLabels of the metrics config.
+func newClusterCumulativeCostMetric(mConfig *metricsconfig.MetricsConfig) *GaugeVecWithDynamicLabels {
+	return NewGaugeVecWithDynamicLabels(&prometheus.GaugeOpts{
+		Name: "hive_cluster_deployments_cumulative_cost",
+		Help: "Estimated cost of the existing clusters since they were installed, according to the cluster-price-table ConfigMap.",
+	}, []string{"currency", "clusterpool_namespacedname"}, GetOptionalClusterTypeLabels(mConfig))
+}
+
+// calculateClusterCostMetrics reports the estimated costs of the clusters.
+func (mc *Calculator) calculateClusterCostMetrics(cds []hivev1.ClusterDeployment) {
+	hourly, cumulative := sumClusterCosts(cds, mc.metricClusterHourlyCost, mc.metricClusterCumulativeCost)
+	mc.metricClusterHourlyCost.ObserveSums(hourly)
+	mc.metricClusterCumulativeCost.ObserveSums(cumulative)
+}
+
+// sumClusterCosts sums the cost status of the clusters by the labels the metrics would be observed with.
+// ClusterDeployments that have no cost status or are being deleted are ignored.
+func sumClusterCosts(cds []hivev1.ClusterDeployment, hourlyMetric, cumulativeMetric *GaugeVecWithDynamicLabels) (hourlySums, cumulativeSums map[string]*gaugeSum) {
+	hourlySums, cumulativeSums = map[string]*gaugeSum{}, map[string]*gaugeSum{}
+	for i := range cds {
+		cd := &cds[i]
+		cost := cd.Status.Cost
+		if cost == nil || cd.DeletionTimestamp != nil {
+			continue
+		}
+		hourly, err := strconv.ParseFloat(cost.HourlyCost, 64)
+		if err != nil {
+			continue
+		}
+		cumulative, err := strconv.ParseFloat(cost.CumulativeCost, 64)
+		if err != nil {
+			continue
+		}
+		poolNSName := ""
+		if poolRef := cd.Spec.ClusterPoolRef; poolRef != nil {
+			poolNSName = poolRef.Namespace + "/" + poolRef.PoolName
+		}
+		fixedLabels := map[string]string{"currency": cost.Currency, "clusterpool_namespacedname": poolNSName}
+		hourlyMetric.Add(hourlySums, cd, fixedLabels, hourly)
+		cumulativeMetric.Add(cumulativeSums, cd, fixedLabels, cumulative)
+	}
+	return hourlySums, cumulativeSums
+}
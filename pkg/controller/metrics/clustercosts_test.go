@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestSumClusterCosts(t *testing.T) {
+	created := metav1.Time{Time: time.Now().Add(-24 * time.Hour)}
+	deleted := metav1.Time{Time: time.Now()}
+	cds := []hivev1.ClusterDeployment{
+		testClusterDeploymentWithCost("a", "managed", created, "pool-a", "1.5000", "10.0000"),
+		testClusterDeploymentWithCost("b", "managed", created, "pool-a", "0.0500", "2.2500"),
+		testClusterDeploymentWithCost("c", "unmanaged", created, "pool-a", "1.0000", "1.0000"),
+		testClusterDeploymentWithCost("d", "managed", created, "", "2.0000", "40.0000"),
+		// Not estimated yet
+		testClusterDeployment("e", "managed", created, true),
+	}
+	// ClusterDeployment being deleted
+	cds = append(cds, testClusterDeploymentWithCost("f", "managed", created, "pool-a", "1.0000", "1.0000"))
+	cds[len(cds)-1].DeletionTimestamp = &deleted
+
+	metric := NewGaugeVecWithDynamicLabels(&prometheus.GaugeOpts{Name: "test"}, []string{"currency", "clusterpool_namespacedname"},
+		map[string]string{"cluster_type": hivev1.HiveClusterTypeLabel})
+
+	hourlySums, cumulativeSums := sumClusterCosts(cds, metric, metric)
+
+	actual := map[[2]string][2]float64{}
+	for key, hourly := range hourlySums {
+		assert.Equal(t, "USD", hourly.fixedLabels["currency"], "unexpected currency")
+		cumulative, ok := cumulativeSums[key]
+		if !assert.True(t, ok, "missing cumulative sum for %s", key) {
+			continue
+		}
+		labels := [2]string{hourly.fixedLabels["clusterpool_namespacedname"], GetLabelValue(hourly.obj, hivev1.HiveClusterTypeLabel)}
+		actual[labels] = [2]float64{hourly.value, cumulative.value}
+	}
+	assert.Equal(t, map[[2]string][2]float64{
+		{"pools/pool-a", "managed"}:   {1.55, 12.25},
+		{"pools/pool-a", "unmanaged"}: {1, 1},
+		{"", "managed"}:               {2, 40},
+	}, actual, "unexpected sums")
+}
+
+func testClusterDeploymentWithCost(name, clusterType string, created metav1.Time, pool, hourly, cumulative string) hivev1.ClusterDeployment {
+	cd := testClusterDeployment(name, clusterType, created, true)
+	if pool != "" {
+		cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{Namespace: "pools", PoolName: pool}
+	}
+	cd.Status.Cost = &hivev1.ClusterCostStatus{
+		Currency:       "USD",
+		HourlyCost:     hourly,
+		CumulativeCost: cumulative,
+	}
+	return cd
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/apis/hive/v1/metricsconfig"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	clusterOperatorDegraded    = "Degraded"
+	clusterOperatorUnavailable = "Unavailable"
+	clusterOperatorProgressing = "Progressing"
+)
+
+// newClusterOperatorConditionsMetric returns the metric counting the clusters on which each cluster operator is
+// unhealthy. The clusters are grouped by the AdditionalClusterDeploymentLabels of the metrics config.
+func newClusterOperatorConditionsMetric(mConfig *metricsconfig.MetricsConfig) *GaugeVecWithDynamicLabels {
+	return NewGaugeVecWithDynamicLabels(&prometheus.GaugeOpts{
+		Name: "hive_cluster_operator_conditions",
+		Help: "Number of clusters on which a cluster operator is Degraded, Unavailable or Progressing, according to their ClusterStates.",
+	}, []string{"operator", "condition"}, GetOptionalClusterTypeLabels(mConfig))
+}
+
+// calculateClusterOperatorMetrics reports the number of clusters on which each cluster operator is unhealthy.
+func (mc *Calculator) calculateClusterOperatorMetrics(ctx context.Context, cds []hivev1.ClusterDeployment, mcLog log.FieldLogger) {
+	mcLog.Debug("calculating metrics across all ClusterStates")
+	states := &hivev1.ClusterStateList{}
+	if err := mc.Client.List(ctx, states); err != nil {
+		mcLog.WithError(err).Error("error listing cluster states")
+		return
+	}
+	mc.metricClusterOperatorConditions.ObserveSums(countClusterOperatorConditions(cds, states.Items, mc.metricClusterOperatorConditions))
+}
+
+// countClusterOperatorConditions counts the clusters on which each cluster operator is Degraded, Unavailable or
+// Progressing, by the labels the metric would be observed with. ClusterStates of ClusterDeployments that are missing
+// or being deleted are ignored.
+func countClusterOperatorConditions(cds []hivev1.ClusterDeployment, states []hivev1.ClusterState, metric *GaugeVecWithDynamicLabels) map[string]*gaugeSum {
+	cdsByName := make(map[types.NamespacedName]*hivev1.ClusterDeployment, len(cds))
+	for i := range cds {
+		if cds[i].DeletionTimestamp != nil {
+			continue
+		}
+		cdsByName[types.NamespacedName{Namespace: cds[i].Namespace, Name: cds[i].Name}] = &cds[i]
+	}
+
+	counts := map[string]*gaugeSum{}
+	for i := range states {
+		cd, ok := cdsByName[types.NamespacedName{Namespace: states[i].Namespace, Name: states[i].Name}]
+		if !ok {
+			continue
+		}
+		for j := range states[i].Status.ClusterOperators {
+			op := &states[i].Status.ClusterOperators[j]
+			degraded, unavailable, progressing := controllerutils.ClusterOperatorHealth(op)
+			for condition, unhealthy := range map[string]bool{
+				clusterOperatorDegraded:    degraded,
+				clusterOperatorUnavailable: unavailable,
+				clusterOperatorProgressing: progressing,
+			} {
+				if !unhealthy {
+					continue
+				}
+				metric.Add(counts, cd, map[string]string{"operator": op.Name, "condition": condition}, 1)
+			}
+		}
+	}
+	return counts
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	testcs "github.com/openshift/hive/pkg/test/clusterstate"
+)
+
+func TestCountClusterOperatorConditions(t *testing.T) {
+	created := metav1.Time{Time: time.Now().Add(-24 * time.Hour)}
+	deleted := metav1.Time{Time: time.Now()}
+	cds := []hivev1.ClusterDeployment{
+		testClusterDeployment("a", "managed", created, true),
+		testClusterDeployment("b", "managed", created, true),
+		testClusterDeployment("c", "unmanaged", created, true),
+		testDeletedClusterDeployment("d", "managed", created, deleted, true),
+	}
+	states := []hivev1.ClusterState{
+		*testcs.Build(testcs.WithName("a"), testcs.WithOperators(
+			testcs.OperatorState("ingress", configv1.ConditionTrue, configv1.ConditionTrue, configv1.ConditionFalse),
+			testcs.OperatorState("dns", configv1.ConditionFalse, configv1.ConditionFalse, configv1.ConditionFalse),
+			testcs.OperatorState("etcd", configv1.ConditionFalse, configv1.ConditionTrue, configv1.ConditionFalse),
+		)),
+		*testcs.Build(testcs.WithName("b"), testcs.WithOperators(
+			testcs.OperatorState("ingress", configv1.ConditionFalse, configv1.ConditionFalse, configv1.ConditionTrue),
+			testcs.OperatorState("dns", configv1.ConditionFalse, configv1.ConditionFalse, configv1.ConditionFalse),
+		)),
+		*testcs.Build(testcs.WithName("c"), testcs.WithOperators(
+			testcs.OperatorState("ingress", configv1.ConditionTrue, configv1.ConditionTrue, configv1.ConditionFalse),
+		)),
+		// ClusterDeployment being deleted
+		*testcs.Build(testcs.WithName("d"), testcs.WithOperators(
+			testcs.OperatorState("ingress", configv1.ConditionTrue, configv1.ConditionTrue, configv1.ConditionFalse),
+		)),
+		// No ClusterDeployment
+		*testcs.Build(testcs.WithName("e"), testcs.WithOperators(
+			testcs.OperatorState("ingress", configv1.ConditionTrue, configv1.ConditionTrue, configv1.ConditionFalse),
+		)),
+	}
+	metric := NewGaugeVecWithDynamicLabels(&prometheus.GaugeOpts{Name: "test"}, []string{"operator", "condition"},
+		map[string]string{"cluster_type": hivev1.HiveClusterTypeLabel})
+
+	counts := countClusterOperatorConditions(cds, states, metric)
+
+	actual := map[[3]string]float64{}
+	for _, count := range counts {
+		key := [3]string{
+			count.fixedLabels["operator"],
+			count.fixedLabels["condition"],
+			GetLabelValue(count.obj, hivev1.HiveClusterTypeLabel),
+		}
+		actual[key] = count.value
+	}
+	expected := map[[3]string]float64{
+		{"ingress", clusterOperatorDegraded, "managed"}:    1,
+		{"ingress", clusterOperatorUnavailable, "managed"}: 1,
+		{"ingress", clusterOperatorProgressing, "managed"}: 1,
+		{"ingress", clusterOperatorDegraded, "unmanaged"}:  1,
+		{"dns", clusterOperatorUnavailable, "managed"}:     2,
+	}
+	assert.Equal(t, expected, actual, "unexpected cluster operator condition counts")
+}
+
+func TestCountClusterOperatorConditions_MissingLabel(t *testing.T) {
+	cd := testClusterDeployment("a", "managed", metav1.Now(), true)
+	cd.Labels = nil
+	states := []hivev1.ClusterState{
+		*testcs.Build(testcs.WithName("a"),
+			testcs.WithOperators(testcs.OperatorState("ingress", configv1.ConditionTrue, configv1.ConditionTrue, configv1.ConditionFalse))),
+	}
+	metric := NewGaugeVecWithDynamicLabels(&prometheus.GaugeOpts{Name: "test"}, []string{"operator", "condition"},
+		map[string]string{"cluster_type": hivev1.HiveClusterTypeLabel})
+
+	counts := countClusterOperatorConditions([]hivev1.ClusterDeployment{cd}, states, metric)
+	if assert.Len(t, counts, 1, "unexpected number of counts") {
+		for _, count := range counts {
+			assert.Equal(t, constants.MetricLabelDefaultValue, metric.buildLabels(count.fixedLabels, count.obj)["cluster_type"],
+				"unexpected cluster_type label")
+			assert.Equal(t, float64(1), count.value, "unexpected count")
+		}
+	}
+}
@@ -214,6 +214,18 @@ type Calculator struct {
 
 	// Interval is the length of time we sleep between metrics calculations.
 	Interval time.Duration
+
+	// metricClusterOperatorConditions is created when the Calculator starts, as its labels depend on the metrics
+	// config.
+	metricClusterOperatorConditions *GaugeVecWithDynamicLabels
+
+	// metricClusterHourlyCost and metricClusterCumulativeCost are created when the Calculator starts, as their labels
+	// depend on the metrics config.
+	metricClusterHourlyCost     *GaugeVecWithDynamicLabels
+	metricClusterCumulativeCost *GaugeVecWithDynamicLabels
+
+	// metricOrphanedCloudResources is created when the Calculator starts, as its labels depend on the metrics config.
+	metricOrphanedCloudResources *GaugeVecWithDynamicLabels
 }
 
 // Start begins the metrics calculation loop.
@@ -228,6 +240,14 @@ func (mc *Calculator) Start(ctx context.Context) error {
 	// Register optional metrics and update them in their corresponding maps, so controllers logging them can access
 	// the information
 	mc.registerOptionalMetrics(mConfig)
+	mc.metricClusterOperatorConditions = newClusterOperatorConditionsMetric(mConfig)
+	mc.metricClusterOperatorConditions.Register()
+	mc.metricClusterHourlyCost = newClusterHourlyCostMetric(mConfig)
+	mc.metricClusterHourlyCost.Register()
+	mc.metricClusterCumulativeCost = newClusterCumulativeCostMetric(mConfig)
+	mc.metricClusterCumulativeCost.Register()
+	mc.metricOrphanedCloudResources = newOrphanedCloudResourcesMetric(mConfig)
+	mc.metricOrphanedCloudResources.Register()
 
 	// Run forever, sleep at the end:
 	wait.UntilWithContext(ctx, func(ctx context.Context) {
@@ -317,6 +337,10 @@ func (mc *Calculator) Start(ctx context.Context) error {
 				metricClusterDeploymentsDeprovisioningTotal,
 				metricClusterDeploymentsWithConditionTotal,
 				mcLog)
+
+			mc.calculateClusterOperatorMetrics(ctx, clusterDeployments.Items, mcLog)
+			mc.calculateClusterCostMetrics(clusterDeployments.Items)
+			mc.calculateOrphanedCloudResourceMetrics(clusterDeployments.Items)
 		}
 		mcLog.Debug("calculating metrics across all install jobs")
 
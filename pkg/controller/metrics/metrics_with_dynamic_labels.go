@@ -170,8 +170,77 @@ func (h HistogramVecWithDynamicLabels) Observe(obj metav1.Object, fixedLabels ma
 	h.metric.With(h.buildLabels(fixedLabels, obj)).Observe(val)
 }
 
+type GaugeVecWithDynamicLabels struct {
+	*prometheus.GaugeOpts
+	metric *prometheus.GaugeVec
+	*dynamicLabels
+}
+
+func NewGaugeVecWithDynamicLabels(gaugeOpts *prometheus.GaugeOpts, fixedLabels []string,
+	optionalLabels map[string]string) *GaugeVecWithDynamicLabels {
+	gaugeVecMetric := &GaugeVecWithDynamicLabels{
+		GaugeOpts: gaugeOpts,
+		dynamicLabels: &dynamicLabels{
+			fixedLabels:    fixedLabels,
+			optionalLabels: optionalLabels,
+		},
+	}
+	if repeatedLabels := gaugeVecMetric.getRepeatedLabels(); repeatedLabels != nil {
+		panic(fmt.Sprintf("Label(s) %v in HiveConfig.Spec.AdditionalClusterDeploymentLabels conflict with fixed label(s) for the metric %s. Please rename your label.", repeatedLabels, gaugeOpts.Name))
+	}
+	gaugeVecMetric.metric = prometheus.NewGaugeVec(*gaugeVecMetric.GaugeOpts, gaugeVecMetric.getLabelList())
+	return gaugeVecMetric
+}
+
+// Register registers the GaugeVec metric.
+func (g GaugeVecWithDynamicLabels) Register() {
+	metrics.Registry.MustRegister(g.metric)
+}
+
+// Observe sets the gauge metric to val.
+func (g GaugeVecWithDynamicLabels) Observe(obj metav1.Object, fixedLabels map[string]string, val float64) {
+	g.metric.With(g.buildLabels(fixedLabels, obj)).Set(val)
+}
+
+// Reset deletes all values of the gauge metric, so that label combinations which are no longer observed are not
+// reported.
+func (g GaugeVecWithDynamicLabels) Reset() {
+	g.metric.Reset()
+}
+
+// gaugeSum is the sum of the values to be observed with the same labels.
+type gaugeSum struct {
+	// obj is one of the objects summed, from which the optional labels are taken.
+	obj         metav1.Object
+	fixedLabels map[string]string
+	value       float64
+}
+
+// Add adds val to the sum of the values to be observed with the labels of obj and fixedLabels. Sums are keyed by the
+// full label set, so objects whose optional labels have the same values are summed together.
+func (g GaugeVecWithDynamicLabels) Add(sums map[string]*gaugeSum, obj metav1.Object, fixedLabels map[string]string, val float64) {
+	// fmt prints maps sorted by key, so equal label sets have equal keys.
+	key := fmt.Sprint(g.buildLabels(fixedLabels, obj))
+	sum, ok := sums[key]
+	if !ok {
+		sum = &gaugeSum{obj: obj, fixedLabels: fixedLabels}
+		sums[key] = sum
+	}
+	sum.value += val
+}
+
+// ObserveSums resets the gauge metric and sets it to each of the sums, so that label sets which are no longer summed
+// are not reported.
+func (g GaugeVecWithDynamicLabels) ObserveSums(sums map[string]*gaugeSum) {
+	g.Reset()
+	for _, sum := range sums {
+		g.Observe(sum.obj, sum.fixedLabels, sum.value)
+	}
+}
+
 var _ metricsWithDynamicLabels = CounterVecWithDynamicLabels{}
 var _ metricsWithDynamicLabels = HistogramVecWithDynamicLabels{}
+var _ metricsWithDynamicLabels = GaugeVecWithDynamicLabels{}
 
 // GetOptionalClusterTypeLabels reads the AdditionalClusterDeploymentLabels from the metrics config and returns the same
 // as a map
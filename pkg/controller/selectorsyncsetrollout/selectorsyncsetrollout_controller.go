@@ -0,0 +1,413 @@
+package selectorsyncsetrollout
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.SelectorSyncSetRolloutControllerName
+
+	// resyncInterval is how often an in-progress rollout is re-evaluated in order to pick up clusters that have
+	// started or stopped matching the SelectorSyncSet.
+	resyncInterval = time.Minute
+)
+
+// Add creates a new SelectorSyncSetRollout Controller and adds it to the Manager with default RBAC. The Manager will
+// set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	return &ReconcileSelectorSyncSetRollout{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewDelayingReconciler(r, log.WithField("controller", ControllerName)),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to SelectorSyncSets
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.SelectorSyncSet{}), &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterSyncs, which carry the results of applying the SelectorSyncSets to each cluster
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &hiveintv1alpha1.ClusterSync{}),
+		handler.EnqueueRequestsFromMapFunc(requestsForClusterSync(mgr.GetClient(), log.WithField("controller", ControllerName))),
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// requestsForClusterSync returns a map func enqueuing the SelectorSyncSets of a ClusterSync whose rollout is in
+// progress. Complete and halted rollouts do not depend on the results of the clusters, and a new generation of a
+// SelectorSyncSet is reconciled through the watch of the SelectorSyncSet itself.
+func requestsForClusterSync(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		clusterSync, ok := o.(*hiveintv1alpha1.ClusterSync)
+		if !ok {
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, status := range clusterSync.Status.SelectorSyncSets {
+			name := types.NamespacedName{Name: status.Name}
+			sss := &hivev1.SelectorSyncSet{}
+			if err := c.Get(ctx, name, sss); err != nil {
+				if !apierrors.IsNotFound(err) {
+					logger.WithError(err).WithField("selectorSyncSet", status.Name).Log(controllerutils.LogLevel(err), "could not get SelectorSyncSet")
+				}
+				continue
+			}
+			if sss.Spec.RolloutStrategy == nil || rolloutFinished(sss) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: name})
+		}
+		return requests
+	}
+}
+
+// rolloutFinished returns true if the rollout of the current generation of the SelectorSyncSet is complete or halted.
+// Such a rollout only changes with a new generation of the SelectorSyncSet.
+func rolloutFinished(sss *hivev1.SelectorSyncSet) bool {
+	rollout := sss.Status.Rollout
+	if rollout == nil || rollout.ObservedGeneration != sss.Generation {
+		return false
+	}
+	return rollout.Phase == hivev1.CompleteSelectorSyncSetRolloutPhase || rollout.Phase == hivev1.HaltedSelectorSyncSetRolloutPhase
+}
+
+var _ reconcile.Reconciler = &ReconcileSelectorSyncSetRollout{}
+
+// ReconcileSelectorSyncSetRollout reconciles a SelectorSyncSet object for the purpose of rolling out changes to it in
+// waves.
+type ReconcileSelectorSyncSetRollout struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+type rolloutTarget struct {
+	key  int64
+	name types.NamespacedName
+}
+
+// Reconcile advances the rollout of a SelectorSyncSet and records its progress in the status.
+func (r *ReconcileSelectorSyncSetRollout) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "selectorSyncSet", request.NamespacedName)
+	logger.Debug("reconciling selector syncset rollout")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	sss := &hivev1.SelectorSyncSet{}
+	switch err := r.Get(context.Background(), request.NamespacedName, sss); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("selector syncset not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Error("error reading selector syncset")
+		return reconcile.Result{}, err
+	}
+
+	if sss.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	if sss.Spec.RolloutStrategy == nil {
+		if sss.Status.Rollout == nil {
+			return reconcile.Result{}, nil
+		}
+		logger.Info("clearing rollout status since the selector syncset no longer has a rollout strategy")
+		sss.Status.Rollout = nil
+		return reconcile.Result{}, r.updateStatus(sss, logger)
+	}
+
+	if rolloutFinished(sss) {
+		// Do not read the ClusterSyncs of every target cluster again until there is a new generation to roll out.
+		logger.WithField("phase", sss.Status.Rollout.Phase).Debug("rollout of the current generation is finished")
+		return reconcile.Result{}, nil
+	}
+
+	origRollout := sss.Status.Rollout.DeepCopy()
+	rollout := sss.Status.Rollout
+	if rollout == nil || rollout.ObservedGeneration != sss.Generation {
+		logger.WithField("generation", sss.Generation).Info("starting rollout of new generation")
+		now := metav1.Now()
+		rollout = &hivev1.SelectorSyncSetRolloutStatus{
+			ObservedGeneration: sss.Generation,
+			Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+			Wave:               1,
+			LastTransitionTime: &now,
+		}
+	}
+
+	// An invalid selector matches no clusters, so halt rather than report the rollout complete.
+	if _, err := metav1.LabelSelectorAsSelector(&sss.Spec.ClusterDeploymentSelector); err != nil {
+		logger.WithError(err).Warn("halting rollout since the cluster deployment selector is invalid")
+		setPhase(rollout, hivev1.HaltedSelectorSyncSetRolloutPhase)
+		rollout.Message = fmt.Sprintf("Halted: invalid clusterDeploymentSelector: %v", err)
+		sss.Status.Rollout = rollout
+		if reflect.DeepEqual(origRollout, rollout) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, r.updateStatus(sss, logger)
+	}
+
+	targets, err := r.getRolloutTargets(sss, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	waveSize := resolveWaveSize(sss.Spec.RolloutStrategy.WaveSize, len(targets))
+	rollout.TotalWaves = int32(math.Max(1, math.Ceil(float64(len(targets))/float64(waveSize))))
+	rollout.TargetClusters = int32(len(targets))
+
+	var requeueAfter time.Duration
+	for {
+		released := targets[:min(len(targets), int(rollout.Wave)*waveSize)]
+		currentWave := released[min(len(released), int(rollout.Wave-1)*waveSize):]
+		if rollout.Phase == hivev1.CompleteSelectorSyncSetRolloutPhase {
+			released, currentWave = targets, nil
+		}
+
+		updated, failed, pending, err := r.countResults(sss, released, currentWave, logger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		rollout.ReleasedClusters = int32(len(released))
+		rollout.UpdatedClusters = int32(updated)
+		rollout.FailedClusters = int32(failed)
+		rollout.ReleasedKeyLimit = -1
+		if len(released) > 0 {
+			rollout.ReleasedKeyLimit = released[len(released)-1].key
+		}
+
+		switch rollout.Phase {
+		case hivev1.CompleteSelectorSyncSetRolloutPhase:
+			rollout.Message = fmt.Sprintf("Rolled out to all %d clusters", len(targets))
+		case hivev1.ProgressingSelectorSyncSetRolloutPhase, hivev1.WaitingSelectorSyncSetRolloutPhase:
+			maxFailures := int(sss.Spec.RolloutStrategy.MaxFailuresPerWave)
+			switch {
+			case failed > maxFailures:
+				logger.WithField("wave", rollout.Wave).WithField("failed", failed).Warn("halting rollout since too many clusters failed")
+				setPhase(rollout, hivev1.HaltedSelectorSyncSetRolloutPhase)
+				rollout.Message = fmt.Sprintf("Halted in wave %d of %d: %d clusters failed to apply, more than the %d allowed",
+					rollout.Wave, rollout.TotalWaves, failed, maxFailures)
+			case pending > 0:
+				setPhase(rollout, hivev1.ProgressingSelectorSyncSetRolloutPhase)
+				rollout.Message = fmt.Sprintf("Wave %d of %d: waiting for %d of %d clusters to apply",
+					rollout.Wave, rollout.TotalWaves, pending, len(currentWave))
+			case rollout.Wave >= rollout.TotalWaves:
+				logger.Info("rollout complete")
+				setPhase(rollout, hivev1.CompleteSelectorSyncSetRolloutPhase)
+				// Recount with every target cluster released.
+				continue
+			default:
+				pause := time.Duration(0)
+				if p := sss.Spec.RolloutStrategy.PauseBetweenWaves; p != nil {
+					pause = p.Duration
+				}
+				setPhase(rollout, hivev1.WaitingSelectorSyncSetRolloutPhase)
+				if remaining := pause - time.Since(rollout.LastTransitionTime.Time); remaining > 0 {
+					rollout.Message = fmt.Sprintf("Wave %d of %d complete: starting the next wave in %s",
+						rollout.Wave, rollout.TotalWaves, remaining.Round(time.Second))
+					requeueAfter = remaining
+					break
+				}
+				logger.WithField("wave", rollout.Wave+1).Info("starting next wave of rollout")
+				rollout.Wave++
+				setPhase(rollout, hivev1.ProgressingSelectorSyncSetRolloutPhase)
+				// Release the clusters of the next wave.
+				continue
+			}
+		}
+		break
+	}
+
+	sss.Status.Rollout = rollout
+	if !reflect.DeepEqual(origRollout, rollout) {
+		if err := r.updateStatus(sss, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	switch {
+	case requeueAfter > 0:
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	case rollout.Phase == hivev1.CompleteSelectorSyncSetRolloutPhase, rollout.Phase == hivev1.HaltedSelectorSyncSetRolloutPhase:
+		// Complete and halted rollouts only change with a new generation of the SelectorSyncSet.
+		return reconcile.Result{}, nil
+	default:
+		return reconcile.Result{RequeueAfter: resyncInterval}, nil
+	}
+}
+
+// getRolloutTargets returns the clusters to which the SelectorSyncSet is to be rolled out, ordered by their rollout
+// keys. These are the clusters matching the SelectorSyncSet that the clustersync controller would sync.
+func (r *ReconcileSelectorSyncSetRollout) getRolloutTargets(sss *hivev1.SelectorSyncSet, logger log.FieldLogger) ([]rolloutTarget, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&sss.Spec.ClusterDeploymentSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cluster deployment selector")
+	}
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.Background(), cdList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments")
+		return nil, err
+	}
+	var targets []rolloutTarget
+	for i := range cdList.Items {
+		cd := &cdList.Items[i]
+		if cd.DeletionTimestamp != nil || !cd.Spec.Installed {
+			continue
+		}
+		if controllerutils.IsClusterPausedOrRelocating(cd, logger) {
+			continue
+		}
+		if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
+			continue
+		}
+		targets = append(targets, rolloutTarget{
+			key:  controllerutils.SelectorSyncSetRolloutKey(sss.Name, cd.Namespace, cd.Name),
+			name: types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name},
+		})
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].key != targets[j].key {
+			return targets[i].key < targets[j].key
+		}
+		return targets[i].name.String() < targets[j].name.String()
+	})
+	return targets, nil
+}
+
+// countResults counts the released clusters that have successfully applied the current generation of the
+// SelectorSyncSet, or dry-run it when it is in dry-run mode, and the clusters in the current wave that have failed to
+// apply it or have not yet applied it.
+func (r *ReconcileSelectorSyncSetRollout) countResults(sss *hivev1.SelectorSyncSet, released, currentWave []rolloutTarget, logger log.FieldLogger) (updated, failed, pending int, err error) {
+	inCurrentWave := make(map[types.NamespacedName]bool, len(currentWave))
+	for _, t := range currentWave {
+		inCurrentWave[t.name] = true
+	}
+	for _, t := range released {
+		result, err := r.getSyncResult(sss, t.name, logger)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		switch {
+		case result == hiveintv1alpha1.SuccessSyncSetResult, result == hiveintv1alpha1.DryRunSyncSetResult:
+			updated++
+		case !inCurrentWave[t.name]:
+		case result == hiveintv1alpha1.FailureSyncSetResult:
+			failed++
+		default:
+			pending++
+		}
+	}
+	return
+}
+
+// getSyncResult returns the result of applying the current generation of the SelectorSyncSet to the cluster, or the
+// empty string if the cluster has not yet applied it. When the SelectorSyncSet has health checks, applying it only
+// succeeds once the health checks have passed, and fails if they did not pass in time.
+func (r *ReconcileSelectorSyncSetRollout) getSyncResult(sss *hivev1.SelectorSyncSet, cluster types.NamespacedName, logger log.FieldLogger) (hiveintv1alpha1.SyncSetResult, error) {
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	switch err := r.Get(context.Background(), cluster, clusterSync); {
+	case apierrors.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		logger.WithError(err).WithField("cluster", cluster).Log(controllerutils.LogLevel(err), "could not get ClusterSync")
+		return "", err
+	}
+	for _, status := range clusterSync.Status.SelectorSyncSets {
+		if status.Name != sss.Name || status.ObservedGeneration != sss.Generation {
+			continue
+		}
+		if status.Result != hiveintv1alpha1.SuccessSyncSetResult || len(sss.Spec.HealthChecks) == 0 || sss.Spec.DryRun {
+			return status.Result, nil
+		}
+		switch {
+		case status.HealthCheck == nil, status.HealthCheck.Phase == hiveintv1alpha1.PendingSyncHealthCheckPhase:
+			return "", nil
+		case status.HealthCheck.Phase == hiveintv1alpha1.HealthySyncHealthCheckPhase:
+			return hiveintv1alpha1.SuccessSyncSetResult, nil
+		default:
+			return hiveintv1alpha1.FailureSyncSetResult, nil
+		}
+	}
+	return "", nil
+}
+
+func (r *ReconcileSelectorSyncSetRollout) updateStatus(sss *hivev1.SelectorSyncSet, logger log.FieldLogger) error {
+	if err := r.Status().Update(context.Background(), sss); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update selector syncset status")
+		return err
+	}
+	return nil
+}
+
+func resolveWaveSize(waveSize intstr.IntOrString, targets int) int {
+	size, err := intstr.GetScaledValueFromIntOrPercent(&waveSize, targets, true)
+	if err != nil || size < 1 {
+		return 1
+	}
+	return size
+}
+
+func setPhase(rollout *hivev1.SelectorSyncSetRolloutStatus, phase hivev1.SelectorSyncSetRolloutPhase) {
+	if rollout.Phase == phase {
+		return
+	}
+	now := metav1.Now()
+	rollout.Phase = phase
+	rollout.LastTransitionTime = &now
+}
@@ -0,0 +1,560 @@
+package selectorsyncsetrollout
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testsss "github.com/openshift/hive/pkg/test/selectorsyncset"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	testName      = "test-sss"
+	testNamespace = "test-namespace"
+	testLabel     = "rollout"
+)
+
+func TestReconcileSelectorSyncSetRollout(t *testing.T) {
+	scheme := scheme.GetScheme()
+
+	// The clusters in the order in which the rollout reaches them
+	clusters := []string{"cd1", "cd2", "cd3", "cd4"}
+	sort.Slice(clusters, func(i, j int) bool {
+		return controllerutils.SelectorSyncSetRolloutKey(testName, testNamespace, clusters[i]) <
+			controllerutils.SelectorSyncSetRolloutKey(testName, testNamespace, clusters[j])
+	})
+	keyOf := func(i int) int64 {
+		return controllerutils.SelectorSyncSetRolloutKey(testName, testNamespace, clusters[i])
+	}
+
+	cd := func(name string, opts ...testcd.Option) runtime.Object {
+		return testcd.FullBuilder(testNamespace, name, scheme).
+			GenericOptions(testgeneric.WithLabel(testLabel, "true")).
+			Build(append([]testcd.Option{
+				testcd.Installed(),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.UnreachableCondition,
+					Status: corev1.ConditionFalse,
+				}),
+			}, opts...)...)
+	}
+	allClusters := func() []runtime.Object {
+		objs := make([]runtime.Object, len(clusters))
+		for i, name := range clusters {
+			objs[i] = cd(name)
+		}
+		return objs
+	}
+	clusterSync := func(i int, generation int64, result hiveintv1alpha1.SyncSetResult) runtime.Object {
+		return testcs.FullBuilder(testNamespace, clusters[i], scheme).Build(
+			testcs.WithSelectorSyncSetStatus(hiveintv1alpha1.SyncStatus{
+				Name:               testName,
+				ObservedGeneration: generation,
+				Result:             result,
+			}),
+		)
+	}
+	healthCheckedClusterSync := func(i int, phase hiveintv1alpha1.SyncHealthCheckPhase) runtime.Object {
+		return testcs.FullBuilder(testNamespace, clusters[i], scheme).Build(
+			testcs.WithSelectorSyncSetStatus(hiveintv1alpha1.SyncStatus{
+				Name:               testName,
+				ObservedGeneration: 2,
+				Result:             hiveintv1alpha1.SuccessSyncSetResult,
+				HealthCheck:        &hiveintv1alpha1.SyncHealthCheckStatus{Phase: phase},
+			}),
+		)
+	}
+	withHealthChecks := testsss.WithHealthChecks(hivev1.SyncSetHealthCheck{
+		APIVersion:    "apps/v1",
+		Kind:          "Deployment",
+		Name:          "app",
+		ConditionType: "Available",
+	})
+	strategy := func(pause time.Duration, maxFailures int32) *hivev1.SelectorSyncSetRolloutStrategy {
+		s := &hivev1.SelectorSyncSetRolloutStrategy{
+			WaveSize:           intstr.FromString("50%"),
+			MaxFailuresPerWave: maxFailures,
+		}
+		if pause > 0 {
+			s.PauseBetweenWaves = &metav1.Duration{Duration: pause}
+		}
+		return s
+	}
+	transitionedAgo := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(time.Now().Add(-d))
+		return &t
+	}
+
+	cases := []struct {
+		name             string
+		strategy         *hivev1.SelectorSyncSetRolloutStrategy
+		sssOptions       []testsss.Option
+		existingRollout  *hivev1.SelectorSyncSetRolloutStatus
+		existing         []runtime.Object
+		expectedRollout  *hivev1.SelectorSyncSetRolloutStatus
+		expectedMessage  string
+		expectRequeueMin time.Duration
+		expectNoRequeue  bool
+	}{
+		{
+			name:     "no rollout strategy",
+			existing: allClusters(),
+		},
+		{
+			name: "rollout strategy removed",
+			existingRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+			},
+			existing: allClusters(),
+		},
+		{
+			name:     "new rollout",
+			strategy: strategy(0, 0),
+			existing: allClusters(),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				TotalWaves:         2,
+				TargetClusters:     4,
+				ReleasedClusters:   2,
+				ReleasedKeyLimit:   keyOf(1),
+			},
+			expectedMessage: "Wave 1 of 2: waiting for 2 of 2 clusters to apply",
+		},
+		{
+			name:     "new generation restarts rollout",
+			strategy: strategy(0, 0),
+			existingRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              hivev1.CompleteSelectorSyncSetRolloutPhase,
+				Wave:               2,
+			},
+			existing: append(allClusters(), clusterSync(0, 1, hiveintv1alpha1.SuccessSyncSetResult)),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				TotalWaves:         2,
+				TargetClusters:     4,
+				ReleasedClusters:   2,
+				ReleasedKeyLimit:   keyOf(1),
+			},
+		},
+		{
+			name:     "wave in progress",
+			strategy: strategy(0, 0),
+			existingRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				LastTransitionTime: transitionedAgo(time.Minute),
+			},
+			existing: append(allClusters(), clusterSync(0, 2, hiveintv1alpha1.SuccessSyncSetResult)),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				TotalWaves:         2,
+				TargetClusters:     4,
+				ReleasedClusters:   2,
+				UpdatedClusters:    1,
+				ReleasedKeyLimit:   keyOf(1),
+			},
+			expectedMessage: "Wave 1 of 2: waiting for 1 of 2 clusters to apply",
+		},
+		{
+			name:     "next wave without pause",
+			strategy: strategy(0, 0),
+			existingRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				LastTransitionTime: transitionedAgo(time.Minute),
+			},
+			existing: append(allClusters(),
+				clusterSync(0, 2, hiveintv1alpha1.SuccessSyncSetResult),
+				clusterSync(1, 2, hiveintv1alpha1.SuccessSyncSetResult),
+			),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               2,
+				TotalWaves:         2,
+				TargetClusters:     4,
+				ReleasedClusters:   4,
+				UpdatedClusters:    2,
+				ReleasedKeyLimit:   keyOf(3),
+			},
+		},
+		{
+			name:     "pause between waves",
+			strategy: strategy(time.Hour, 0),
+			existingRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				LastTransitionTime: transitionedAgo(time.Minute),
+			},
+			existing: append(allClusters(),
+				clusterSync(0, 2, hiveintv1alpha1.SuccessSyncSetResult),
+				clusterSync(1, 2, hiveintv1alpha1.SuccessSyncSetResult),
+			),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.WaitingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				TotalWaves:         2,
+				TargetClusters:     4,
+				ReleasedClusters:   2,
+				UpdatedClusters:    2,
+				ReleasedKeyLimit:   keyOf(1),
+			},
+			expectRequeueMin: 59 * time.Minute,
+		},
+		{
+			name:     "pause elapsed",
+			strategy: strategy(time.Hour, 0),
+			existingRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.WaitingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				LastTransitionTime: transitionedAgo(2 * time.Hour),
+			},
+			existing: append(allClusters(),
+				clusterSync(0, 2, hiveintv1alpha1.SuccessSyncSetResult),
+				clusterSync(1, 2, hiveintv1alpha1.SuccessSyncSetResult),
+			),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               2,
+				TotalWaves:         2,
+				TargetClusters:     4,
+				ReleasedClusters:   4,
+				UpdatedClusters:    2,
+				ReleasedKeyLimit:   keyOf(3),
+			},
+		},
+		{
+			name:     "halt on failure",
+			strategy: strategy(0, 0),
+			existingRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				LastTransitionTime: transitionedAgo(time.Minute),
+			},
+			existing: append(allClusters(),
+				clusterSync(0, 2, hiveintv1alpha1.FailureSyncSetResult),
+			),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.HaltedSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				TotalWaves:         2,
+				TargetClusters:     4,
+				ReleasedClusters:   2,
+				FailedClusters:     1,
+				ReleasedKeyLimit:   keyOf(1),
+			},
+			expectedMessage: "Halted in wave 1 of 2: 1 clusters failed to apply, more than the 0 allowed",
+			expectNoRequeue: true,
+		},
+		{
+			name:       "wave waits for health checks",
+			strategy:   strategy(0, 0),
+			sssOptions: []testsss.Option{withHealthChecks},
+			existingRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				LastTransitionTime: transitionedAgo(time.Minute),
+			},
+			existing: append(allClusters(),
+				healthCheckedClusterSync(0, hiveintv1alpha1.HealthySyncHealthCheckPhase),
+				healthCheckedClusterSync(1, hiveintv1alpha1.PendingSyncHealthCheckPhase),
+			),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				TotalWaves:         2,
+				TargetClusters:     4,
+				ReleasedClusters:   2,
+				UpdatedClusters:    1,
+				ReleasedKeyLimit:   keyOf(1),
+			},
+			expectedMessage: "Wave 1 of 2: waiting for 1 of 2 clusters to apply",
+		},
+		{
+			name:       "halt on failed health checks",
+			strategy:   strategy(0, 0),
+			sssOptions: []testsss.Option{withHealthChecks},
+			existingRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				LastTransitionTime: transitionedAgo(time.Minute),
+			},
+			existing: append(allClusters(),
+				healthCheckedClusterSync(0, hiveintv1alpha1.HealthySyncHealthCheckPhase),
+				healthCheckedClusterSync(1, hiveintv1alpha1.RolledBackSyncHealthCheckPhase),
+			),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.HaltedSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				TotalWaves:         2,
+				TargetClusters:     4,
+				ReleasedClusters:   2,
+				UpdatedClusters:    1,
+				FailedClusters:     1,
+				ReleasedKeyLimit:   keyOf(1),
+			},
+			expectNoRequeue: true,
+		},
+		{
+			name:     "halt on invalid selector",
+			strategy: strategy(0, 0),
+			sssOptions: []testsss.Option{func(sss *hivev1.SelectorSyncSet) {
+				sss.Spec.ClusterDeploymentSelector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: testLabel, Operator: "Bogus"}}
+			}},
+			existing: allClusters(),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.HaltedSelectorSyncSetRolloutPhase,
+				Wave:               1,
+			},
+			expectedMessage: `Halted: invalid clusterDeploymentSelector: "Bogus" is not a valid label selector operator`,
+			expectNoRequeue: true,
+		},
+		{
+			name:     "tolerated failure",
+			strategy: strategy(0, 1),
+			existingRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				LastTransitionTime: transitionedAgo(time.Minute),
+			},
+			existing: append(allClusters(),
+				clusterSync(0, 2, hiveintv1alpha1.FailureSyncSetResult),
+				clusterSync(1, 2, hiveintv1alpha1.SuccessSyncSetResult),
+			),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               2,
+				TotalWaves:         2,
+				TargetClusters:     4,
+				ReleasedClusters:   4,
+				UpdatedClusters:    1,
+				ReleasedKeyLimit:   keyOf(3),
+			},
+		},
+		{
+			name:     "halted rollout stays halted",
+			strategy: strategy(0, 0),
+			existingRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.HaltedSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				LastTransitionTime: transitionedAgo(time.Minute),
+				Message:            "halted",
+			},
+			existing: append(allClusters(),
+				clusterSync(0, 2, hiveintv1alpha1.SuccessSyncSetResult),
+				clusterSync(1, 2, hiveintv1alpha1.SuccessSyncSetResult),
+			),
+			// The results of the clusters are not read again.
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.HaltedSelectorSyncSetRolloutPhase,
+				Wave:               1,
+			},
+			expectedMessage: "halted",
+			expectNoRequeue: true,
+		},
+		{
+			name:     "complete rollout is not recounted",
+			strategy: strategy(0, 0),
+			existingRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.CompleteSelectorSyncSetRolloutPhase,
+				Wave:               2,
+				TotalWaves:         2,
+				TargetClusters:     3,
+				ReleasedClusters:   3,
+				UpdatedClusters:    3,
+				ReleasedKeyLimit:   keyOf(2),
+				LastTransitionTime: transitionedAgo(time.Minute),
+				Message:            "Rolled out to all 3 clusters",
+			},
+			existing: append(allClusters(),
+				clusterSync(3, 1, hiveintv1alpha1.SuccessSyncSetResult),
+			),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.CompleteSelectorSyncSetRolloutPhase,
+				Wave:               2,
+				TotalWaves:         2,
+				TargetClusters:     3,
+				ReleasedClusters:   3,
+				UpdatedClusters:    3,
+				ReleasedKeyLimit:   keyOf(2),
+			},
+			expectedMessage: "Rolled out to all 3 clusters",
+			expectNoRequeue: true,
+		},
+		{
+			name:     "rollout complete",
+			strategy: strategy(0, 0),
+			existingRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               2,
+				LastTransitionTime: transitionedAgo(time.Minute),
+			},
+			existing: append(allClusters(),
+				clusterSync(0, 2, hiveintv1alpha1.SuccessSyncSetResult),
+				clusterSync(1, 2, hiveintv1alpha1.SuccessSyncSetResult),
+				clusterSync(2, 2, hiveintv1alpha1.SuccessSyncSetResult),
+				clusterSync(3, 2, hiveintv1alpha1.SuccessSyncSetResult),
+			),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.CompleteSelectorSyncSetRolloutPhase,
+				Wave:               2,
+				TotalWaves:         2,
+				TargetClusters:     4,
+				ReleasedClusters:   4,
+				UpdatedClusters:    4,
+				ReleasedKeyLimit:   keyOf(3),
+			},
+			expectedMessage: "Rolled out to all 4 clusters",
+		},
+		{
+			name:     "only installed, reachable clusters are targeted",
+			strategy: strategy(0, 0),
+			existing: []runtime.Object{
+				cd(clusters[0]),
+				cd(clusters[1], testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.UnreachableCondition,
+					Status: corev1.ConditionTrue,
+				})),
+				cd(clusters[2], func(cd *hivev1.ClusterDeployment) { cd.Spec.Installed = false }),
+				cd(clusters[3]),
+				testcd.FullBuilder(testNamespace, "unlabeled", scheme).Build(testcd.Installed()),
+			},
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Wave:               1,
+				TotalWaves:         2,
+				TargetClusters:     2,
+				ReleasedClusters:   1,
+				ReleasedKeyLimit:   keyOf(0),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sss := testsss.FullBuilder(testName, scheme).Build(append([]testsss.Option{
+				testsss.WithGeneration(2),
+				testsss.WithLabelSelector(testLabel, "true"),
+				testsss.WithRolloutStrategy(tc.strategy),
+				testsss.WithRolloutStatus(tc.existingRollout),
+			}, tc.sssOptions...)...)
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(append(tc.existing, sss)...).Build()
+			r := &ReconcileSelectorSyncSetRollout{
+				Client: c,
+				logger: log.WithField("controller", ControllerName),
+			}
+			result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: testName}})
+			require.NoError(t, err, "unexpected error from reconcile")
+			if tc.expectRequeueMin > 0 {
+				assert.GreaterOrEqual(t, result.RequeueAfter, tc.expectRequeueMin, "unexpected requeue")
+			}
+			if tc.expectNoRequeue {
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue")
+			}
+
+			actual := &hivev1.SelectorSyncSet{}
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: testName}, actual))
+			if tc.expectedRollout == nil {
+				assert.Nil(t, actual.Status.Rollout, "expected no rollout status")
+				return
+			}
+			rollout := actual.Status.Rollout
+			if assert.NotNil(t, rollout, "expected rollout status") {
+				assert.NotNil(t, rollout.LastTransitionTime, "expected last transition time")
+				if tc.expectedMessage != "" {
+					assert.Equal(t, tc.expectedMessage, rollout.Message, "unexpected message")
+				}
+				rollout.LastTransitionTime = nil
+				rollout.Message = ""
+				assert.Equal(t, tc.expectedRollout, rollout, "unexpected rollout status")
+			}
+		})
+	}
+}
+
+func TestRequestsForClusterSync(t *testing.T) {
+	scheme := scheme.GetScheme()
+	sss := func(name string, strategy bool, rolloutGeneration int64, phase hivev1.SelectorSyncSetRolloutPhase) runtime.Object {
+		opts := []testsss.Option{testsss.WithGeneration(2)}
+		if strategy {
+			opts = append(opts, testsss.WithRolloutStrategy(&hivev1.SelectorSyncSetRolloutStrategy{WaveSize: intstr.FromInt(1)}))
+		}
+		if phase != "" {
+			opts = append(opts, testsss.WithRolloutStatus(&hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: rolloutGeneration,
+				Phase:              phase,
+			}))
+		}
+		return testsss.FullBuilder(name, scheme).Build(opts...)
+	}
+	existing := []runtime.Object{
+		sss("progressing", true, 2, hivev1.ProgressingSelectorSyncSetRolloutPhase),
+		sss("waiting", true, 2, hivev1.WaitingSelectorSyncSetRolloutPhase),
+		sss("complete", true, 2, hivev1.CompleteSelectorSyncSetRolloutPhase),
+		sss("halted", true, 2, hivev1.HaltedSelectorSyncSetRolloutPhase),
+		sss("new-generation", true, 1, hivev1.CompleteSelectorSyncSetRolloutPhase),
+		sss("no-strategy", false, 0, ""),
+	}
+	clusterSync := testcs.FullBuilder(testNamespace, "cd1", scheme).Build()
+	for _, name := range []string{"progressing", "waiting", "complete", "halted", "new-generation", "no-strategy", "deleted"} {
+		clusterSync.Status.SelectorSyncSets = append(clusterSync.Status.SelectorSyncSets, hiveintv1alpha1.SyncStatus{Name: name})
+	}
+	c := testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build()
+
+	requests := requestsForClusterSync(c, log.WithField("controller", ControllerName))(context.Background(), clusterSync)
+
+	var names []string
+	for _, r := range requests {
+		names = append(names, r.Name)
+	}
+	assert.ElementsMatch(t, []string{"progressing", "waiting", "new-generation"}, names, "unexpected SelectorSyncSets enqueued")
+}
@@ -177,3 +177,15 @@ func GCPNetworkProjectID(cd *hivev1.ClusterDeployment) *string {
 	// may still be nil
 	return cd.Spec.ClusterMetadata.Platform.GCP.NetworkProjectID
 }
+
+// SetClusterRegion sets the region of an AWS, Azure or GCP ClusterDeployment.
+func SetClusterRegion(cd *hivev1.ClusterDeployment, region string) {
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		cd.Spec.Platform.AWS.Region = region
+	case cd.Spec.Platform.Azure != nil:
+		cd.Spec.Platform.Azure.Region = region
+	case cd.Spec.Platform.GCP != nil:
+		cd.Spec.Platform.GCP.Region = region
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"strconv"
+	"strings"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
@@ -36,3 +37,27 @@ func MarkClusterForRemoval(cd *hivev1.ClusterDeployment) {
 	}
 	cd.Annotations[constants.RemovePoolClusterAnnotation] = "true"
 }
+
+// protectedPatchPaths are the fields of the objects generated for a ClusterPool that ClusterDeploymentCustomization
+// patches must not change, mapped to whether their descendants are protected too. The labels and annotations maps
+// cannot be replaced, as the pool sets entries of its own in them, but their entries can be patched.
+var protectedPatchPaths = map[string]bool{
+	"/metadata/name":        true,
+	"/metadata/namespace":   true,
+	"/metadata/labels":      false,
+	"/metadata/annotations": false,
+	"/spec/clusterPoolRef":  true,
+}
+
+// IsProtectedPatchPath returns true if a ClusterDeploymentCustomization patch of the JSON pointer path would change
+// the name, namespace or clusterPoolRef of the object, or replace its labels or annotations as a whole. Patches of the
+// ancestors of these fields, such as /metadata, are protected as well.
+func IsProtectedPatchPath(path string) bool {
+	for protected, descendants := range protectedPatchPaths {
+		if path == protected || strings.HasPrefix(protected, path+"/") ||
+			(descendants && strings.HasPrefix(path, protected+"/")) {
+			return true
+		}
+	}
+	return false
+}
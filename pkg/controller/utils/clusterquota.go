@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// ClusterQuotaNamespace returns the namespace against which a ClusterDeployment is counted by ClusterQuotas. Clusters
+// belonging to a ClusterPool are counted against the namespace of the pool.
+func ClusterQuotaNamespace(cd *hivev1.ClusterDeployment) string {
+	if cd.Spec.ClusterPoolRef != nil && cd.Spec.ClusterPoolRef.Namespace != "" {
+		return cd.Spec.ClusterPoolRef.Namespace
+	}
+	return cd.Namespace
+}
+
+// IsRunningForClusterQuota returns true if the ClusterDeployment is counted as running by ClusterQuotas.
+// A ClusterDeployment with no PowerState is running.
+func IsRunningForClusterQuota(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.PowerState != hivev1.ClusterPowerStateHibernating
+}
+
+// ClusterPoolQuotaSize returns the size of a ClusterPool as counted by ClusterQuotas. For pools with Autoscaling
+// configured this is the autoscaling MaxSize, as that is how large the pool may grow without further changes.
+func ClusterPoolQuotaSize(pool *hivev1.ClusterPool) int32 {
+	if pool.Spec.Autoscaling != nil {
+		return pool.Spec.Autoscaling.MaxSize
+	}
+	return pool.Spec.Size
+}
+
+// ClusterQuotaConsumption is the current usage of a ClusterQuota.
+type ClusterQuotaConsumption struct {
+	Used hivev1.ClusterQuotaUsage
+	// Namespaces are the names of the namespaces selected by the quota.
+	Namespaces sets.String
+	// Pools are the ClusterPools in the selected namespaces, oldest first.
+	Pools []*hivev1.ClusterPool
+}
+
+// GetClusterQuotasForNamespace returns the ClusterQuotas whose NamespaceSelector selects the given namespace.
+func GetClusterQuotasForNamespace(c client.Client, namespace string) ([]*hivev1.ClusterQuota, error) {
+	quotaList := &hivev1.ClusterQuotaList{}
+	if err := c.List(context.Background(), quotaList); err != nil {
+		return nil, err
+	}
+	if len(quotaList.Items) == 0 {
+		return nil, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: namespace}, ns); err != nil {
+		return nil, err
+	}
+	var quotas []*hivev1.ClusterQuota
+	for i := range quotaList.Items {
+		quota := &quotaList.Items[i]
+		selector, err := metav1.LabelSelectorAsSelector(&quota.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace selector in ClusterQuota %s: %w", quota.Name, err)
+		}
+		if selector.Matches(labels.Set(ns.Labels)) {
+			quotas = append(quotas, quota)
+		}
+	}
+	return quotas, nil
+}
+
+// GetClusterQuotaConsumption computes the current usage of a ClusterQuota across the namespaces it selects.
+func GetClusterQuotaConsumption(c client.Client, quota *hivev1.ClusterQuota) (*ClusterQuotaConsumption, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&quota.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace selector in ClusterQuota %s: %w", quota.Name, err)
+	}
+	nsList := &corev1.NamespaceList{}
+	if err := c.List(context.Background(), nsList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	consumption := &ClusterQuotaConsumption{Namespaces: sets.NewString()}
+	for _, ns := range nsList.Items {
+		consumption.Namespaces.Insert(ns.Name)
+	}
+
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cdList); err != nil {
+		return nil, err
+	}
+	for i := range cdList.Items {
+		cd := &cdList.Items[i]
+		if !consumption.Namespaces.Has(ClusterQuotaNamespace(cd)) {
+			continue
+		}
+		consumption.Used.ClusterDeployments++
+		if IsRunningForClusterQuota(cd) {
+			consumption.Used.RunningClusterDeployments++
+		}
+	}
+
+	poolList := &hivev1.ClusterPoolList{}
+	if err := c.List(context.Background(), poolList); err != nil {
+		return nil, err
+	}
+	for i := range poolList.Items {
+		pool := &poolList.Items[i]
+		// A deleted pool no longer maintains its size; its remaining clusters are still counted above.
+		if pool.DeletionTimestamp != nil || !consumption.Namespaces.Has(pool.Namespace) {
+			continue
+		}
+		consumption.Used.ClusterPoolSize += ClusterPoolQuotaSize(pool)
+		consumption.Pools = append(consumption.Pools, pool)
+	}
+	sort.Slice(consumption.Pools, func(i, j int) bool {
+		pi, pj := consumption.Pools[i], consumption.Pools[j]
+		if !pi.CreationTimestamp.Equal(&pj.CreationTimestamp) {
+			return pi.CreationTimestamp.Before(&pj.CreationTimestamp)
+		}
+		if pi.Namespace != pj.Namespace {
+			return pi.Namespace < pj.Namespace
+		}
+		return pi.Name < pj.Name
+	})
+	return consumption, nil
+}
+
+// ClusterQuotaViolations returns a message for each limit of the quota that would be exceeded by adding the
+// requested amounts to the used amounts. Resources for which nothing is requested are not checked, so that usage
+// which is already over a lowered limit does not block unrelated changes.
+func ClusterQuotaViolations(quota *hivev1.ClusterQuota, used, requested hivev1.ClusterQuotaUsage) []string {
+	var violations []string
+	check := func(resource string, limit *int32, used, requested int32) {
+		if limit == nil || requested <= 0 || used+requested <= *limit {
+			return
+		}
+		violations = append(violations, fmt.Sprintf("exceeded ClusterQuota %s: requested %s=%d, used %s=%d, limited %s=%d",
+			quota.Name, resource, requested, resource, used, resource, *limit))
+	}
+	check("clusterDeployments", quota.Spec.Hard.ClusterDeployments, used.ClusterDeployments, requested.ClusterDeployments)
+	check("runningClusterDeployments", quota.Spec.Hard.RunningClusterDeployments, used.RunningClusterDeployments, requested.RunningClusterDeployments)
+	check("clusterPoolSize", quota.Spec.Hard.ClusterPoolSize, used.ClusterPoolSize, requested.ClusterPoolSize)
+	return violations
+}
+
+// CheckClusterQuotas returns the violations of the ClusterQuotas selecting the given namespace that would result from
+// adding the requested amounts, joined into a single message. The message is empty if no quota would be exceeded.
+// The usage of each quota is counted from the current ClusterDeployments and ClusterPools rather than taken from its
+// status, which may lag behind changes that have not yet been reconciled. Nothing is written: the status of a quota is
+// owned by the clusterquota controller.
+func CheckClusterQuotas(c client.Client, namespace string, requested hivev1.ClusterQuotaUsage) (string, error) {
+	if requested.ClusterDeployments <= 0 && requested.RunningClusterDeployments <= 0 && requested.ClusterPoolSize <= 0 {
+		return "", nil
+	}
+	quotas, err := GetClusterQuotasForNamespace(c, namespace)
+	if err != nil {
+		return "", err
+	}
+	var violations []string
+	for _, quota := range quotas {
+		consumption, err := GetClusterQuotaConsumption(c, quota)
+		if err != nil {
+			return "", err
+		}
+		violations = append(violations, ClusterQuotaViolations(quota, consumption.Used, requested)...)
+	}
+	return strings.Join(violations, "; "), nil
+}
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+)
+
+func TestClusterQuotaViolations(t *testing.T) {
+	quota := &hivev1.ClusterQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-quota"},
+		Spec: hivev1.ClusterQuotaSpec{
+			Hard: hivev1.ClusterQuotaLimits{
+				ClusterDeployments:        pointer.Int32Ptr(5),
+				RunningClusterDeployments: pointer.Int32Ptr(2),
+			},
+		},
+	}
+	cases := []struct {
+		name               string
+		used               hivev1.ClusterQuotaUsage
+		requested          hivev1.ClusterQuotaUsage
+		expectedViolations []string
+	}{
+		{
+			name:      "within quota",
+			used:      hivev1.ClusterQuotaUsage{ClusterDeployments: 4, RunningClusterDeployments: 1},
+			requested: hivev1.ClusterQuotaUsage{ClusterDeployments: 1, RunningClusterDeployments: 1},
+		},
+		{
+			name:      "exceeds quota",
+			used:      hivev1.ClusterQuotaUsage{ClusterDeployments: 5, RunningClusterDeployments: 2},
+			requested: hivev1.ClusterQuotaUsage{ClusterDeployments: 1, RunningClusterDeployments: 1},
+			expectedViolations: []string{
+				"exceeded ClusterQuota test-quota: requested clusterDeployments=1, used clusterDeployments=5, limited clusterDeployments=5",
+				"exceeded ClusterQuota test-quota: requested runningClusterDeployments=1, used runningClusterDeployments=2, limited runningClusterDeployments=2",
+			},
+		},
+		{
+			name:      "over quota but nothing requested",
+			used:      hivev1.ClusterQuotaUsage{ClusterDeployments: 7, RunningClusterDeployments: 3},
+			requested: hivev1.ClusterQuotaUsage{},
+		},
+		{
+			name:      "unlimited resource",
+			used:      hivev1.ClusterQuotaUsage{ClusterPoolSize: 100},
+			requested: hivev1.ClusterQuotaUsage{ClusterPoolSize: 10},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedViolations, ClusterQuotaViolations(quota, tc.used, tc.requested))
+		})
+	}
+}
+
+func TestGetClusterQuotasForNamespace(t *testing.T) {
+	quota := func(name string, selector metav1.LabelSelector) *hivev1.ClusterQuota {
+		return &hivev1.ClusterQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       hivev1.ClusterQuotaSpec{NamespaceSelector: selector},
+		}
+	}
+	c := testfake.NewFakeClientBuilder().WithRuntimeObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace", Labels: map[string]string{"tenant": "a"}}},
+		quota("all", metav1.LabelSelector{}),
+		quota("tenant-a", metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}}),
+		quota("tenant-b", metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "b"}}),
+	).Build()
+
+	quotas, err := GetClusterQuotasForNamespace(c, "test-namespace")
+	require.NoError(t, err, "unexpected error getting quotas")
+	var names []string
+	for _, q := range quotas {
+		names = append(names, q.Name)
+	}
+	assert.ElementsMatch(t, []string{"all", "tenant-a"}, names, "unexpected quotas")
+}
+
+func TestCheckClusterQuotas(t *testing.T) {
+	newClient := func() client.Client {
+		return testfake.NewFakeClientBuilder().WithRuntimeObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace"}},
+			&hivev1.ClusterQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-quota"},
+				Spec: hivev1.ClusterQuotaSpec{
+					Hard: hivev1.ClusterQuotaLimits{ClusterDeployments: pointer.Int32Ptr(2)},
+				},
+			},
+			&hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "cd1"}},
+		).Build()
+	}
+	oneCD := hivev1.ClusterQuotaUsage{ClusterDeployments: 1}
+
+	t.Run("usage is counted rather than taken from the status", func(t *testing.T) {
+		c := newClient()
+		require.NoError(t, c.Create(context.Background(),
+			&hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "cd2"}}))
+		message, err := CheckClusterQuotas(c, "test-namespace", oneCD)
+		require.NoError(t, err, "unexpected error checking quotas")
+		assert.Contains(t, message, "exceeded ClusterQuota test-quota", "expected quota to be exceeded")
+	})
+
+	t.Run("quota status is not written", func(t *testing.T) {
+		c := newClient()
+		message, err := CheckClusterQuotas(c, "test-namespace", oneCD)
+		require.NoError(t, err, "unexpected error checking quotas")
+		assert.Empty(t, message, "unexpected quota violation")
+		quota := &hivev1.ClusterQuota{}
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "test-quota"}, quota))
+		assert.Zero(t, quota.Status.Used, "expected quota status to be left to the controller")
+	})
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// ClusterOperatorHealth reports whether a cluster operator, as recorded in a ClusterState, is Degraded, not Available,
+// or Progressing. An operator that does not report a condition is not counted as unhealthy for that condition.
+func ClusterOperatorHealth(op *hivev1.ClusterOperatorState) (degraded, unavailable, progressing bool) {
+	for _, cond := range op.Conditions {
+		switch cond.Type {
+		case configv1.OperatorDegraded:
+			degraded = cond.Status == configv1.ConditionTrue
+		case configv1.OperatorAvailable:
+			unavailable = cond.Status == configv1.ConditionFalse
+		case configv1.OperatorProgressing:
+			progressing = cond.Status == configv1.ConditionTrue
+		}
+	}
+	return
+}
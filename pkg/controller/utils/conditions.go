@@ -704,6 +704,98 @@ func SetClusterInstallConditionWithChangeCheck(
 	return conditions, changed
 }
 
+// SetClusterImageSetConditionWithChangeCheck sets a condition on a ClusterImageSet resource's status.
+// It returns the conditions as well a boolean indicating whether there was a change made
+// to the conditions.
+func SetClusterImageSetConditionWithChangeCheck(
+	conditions []hivev1.ClusterImageSetCondition,
+	conditionType hivev1.ClusterImageSetConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) ([]hivev1.ClusterImageSetCondition, bool) {
+	changed := false
+	now := metav1.Now()
+	existingCondition := FindCondition(conditions, conditionType)
+	if existingCondition == nil {
+		conditions = append(
+			conditions,
+			hivev1.ClusterImageSetCondition{
+				Type:               conditionType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: now,
+				LastProbeTime:      now,
+			},
+		)
+		changed = true
+	} else {
+		if shouldUpdateCondition(
+			existingCondition.Status, existingCondition.Reason, existingCondition.Message,
+			status, reason, message,
+			updateConditionCheck,
+		) {
+			if existingCondition.Status != status {
+				existingCondition.LastTransitionTime = now
+			}
+			existingCondition.Status = status
+			existingCondition.Reason = reason
+			existingCondition.Message = message
+			existingCondition.LastProbeTime = now
+			changed = true
+		}
+	}
+	return conditions, changed
+}
+
+// SetClusterUpgradeConditionWithChangeCheck sets a condition on a ClusterUpgrade resource's status.
+// It returns the conditions as well a boolean indicating whether there was a change made
+// to the conditions.
+func SetClusterUpgradeConditionWithChangeCheck(
+	conditions []hivev1.ClusterUpgradeCondition,
+	conditionType hivev1.ClusterUpgradeConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) ([]hivev1.ClusterUpgradeCondition, bool) {
+	changed := false
+	now := metav1.Now()
+	existingCondition := FindCondition(conditions, conditionType)
+	if existingCondition == nil {
+		conditions = append(
+			conditions,
+			hivev1.ClusterUpgradeCondition{
+				Type:               conditionType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: now,
+				LastProbeTime:      now,
+			},
+		)
+		changed = true
+	} else {
+		if shouldUpdateCondition(
+			existingCondition.Status, existingCondition.Reason, existingCondition.Message,
+			status, reason, message,
+			updateConditionCheck,
+		) {
+			if existingCondition.Status != status {
+				existingCondition.LastTransitionTime = now
+			}
+			existingCondition.Status = status
+			existingCondition.Reason = reason
+			existingCondition.Message = message
+			existingCondition.LastProbeTime = now
+			changed = true
+		}
+	}
+	return conditions, changed
+}
+
 func FindCondition[C hivev1.Condition, T hivev1.ConditionType](conditions []C, conditionType T) *C {
 	for i, condition := range conditions {
 		if condition.ConditionType().String() == conditionType.String() {
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	// Embed the time zone database so schedules can be evaluated regardless of the image's tzdata.
+	_ "time/tzdata"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// hibernationScheduleHorizonDays is the number of days on either side of "now" for which we expand
+// HibernationSchedule windows. Windows recur weekly and may span midnight, so a little over a week in
+// each direction is enough to find the previous and next transitions.
+const hibernationScheduleHorizonDays = 8
+
+// HibernationScheduleTransition is a point in time at which a HibernationSchedule changes the desired PowerState.
+type HibernationScheduleTransition struct {
+	Time       time.Time
+	PowerState hivev1.ClusterPowerState
+}
+
+type runInterval struct {
+	start, end time.Time
+}
+
+// ValidateHibernationSchedule returns an error if the HibernationSchedule cannot be evaluated.
+func ValidateHibernationSchedule(sched *hivev1.HibernationSchedule) error {
+	_, err := expandHibernationSchedule(sched, time.Now())
+	return err
+}
+
+// EvaluateHibernationSchedule returns the PowerState dictated by the HibernationSchedule at the given time, the most
+// recent transition at or before that time, and the next transition after it. The transitions are nil if the schedule
+// never changes PowerState (e.g. the windows cover the whole week).
+func EvaluateHibernationSchedule(sched *hivev1.HibernationSchedule, now time.Time) (hivev1.ClusterPowerState, *HibernationScheduleTransition, *HibernationScheduleTransition, error) {
+	intervals, err := expandHibernationSchedule(sched, now)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	powerStateAt := func(t time.Time) hivev1.ClusterPowerState {
+		for _, i := range intervals {
+			if !t.Before(i.start) && t.Before(i.end) {
+				return hivev1.ClusterPowerStateRunning
+			}
+		}
+		return hivev1.ClusterPowerStateHibernating
+	}
+
+	// The desired PowerState can only change at the edge of an interval.
+	edges := make([]time.Time, 0, 2*len(intervals))
+	for _, i := range intervals {
+		edges = append(edges, i.start, i.end)
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].Before(edges[j]) })
+
+	// Intervals near the horizon are incomplete, so only consider edges within a week of now. As the schedule
+	// repeats weekly, any transition has an occurrence within that range.
+	earliest, latest := now.AddDate(0, 0, -7), now.AddDate(0, 0, 7)
+
+	current := powerStateAt(now)
+	var last, next *HibernationScheduleTransition
+	for _, edge := range edges {
+		if edge.Before(earliest) || edge.After(latest) {
+			continue
+		}
+		state := powerStateAt(edge)
+		if state == powerStateAt(edge.Add(-time.Nanosecond)) {
+			// Overlapping or adjacent windows; not a real transition.
+			continue
+		}
+		if !edge.After(now) {
+			last = &HibernationScheduleTransition{Time: edge, PowerState: state}
+		} else if next == nil {
+			next = &HibernationScheduleTransition{Time: edge, PowerState: state}
+		}
+	}
+	return current, last, next, nil
+}
+
+// expandHibernationSchedule converts the recurring windows of the HibernationSchedule into concrete run intervals
+// surrounding the given time.
+func expandHibernationSchedule(sched *hivev1.HibernationSchedule, now time.Time) ([]runInterval, error) {
+	if sched == nil {
+		return nil, fmt.Errorf("no hibernation schedule")
+	}
+	loc := time.UTC
+	if sched.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(sched.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", sched.TimeZone, err)
+		}
+	}
+	if len(sched.RunWindows) == 0 {
+		return nil, fmt.Errorf("hibernation schedule must specify at least one run window")
+	}
+
+	now = now.In(loc)
+	var intervals []runInterval
+	for i, w := range sched.RunWindows {
+		startH, startM, err := parseTimeOfDay(w.Start)
+		if err != nil {
+			return nil, fmt.Errorf("run window %d: invalid start: %w", i, err)
+		}
+		endH, endM, err := parseTimeOfDay(w.End)
+		if err != nil {
+			return nil, fmt.Errorf("run window %d: invalid end: %w", i, err)
+		}
+		days := map[time.Weekday]bool{}
+		for _, d := range w.Days {
+			wd, ok := parseWeekday(d)
+			if !ok {
+				return nil, fmt.Errorf("run window %d: invalid day %q", i, d)
+			}
+			days[wd] = true
+		}
+		overnight := endH*60+endM <= startH*60+startM
+		for offset := -hibernationScheduleHorizonDays; offset <= hibernationScheduleHorizonDays; offset++ {
+			day := time.Date(now.Year(), now.Month(), now.Day()+offset, 0, 0, 0, 0, loc)
+			if len(days) > 0 && !days[day.Weekday()] {
+				continue
+			}
+			start := time.Date(day.Year(), day.Month(), day.Day(), startH, startM, 0, 0, loc)
+			endDay := day.Day()
+			if overnight {
+				endDay++
+			}
+			end := time.Date(day.Year(), day.Month(), endDay, endH, endM, 0, 0, loc)
+			intervals = append(intervals, runInterval{start: start, end: end})
+		}
+	}
+	return intervals, nil
+}
+
+func parseTimeOfDay(s string) (int, int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, 0, err
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+func parseWeekday(d hivev1.Weekday) (time.Weekday, bool) {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if string(d) == wd.String() {
+			return wd, true
+		}
+	}
+	return 0, false
+}
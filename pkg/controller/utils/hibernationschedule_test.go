@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestEvaluateHibernationSchedule(t *testing.T) {
+	weekdays := []hivev1.Weekday{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"}
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	cases := []struct {
+		name             string
+		schedule         *hivev1.HibernationSchedule
+		now              time.Time
+		expectPowerState hivev1.ClusterPowerState
+		expectLast       *HibernationScheduleTransition
+		expectNext       *HibernationScheduleTransition
+		expectErr        bool
+	}{
+		{
+			name: "weekday, inside window",
+			schedule: &hivev1.HibernationSchedule{
+				TimeZone:   "America/New_York",
+				RunWindows: []hivev1.HibernationRunWindow{{Days: weekdays, Start: "08:00", End: "19:00"}},
+			},
+			// Wednesday
+			now:              time.Date(2024, 5, 15, 12, 0, 0, 0, newYork),
+			expectPowerState: hivev1.ClusterPowerStateRunning,
+			expectLast: &HibernationScheduleTransition{
+				Time:       time.Date(2024, 5, 15, 8, 0, 0, 0, newYork),
+				PowerState: hivev1.ClusterPowerStateRunning,
+			},
+			expectNext: &HibernationScheduleTransition{
+				Time:       time.Date(2024, 5, 15, 19, 0, 0, 0, newYork),
+				PowerState: hivev1.ClusterPowerStateHibernating,
+			},
+		},
+		{
+			name: "weekend",
+			schedule: &hivev1.HibernationSchedule{
+				TimeZone:   "America/New_York",
+				RunWindows: []hivev1.HibernationRunWindow{{Days: weekdays, Start: "08:00", End: "19:00"}},
+			},
+			// Saturday
+			now:              time.Date(2024, 5, 18, 12, 0, 0, 0, newYork),
+			expectPowerState: hivev1.ClusterPowerStateHibernating,
+			expectLast: &HibernationScheduleTransition{
+				Time:       time.Date(2024, 5, 17, 19, 0, 0, 0, newYork),
+				PowerState: hivev1.ClusterPowerStateHibernating,
+			},
+			expectNext: &HibernationScheduleTransition{
+				Time:       time.Date(2024, 5, 20, 8, 0, 0, 0, newYork),
+				PowerState: hivev1.ClusterPowerStateRunning,
+			},
+		},
+		{
+			name: "overnight window defaults to UTC",
+			schedule: &hivev1.HibernationSchedule{
+				RunWindows: []hivev1.HibernationRunWindow{{Start: "22:00", End: "02:00"}},
+			},
+			now:              time.Date(2024, 5, 15, 1, 0, 0, 0, time.UTC),
+			expectPowerState: hivev1.ClusterPowerStateRunning,
+			expectLast: &HibernationScheduleTransition{
+				Time:       time.Date(2024, 5, 14, 22, 0, 0, 0, time.UTC),
+				PowerState: hivev1.ClusterPowerStateRunning,
+			},
+			expectNext: &HibernationScheduleTransition{
+				Time:       time.Date(2024, 5, 15, 2, 0, 0, 0, time.UTC),
+				PowerState: hivev1.ClusterPowerStateHibernating,
+			},
+		},
+		{
+			name: "overlapping windows are merged",
+			schedule: &hivev1.HibernationSchedule{
+				RunWindows: []hivev1.HibernationRunWindow{
+					{Start: "08:00", End: "12:00"},
+					{Start: "11:00", End: "17:00"},
+				},
+			},
+			now:              time.Date(2024, 5, 15, 11, 30, 0, 0, time.UTC),
+			expectPowerState: hivev1.ClusterPowerStateRunning,
+			expectLast: &HibernationScheduleTransition{
+				Time:       time.Date(2024, 5, 15, 8, 0, 0, 0, time.UTC),
+				PowerState: hivev1.ClusterPowerStateRunning,
+			},
+			expectNext: &HibernationScheduleTransition{
+				Time:       time.Date(2024, 5, 15, 17, 0, 0, 0, time.UTC),
+				PowerState: hivev1.ClusterPowerStateHibernating,
+			},
+		},
+		{
+			name: "always running",
+			schedule: &hivev1.HibernationSchedule{
+				RunWindows: []hivev1.HibernationRunWindow{{Start: "00:00", End: "00:00"}},
+			},
+			now:              time.Date(2024, 5, 15, 11, 30, 0, 0, time.UTC),
+			expectPowerState: hivev1.ClusterPowerStateRunning,
+		},
+		{
+			name: "invalid time zone",
+			schedule: &hivev1.HibernationSchedule{
+				TimeZone:   "Mars/Olympus_Mons",
+				RunWindows: []hivev1.HibernationRunWindow{{Start: "08:00", End: "19:00"}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid day",
+			schedule: &hivev1.HibernationSchedule{
+				RunWindows: []hivev1.HibernationRunWindow{{Days: []hivev1.Weekday{"Caturday"}, Start: "08:00", End: "19:00"}},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "no windows",
+			schedule:  &hivev1.HibernationSchedule{},
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			powerState, last, next, err := EvaluateHibernationSchedule(tc.schedule, tc.now)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectPowerState, powerState, "unexpected power state")
+			assertTransitionEqual(t, tc.expectLast, last, "last")
+			assertTransitionEqual(t, tc.expectNext, next, "next")
+		})
+	}
+}
+
+func assertTransitionEqual(t *testing.T, expected, actual *HibernationScheduleTransition, which string) {
+	if expected == nil {
+		assert.Nil(t, actual, "expected no %s transition", which)
+		return
+	}
+	if assert.NotNil(t, actual, "expected a %s transition", which) {
+		assert.True(t, expected.Time.Equal(actual.Time), "unexpected %s transition time: %v", which, actual.Time)
+		assert.Equal(t, expected.PowerState, actual.PowerState, "unexpected %s transition power state", which)
+	}
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// InstallLogsUploadProvider returns the provider to which the FailedProvisionConfig uploads the logs of failed
+// installations, along with the reference to the secret in the hive namespace holding its credentials, if any.
+// The provider is empty if the config uploads no logs. Only one provider should be configured; if several are, the
+// first of AWS, Azure, GCP, HTTP and PersistentVolumeClaim is used. ValidateInstallLogsUploadProviders rejects such
+// configs.
+func InstallLogsUploadProvider(fpConfig *hivev1.FailedProvisionConfig) (string, *corev1.LocalObjectReference) {
+	switch {
+	case fpConfig == nil:
+		return "", nil
+	case fpConfig.AWS != nil:
+		return constants.InstallLogsUploadProviderAWS, &fpConfig.AWS.CredentialsSecretRef
+	case fpConfig.Azure != nil:
+		return constants.InstallLogsUploadProviderAzure, &fpConfig.Azure.CredentialsSecretRef
+	case fpConfig.GCP != nil:
+		return constants.InstallLogsUploadProviderGCP, &fpConfig.GCP.CredentialsSecretRef
+	case fpConfig.HTTP != nil:
+		return constants.InstallLogsUploadProviderHTTP, fpConfig.HTTP.CredentialsSecretRef
+	case fpConfig.PersistentVolumeClaim != nil:
+		return constants.InstallLogsUploadProviderPVC, nil
+	}
+	return "", nil
+}
+
+// ValidateInstallLogsUploadProviders returns an error if more than one provider to upload the logs of failed
+// installations to is configured in the FailedProvisionConfig.
+func ValidateInstallLogsUploadProviders(fpConfig *hivev1.FailedProvisionConfig) error {
+	if fpConfig == nil {
+		return nil
+	}
+	var providers []string
+	if fpConfig.AWS != nil {
+		providers = append(providers, "aws")
+	}
+	if fpConfig.Azure != nil {
+		providers = append(providers, "azure")
+	}
+	if fpConfig.GCP != nil {
+		providers = append(providers, "gcp")
+	}
+	if fpConfig.HTTP != nil {
+		providers = append(providers, "http")
+	}
+	if fpConfig.PersistentVolumeClaim != nil {
+		providers = append(providers, "persistentVolumeClaim")
+	}
+	if len(providers) > 1 {
+		return fmt.Errorf("only one install logs upload provider may be configured, found %s", strings.Join(providers, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestValidateInstallLogsUploadProviders(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *hivev1.FailedProvisionConfig
+		expectError bool
+	}{
+		{
+			name: "nil config",
+		},
+		{
+			name:   "no provider",
+			config: &hivev1.FailedProvisionConfig{},
+		},
+		{
+			name: "one provider",
+			config: &hivev1.FailedProvisionConfig{
+				PersistentVolumeClaim: &hivev1.FailedProvisionPVCConfig{ClaimName: "install-logs"},
+			},
+		},
+		{
+			name: "several providers",
+			config: &hivev1.FailedProvisionConfig{
+				HTTP:                  &hivev1.FailedProvisionHTTPConfig{URL: "https://logs.example.com"},
+				PersistentVolumeClaim: &hivev1.FailedProvisionPVCConfig{ClaimName: "install-logs"},
+			},
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateInstallLogsUploadProviders(test.config)
+			if test.expectError {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+		})
+	}
+}
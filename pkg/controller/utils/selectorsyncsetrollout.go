@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"hash/fnv"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// SelectorSyncSetRolloutKey returns the position of a cluster in the rollout order of a SelectorSyncSet. Clusters are
+// rolled out in increasing order of their keys. The key is derived from the names of the SelectorSyncSet and the
+// ClusterDeployment so that the order is stable across changes to the SelectorSyncSet but differs between
+// SelectorSyncSets, so that the same clusters are not always in the first wave.
+func SelectorSyncSetRolloutKey(sssName, cdNamespace, cdName string) int64 {
+	h := fnv.New32a()
+	h.Write([]byte(sssName + "/" + cdNamespace + "/" + cdName))
+	return int64(h.Sum32())
+}
+
+// IsSelectorSyncSetReleasedToCluster returns true if the current generation of the SelectorSyncSet may be applied to
+// the ClusterDeployment. This is always the case for SelectorSyncSets without a rollout strategy. Otherwise the
+// cluster has to have been reached by the rollout of the current generation.
+func IsSelectorSyncSetReleasedToCluster(sss *hivev1.SelectorSyncSet, cd *hivev1.ClusterDeployment) bool {
+	if sss.Spec.RolloutStrategy == nil {
+		return true
+	}
+	rollout := sss.Status.Rollout
+	if rollout == nil || rollout.ObservedGeneration != sss.Generation {
+		return false
+	}
+	if rollout.Phase == hivev1.CompleteSelectorSyncSetRolloutPhase {
+		return true
+	}
+	return SelectorSyncSetRolloutKey(sss.Name, cd.Namespace, cd.Name) <= rollout.ReleasedKeyLimit
+}
+
+// SelectorSyncSetReleaseChanged returns true if the update of the SelectorSyncSet from old to new may change the
+// clusters to which it is released, or what is released to them. Updates that only report the progress of a rollout
+// do not.
+func SelectorSyncSetReleaseChanged(old, new *hivev1.SelectorSyncSet) bool {
+	if old.Generation != new.Generation || (old.Spec.RolloutStrategy == nil) != (new.Spec.RolloutStrategy == nil) {
+		return true
+	}
+	oldRollout, newRollout := old.Status.Rollout, new.Status.Rollout
+	if oldRollout == nil || newRollout == nil {
+		return oldRollout != newRollout
+	}
+	return oldRollout.ObservedGeneration != newRollout.ObservedGeneration ||
+		(oldRollout.Phase == hivev1.CompleteSelectorSyncSetRolloutPhase) != (newRollout.Phase == hivev1.CompleteSelectorSyncSetRolloutPhase) ||
+		oldRollout.ReleasedKeyLimit != newRollout.ReleasedKeyLimit
+}
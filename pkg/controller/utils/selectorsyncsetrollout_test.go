@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestSelectorSyncSetReleaseChanged(t *testing.T) {
+	sss := func(generation int64, rollout *hivev1.SelectorSyncSetRolloutStatus) *hivev1.SelectorSyncSet {
+		return &hivev1.SelectorSyncSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-sss", Generation: generation},
+			Spec:       hivev1.SelectorSyncSetSpec{RolloutStrategy: &hivev1.SelectorSyncSetRolloutStrategy{}},
+			Status:     hivev1.SelectorSyncSetStatus{Rollout: rollout},
+		}
+	}
+	rollout := func(phase hivev1.SelectorSyncSetRolloutPhase, releasedKeyLimit int64, updated int32) *hivev1.SelectorSyncSetRolloutStatus {
+		return &hivev1.SelectorSyncSetRolloutStatus{
+			ObservedGeneration: 1,
+			Phase:              phase,
+			ReleasedKeyLimit:   releasedKeyLimit,
+			UpdatedClusters:    updated,
+		}
+	}
+	cases := []struct {
+		name     string
+		old      *hivev1.SelectorSyncSet
+		new      *hivev1.SelectorSyncSet
+		expected bool
+	}{
+		{
+			name:     "progress of the current wave",
+			old:      sss(1, rollout(hivev1.ProgressingSelectorSyncSetRolloutPhase, 10, 1)),
+			new:      sss(1, rollout(hivev1.ProgressingSelectorSyncSetRolloutPhase, 10, 2)),
+			expected: false,
+		},
+		{
+			name:     "wave complete",
+			old:      sss(1, rollout(hivev1.ProgressingSelectorSyncSetRolloutPhase, 10, 2)),
+			new:      sss(1, rollout(hivev1.WaitingSelectorSyncSetRolloutPhase, 10, 2)),
+			expected: false,
+		},
+		{
+			name:     "next wave released",
+			old:      sss(1, rollout(hivev1.WaitingSelectorSyncSetRolloutPhase, 10, 2)),
+			new:      sss(1, rollout(hivev1.ProgressingSelectorSyncSetRolloutPhase, 20, 2)),
+			expected: true,
+		},
+		{
+			name:     "rollout complete",
+			old:      sss(1, rollout(hivev1.ProgressingSelectorSyncSetRolloutPhase, 20, 2)),
+			new:      sss(1, rollout(hivev1.CompleteSelectorSyncSetRolloutPhase, 20, 4)),
+			expected: true,
+		},
+		{
+			name:     "rollout started",
+			old:      sss(1, nil),
+			new:      sss(1, rollout(hivev1.ProgressingSelectorSyncSetRolloutPhase, 10, 0)),
+			expected: true,
+		},
+		{
+			name:     "new generation",
+			old:      sss(1, rollout(hivev1.CompleteSelectorSyncSetRolloutPhase, 20, 4)),
+			new:      sss(2, rollout(hivev1.CompleteSelectorSyncSetRolloutPhase, 20, 4)),
+			expected: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, SelectorSyncSetReleaseChanged(tc.old, tc.new))
+		})
+	}
+}
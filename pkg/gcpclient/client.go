@@ -47,6 +47,10 @@ type Client interface {
 
 	ListComputeInstances(ListComputeInstancesOptions, func(*compute.InstanceAggregatedList) error) error
 
+	ListComputeDisks(ListComputeDisksOptions, func(*compute.DiskAggregatedList) error) error
+
+	ListComputeForwardingRules(ListComputeForwardingRulesOptions, func(*compute.ForwardingRuleAggregatedList) error) error
+
 	StopInstance(*compute.Instance) error
 
 	StartInstance(*compute.Instance) error
@@ -72,6 +76,16 @@ type ListComputeInstancesOptions struct {
 	Fields string
 }
 
+// ListComputeDisksOptions are the options for listing compute disks.
+type ListComputeDisksOptions struct {
+	Filter string
+}
+
+// ListComputeForwardingRulesOptions are the options for listing compute forwarding rules.
+type ListComputeForwardingRulesOptions struct {
+	Filter string
+}
+
 type gcpClient struct {
 	projectName                string
 	creds                      *google.Credentials
@@ -247,6 +261,30 @@ func (c *gcpClient) ListComputeInstances(opts ListComputeInstancesOptions, pages
 	return nil
 }
 
+func (c *gcpClient) ListComputeDisks(opts ListComputeDisksOptions, pagesFn func(*compute.DiskAggregatedList) error) error {
+	req := c.computeClient.Disks.AggregatedList(c.projectName)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute disks")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeForwardingRules(opts ListComputeForwardingRulesOptions, pagesFn func(*compute.ForwardingRuleAggregatedList) error) error {
+	req := c.computeClient.ForwardingRules.AggregatedList(c.projectName)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute forwarding rules")
+	}
+	return nil
+}
+
 func (c *gcpClient) StopInstance(instance *compute.Instance) error {
 	zone := instanceZone(instance)
 	_, err := c.computeClient.Instances.Stop(c.projectName, zone, instance.Name).Do()
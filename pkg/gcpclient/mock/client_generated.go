@@ -122,6 +122,34 @@ func (mr *MockClientMockRecorder) GetManagedZone(managedZone interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagedZone", reflect.TypeOf((*MockClient)(nil).GetManagedZone), managedZone)
 }
 
+// ListComputeDisks mocks base method.
+func (m *MockClient) ListComputeDisks(arg0 gcpclient.ListComputeDisksOptions, arg1 func(*compute.DiskAggregatedList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeDisks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeDisks indicates an expected call of ListComputeDisks.
+func (mr *MockClientMockRecorder) ListComputeDisks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeDisks", reflect.TypeOf((*MockClient)(nil).ListComputeDisks), arg0, arg1)
+}
+
+// ListComputeForwardingRules mocks base method.
+func (m *MockClient) ListComputeForwardingRules(arg0 gcpclient.ListComputeForwardingRulesOptions, arg1 func(*compute.ForwardingRuleAggregatedList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeForwardingRules", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeForwardingRules indicates an expected call of ListComputeForwardingRules.
+func (mr *MockClientMockRecorder) ListComputeForwardingRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeForwardingRules", reflect.TypeOf((*MockClient)(nil).ListComputeForwardingRules), arg0, arg1)
+}
+
 // ListComputeImages mocks base method.
 func (m *MockClient) ListComputeImages(arg0 gcpclient.ListComputeImagesOptions) (*compute.ImageList, error) {
 	m.ctrl.T.Helper()
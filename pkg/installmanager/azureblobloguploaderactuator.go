@@ -0,0 +1,115 @@
+package installmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// azureStorageAPIVersion is the version of the Azure Storage REST API used to upload blobs.
+const azureStorageAPIVersion = "2020-10-02"
+
+// Ensure azureBlobLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &azureBlobLogUploaderActuator{}
+
+// azureBlobLogUploaderActuator uploads logs to Azure Blob Storage.
+type azureBlobLogUploaderActuator struct {
+	// authorizerFn is the function to build the authorizer of Azure Storage requests, here for lazy loading the
+	// credentials.
+	authorizerFn func(client.Client, string, string, azure.Environment) (autorest.Authorizer, error)
+}
+
+// IsConfigured returns true if logs are to be uploaded to Azure Blob Storage.
+func (a *azureBlobLogUploaderActuator) IsConfigured() bool {
+	return isInstallLogsUploadProvider(constants.InstallLogsUploadProviderAzure)
+}
+
+// UploadLogs uploads installer logs to the provider's storage mechanism.
+func (a *azureBlobLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+	config, err := readInstallLogsUploadConfig()
+	if err != nil {
+		return err
+	}
+	azureConfig := config.Azure
+	if azureConfig == nil {
+		return errors.New("couldn't find Azure upload config. Skipping upload")
+	}
+
+	cloudName := azureConfig.CloudName
+	if cloudName == "" {
+		cloudName = hivev1azure.PublicCloud
+	}
+	env, err := azure.EnvironmentFromName(cloudName.Name())
+	if err != nil {
+		return err
+	}
+
+	authorizer, err := a.authorizerFn(c, azureConfig.CredentialsSecretRef.Name, clusterprovision.Namespace, env)
+	if err != nil {
+		return err
+	}
+
+	endpoint := azureConfig.ServiceEndpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.%s", azureConfig.StorageAccount, env.StorageEndpointSuffix)
+	}
+	containerURL := strings.TrimSuffix(endpoint, "/") + "/" + azureConfig.Container
+
+	log.Infof("Uploading log(s) to Azure Blob Storage: %v/%v/", containerURL, installLogsFolder(clusterName, clusterprovision))
+
+	return uploadLogFiles(clusterName, clusterprovision, func(key string, file *os.File, size int64) error {
+		req, err := http.NewRequest(http.MethodPut, containerURL+"/"+key, file)
+		if err != nil {
+			return err
+		}
+		req.ContentLength = size
+		req.Header.Set("Content-Type", "text/plain")
+		req.Header.Set("x-ms-blob-type", "BlockBlob")
+		req.Header.Set("x-ms-version", azureStorageAPIVersion)
+		if req, err = autorest.Prepare(req, authorizer.WithAuthorization()); err != nil {
+			return err
+		}
+		return putLogFile(http.DefaultClient, req)
+	}, filenames...)
+}
+
+// getAzureStorageAuthorizer builds an authorizer of Azure Storage requests from the service principal in the secret.
+func getAzureStorageAuthorizer(c client.Client, secretName, namespace string, env azure.Environment) (autorest.Authorizer, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: namespace}, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to get Azure credentials secret")
+	}
+	authJSON, ok := secret.Data[constants.AzureCredentialsName]
+	if !ok {
+		return nil, errors.New("creds secret does not contain \"" + constants.AzureCredentialsName + "\" data")
+	}
+	var authMap map[string]string
+	if err := json.Unmarshal(authJSON, &authMap); err != nil {
+		return nil, err
+	}
+	for _, key := range []string{"clientId", "clientSecret", "tenantId"} {
+		if _, ok := authMap[key]; !ok {
+			return nil, fmt.Errorf("missing %s in auth", key)
+		}
+	}
+	config := auth.NewClientCredentialsConfig(authMap["clientId"], authMap["clientSecret"], authMap["tenantId"])
+	config.Resource = env.ResourceIdentifiers.Storage
+	config.AADEndpoint = env.ActiveDirectoryEndpoint
+	return config.Authorizer()
+}
@@ -0,0 +1,73 @@
+package installmanager
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// Ensure gcsLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &gcsLogUploaderActuator{}
+
+// gcsLogUploaderActuator uploads logs to Google Cloud Storage.
+type gcsLogUploaderActuator struct {
+	// storageServiceFn is the function to build a Cloud Storage client, here for lazy loading the client.
+	storageServiceFn func(client.Client, string, string) (*storage.Service, error)
+}
+
+// IsConfigured returns true if logs are to be uploaded to Google Cloud Storage.
+func (a *gcsLogUploaderActuator) IsConfigured() bool {
+	return isInstallLogsUploadProvider(constants.InstallLogsUploadProviderGCP)
+}
+
+// UploadLogs uploads installer logs to the provider's storage mechanism.
+func (a *gcsLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+	config, err := readInstallLogsUploadConfig()
+	if err != nil {
+		return err
+	}
+	if config.GCP == nil {
+		return errors.New("couldn't find GCP upload config. Skipping upload")
+	}
+
+	svc, err := a.storageServiceFn(c, config.GCP.CredentialsSecretRef.Name, clusterprovision.Namespace)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Uploading log(s) to Cloud Storage: gs://%v/%v/", config.GCP.Bucket, installLogsFolder(clusterName, clusterprovision))
+
+	return uploadLogFiles(clusterName, clusterprovision, func(key string, file *os.File, _ int64) error {
+		_, err := svc.Objects.Insert(config.GCP.Bucket, &storage.Object{Name: key}).Media(file).Do()
+		return err
+	}, filenames...)
+}
+
+// getStorageService builds a Cloud Storage client from the service account key in the secret.
+func getStorageService(c client.Client, secretName, namespace string) (*storage.Service, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: namespace}, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to get GCP credentials secret")
+	}
+	authJSON, ok := secret.Data[constants.GCPCredentialsName]
+	if !ok {
+		return nil, errors.New("creds secret does not contain \"" + constants.GCPCredentialsName + "\" data")
+	}
+	ctx := context.TODO()
+	creds, err := google.CredentialsFromJSON(ctx, authJSON, storage.DevstorageReadWriteScope)
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewService(ctx, option.WithCredentials(creds), option.WithUserAgent("openshift.io hive/v1"))
+}
@@ -0,0 +1,130 @@
+package installmanager
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// httpTokenSecretKey is the key of the bearer token in the HTTP upload credentials secret.
+	httpTokenSecretKey = "token"
+	// httpUsernameSecretKey and httpPasswordSecretKey are the keys of the basic authentication credentials in the
+	// HTTP upload credentials secret.
+	httpUsernameSecretKey = "username"
+	httpPasswordSecretKey = "password"
+	// httpCASecretKey is the key of the certificate authorities to trust in the HTTP upload credentials secret.
+	httpCASecretKey = "ca.crt"
+)
+
+// Ensure httpLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &httpLogUploaderActuator{}
+
+// httpLogUploaderActuator uploads logs with HTTP PUT requests.
+type httpLogUploaderActuator struct{}
+
+// IsConfigured returns true if logs are to be uploaded with HTTP PUT requests.
+func (a *httpLogUploaderActuator) IsConfigured() bool {
+	return isInstallLogsUploadProvider(constants.InstallLogsUploadProviderHTTP)
+}
+
+// UploadLogs uploads installer logs to the provider's storage mechanism.
+func (a *httpLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+	config, err := readInstallLogsUploadConfig()
+	if err != nil {
+		return err
+	}
+	if config.HTTP == nil || config.HTTP.URL == "" {
+		return errors.New("couldn't find HTTP upload config. Skipping upload")
+	}
+
+	httpClient := &http.Client{}
+	authorize := func(*http.Request) {}
+	if ref := config.HTTP.CredentialsSecretRef; ref != nil && ref.Name != "" {
+		secret := &corev1.Secret{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: clusterprovision.Namespace}, secret); err != nil {
+			return errors.Wrap(err, "failed to get HTTP upload credentials secret")
+		}
+		if httpClient, err = httpClientFromSecret(secret); err != nil {
+			return err
+		}
+		authorize = httpAuthorizationFromSecret(secret)
+	}
+
+	baseURL := strings.TrimSuffix(config.HTTP.URL, "/")
+	log.Infof("Uploading log(s) with HTTP PUT: %v/%v/", baseURL, installLogsFolder(clusterName, clusterprovision))
+
+	return uploadLogFiles(clusterName, clusterprovision, func(key string, file *os.File, size int64) error {
+		req, err := http.NewRequest(http.MethodPut, baseURL+"/"+key, file)
+		if err != nil {
+			return err
+		}
+		req.ContentLength = size
+		req.Header.Set("Content-Type", "text/plain")
+		authorize(req)
+		return putLogFile(httpClient, req)
+	}, filenames...)
+}
+
+// httpClientFromSecret returns an HTTP client trusting the certificate authorities of the secret, if any.
+func httpClientFromSecret(secret *corev1.Secret) (*http.Client, error) {
+	caData, ok := secret.Data[httpCASecretKey]
+	if !ok {
+		return &http.Client{}, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caData) {
+		return nil, fmt.Errorf("no certificates found in %s of the HTTP upload credentials secret", httpCASecretKey)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return &http.Client{Transport: transport}, nil
+}
+
+// httpAuthorizationFromSecret returns a function authorizing requests with the bearer token or the basic
+// authentication credentials of the secret.
+func httpAuthorizationFromSecret(secret *corev1.Secret) func(*http.Request) {
+	if token, ok := secret.Data[httpTokenSecretKey]; ok {
+		return func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+		}
+	}
+	if username, ok := secret.Data[httpUsernameSecretKey]; ok {
+		password := secret.Data[httpPasswordSecretKey]
+		return func(req *http.Request) {
+			req.SetBasicAuth(string(username), string(password))
+		}
+	}
+	return func(*http.Request) {}
+}
+
+// putLogFile sends a request uploading a log file and checks that it succeeded.
+func putLogFile(httpClient *http.Client, req *http.Request) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected response status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
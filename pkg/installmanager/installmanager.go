@@ -128,6 +128,7 @@ type InstallManager struct {
 	loadSecrets                      func(*InstallManager, *hivev1.ClusterDeployment)
 	cleanupFailedProvision           func(dynamicClient client.Client, cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) error
 	updateClusterProvision           func(*InstallManager, provisionMutation) error
+	updateClusterProvisionTimeline   func(*InstallManager, []hivev1.ClusterProvisionPhase) error
 	readClusterMetadata              func(*InstallManager) ([]byte, *installertypes.ClusterMetadata, error)
 	uploadAdminKubeconfig            func(*InstallManager) (*corev1.Secret, error)
 	uploadAdminPassword              func(*InstallManager) (*corev1.Secret, error)
@@ -157,14 +158,15 @@ completing install, and monitors for artifacts that need to be uploaded back to
 The following environment variables, if present, configure the Install Manager to upload logs for
 failed provisions:
 
-HIVE_INSTALL_LOGS_UPLOAD_PROVIDER: The cloud provider hosting the object store. At this time only
-	"aws" is supported.
+HIVE_INSTALL_LOGS_UPLOAD_PROVIDER: Where the logs are uploaded to. One of "aws", "azure", "gcp", "http"
+	or "pvc".
 HIVE_INSTALL_LOGS_CREDENTIALS_SECRET: The name of a secret in the current namespace containing
-	credentials sufficient to write data to the specified bucket. For example, for AWS, the secret
+	credentials sufficient to write data to the specified destination. For example, for AWS, the secret
 	data could contain base64-encoded values for "aws_access_key_id" and "aws_secret_access_key".
-HIVE_INSTALL_LOGS_AWS_REGION: The region containing the specified bucket.
-HIVE_INSTALL_LOGS_AWS_S3_BUCKET: The name of the S3 bucket to which to upload the logs. The bucket
-	must exist and be writable using the specified credentials.
+HIVE_INSTALL_LOGS_UPLOAD_CONFIG: The JSON encoded FailedProvisionConfig of HiveConfig holding the
+	configuration of the provider, such as the bucket, container or URL to upload the logs to. The
+	destination must exist and be writable using the specified credentials. For "pvc", the claim
+	must be mounted at /install-logs.
 SSH_PRIV_KEY_PATH: File system path of a file containing the SSH private key corresponding to the
 	public key in the install config.`,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -214,6 +216,7 @@ func (m *InstallManager) Complete(args []string) error {
 	// Connect up structure's function pointers
 	m.loadSecrets = loadSecrets
 	m.updateClusterProvision = updateClusterProvisionWithRetries
+	m.updateClusterProvisionTimeline = updateClusterProvisionTimeline
 	m.readClusterMetadata = readClusterMetadata
 	m.uploadAdminKubeconfig = uploadAdminKubeconfig
 	m.uploadAdminPassword = uploadAdminPassword
@@ -351,6 +354,11 @@ func (m *InstallManager) Run() error {
 		m.log.WithError(err).Error("error adding pull secret to install-config.yaml")
 		return err
 	}
+	icData, err = applyRetryFallback(icData, cd)
+	if err != nil {
+		m.log.WithError(err).Error("error applying retry policy fallback to install-config.yaml")
+		return err
+	}
 	destInstallConfigPath := filepath.Join(m.WorkDir, "install-config.yaml")
 	if err := os.WriteFile(destInstallConfigPath, icData, 0644); err != nil {
 		m.log.WithError(err).Error("error writing install-config.yaml")
@@ -369,7 +377,14 @@ func (m *InstallManager) Run() error {
 	// cluster provision attempt. Cleanup any resources that may have been provisioned.
 	if m.ClusterProvision.Spec.PrevInfraID != nil {
 		m.log.Info("cleaning up resources from previous provision attempt")
-		if err := m.cleanupFailedInstall(cd, *m.ClusterProvision.Spec.PrevInfraID, *m.ClusterProvision.Spec.PrevProvisionName, m.Namespace); err != nil {
+		prevCD := cd
+		// A retry policy fallback may have moved the cluster since the previous attempt.
+		if retries := cd.Status.ProvisionRetries; retries != nil && retries.PrevRegion != "" {
+			m.log.WithField("region", retries.PrevRegion).Info("previous provision attempt was in another region")
+			prevCD = cd.DeepCopy()
+			utils.SetClusterRegion(prevCD, retries.PrevRegion)
+		}
+		if err := m.cleanupFailedInstall(prevCD, *m.ClusterProvision.Spec.PrevInfraID, *m.ClusterProvision.Spec.PrevProvisionName, m.Namespace); err != nil {
 			m.log.WithError(err).Error("error while trying to preemptively clean up")
 			return err
 		}
@@ -585,6 +600,10 @@ func getActuator() LogUploaderActuator {
 	// As we add more LogUploaderActuators, add them here
 	actuators := []LogUploaderActuator{
 		&s3LogUploaderActuator{awsClientFn: getAWSClient},
+		&azureBlobLogUploaderActuator{authorizerFn: getAzureStorageAuthorizer},
+		&gcsLogUploaderActuator{storageServiceFn: getStorageService},
+		&httpLogUploaderActuator{},
+		&pvcLogUploaderActuator{dir: constants.InstallLogsDir},
 	}
 
 	for _, a := range actuators {
@@ -1182,7 +1201,8 @@ func (m *InstallManager) runOpenShiftInstallCommand(args ...string) error {
 }
 
 // tailFullInstallLog streams the full install log to standard out so that
-// the log can be seen from the pods logs.
+// the log can be seen from the pods logs. Along the way, it records the
+// timeline of the installer phases in the status of the ClusterProvision.
 func (m *InstallManager) tailFullInstallLog(scrubInstallLog bool) {
 	logfileName := filepath.Join(m.WorkDir, installerFullLogFile)
 	m.waitForFiles([]string{logfileName})
@@ -1197,6 +1217,7 @@ func (m *InstallManager) tailFullInstallLog(scrubInstallLog bool) {
 
 	r := bufio.NewReader(logfile)
 	fullLine := ""
+	timeline := &installTimeline{now: time.Now}
 
 	// Set up additional log fields
 	suffix := ""
@@ -1238,6 +1259,13 @@ func (m *InstallManager) tailFullInstallLog(scrubInstallLog bool) {
 			continue
 		}
 
+		if timeline.observeLine(fullLine) {
+			if err := m.updateClusterProvisionTimeline(m, timeline.phases); err != nil {
+				// Not a fatal error, the timeline is updated again with the next phase.
+				m.log.WithError(err).Warning("error updating cluster provision with install timeline")
+			}
+		}
+
 		if scrubInstallLog {
 			cleanLine := cleanupLogOutput(fullLine)
 			fmt.Println(cleanLine + suffix)
@@ -1892,6 +1920,80 @@ func pasteInPullSecret(icData []byte, pullSecretFile string) ([]byte, error) {
 	return yaml.Marshal(icRaw)
 }
 
+// retryFallbackRegionalFields are the fields of the platform sections of the install config, by platform, which only
+// make sense in the region they were set for, and which are cleared when a retry policy fallback changes the region.
+var retryFallbackRegionalFields = map[string][]string{
+	"aws":   {"zones", "subnets", "amiID"},
+	"azure": {"zones", "networkResourceGroupName", "virtualNetwork", "controlPlaneSubnet", "computeSubnet"},
+	"gcp":   {"zones", "network", "controlPlaneSubnet", "computeSubnet"},
+}
+
+// applyRetryFallback applies the retry policy fallback recorded in the status of the ClusterDeployment, if any, to
+// the install config: the region replaces that of the platform, clearing the fields tied to the previous region such
+// as availability zones, subnets and AMI IDs, and the instance type replaces that of the control plane and compute
+// machine pools.
+func applyRetryFallback(icData []byte, cd *hivev1.ClusterDeployment) ([]byte, error) {
+	if cd.Status.ProvisionRetries == nil || cd.Status.ProvisionRetries.Fallback == nil {
+		return icData, nil
+	}
+	fallback := cd.Status.ProvisionRetries.Fallback
+	var platformKey string
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		platformKey = "aws"
+	case cd.Spec.Platform.Azure != nil:
+		platformKey = "azure"
+	case cd.Spec.Platform.GCP != nil:
+		platformKey = "gcp"
+	default:
+		return icData, nil
+	}
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	// patchPlatform sets the fields of the platform-specific section of a platform or machine pool platform,
+	// creating it if necessary.
+	patchPlatform := func(obj map[string]interface{}, setRegion bool) {
+		platform, _ := obj["platform"].(map[string]interface{})
+		if platform == nil {
+			platform = map[string]interface{}{}
+			obj["platform"] = platform
+		}
+		section, _ := platform[platformKey].(map[string]interface{})
+		if section == nil {
+			section = map[string]interface{}{}
+			platform[platformKey] = section
+		}
+		if fallback.Region != "" {
+			if setRegion {
+				section["region"] = fallback.Region
+			}
+			for _, field := range retryFallbackRegionalFields[platformKey] {
+				delete(section, field)
+				if dmp, ok := section["defaultMachinePlatform"].(map[string]interface{}); ok {
+					delete(dmp, field)
+				}
+			}
+		}
+		if !setRegion && fallback.InstanceType != "" {
+			section["type"] = fallback.InstanceType
+		}
+	}
+	patchPlatform(icRaw, true)
+	if controlPlane, ok := icRaw["controlPlane"].(map[string]interface{}); ok {
+		patchPlatform(controlPlane, false)
+	}
+	if compute, ok := icRaw["compute"].([]interface{}); ok {
+		for _, pool := range compute {
+			if pool, ok := pool.(map[string]interface{}); ok {
+				patchPlatform(pool, false)
+			}
+		}
+	}
+	return yaml.Marshal(icRaw)
+}
+
 func getHomeDir() string {
 	home := os.Getenv("HOME")
 	if home != "" {
@@ -29,6 +29,7 @@ import (
 	installertypes "github.com/openshift/installer/pkg/types"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/util/scheme"
@@ -257,7 +258,7 @@ func TestInstallManager(t *testing.T) {
 			im.cleanupFailedProvision = alwaysSucceedCleanupFailedProvision
 
 			// Save the list of actuators so that it can be restored at the end of this test
-			im.actuator = &s3LogUploaderActuator{awsClientFn: func(c client.Client, secretName, namespace, region string, logger log.FieldLogger) (awsclient.Client, error) {
+			im.actuator = &s3LogUploaderActuator{awsClientFn: func(c client.Client, namespace string, config *hivev1.FailedProvisionAWSConfig, logger log.FieldLogger) (awsclient.Client, error) {
 				return mocks.mockAWSClient, nil
 			}}
 
@@ -727,6 +728,143 @@ func Test_pasteInPullSecret(t *testing.T) {
 	}
 }
 
+func Test_applyRetryFallback(t *testing.T) {
+	icData := []byte(`apiVersion: v1
+baseDomain: example.com
+compute:
+- name: worker
+  platform:
+    aws:
+      amiID: ami-0123456789abcdef0
+      type: m5.xlarge
+      zones:
+      - us-east-1a
+  replicas: 3
+controlPlane:
+  name: master
+  platform:
+    aws:
+      type: m5.xlarge
+      zones:
+      - us-east-1a
+  replicas: 3
+platform:
+  aws:
+    defaultMachinePlatform:
+      amiID: ami-0123456789abcdef0
+    region: us-east-1
+    subnets:
+    - subnet-0123456789abcdef0
+    userTags:
+      team: hive
+`)
+	cases := []struct {
+		name     string
+		fallback *hivev1.ProvisionRetryFallback
+		expected string
+	}{
+		{
+			name:     "no fallback",
+			expected: string(icData),
+		},
+		{
+			name:     "region",
+			fallback: &hivev1.ProvisionRetryFallback{Region: "us-west-2"},
+			expected: `apiVersion: v1
+baseDomain: example.com
+compute:
+- name: worker
+  platform:
+    aws:
+      type: m5.xlarge
+  replicas: 3
+controlPlane:
+  name: master
+  platform:
+    aws:
+      type: m5.xlarge
+  replicas: 3
+platform:
+  aws:
+    defaultMachinePlatform: {}
+    region: us-west-2
+    userTags:
+      team: hive
+`,
+		},
+		{
+			name:     "instance type",
+			fallback: &hivev1.ProvisionRetryFallback{InstanceType: "m6i.xlarge"},
+			expected: `apiVersion: v1
+baseDomain: example.com
+compute:
+- name: worker
+  platform:
+    aws:
+      amiID: ami-0123456789abcdef0
+      type: m6i.xlarge
+      zones:
+      - us-east-1a
+  replicas: 3
+controlPlane:
+  name: master
+  platform:
+    aws:
+      type: m6i.xlarge
+      zones:
+      - us-east-1a
+  replicas: 3
+platform:
+  aws:
+    defaultMachinePlatform:
+      amiID: ami-0123456789abcdef0
+    region: us-east-1
+    subnets:
+    - subnet-0123456789abcdef0
+    userTags:
+      team: hive
+`,
+		},
+		{
+			name:     "region and instance type",
+			fallback: &hivev1.ProvisionRetryFallback{Region: "us-west-2", InstanceType: "m6i.xlarge"},
+			expected: `apiVersion: v1
+baseDomain: example.com
+compute:
+- name: worker
+  platform:
+    aws:
+      type: m6i.xlarge
+  replicas: 3
+controlPlane:
+  name: master
+  platform:
+    aws:
+      type: m6i.xlarge
+  replicas: 3
+platform:
+  aws:
+    defaultMachinePlatform: {}
+    region: us-west-2
+    userTags:
+      team: hive
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			cd.Spec.Platform.AWS = &hivev1aws.Platform{Region: "us-east-1"}
+			if tc.fallback != nil {
+				cd.Status.ProvisionRetries = &hivev1.ProvisionRetriesStatus{Fallback: tc.fallback}
+			}
+			actual, err := applyRetryFallback(icData, cd)
+			require.NoError(t, err, "unexpected error applying retry fallback")
+			assert.Equal(t, tc.expected, string(actual), "unexpected InstallConfig")
+		})
+	}
+}
+
 // machineSetWithSecurityGroupsYAML returns a YAML string representing a MachineSet.
 // The specified sgs (SecurityGroups) are included in the providerSpec.
 // NOTE: The sgs string must be indented 10 spaces!
@@ -0,0 +1,116 @@
+package installmanager
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// installLogTimeRE matches the timestamp the installer writes at the start of each line of its full log.
+var installLogTimeRE = regexp.MustCompile(`^time="([^"]+)"`)
+
+// installPhaseMarker is a line of the install log marking the completion of a phase and/or the start of the next one.
+type installPhaseMarker struct {
+	re       *regexp.Regexp
+	complete hivev1.ClusterProvisionPhaseName
+	start    hivev1.ClusterProvisionPhaseName
+}
+
+// installPhaseMarkers are the lines of the install log delimiting the phases of the installer. Only the first line
+// matching a marker counts; later ones, e.g. from re-running wait-for install-complete, are ignored.
+var installPhaseMarkers = []installPhaseMarker{
+	{
+		re:    regexp.MustCompile(`Creating infrastructure resources`),
+		start: hivev1.ClusterProvisionPhaseInfrastructureCreation,
+	},
+	{
+		re:       regexp.MustCompile(`Waiting up to \S+ (\([^)]*\) )?for the Kubernetes API`),
+		complete: hivev1.ClusterProvisionPhaseInfrastructureCreation,
+		start:    hivev1.ClusterProvisionPhaseAPIWait,
+	},
+	{
+		re:       regexp.MustCompile(`API v\S+ up`),
+		complete: hivev1.ClusterProvisionPhaseAPIWait,
+		start:    hivev1.ClusterProvisionPhaseBootstrap,
+	},
+	{
+		re:       regexp.MustCompile(`It is now safe to remove the bootstrap resources|Destroying the bootstrap resources`),
+		complete: hivev1.ClusterProvisionPhaseBootstrap,
+		start:    hivev1.ClusterProvisionPhaseClusterInitialization,
+	},
+	{
+		re:       regexp.MustCompile(`Install complete!`),
+		complete: hivev1.ClusterProvisionPhaseClusterInitialization,
+	},
+}
+
+// installTimeline builds the timeline of the installer phases from the lines of the install log.
+type installTimeline struct {
+	phases []hivev1.ClusterProvisionPhase
+	// now is used for lines without a timestamp.
+	now func() time.Time
+}
+
+// observeLine updates the timeline from a line of the install log, returning true if the timeline changed.
+func (t *installTimeline) observeLine(line string) bool {
+	for _, marker := range installPhaseMarkers {
+		if !marker.re.MatchString(line) {
+			continue
+		}
+		at := metav1.NewTime(t.lineTime(line))
+		changed := false
+		if marker.complete != "" {
+			if phase := t.findPhase(marker.complete); phase != nil && phase.CompletionTime == nil {
+				phase.CompletionTime = &at
+				changed = true
+			}
+		}
+		if marker.start != "" && t.findPhase(marker.start) == nil {
+			t.phases = append(t.phases, hivev1.ClusterProvisionPhase{Name: marker.start, StartTime: at})
+			changed = true
+		}
+		return changed
+	}
+	return false
+}
+
+func (t *installTimeline) findPhase(name hivev1.ClusterProvisionPhaseName) *hivev1.ClusterProvisionPhase {
+	for i := range t.phases {
+		if t.phases[i].Name == name {
+			return &t.phases[i]
+		}
+	}
+	return nil
+}
+
+// lineTime returns the time a line of the install log was written, falling back to the current time.
+func (t *installTimeline) lineTime(line string) time.Time {
+	if m := installLogTimeRE.FindStringSubmatch(line); m != nil {
+		if parsed, err := time.Parse(time.RFC3339, m[1]); err == nil {
+			return parsed
+		}
+	}
+	return t.now()
+}
+
+// updateClusterProvisionTimeline saves the timeline of the installer phases to the status of the ClusterProvision.
+// It reads its own copy of the ClusterProvision as it runs alongside the install.
+func updateClusterProvisionTimeline(m *InstallManager, phases []hivev1.ClusterProvisionPhase) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		provision := &hivev1.ClusterProvision{}
+		if err := m.DynamicClient.Get(context.Background(), types.NamespacedName{Namespace: m.Namespace, Name: m.ClusterProvisionName}, provision); err != nil {
+			return err
+		}
+		provision.Status.Timeline = make([]hivev1.ClusterProvisionPhase, len(phases))
+		for i := range phases {
+			phases[i].DeepCopyInto(&provision.Status.Timeline[i])
+		}
+		return m.DynamicClient.Status().Update(context.Background(), provision)
+	})
+}
@@ -0,0 +1,147 @@
+package installmanager
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const testTimelineLog = `time="2023-08-01T10:00:00Z" level=info msg="Consuming Install Config from target directory"
+time="2023-08-01T10:01:00Z" level=info msg="Creating infrastructure resources..."
+time="2023-08-01T10:05:00Z" level=info msg="Waiting up to 20m0s (until 10:25AM) for the Kubernetes API at https://api.test-cluster.example.com:6443..."
+time="2023-08-01T10:09:00Z" level=info msg="API v1.27.3+b3d1e4f up"
+time="2023-08-01T10:09:00Z" level=info msg="Waiting up to 30m0s (until 10:39AM) for bootstrapping to complete..."
+time="2023-08-01T10:21:00Z" level=info msg="It is now safe to remove the bootstrap resources"
+time="2023-08-01T10:21:00Z" level=info msg="Destroying the bootstrap resources..."
+time="2023-08-01T10:23:00Z" level=info msg="Waiting up to 40m0s (until 11:03AM) for the cluster at https://api.test-cluster.example.com:6443 to initialize..."
+time="2023-08-01T10:41:00Z" level=info msg="Install complete!"
+time="2023-08-01T10:41:00Z" level=info msg="Time elapsed: 41m0s"`
+
+func TestInstallTimeline(t *testing.T) {
+	at := func(s string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, "2023-08-01T"+s+"Z")
+		require.NoError(t, err)
+		return parsed
+	}
+	now := at("12:00:00")
+
+	tests := []struct {
+		name            string
+		log             string
+		expectedChanges int
+		expectedPhases  []hivev1.ClusterProvisionPhase
+	}{
+		{
+			name:            "complete install",
+			log:             testTimelineLog,
+			expectedChanges: 5,
+			expectedPhases: []hivev1.ClusterProvisionPhase{
+				testPhase(hivev1.ClusterProvisionPhaseInfrastructureCreation, at("10:01:00"), at("10:05:00")),
+				testPhase(hivev1.ClusterProvisionPhaseAPIWait, at("10:05:00"), at("10:09:00")),
+				testPhase(hivev1.ClusterProvisionPhaseBootstrap, at("10:09:00"), at("10:21:00")),
+				testPhase(hivev1.ClusterProvisionPhaseClusterInitialization, at("10:21:00"), at("10:41:00")),
+			},
+		},
+		{
+			name:            "failed during bootstrap",
+			log:             strings.Join(strings.Split(testTimelineLog, "\n")[:5], "\n"),
+			expectedChanges: 3,
+			expectedPhases: []hivev1.ClusterProvisionPhase{
+				testPhase(hivev1.ClusterProvisionPhaseInfrastructureCreation, at("10:01:00"), at("10:05:00")),
+				testPhase(hivev1.ClusterProvisionPhaseAPIWait, at("10:05:00"), at("10:09:00")),
+				testPhase(hivev1.ClusterProvisionPhaseBootstrap, at("10:09:00"), time.Time{}),
+			},
+		},
+		{
+			name: "lines without timestamps",
+			log: `level=info msg="Creating infrastructure resources..."
+level=info msg="Waiting up to 30m0s for the Kubernetes API at https://api.test-cluster.example.com:6443..."`,
+			expectedChanges: 2,
+			expectedPhases: []hivev1.ClusterProvisionPhase{
+				testPhase(hivev1.ClusterProvisionPhaseInfrastructureCreation, now, now),
+				testPhase(hivev1.ClusterProvisionPhaseAPIWait, now, time.Time{}),
+			},
+		},
+		{
+			name:            "repeated markers are ignored",
+			log:             testTimelineLog + "\n" + `time="2023-08-01T11:00:00Z" level=info msg="Install complete!"`,
+			expectedChanges: 5,
+			expectedPhases: []hivev1.ClusterProvisionPhase{
+				testPhase(hivev1.ClusterProvisionPhaseInfrastructureCreation, at("10:01:00"), at("10:05:00")),
+				testPhase(hivev1.ClusterProvisionPhaseAPIWait, at("10:05:00"), at("10:09:00")),
+				testPhase(hivev1.ClusterProvisionPhaseBootstrap, at("10:09:00"), at("10:21:00")),
+				testPhase(hivev1.ClusterProvisionPhaseClusterInitialization, at("10:21:00"), at("10:41:00")),
+			},
+		},
+		{
+			name: "no phases",
+			log:  `level=fatal msg="failed to fetch Cluster: failed to generate asset \"Cluster\""`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			timeline := &installTimeline{now: func() time.Time { return now }}
+			changes := 0
+			for _, line := range strings.Split(test.log, "\n") {
+				if timeline.observeLine(line) {
+					changes++
+				}
+			}
+			assert.Equal(t, test.expectedChanges, changes, "unexpected number of timeline changes")
+			require.Len(t, timeline.phases, len(test.expectedPhases), "unexpected number of phases")
+			for i, expected := range test.expectedPhases {
+				actual := timeline.phases[i]
+				assert.Equal(t, expected.Name, actual.Name, "unexpected phase name")
+				assert.True(t, expected.StartTime.Equal(&actual.StartTime), "unexpected start time for %s: %v", actual.Name, actual.StartTime)
+				if expected.CompletionTime == nil {
+					assert.Nil(t, actual.CompletionTime, "unexpected completion time for %s", actual.Name)
+				} else if assert.NotNil(t, actual.CompletionTime, "missing completion time for %s", actual.Name) {
+					assert.True(t, expected.CompletionTime.Equal(actual.CompletionTime), "unexpected completion time for %s: %v", actual.Name, actual.CompletionTime)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateClusterProvisionTimeline(t *testing.T) {
+	mocks := setupDefaultMocks(t, testClusterProvision())
+	im := &InstallManager{
+		DynamicClient:        mocks.fakeKubeClient,
+		Namespace:            testNamespace,
+		ClusterProvisionName: testProvisionName,
+		log:                  log.WithField("test", "TestUpdateClusterProvisionTimeline"),
+	}
+
+	timeline := &installTimeline{now: time.Now}
+	for _, line := range strings.Split(testTimelineLog, "\n")[:4] {
+		timeline.observeLine(line)
+	}
+	require.NoError(t, updateClusterProvisionTimeline(im, timeline.phases), "unexpected error updating timeline")
+
+	provision := &hivev1.ClusterProvision{}
+	require.NoError(t, mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testProvisionName}, provision))
+	if assert.Len(t, provision.Status.Timeline, 3, "unexpected timeline") {
+		assert.Equal(t, hivev1.ClusterProvisionPhaseInfrastructureCreation, provision.Status.Timeline[0].Name)
+		assert.NotNil(t, provision.Status.Timeline[1].CompletionTime, "expected APIWait to be complete")
+		assert.Nil(t, provision.Status.Timeline[2].CompletionTime, "expected Bootstrap to be in progress")
+	}
+	assert.Equal(t, hivev1.ClusterProvisionStageProvisioning, provision.Spec.Stage, "spec should be unchanged")
+}
+
+func testPhase(name hivev1.ClusterProvisionPhaseName, start, completion time.Time) hivev1.ClusterProvisionPhase {
+	phase := hivev1.ClusterProvisionPhase{Name: name}
+	phase.StartTime.Time = start
+	if !completion.IsZero() {
+		phase.CompletionTime = &metav1.Time{Time: completion}
+	}
+	return phase
+}
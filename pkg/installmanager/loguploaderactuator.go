@@ -1,9 +1,17 @@
 package installmanager
 
 import (
-	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 )
 
 // LogUploaderActuator interface is the interface that is used to add provider support for uploading logs.
@@ -14,3 +22,68 @@ type LogUploaderActuator interface {
 	// UploadLogs uploads installer logs to the provider's storage mechanism.
 	UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error
 }
+
+// isInstallLogsUploadProvider returns true if logs are to be uploaded to the given provider.
+func isInstallLogsUploadProvider(provider string) bool {
+	configuredProvider, foundProviderEnvVar := os.LookupEnv(constants.InstallLogsUploadProviderEnvVar)
+	if !foundProviderEnvVar {
+		log.Debug("Couldn't find install logs provider environment variable. Skipping.")
+		return false
+	}
+
+	return configuredProvider == provider
+}
+
+// readInstallLogsUploadConfig reads the FailedProvisionConfig specifying where to upload logs to from the environment.
+func readInstallLogsUploadConfig() (*hivev1.FailedProvisionConfig, error) {
+	configJSON, foundConfigEnvVar := os.LookupEnv(constants.InstallLogsUploadConfigEnvVar)
+	if !foundConfigEnvVar {
+		return nil, errors.New("couldn't find upload config in environment variable. Skipping upload")
+	}
+	config := &hivev1.FailedProvisionConfig{}
+	if err := json.Unmarshal([]byte(configJSON), config); err != nil {
+		return nil, errors.Wrap(err, "couldn't unmarshal upload config. Skipping upload")
+	}
+	return config, nil
+}
+
+// installLogsFolder returns the folder the logs of all the provisions of a cluster are uploaded to.
+func installLogsFolder(clusterName string, clusterprovision *hivev1.ClusterProvision) string {
+	return fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
+}
+
+// uploadLogFiles calls upload for each of the log files with the opened file and the key to upload it under.
+// Failing to upload a file does not prevent uploading the others; the errors are aggregated.
+func uploadLogFiles(clusterName string, clusterprovision *hivev1.ClusterProvision, upload func(key string, file *os.File, size int64) error, filenames ...string) error {
+	retvalErrs := []error{}
+
+	folder := installLogsFolder(clusterName, clusterprovision)
+
+	for _, filename := range filenames {
+		if err := uploadLogFile(folder, clusterprovision.Name, filename, upload); err != nil {
+			retvalErrs = append(retvalErrs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(retvalErrs)
+}
+
+func uploadLogFile(folder, provisionName, filename string, upload func(key string, file *os.File, size int64) error) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return errors.Wrapf(err, "Failed opening log file: %v", filename)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "Failed stat on log file: %v", filename)
+	}
+
+	logkey := fmt.Sprintf("%v/%v-%v", folder, provisionName, stat.Name())
+
+	if err := upload(logkey, file, stat.Size()); err != nil {
+		return errors.Wrapf(err, "Failed uploading log file: %v", filename)
+	}
+	return nil
+}
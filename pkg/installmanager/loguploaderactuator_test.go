@@ -0,0 +1,261 @@
+package installmanager
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const testLogContents = "install log contents"
+
+// setupInstallLogsUploadEnv sets the environment variables the install pod is given to upload logs to provider and
+// returns a function unsetting them.
+func setupInstallLogsUploadEnv(provider, config string) func() {
+	os.Setenv(constants.InstallLogsUploadProviderEnvVar, provider)
+	os.Setenv(constants.InstallLogsUploadConfigEnvVar, config)
+	return func() {
+		os.Unsetenv(constants.InstallLogsUploadProviderEnvVar)
+		os.Unsetenv(constants.InstallLogsUploadConfigEnvVar)
+	}
+}
+
+// writeTestLogFile writes a log file to upload and returns its path.
+func writeTestLogFile(t *testing.T) string {
+	filename := filepath.Join(t.TempDir(), "install.log")
+	require.NoError(t, os.WriteFile(filename, []byte(testLogContents), 0644), "failed to write log file")
+	return filename
+}
+
+// expectedLogKey is the key the test log file is expected to be uploaded under.
+func expectedLogKey() string {
+	return "notarealcluster-" + testNamespace + "/" + testProvisionName + "-install.log"
+}
+
+type recordedRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   string
+}
+
+// newRecordingServer starts a server recording the requests it receives and answering them with the given status.
+func newRecordingServer(t *testing.T, status int, response string) (*httptest.Server, *[]recordedRequest) {
+	requests := &[]recordedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err, "failed to read request body")
+		*requests = append(*requests, recordedRequest{method: r.Method, path: r.URL.Path, header: r.Header, body: string(body)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestHTTPUploadLogs(t *testing.T) {
+	tests := []struct {
+		name                    string
+		existing                []runtime.Object
+		withCredentials         bool
+		status                  int
+		expectedAuthorization   string
+		expectedUploadLogsError bool
+	}{
+		{
+			name:   "no credentials",
+			status: http.StatusCreated,
+		},
+		{
+			name: "bearer token",
+			existing: []runtime.Object{testHTTPUploadSecret(map[string][]byte{
+				"token": []byte("abc123\n"),
+			})},
+			withCredentials:       true,
+			status:                http.StatusOK,
+			expectedAuthorization: "Bearer abc123",
+		},
+		{
+			name: "basic auth",
+			existing: []runtime.Object{testHTTPUploadSecret(map[string][]byte{
+				"username": []byte("user"),
+				"password": []byte("pass"),
+			})},
+			withCredentials:       true,
+			status:                http.StatusOK,
+			expectedAuthorization: "Basic dXNlcjpwYXNz",
+		},
+		{
+			name:                    "missing credentials secret",
+			withCredentials:         true,
+			status:                  http.StatusOK,
+			expectedUploadLogsError: true,
+		},
+		{
+			name:                    "server error",
+			status:                  http.StatusForbidden,
+			expectedUploadLogsError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mocks := setupDefaultMocks(t, test.existing...)
+			server, requests := newRecordingServer(t, test.status, "")
+
+			config := `{"http":{"url":"` + server.URL + `/logs/"}}`
+			if test.withCredentials {
+				config = `{"http":{"url":"` + server.URL + `/logs/","credentialsSecretRef":{"name":"http-creds"}}}`
+			}
+			defer setupInstallLogsUploadEnv(constants.InstallLogsUploadProviderHTTP, config)()
+
+			actuator := &httpLogUploaderActuator{}
+			require.True(t, actuator.IsConfigured(), "actuator should be configured")
+
+			err := actuator.UploadLogs("notarealcluster", testClusterProvision(), mocks.fakeKubeClient, log.New(), writeTestLogFile(t))
+
+			if test.expectedUploadLogsError {
+				assert.Error(t, err, "Function didn't error as expected")
+				return
+			}
+			require.NoError(t, err, "Function errored unexpectedly")
+			if assert.Len(t, *requests, 1, "unexpected number of requests") {
+				req := (*requests)[0]
+				assert.Equal(t, http.MethodPut, req.method, "unexpected method")
+				assert.Equal(t, "/logs/"+expectedLogKey(), req.path, "unexpected path")
+				assert.Equal(t, testLogContents, req.body, "unexpected body")
+				assert.Equal(t, test.expectedAuthorization, req.header.Get("Authorization"), "unexpected authorization")
+			}
+		})
+	}
+}
+
+func TestAzureBlobUploadLogs(t *testing.T) {
+	mocks := setupDefaultMocks(t)
+	server, requests := newRecordingServer(t, http.StatusCreated, "")
+
+	defer setupInstallLogsUploadEnv(constants.InstallLogsUploadProviderAzure,
+		`{"azure":{"credentialsSecretRef":{"name":"azure-creds"},"storageAccount":"account1","container":"container1","serviceEndpoint":"`+server.URL+`"}}`)()
+
+	var authorizedEnv azure.Environment
+	actuator := &azureBlobLogUploaderActuator{authorizerFn: func(_ client.Client, secretName, namespace string, env azure.Environment) (autorest.Authorizer, error) {
+		assert.Equal(t, "azure-creds", secretName, "unexpected secret name")
+		assert.Equal(t, testNamespace, namespace, "unexpected namespace")
+		authorizedEnv = env
+		return autorest.NullAuthorizer{}, nil
+	}}
+	require.True(t, actuator.IsConfigured(), "actuator should be configured")
+
+	err := actuator.UploadLogs("notarealcluster", testClusterProvision(), mocks.fakeKubeClient, log.New(), writeTestLogFile(t))
+	require.NoError(t, err, "Function errored unexpectedly")
+
+	assert.Equal(t, azure.PublicCloud.Name, authorizedEnv.Name, "unexpected cloud environment")
+	if assert.Len(t, *requests, 1, "unexpected number of requests") {
+		req := (*requests)[0]
+		assert.Equal(t, http.MethodPut, req.method, "unexpected method")
+		assert.Equal(t, "/container1/"+expectedLogKey(), req.path, "unexpected path")
+		assert.Equal(t, "BlockBlob", req.header.Get("x-ms-blob-type"), "unexpected blob type")
+		assert.Equal(t, testLogContents, req.body, "unexpected body")
+	}
+}
+
+func TestGCSUploadLogs(t *testing.T) {
+	mocks := setupDefaultMocks(t)
+	server, requests := newRecordingServer(t, http.StatusOK, `{"name":"`+expectedLogKey()+`"}`)
+
+	defer setupInstallLogsUploadEnv(constants.InstallLogsUploadProviderGCP,
+		`{"gcp":{"credentialsSecretRef":{"name":"gcp-creds"},"bucket":"bucket1"}}`)()
+
+	actuator := &gcsLogUploaderActuator{storageServiceFn: func(_ client.Client, secretName, namespace string) (*storage.Service, error) {
+		assert.Equal(t, "gcp-creds", secretName, "unexpected secret name")
+		assert.Equal(t, testNamespace, namespace, "unexpected namespace")
+		return storage.NewService(context.Background(), option.WithEndpoint(server.URL+"/storage/v1/"), option.WithoutAuthentication())
+	}}
+	require.True(t, actuator.IsConfigured(), "actuator should be configured")
+
+	err := actuator.UploadLogs("notarealcluster", testClusterProvision(), mocks.fakeKubeClient, log.New(), writeTestLogFile(t))
+	require.NoError(t, err, "Function errored unexpectedly")
+
+	if assert.Len(t, *requests, 1, "unexpected number of requests") {
+		req := (*requests)[0]
+		assert.Equal(t, http.MethodPost, req.method, "unexpected method")
+		assert.Equal(t, "/upload/storage/v1/b/bucket1/o", req.path, "unexpected path")
+		assert.Contains(t, req.body, expectedLogKey(), "object name not uploaded")
+		assert.Contains(t, req.body, testLogContents, "log contents not uploaded")
+	}
+}
+
+func TestPVCUploadLogs(t *testing.T) {
+	mocks := setupDefaultMocks(t)
+	defer setupInstallLogsUploadEnv(constants.InstallLogsUploadProviderPVC, `{"persistentVolumeClaim":{"claimName":"install-logs"}}`)()
+
+	dir := t.TempDir()
+	actuator := &pvcLogUploaderActuator{dir: dir}
+	require.True(t, actuator.IsConfigured(), "actuator should be configured")
+
+	err := actuator.UploadLogs("notarealcluster", testClusterProvision(), mocks.fakeKubeClient, log.New(), writeTestLogFile(t))
+	require.NoError(t, err, "Function errored unexpectedly")
+
+	contents, err := os.ReadFile(filepath.Join(dir, expectedLogKey()))
+	require.NoError(t, err, "log file not saved")
+	assert.Equal(t, testLogContents, string(contents), "unexpected log contents")
+
+	missing := &pvcLogUploaderActuator{dir: filepath.Join(dir, "missing")}
+	assert.Error(t, missing.UploadLogs("notarealcluster", testClusterProvision(), mocks.fakeKubeClient, log.New(), writeTestLogFile(t)),
+		"expected error when the claim is not mounted")
+}
+
+func TestIsConfiguredSelectsOneActuator(t *testing.T) {
+	actuators := []LogUploaderActuator{
+		&s3LogUploaderActuator{},
+		&azureBlobLogUploaderActuator{},
+		&gcsLogUploaderActuator{},
+		&httpLogUploaderActuator{},
+		&pvcLogUploaderActuator{},
+	}
+	for _, provider := range []string{
+		constants.InstallLogsUploadProviderAWS,
+		constants.InstallLogsUploadProviderAzure,
+		constants.InstallLogsUploadProviderGCP,
+		constants.InstallLogsUploadProviderHTTP,
+		constants.InstallLogsUploadProviderPVC,
+	} {
+		t.Run(provider, func(t *testing.T) {
+			defer setupInstallLogsUploadEnv(provider, "{}")()
+			configured := 0
+			for _, a := range actuators {
+				if a.IsConfigured() {
+					configured++
+				}
+			}
+			assert.Equal(t, 1, configured, "expected exactly one configured actuator")
+		})
+	}
+}
+
+func testHTTPUploadSecret(data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "http-creds",
+			Namespace: testNamespace,
+		},
+		Data: data,
+	}
+}
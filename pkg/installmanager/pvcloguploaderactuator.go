@@ -0,0 +1,53 @@
+package installmanager
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// Ensure pvcLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &pvcLogUploaderActuator{}
+
+// pvcLogUploaderActuator saves logs to the PersistentVolumeClaim mounted in the install pod.
+type pvcLogUploaderActuator struct {
+	// dir is the directory where the PersistentVolumeClaim is mounted.
+	dir string
+}
+
+// IsConfigured returns true if logs are to be saved to a PersistentVolumeClaim.
+func (a *pvcLogUploaderActuator) IsConfigured() bool {
+	return isInstallLogsUploadProvider(constants.InstallLogsUploadProviderPVC)
+}
+
+// UploadLogs copies installer logs to the PersistentVolumeClaim.
+func (a *pvcLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+	if _, err := os.Stat(a.dir); err != nil {
+		return errors.Wrap(err, "couldn't find the PersistentVolumeClaim mount. Skipping upload")
+	}
+
+	log.Infof("Saving log(s) to PersistentVolumeClaim: %v/", filepath.Join(a.dir, installLogsFolder(clusterName, clusterprovision)))
+
+	return uploadLogFiles(clusterName, clusterprovision, func(key string, file *os.File, _ int64) error {
+		dest := filepath.Join(a.dir, key)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		out, err := os.Create(dest)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, file); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	}, filenames...)
+}
@@ -1,12 +1,14 @@
 package installmanager
 
 import (
-	"fmt"
+	"context"
 	"os"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -14,89 +16,81 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 
 	"github.com/pkg/errors"
-	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
+// defaultInstallLogsAWSRegion is the region used for S3 operations when none is configured.
+const defaultInstallLogsAWSRegion = "us-east-1"
+
 // Ensure s3LogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
 var _ LogUploaderActuator = &s3LogUploaderActuator{}
 
 // s3LogUploaderActuator manages getting the desired state, getting the current state and reconciling the two.
 type s3LogUploaderActuator struct {
 	// awsClientFn is the function to build an AWS client, here for lazy loading the client.
-	awsClientFn func(client.Client, string, string, string, log.FieldLogger) (awsclient.Client, error)
+	awsClientFn func(client.Client, string, *hivev1.FailedProvisionAWSConfig, log.FieldLogger) (awsclient.Client, error)
 }
 
 // IsConfigured returns true if the actuator can handle a particular ClusterDeprovision
 func (a *s3LogUploaderActuator) IsConfigured() bool {
-	provider, foundProviderEnvVar := os.LookupEnv(constants.InstallLogsUploadProviderEnvVar)
-	if !foundProviderEnvVar {
-		log.Debug("Couldn't find install logs provider environment variable. Skipping.")
-		return false
-	}
-
-	return provider == constants.InstallLogsUploadProviderAWS
+	return isInstallLogsUploadProvider(constants.InstallLogsUploadProviderAWS)
 }
 
 // UploadLogs uploads installer logs to the provider's storage mechanism.
 func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
-	secretName, foundSecretName := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
-	if !foundSecretName {
-		return errors.New("couldn't find secret name in environment variable. Skipping upload")
+	config, err := readInstallLogsUploadConfig()
+	if err != nil {
+		return err
 	}
-
-	region, foundRegionEnvVar := os.LookupEnv(constants.InstallLogsAWSRegionEnvVar)
-	if !foundRegionEnvVar {
-		return errors.New("couldn't find region in environment variable. Skipping upload")
+	if config.AWS == nil {
+		return errors.New("couldn't find AWS upload config. Skipping upload")
 	}
 
-	bucket, foundBucketEnvVar := os.LookupEnv(constants.InstallLogsAWSS3BucketEnvVar)
-	if !foundBucketEnvVar {
-		return errors.New("couldn't find bucket in environment variable. Skipping upload")
-	}
-
-	awsc, err := a.awsClientFn(c, secretName, clusterprovision.Namespace, region, log)
+	awsc, err := a.awsClientFn(c, clusterprovision.Namespace, config.AWS, log)
 	if err != nil {
 		return err
 	}
 
-	retvalErrs := []error{}
-
-	folder := fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
-
-	log.Infof("Uploading log(s) to S3: s3://%v/%v/", bucket, folder)
-
-	for _, filename := range filenames {
-		file, err := os.Open(filename)
-		if err != nil {
-			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed opening log file: %v", filename))
-			continue
-		}
-		defer file.Close()
-
-		stat, err := file.Stat()
-		if err != nil {
-			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed stat on log file: %v", filename))
-			continue
-		}
-
-		logkey := fmt.Sprintf("%v/%v-%v", folder, clusterprovision.Name, stat.Name())
+	log.Infof("Uploading log(s) to S3: s3://%v/%v/", config.AWS.Bucket, installLogsFolder(clusterName, clusterprovision))
 
-		_, err = awsc.Upload(&s3manager.UploadInput{
-			Bucket: aws.String(bucket),
-			Key:    aws.String(logkey),
+	return uploadLogFiles(clusterName, clusterprovision, func(key string, file *os.File, _ int64) error {
+		_, err := awsc.Upload(&s3manager.UploadInput{
+			Bucket: aws.String(config.AWS.Bucket),
+			Key:    aws.String(key),
 			Body:   file,
 		})
+		return err
+	}, filenames...)
+}
 
+// getAWSClient builds an AWS client uploading to the S3 compatible provider of the config, if any.
+func getAWSClient(c client.Client, namespace string, config *hivev1.FailedProvisionAWSConfig, logger log.FieldLogger) (awsclient.Client, error) {
+	region := config.Region
+	if region == "" {
+		region = defaultInstallLogsAWSRegion
+	}
+	if config.ServiceEndpoint == "" && !config.ForcePathStyle {
+		awsClient, err := awsclient.NewClient(c, config.CredentialsSecretRef.Name, namespace, region)
 		if err != nil {
-			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed uploading log file: %v", filename))
+			logger.WithError(err).Error("failed to get AWS client")
 		}
+		return awsClient, err
 	}
 
-	return utilerrors.NewAggregate(retvalErrs)
-}
-
-func getAWSClient(c client.Client, secretName, namespace, region string, logger log.FieldLogger) (awsclient.Client, error) {
-	awsClient, err := awsclient.NewClient(c, secretName, namespace, region)
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: config.CredentialsSecretRef.Name, Namespace: namespace}, secret); err != nil {
+		logger.WithError(err).Error("failed to get AWS credentials secret")
+		return nil, err
+	}
+	s, err := awsclient.NewSessionFromSecret(secret, region)
+	if err != nil {
+		logger.WithError(err).Error("failed to create AWS session")
+		return nil, err
+	}
+	s3Config := &aws.Config{S3ForcePathStyle: aws.Bool(config.ForcePathStyle)}
+	if config.ServiceEndpoint != "" {
+		s3Config.Endpoint = aws.String(config.ServiceEndpoint)
+	}
+	awsClient, err := awsclient.NewClientFromSession(s.Copy(s3Config))
 	if err != nil {
 		logger.WithError(err).Error("failed to get AWS client")
 	}
@@ -12,6 +12,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 )
@@ -52,8 +53,7 @@ func TestUploadLogs(t *testing.T) {
 			if test.setupEnvVars {
 				os.Setenv(constants.InstallLogsUploadProviderEnvVar, constants.InstallLogsUploadProviderAWS)
 				os.Setenv(constants.InstallLogsCredentialsSecretRefEnvVar, "notarealsecret")
-				os.Setenv(constants.InstallLogsAWSRegionEnvVar, "region1")
-				os.Setenv(constants.InstallLogsAWSS3BucketEnvVar, "bucket1")
+				os.Setenv(constants.InstallLogsUploadConfigEnvVar, `{"aws":{"credentialsSecretRef":{"name":"notarealsecret"},"region":"region1","bucket":"bucket1"}}`)
 			}
 			if test.setupPutObjectMock {
 				mocks.mockAWSClient.EXPECT().
@@ -61,7 +61,7 @@ func TestUploadLogs(t *testing.T) {
 					Return(nil, test.putObjectError)
 			}
 
-			actuator := &s3LogUploaderActuator{awsClientFn: func(client.Client, string, string, string, log.FieldLogger) (awsclient.Client, error) {
+			actuator := &s3LogUploaderActuator{awsClientFn: func(client.Client, string, *hivev1.FailedProvisionAWSConfig, log.FieldLogger) (awsclient.Client, error) {
 				return mocks.mockAWSClient, nil
 			}}
 			provision := testClusterProvision()
@@ -79,8 +79,7 @@ func TestUploadLogs(t *testing.T) {
 			if test.setupEnvVars {
 				os.Unsetenv(constants.InstallLogsUploadProviderEnvVar)
 				os.Unsetenv(constants.InstallLogsCredentialsSecretRefEnvVar)
-				os.Unsetenv(constants.InstallLogsAWSRegionEnvVar)
-				os.Unsetenv(constants.InstallLogsAWSS3BucketEnvVar)
+				os.Unsetenv(constants.InstallLogsUploadConfigEnvVar)
 			}
 		})
 	}
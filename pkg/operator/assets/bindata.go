@@ -5,6 +5,7 @@
 // config/hiveadmission/apiservice.yaml
 // config/hiveadmission/clusterdeployment-webhook.yaml
 // config/hiveadmission/clusterimageset-webhook.yaml
+// config/hiveadmission/clusterpool-webhook.yaml
 // config/hiveadmission/clusterprovision-webhook.yaml
 // config/hiveadmission/deployment.yaml
 // config/hiveadmission/dnszones-webhook.yaml
@@ -363,6 +364,52 @@ func configHiveadmissionClusterimagesetWebhookYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionClusterpoolWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  annotations:
+    service.beta.openshift.io/inject-cabundle: "true"
+  name: clusterpoolvalidators.admission.hive.openshift.io
+webhooks:
+- name: clusterpoolvalidators.admission.hive.openshift.io
+  admissionReviewVersions:
+  - v1beta1
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterpoolvalidators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterpools
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionClusterpoolWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterpoolWebhookYaml, nil
+}
+
+func configHiveadmissionClusterpoolWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterpoolWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterpool-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionClusterprovisionWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1
 kind: ValidatingWebhookConfiguration
@@ -579,6 +626,23 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusterquotas
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusterdeployments
+  - clusterpools
+  verbs:
+  - get
+  - list
+  - watch
 - apiGroups:
   - authorization.k8s.io
   resources:
@@ -1211,6 +1275,9 @@ rules:
   - hive.openshift.io
   resources:
   - clusterimagesets
+  - clusterquotas
+  - clusterstatesummaries
+  - clusterupgrades
   - hiveconfigs
   - selectorsyncsets
   - selectorsyncidentityproviders
@@ -1596,6 +1663,9 @@ rules:
   - hive.openshift.io
   resources:
   - clusterimagesets
+  - clusterquotas
+  - clusterstatesummaries
+  - clusterupgrades
   - hiveconfigs
   verbs:
   - get
@@ -2007,29 +2077,34 @@ data:
       installFailingReason: InstallConfigNetworkBadCACert
       installFailingMessage: Failure attempting to create a network client - invalid CA certificate
 
-    # Keep these at the bottom so that they're only hit if nothing above matches.
+    # Keep these at the bottom, with a negative priority, so that they're only hit if nothing above
+    # or in the additional-install-log-regexes configmap matches.
     # We don't want to show these to users unless it's a last resort. It's barely better than "unknown error".
     # These are clues to SRE that they need to add more specific regexps to this file.
     - name: FallbackQuotaExceeded
       searchRegexStrings:
-      - "Quota '[A-Z_]*' exceeded"
+      - "Quota '(?P<quota>[A-Z_]*)' exceeded"
       installFailingReason: FallbackQuotaExceeded
-      installFailingMessage: Unknown quota exceeded - couldn't parse a specific resource type
+      installFailingMessage: Unknown quota exceeded (${quota}) - couldn't parse a specific resource type
+      priority: -1
     - name: FallbackResourceLimitExceeded
       searchRegexStrings:
-      - "LimitExceeded"
+      - "(?P<code>[A-Za-z.]*LimitExceeded)"
       installFailingReason: FallbackResourceLimitExceeded
-      installFailingMessage: Unknown resource limit exceeded - couldn't parse a specific resource type
+      installFailingMessage: Unknown resource limit exceeded (${code}) - couldn't parse a specific resource type
+      priority: -1
     - name: FallbackInvalidInstallConfig
       searchRegexStrings:
       - "failed to load asset \\\"Install Config\\\""
       installFailingReason: FallbackInvalidInstallConfig
       installFailingMessage: Unknown error - installer failed to load install config
+      priority: -1
     - name: FallbackInstancesFailedToBecomeReady
       searchRegexStrings:
       - "Error waiting for instance .* to become ready"
       installFailingReason: FallbackInstancesFailedToBecomeReady
       installFailingMessage: Unknown error - instances failed to become ready
+      priority: -1
 `)
 
 func configConfigmapsInstallLogRegexesConfigmapYamlBytes() ([]byte, error) {
@@ -2104,6 +2179,7 @@ var _bindata = map[string]func() (*asset, error){
 	"config/hiveadmission/apiservice.yaml":                      configHiveadmissionApiserviceYaml,
 	"config/hiveadmission/clusterdeployment-webhook.yaml":       configHiveadmissionClusterdeploymentWebhookYaml,
 	"config/hiveadmission/clusterimageset-webhook.yaml":         configHiveadmissionClusterimagesetWebhookYaml,
+	"config/hiveadmission/clusterpool-webhook.yaml":             configHiveadmissionClusterpoolWebhookYaml,
 	"config/hiveadmission/clusterprovision-webhook.yaml":        configHiveadmissionClusterprovisionWebhookYaml,
 	"config/hiveadmission/deployment.yaml":                      configHiveadmissionDeploymentYaml,
 	"config/hiveadmission/dnszones-webhook.yaml":                configHiveadmissionDnszonesWebhookYaml,
@@ -2189,6 +2265,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 			"apiservice.yaml":                      {configHiveadmissionApiserviceYaml, map[string]*bintree{}},
 			"clusterdeployment-webhook.yaml":       {configHiveadmissionClusterdeploymentWebhookYaml, map[string]*bintree{}},
 			"clusterimageset-webhook.yaml":         {configHiveadmissionClusterimagesetWebhookYaml, map[string]*bintree{}},
+			"clusterpool-webhook.yaml":             {configHiveadmissionClusterpoolWebhookYaml, map[string]*bintree{}},
 			"clusterprovision-webhook.yaml":        {configHiveadmissionClusterprovisionWebhookYaml, map[string]*bintree{}},
 			"deployment.yaml":                      {configHiveadmissionDeploymentYaml, map[string]*bintree{}},
 			"dnszones-webhook.yaml":                {configHiveadmissionDnszonesWebhookYaml, map[string]*bintree{}},
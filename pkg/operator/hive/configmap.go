@@ -115,6 +115,17 @@ var metricsConfigConfigMapInfo = configMapInfo{
 	},
 }
 
+var cloudResourceSweeperConfigMapInfo = configMapInfo{
+	name:                 "hive-cloud-resource-sweeper-config",
+	nameKey:              "hive-cloud-resource-sweeper-config",
+	mountPath:            "/data/cloud-resource-sweeper-config",
+	envVar:               constants.CloudResourceSweeperConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return &instance.Spec.CloudResources.Sweeper, nil
+	},
+}
+
 func (r *ReconcileHiveConfig) supportedContractsConfigMapInfo() configMapInfo {
 	f := func(instance *hivev1.HiveConfig) (interface{}, error) {
 		supported := map[string][]contracts.ContractImplementation{}
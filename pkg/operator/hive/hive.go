@@ -152,15 +152,16 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 	addConfigVolume(&hiveDeployment.Spec.Template.Spec, awsPrivateLinkConfigMapInfo, hiveContainer)
 	addConfigVolume(&hiveDeployment.Spec.Template.Spec, failedProvisionConfigMapInfo, hiveContainer)
 	addConfigVolume(&hiveDeployment.Spec.Template.Spec, metricsConfigConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, cloudResourceSweeperConfigMapInfo, hiveContainer)
 
 	// This triggers the clusterdeployment controller to copy the secret into the CD's namespace.
 	// It would be neat if it did that purely based on the FailedProvisionConfig ConfigMap, to
 	// which it does have access, but that code path is shared by other things that need the
 	// same copied secret.
-	if awsSpec := instance.Spec.FailedProvisionConfig.AWS; awsSpec != nil {
+	if _, credsRef := utils.InstallLogsUploadProvider(&instance.Spec.FailedProvisionConfig); credsRef != nil && credsRef.Name != "" {
 		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
 			Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
-			Value: awsSpec.CredentialsSecretRef.Name,
+			Value: credsRef.Name,
 		})
 	}
 
@@ -199,6 +200,23 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		}
 	}
 
+	if instance.Spec.CloudResources.Inventory.Enabled {
+		hLog.Infof("Cloud resource inventory enabled")
+		tmpEnvVar := corev1.EnvVar{
+			Name:  constants.CloudResourceInventoryEnvVar,
+			Value: "true",
+		}
+		hiveContainer.Env = append(hiveContainer.Env, tmpEnvVar)
+
+		if interval := instance.Spec.CloudResources.Inventory.Interval; interval != "" {
+			tmpEnvVar := corev1.EnvVar{
+				Name:  constants.CloudResourceInventoryIntervalEnvVar,
+				Value: interval,
+			}
+			hiveContainer.Env = append(hiveContainer.Env, tmpEnvVar)
+		}
+	}
+
 	if instance.Spec.ArgoCD.Enabled {
 		hLog.Infof("ArgoCD integration enabled")
 		tmpEnvVar := corev1.EnvVar{
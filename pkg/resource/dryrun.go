@@ -0,0 +1,205 @@
+package resource
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jonboulle/clockwork"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	kresource "k8s.io/cli-runtime/pkg/resource"
+	kcmdapply "k8s.io/kubectl/pkg/cmd/apply"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	kubectlutil "k8s.io/kubectl/pkg/util"
+)
+
+// dryRunIgnoredFields are the fields that the server may change on every apply and that therefore do not indicate
+// a change to the resource.
+var dryRunIgnoredFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration"},
+}
+
+// DryRunApply performs a dry-run of Apply: it computes the same client-side apply patch as Apply, from the
+// last-applied-configuration annotation of the live resource, and submits it to the target cluster with
+// dryRun=All. It returns the change that applying the resource would make along with the paths of any fields
+// that would be modified, added or removed.
+func (r *helper) DryRunApply(obj []byte) (ApplyResult, []string, error) {
+	return r.dryRun(obj, r.dryRunApply)
+}
+
+// DryRunCreateOrUpdate performs a dry-run of CreateOrUpdate: it computes the same patch as CreateOrUpdate, from the
+// resource as given rather than with a last-applied-configuration annotation, and submits it to the target cluster
+// with dryRun=All. It returns the change that CreateOrUpdate would make along with the paths of any fields that would
+// be modified, added or removed.
+func (r *helper) DryRunCreateOrUpdate(obj []byte) (ApplyResult, []string, error) {
+	return r.dryRun(obj, r.dryRunCreateOrUpdate)
+}
+
+// DryRunCreate performs a dry-run of Create: a resource that already exists is left unchanged, whatever its content,
+// and a resource that does not is submitted for creation to the target cluster with dryRun=All.
+func (r *helper) DryRunCreate(obj []byte) (ApplyResult, []string, error) {
+	return r.dryRun(obj, r.dryRunCreate)
+}
+
+func (r *helper) dryRun(obj []byte, fn func(cmdutil.Factory, []byte, io.Writer) (ApplyResult, []string, error)) (ApplyResult, []string, error) {
+	factory, err := r.getFactory("")
+	if err != nil {
+		r.logger.WithError(err).Error("failed to obtain factory for dry-run apply")
+		return "", nil, err
+	}
+	errOut := &bytes.Buffer{}
+	result, changedFields, err := fn(factory, obj, errOut)
+	if err != nil {
+		r.logger.WithError(err).
+			WithField("stderr", errOut.String()).Warn("running the dry-run apply failed")
+		return "", nil, err
+	}
+	return result, changedFields, nil
+}
+
+func (r *helper) dryRunApply(f cmdutil.Factory, obj []byte, errOut io.Writer) (ApplyResult, []string, error) {
+	info, err := r.getResourceInternalInfo(f, obj)
+	if err != nil {
+		return "", nil, err
+	}
+	// As in kubectl apply, the modified configuration includes the last-applied-configuration annotation.
+	modified, err := kubectlutil.GetModifiedConfiguration(info.Object, true, unstructured.UnstructuredJSONScheme)
+	if err != nil {
+		return "", nil, err
+	}
+	return r.dryRunPatch(info, modified, errOut)
+}
+
+func (r *helper) dryRunCreateOrUpdate(f cmdutil.Factory, obj []byte, errOut io.Writer) (ApplyResult, []string, error) {
+	info, err := r.getResourceInternalInfo(f, obj)
+	if err != nil {
+		return "", nil, err
+	}
+	// As in createOrUpdate, the modified configuration is the resource as given.
+	modified, err := runtime.Encode(unstructured.UnstructuredJSONScheme, info.Object)
+	if err != nil {
+		return "", nil, err
+	}
+	return r.dryRunPatch(info, modified, errOut)
+}
+
+func (r *helper) dryRunCreate(f cmdutil.Factory, obj []byte, _ io.Writer) (ApplyResult, []string, error) {
+	info, err := r.getResourceInternalInfo(f, obj)
+	if err != nil {
+		return "", nil, err
+	}
+	sourceObj := info.Object.DeepCopyObject()
+	// As in createOnly, a resource with no name is always created.
+	if info.Name != "" {
+		err = info.Get()
+		if err == nil {
+			return UnchangedApplyResult, nil, nil
+		}
+		if !errors.IsNotFound(err) {
+			return "", nil, err
+		}
+	}
+	helper := kresource.NewHelper(info.Client, info.Mapping).DryRun(true)
+	if _, err := helper.Create(info.Namespace, true, sourceObj); err != nil {
+		return "", nil, err
+	}
+	return CreatedApplyResult, nil, nil
+}
+
+// dryRunPatch submits the patch from the live resource to the modified configuration with dryRun=All, or the
+// creation of the resource if it does not exist.
+func (r *helper) dryRunPatch(info *kresource.Info, modified []byte, errOut io.Writer) (ApplyResult, []string, error) {
+	sourceObj := info.Object.DeepCopyObject()
+	helper := kresource.NewHelper(info.Client, info.Mapping).DryRun(true)
+	if err := info.Get(); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", nil, err
+		}
+		// Object doesn't exist yet. Have the server validate that it could be created.
+		if _, err := helper.Create(info.Namespace, true, sourceObj); err != nil {
+			return "", nil, err
+		}
+		return CreatedApplyResult, nil, nil
+	}
+	patcher := kcmdapply.Patcher{
+		Mapping:       info.Mapping,
+		Helper:        helper,
+		Overwrite:     true,
+		BackOff:       clockwork.NewRealClock(),
+		OpenAPIGetter: annoyingIndirectOpenAPIResourcesGetter{r.openAPISchema},
+	}
+	patch, applied, err := patcher.Patch(info.Object, modified, info.Source, info.Namespace, info.Name, errOut)
+	if err != nil {
+		return "", nil, err
+	}
+	if string(patch) == "{}" {
+		return UnchangedApplyResult, nil, nil
+	}
+	appliedObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(applied)
+	if err != nil {
+		return "", nil, err
+	}
+	changedFields := diffFields(info.Object.(*unstructured.Unstructured).Object, appliedObj)
+	if len(changedFields) == 0 {
+		return UnchangedApplyResult, nil, nil
+	}
+	return ConfiguredApplyResult, changedFields, nil
+}
+
+// diffFields returns the sorted paths of the fields that differ between the live and the applied objects.
+// Lists are compared as a whole.
+func diffFields(live, applied map[string]interface{}) []string {
+	live = runtime.DeepCopyJSON(live)
+	applied = runtime.DeepCopyJSON(applied)
+	for _, path := range dryRunIgnoredFields {
+		unstructured.RemoveNestedField(live, path...)
+		unstructured.RemoveNestedField(applied, path...)
+	}
+	var changed []string
+	diffMaps(nil, live, applied, &changed)
+	sort.Strings(changed)
+	return changed
+}
+
+func diffMaps(path []string, live, applied map[string]interface{}, changed *[]string) {
+	keys := map[string]bool{}
+	for k := range live {
+		keys[k] = true
+	}
+	for k := range applied {
+		keys[k] = true
+	}
+	for k := range keys {
+		fieldPath := append(append([]string{}, path...), k)
+		liveMap, liveIsMap := live[k].(map[string]interface{})
+		appliedMap, appliedIsMap := applied[k].(map[string]interface{})
+		switch {
+		case liveIsMap && appliedIsMap:
+			diffMaps(fieldPath, liveMap, appliedMap, changed)
+		case !reflect.DeepEqual(live[k], applied[k]):
+			*changed = append(*changed, formatFieldPath(fieldPath))
+		}
+	}
+}
+
+// formatFieldPath joins the elements of a field path with dots, quoting elements that contain dots themselves
+// (e.g. label and annotation keys).
+func formatFieldPath(path []string) string {
+	elems := make([]string, len(path))
+	for i, p := range path {
+		if strings.Contains(p, ".") {
+			p = fmt.Sprintf("[%q]", p)
+		}
+		elems[i] = p
+	}
+	return strings.Replace(strings.Join(elems, "."), ".[", "[", -1)
+}
@@ -0,0 +1,165 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffFields(t *testing.T) {
+	cases := []struct {
+		name     string
+		live     map[string]interface{}
+		applied  map[string]interface{}
+		expected []string
+	}{
+		{
+			name: "unchanged",
+			live: map[string]interface{}{
+				"data": map[string]interface{}{"foo": "bar"},
+			},
+			applied: map[string]interface{}{
+				"data": map[string]interface{}{"foo": "bar"},
+			},
+		},
+		{
+			name: "modified",
+			live: map[string]interface{}{
+				"data": map[string]interface{}{"foo": "bar", "baz": "qux"},
+			},
+			applied: map[string]interface{}{
+				"data": map[string]interface{}{"foo": "changed", "baz": "qux"},
+			},
+			expected: []string{"data.foo"},
+		},
+		{
+			name: "added",
+			live: map[string]interface{}{
+				"data": map[string]interface{}{"foo": "bar"},
+			},
+			applied: map[string]interface{}{
+				"data": map[string]interface{}{"foo": "bar", "baz": "qux"},
+			},
+			expected: []string{"data.baz"},
+		},
+		{
+			name: "removed",
+			live: map[string]interface{}{
+				"data": map[string]interface{}{"foo": "bar", "baz": "qux"},
+			},
+			applied: map[string]interface{}{
+				"data": map[string]interface{}{"foo": "bar"},
+			},
+			expected: []string{"data.baz"},
+		},
+		{
+			name: "removed map",
+			live: map[string]interface{}{
+				"data": map[string]interface{}{"foo": "bar"},
+			},
+			applied:  map[string]interface{}{},
+			expected: []string{"data"},
+		},
+		{
+			name: "map replaced by scalar",
+			live: map[string]interface{}{
+				"spec": map[string]interface{}{"foo": map[string]interface{}{"bar": "baz"}},
+			},
+			applied: map[string]interface{}{
+				"spec": map[string]interface{}{"foo": "bar"},
+			},
+			expected: []string{"spec.foo"},
+		},
+		{
+			name: "lists compared as a whole",
+			live: map[string]interface{}{
+				"spec": map[string]interface{}{"items": []interface{}{"a", "b"}},
+			},
+			applied: map[string]interface{}{
+				"spec": map[string]interface{}{"items": []interface{}{"a", "c"}},
+			},
+			expected: []string{"spec.items"},
+		},
+		{
+			name: "ignored fields",
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"resourceVersion": "1",
+					"generation":      int64(1),
+					"managedFields":   []interface{}{"a"},
+					"annotations": map[string]interface{}{
+						"kubectl.kubernetes.io/last-applied-configuration": "{}",
+					},
+				},
+			},
+			applied: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"resourceVersion": "2",
+					"generation":      int64(2),
+					"managedFields":   []interface{}{"b"},
+					"annotations": map[string]interface{}{
+						"kubectl.kubernetes.io/last-applied-configuration": `{"data":{}}`,
+					},
+				},
+			},
+		},
+		{
+			name: "keys with dots",
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"example.com/team": "a"},
+				},
+			},
+			applied: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"example.com/team": "b", "app": "c"},
+				},
+			},
+			expected: []string{`metadata.labels.app`, `metadata.labels["example.com/team"]`},
+		},
+		{
+			name: "sorted",
+			live: map[string]interface{}{
+				"b": "1",
+				"a": map[string]interface{}{"d": "1", "c": "1"},
+			},
+			applied: map[string]interface{}{
+				"b": "2",
+				"a": map[string]interface{}{"d": "2", "c": "2"},
+			},
+			expected: []string{"a.c", "a.d", "b"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			live := deepCopy(tc.live)
+			applied := deepCopy(tc.applied)
+			actual := diffFields(tc.live, tc.applied)
+			assert.Equal(t, tc.expected, actual, "unexpected changed fields")
+			assert.Equal(t, live, tc.live, "live object was modified")
+			assert.Equal(t, applied, tc.applied, "applied object was modified")
+		})
+	}
+}
+
+func TestDiffMaps(t *testing.T) {
+	var changed []string
+	diffMaps(
+		[]string{"spec"},
+		map[string]interface{}{"foo": "bar", "nested": map[string]interface{}{"a": "1"}},
+		map[string]interface{}{"foo": "bar", "nested": map[string]interface{}{"a": "2"}},
+		&changed,
+	)
+	assert.Equal(t, []string{"spec.nested.a"}, changed, "unexpected changed fields")
+}
+
+func deepCopy(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if vm, ok := v.(map[string]interface{}); ok {
+			v = deepCopy(vm)
+		}
+		out[k] = v
+	}
+	return out
+}
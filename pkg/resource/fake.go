@@ -5,6 +5,7 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -66,6 +67,18 @@ func (r *fakeHelper) CreateRuntimeObject(obj runtime.Object, scheme *runtime.Sch
 	return ConfiguredApplyResult, nil
 }
 
+func (r *fakeHelper) DryRunApply(obj []byte) (ApplyResult, []string, error) {
+	return UnchangedApplyResult, nil, nil
+}
+
+func (r *fakeHelper) DryRunCreateOrUpdate(obj []byte) (ApplyResult, []string, error) {
+	return UnchangedApplyResult, nil, nil
+}
+
+func (r *fakeHelper) DryRunCreate(obj []byte) (ApplyResult, []string, error) {
+	return UnchangedApplyResult, nil, nil
+}
+
 func (r *fakeHelper) Info(obj []byte) (*Info, error) {
 	// TODO: Do we need to fake this better?
 	return &Info{}, nil
@@ -78,3 +91,12 @@ func (fakeHelper) Patch(name types.NamespacedName, kind, apiVersion string, patc
 func (fakeHelper) Delete(apiVersion, kind, namespace, name string) error {
 	return nil
 }
+
+func (fakeHelper) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -30,11 +31,20 @@ type Helper interface {
 	CreateOrUpdateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error)
 	Create(obj []byte) (ApplyResult, error)
 	CreateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error)
+	// DryRunApply performs a dry run of Apply of the given resource bytes to the target cluster. It returns
+	// the change that applying the resource would make along with the paths of any fields that would be modified.
+	DryRunApply(obj []byte) (ApplyResult, []string, error)
+	// DryRunCreateOrUpdate performs a dry run of CreateOrUpdate of the given resource bytes to the target cluster.
+	DryRunCreateOrUpdate(obj []byte) (ApplyResult, []string, error)
+	// DryRunCreate performs a dry run of Create of the given resource bytes to the target cluster.
+	DryRunCreate(obj []byte) (ApplyResult, []string, error)
 	// Info determines the name/namespace and type of the passed in resource bytes
 	Info(obj []byte) (*Info, error)
 	// Patch invokes the kubectl patch command with the given resource, patch and patch type
 	Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error
 	Delete(apiVersion, kind, namespace, name string) error
+	// Get retrieves the resource with the given type, namespace and name from the target cluster
+	Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error)
 }
 
 // helper contains configuration for apply and patch operations
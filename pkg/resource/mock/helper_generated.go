@@ -9,6 +9,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	resource "github.com/openshift/hive/pkg/resource"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	types "k8s.io/apimachinery/pkg/types"
 )
@@ -140,6 +141,69 @@ func (mr *MockHelperMockRecorder) Delete(apiVersion, kind, namespace, name inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHelper)(nil).Delete), apiVersion, kind, namespace, name)
 }
 
+// DryRunApply mocks base method.
+func (m *MockHelper) DryRunApply(obj []byte) (resource.ApplyResult, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunApply", obj)
+	ret0, _ := ret[0].(resource.ApplyResult)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DryRunApply indicates an expected call of DryRunApply.
+func (mr *MockHelperMockRecorder) DryRunApply(obj interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunApply", reflect.TypeOf((*MockHelper)(nil).DryRunApply), obj)
+}
+
+// DryRunCreate mocks base method.
+func (m *MockHelper) DryRunCreate(obj []byte) (resource.ApplyResult, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunCreate", obj)
+	ret0, _ := ret[0].(resource.ApplyResult)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DryRunCreate indicates an expected call of DryRunCreate.
+func (mr *MockHelperMockRecorder) DryRunCreate(obj interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunCreate", reflect.TypeOf((*MockHelper)(nil).DryRunCreate), obj)
+}
+
+// DryRunCreateOrUpdate mocks base method.
+func (m *MockHelper) DryRunCreateOrUpdate(obj []byte) (resource.ApplyResult, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunCreateOrUpdate", obj)
+	ret0, _ := ret[0].(resource.ApplyResult)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DryRunCreateOrUpdate indicates an expected call of DryRunCreateOrUpdate.
+func (mr *MockHelperMockRecorder) DryRunCreateOrUpdate(obj interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunCreateOrUpdate", reflect.TypeOf((*MockHelper)(nil).DryRunCreateOrUpdate), obj)
+}
+
+// Get mocks base method.
+func (m *MockHelper) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", apiVersion, kind, namespace, name)
+	ret0, _ := ret[0].(*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockHelperMockRecorder) Get(apiVersion, kind, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHelper)(nil).Get), apiVersion, kind, namespace, name)
+}
+
 // Info mocks base method.
 func (m *MockHelper) Info(obj []byte) (*resource.Info, error) {
 	m.ctrl.T.Helper()
@@ -111,3 +111,33 @@ func WithLifetime(lifetime time.Duration) Option {
 		clusterClaim.Spec.Lifetime = &metav1.Duration{Duration: lifetime}
 	}
 }
+
+func WithPriority(priority int32) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.Priority = priority
+	}
+}
+
+// WithExtension appends a request to extend the lifetime of the ClusterClaim
+func WithExtension(duration time.Duration, reason string) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.Extensions = append(clusterClaim.Spec.Extensions, hivev1.ClusterClaimExtension{
+			Duration: metav1.Duration{Duration: duration},
+			Reason:   reason,
+		})
+	}
+}
+
+// WithRelease requests that the cluster of the ClusterClaim be released
+func WithRelease(reason string) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.Release = &hivev1.ClusterClaimRelease{Reason: reason}
+	}
+}
+
+// WithConstraints sets the constraints used to choose a pool for the ClusterClaim
+func WithConstraints(constraints *hivev1.ClusterClaimConstraints) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.Constraints = constraints
+	}
+}
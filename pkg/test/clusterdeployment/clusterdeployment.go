@@ -203,6 +203,12 @@ func WithHibernateAfter(dur time.Duration) Option {
 	}
 }
 
+func WithHibernationSchedule(schedule *hivev1.HibernationSchedule) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.HibernationSchedule = schedule
+	}
+}
+
 // WithAWSPlatform sets the specified aws platform on the supplied object.
 func WithAWSPlatform(platform *hivev1aws.Platform) Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
@@ -243,3 +249,11 @@ func WithCustomization(cdcName string) Option {
 		clusterDeployment.Spec.ClusterPoolRef.CustomizationRef = &corev1.LocalObjectReference{Name: cdcName}
 	}
 }
+
+// WithInventoryRef records that the ClusterPool inventory entry of the given kind is reserved for the cd.
+func WithInventoryRef(kind hivev1.InventoryEntryKind, name string) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.ClusterPoolRef.InventoryRefs = append(clusterDeployment.Spec.ClusterPoolRef.InventoryRefs,
+			hivev1.InventoryEntry{Kind: kind, Name: name})
+	}
+}
@@ -1,6 +1,7 @@
 package clusterdeploymentcustomization
 
 import (
+	"encoding/json"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -109,6 +110,46 @@ func WithPatch(path, op, value string) Option {
 	}
 }
 
+// WithInstallConfigPatch adds an install-config patch with a typed value that is encoded as JSON.
+func WithInstallConfigPatch(path, op string, value interface{}) Option {
+	return func(cdc *hivev1.ClusterDeploymentCustomization) {
+		cdc.Spec.InstallConfigPatches = append(cdc.Spec.InstallConfigPatches, patchEntity(path, op, value))
+	}
+}
+
+// WithClusterDeploymentPatch adds a ClusterDeployment patch with a typed value that is encoded as JSON.
+func WithClusterDeploymentPatch(path, op string, value interface{}) Option {
+	return func(cdc *hivev1.ClusterDeploymentCustomization) {
+		cdc.Spec.ClusterDeploymentPatches = append(cdc.Spec.ClusterDeploymentPatches, patchEntity(path, op, value))
+	}
+}
+
+// WithMachinePoolPatch adds a MachinePool patch with a typed value that is encoded as JSON.
+func WithMachinePoolPatch(path, op string, value interface{}) Option {
+	return func(cdc *hivev1.ClusterDeploymentCustomization) {
+		cdc.Spec.MachinePoolPatches = append(cdc.Spec.MachinePoolPatches, patchEntity(path, op, value))
+	}
+}
+
+// WithManifestPatch adds a manifest patch with a typed value that is encoded as JSON.
+func WithManifestPatch(path, op string, value interface{}) Option {
+	return func(cdc *hivev1.ClusterDeploymentCustomization) {
+		cdc.Spec.ManifestPatches = append(cdc.Spec.ManifestPatches, patchEntity(path, op, value))
+	}
+}
+
+func patchEntity(path, op string, value interface{}) hivev1.PatchEntity {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	return hivev1.PatchEntity{
+		Path:       path,
+		Op:         op,
+		TypedValue: &runtime.RawExtension{Raw: raw},
+	}
+}
+
 func WithApplySucceeded(reason string, change time.Time) Option {
 	return func(cdc *hivev1.ClusterDeploymentCustomization) {
 		status := corev1.ConditionTrue
@@ -195,6 +195,15 @@ func WithCondition(cond hivev1.ClusterPoolCondition) Option {
 	}
 }
 
+func WithHibernationSchedule(schedule *hivev1.HibernationSchedule) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		if clusterPool.Spec.HibernationConfig == nil {
+			clusterPool.Spec.HibernationConfig = &hivev1.HibernationConfig{}
+		}
+		clusterPool.Spec.HibernationConfig.Schedule = schedule
+	}
+}
+
 func WithRunningCount(size int) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
 		clusterPool.Spec.RunningCount = int32(size)
@@ -214,3 +223,30 @@ func WithInventory(cdcs []string) Option {
 		}
 	}
 }
+
+// WithInventoryEntries appends entries of the given kind to the pool's inventory.
+func WithInventoryEntries(kind hivev1.InventoryEntryKind, names ...string) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		for _, name := range names {
+			clusterPool.Spec.Inventory = append(clusterPool.Spec.Inventory, hivev1.InventoryEntry{Kind: kind, Name: name})
+		}
+	}
+}
+
+func WithAutoscaling(autoscaling *hivev1.ClusterPoolAutoscaling) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Autoscaling = autoscaling
+	}
+}
+
+func WithClaimPolicy(policy *hivev1.ClusterPoolClaimPolicy) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.ClaimPolicy = policy
+	}
+}
+
+func WithReleaseRollover(rollover *hivev1.ClusterPoolReleaseRollover) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.ReleaseRollover = rollover
+	}
+}
@@ -87,3 +87,21 @@ func WithClusterDeploymentSelector(key, value string) Option {
 		}
 	}
 }
+
+func WithDryRun() Option {
+	return func(clusterRelocate *hivev1.ClusterRelocate) {
+		clusterRelocate.Spec.DryRun = true
+	}
+}
+
+func WithClusterStatus(clusterStatus hivev1.ClusterRelocateClusterStatus) Option {
+	return func(clusterRelocate *hivev1.ClusterRelocate) {
+		clusterRelocate.Status.Clusters = append(clusterRelocate.Status.Clusters, clusterStatus)
+	}
+}
+
+func WithCancel() Option {
+	return func(clusterRelocate *hivev1.ClusterRelocate) {
+		clusterRelocate.Spec.Cancel = true
+	}
+}
@@ -0,0 +1,104 @@
+package clusterstate
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/test/generic"
+)
+
+// Option defines a function signature for any function that wants to be passed into Build
+type Option func(*hivev1.ClusterState)
+
+// Build runs each of the functions passed in to generate the object.
+func Build(opts ...Option) *hivev1.ClusterState {
+	retval := &hivev1.ClusterState{}
+	for _, o := range opts {
+		o(retval)
+	}
+
+	return retval
+}
+
+type Builder interface {
+	Build(opts ...Option) *hivev1.ClusterState
+
+	Options(opts ...Option) Builder
+
+	GenericOptions(opts ...generic.Option) Builder
+}
+
+func BasicBuilder() Builder {
+	return &builder{}
+}
+
+func FullBuilder(namespace, name string, typer runtime.ObjectTyper) Builder {
+	b := &builder{}
+	return b.GenericOptions(
+		generic.WithTypeMeta(typer),
+		generic.WithResourceVersion("1"),
+		generic.WithNamespace(namespace),
+		generic.WithName(name),
+	)
+}
+
+type builder struct {
+	options []Option
+}
+
+func (b *builder) Build(opts ...Option) *hivev1.ClusterState {
+	return Build(append(b.options, opts...)...)
+}
+
+func (b *builder) Options(opts ...Option) Builder {
+	return &builder{
+		options: append(b.options, opts...),
+	}
+}
+
+func (b *builder) GenericOptions(opts ...generic.Option) Builder {
+	options := make([]Option, len(opts))
+	for i, o := range opts {
+		options[i] = Generic(o)
+	}
+	return b.Options(options...)
+}
+
+// Generic allows common functions applicable to all objects to be used as Options to Build
+func Generic(opt generic.Option) Option {
+	return func(clusterState *hivev1.ClusterState) {
+		opt(clusterState)
+	}
+}
+
+// WithName sets the object.Name field when building an object with Build.
+func WithName(name string) Option {
+	return Generic(generic.WithName(name))
+}
+
+// WithNamespace sets the object.Namespace field when building an object with Build.
+func WithNamespace(namespace string) Option {
+	return Generic(generic.WithNamespace(namespace))
+}
+
+// WithOperators appends the operator states to the status of the ClusterState.
+func WithOperators(operators ...hivev1.ClusterOperatorState) Option {
+	return func(clusterState *hivev1.ClusterState) {
+		clusterState.Status.ClusterOperators = append(clusterState.Status.ClusterOperators, operators...)
+	}
+}
+
+// OperatorState returns the state of a cluster operator with the given Degraded, Available and Progressing
+// conditions.
+func OperatorState(name string, degraded, available, progressing configv1.ConditionStatus) hivev1.ClusterOperatorState {
+	return hivev1.ClusterOperatorState{
+		Name: name,
+		Conditions: []configv1.ClusterOperatorStatusCondition{
+			{Type: configv1.OperatorDegraded, Status: degraded},
+			{Type: configv1.OperatorAvailable, Status: available},
+			{Type: configv1.OperatorProgressing, Status: progressing},
+		},
+	}
+}
@@ -125,3 +125,21 @@ func WithPatches(patches ...hivev1.SyncObjectPatch) Option {
 		selectorSyncSet.Spec.Patches = patches
 	}
 }
+
+func WithHealthChecks(healthChecks ...hivev1.SyncSetHealthCheck) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.HealthChecks = healthChecks
+	}
+}
+
+func WithRolloutStrategy(strategy *hivev1.SelectorSyncSetRolloutStrategy) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.RolloutStrategy = strategy
+	}
+}
+
+func WithRolloutStatus(rollout *hivev1.SelectorSyncSetRolloutStatus) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Status.Rollout = rollout
+	}
+}
@@ -149,3 +149,21 @@ func WithEnableResourceTemplates(on bool) Option {
 		syncSet.Spec.EnableResourceTemplates = on
 	}
 }
+
+func WithDryRun(on bool) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.DryRun = on
+	}
+}
+
+func WithHealthChecks(healthChecks ...hivev1.SyncSetHealthCheck) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.HealthChecks = healthChecks
+	}
+}
+
+func WithDriftDetection(driftDetection hivev1.SyncSetDriftDetection) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.DriftDetection = driftDetection
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -33,6 +34,7 @@ import (
 
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/manageddns"
 	"github.com/openshift/hive/pkg/util/contracts"
 )
@@ -47,12 +49,16 @@ const (
 )
 
 var (
-	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "InstallAttemptsLimit", "Platform.AgentBareMetal.AgentSelector", "Platform.AWS.PrivateLink.AdditionalAllowedPrincipals"}
+	clusterDeploymentGroupResource = schema.GroupResource{Group: clusterDeploymentGroup, Resource: clusterDeploymentResource}
+
+	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "HibernationSchedule", "InstallAttemptsLimit", "Platform.AgentBareMetal.AgentSelector", "Platform.AWS.PrivateLink.AdditionalAllowedPrincipals"}
 )
 
 // ClusterDeploymentValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type ClusterDeploymentValidatingAdmissionHook struct {
 	decoder *admission.Decoder
+	// client is used to enforce ClusterQuotas. Quotas are not enforced if it is nil.
+	client client.Client
 
 	validManagedDomains  []string
 	fs                   *featureSet
@@ -120,7 +126,16 @@ func (a *ClusterDeploymentValidatingAdmissionHook) Initialize(kubeClientConfig *
 		"version":  clusterDeploymentAdmissionVersion,
 		"resource": "clusterdeploymentvalidator",
 	}).Info("Initializing validation REST resource")
-	return nil // No initialization needed right now.
+	if kubeClientConfig == nil {
+		// Without a client, ClusterQuotas are not enforced.
+		return nil
+	}
+	c, err := getClusterQuotaClient(kubeClientConfig, stopCh)
+	if err != nil {
+		return err
+	}
+	a.client = c
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -299,6 +314,8 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 		allErrs = append(allErrs, validateAWSPrivateLink(specPath.Child("platform", "aws"), cd.Spec.Platform.AWS, a.awsPrivateLinkConfig)...)
 	}
 
+	allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationSchedule"), cd.Spec.HibernationSchedule)...)
+
 	if cd.Spec.Provisioning != nil {
 		if cd.Spec.Provisioning.SSHPrivateKeySecretRef != nil && cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name == "" {
 			allErrs = append(allErrs, field.Required(specPath.Child("provisioning", "sshPrivateKeySecretRef", "name"), "must specify a name for the ssh private key secret if the ssh private key secret is specified"))
@@ -341,6 +358,19 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 		}
 	}
 
+	// Disable this check for DR
+	if !dr {
+		requested := hivev1.ClusterQuotaUsage{ClusterDeployments: 1}
+		// The ClusterPool controller enforces the running quota for the clusters of a pool, since it decides which of
+		// them are running.
+		if cd.Spec.ClusterPoolRef == nil && controllerutils.IsRunningForClusterQuota(cd) {
+			requested.RunningClusterDeployments = 1
+		}
+		if r := validateClusterQuotas(a.client, clusterDeploymentGroupResource, cd.Name, controllerutils.ClusterQuotaNamespace(cd), requested, contextLogger); r != nil {
+			return r
+		}
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
@@ -373,6 +403,17 @@ func validateAWSPrivateLink(path *field.Path, platform *hivev1aws.Platform, conf
 	return allErrs
 }
 
+func validateHibernationSchedule(path *field.Path, schedule *hivev1.HibernationSchedule) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if schedule == nil {
+		return allErrs
+	}
+	if err := controllerutils.ValidateHibernationSchedule(schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(path, schedule, err.Error()))
+	}
+	return allErrs
+}
+
 /* TODO: move to explicit validation for AgentClusterInstall */
 /*
 func validateAgentInstallStrategy(specPath *field.Path, cd *hivev1.ClusterDeployment) field.ErrorList {
@@ -622,7 +663,7 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 	// Add the new data to the contextLogger
 	contextLogger.Data["oldObject.Name"] = oldObject.Name
 
-	hasChangedImmutableField, unsupportedDiff := hasChangedImmutableField(&oldObject.Spec, &cd.Spec)
+	hasChangedImmutableField, unsupportedDiff := hasChangedImmutableField(specWithRetryFallbackRegion(oldObject), &cd.Spec)
 	if hasChangedImmutableField {
 		message := fmt.Sprintf("Attempted to change ClusterDeployment.Spec which is immutable except for %s fields. Unsupported change: \n%s", strings.Join(mutableFields, ","), unsupportedDiff)
 		contextLogger.Infof("Failed validation: %v", message)
@@ -722,6 +763,8 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 		allErrs = append(allErrs, field.Invalid(specPath.Child("clusterPoolRef"), newPoolRef, "cannot add clusterPoolRef"))
 	}
 
+	allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationSchedule"), cd.Spec.HibernationSchedule)...)
+
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
@@ -731,6 +774,16 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 		}
 	}
 
+	// The clusterpool controller keeps the number of running unclaimed clusters within the quota, and resumes them
+	// when they are claimed. Once claimed, clusters are resumed by their claimant, so are checked like any other.
+	unclaimedPoolCluster := oldObject.Spec.ClusterPoolRef != nil && oldObject.Spec.ClusterPoolRef.ClaimName == ""
+	if !unclaimedPoolCluster && !controllerutils.IsRunningForClusterQuota(oldObject) && controllerutils.IsRunningForClusterQuota(cd) {
+		requested := hivev1.ClusterQuotaUsage{RunningClusterDeployments: 1}
+		if r := validateClusterQuotas(a.client, clusterDeploymentGroupResource, cd.Name, controllerutils.ClusterQuotaNamespace(cd), requested, contextLogger); r != nil {
+			return r
+		}
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
@@ -791,6 +844,19 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateDelete(request *admis
 	}
 }
 
+// specWithRetryFallbackRegion returns the spec of a ClusterDeployment with the region of the retry policy fallback
+// recorded in its status, if there is one. Hive changes the region of a cluster to that region before retrying to
+// install it, so the region may change to it until the cluster is installed.
+func specWithRetryFallbackRegion(cd *hivev1.ClusterDeployment) *hivev1.ClusterDeploymentSpec {
+	retries := cd.Status.ProvisionRetries
+	if cd.Spec.Installed || retries == nil || retries.Fallback == nil || retries.Fallback.Region == "" {
+		return &cd.Spec
+	}
+	cd = cd.DeepCopy()
+	controllerutils.SetClusterRegion(cd, retries.Fallback.Region)
+	return &cd.Spec
+}
+
 // hasChangedImmutableField determines if a ClusterDeployment.spec immutable field was changed.
 // it returns the diff string that shows the changes that are not supported
 func hasChangedImmutableField(oldObject, cd *hivev1.ClusterDeploymentSpec) (bool, string) {
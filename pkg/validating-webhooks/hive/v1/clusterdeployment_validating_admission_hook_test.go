@@ -26,6 +26,7 @@ import (
 	hivecontractsv1alpha1 "github.com/openshift/hive/apis/hivecontracts/v1alpha1"
 
 	"github.com/openshift/hive/pkg/constants"
+	testfake "github.com/openshift/hive/pkg/test/fake"
 	"github.com/openshift/hive/pkg/util/contracts"
 )
 
@@ -190,6 +191,24 @@ func validClusterDeploymentDifferentMutableValue() *hivev1.ClusterDeployment {
 	return cd
 }
 
+func clusterDeploymentInNamespace(namespace, name string, powerState hivev1.ClusterPowerState) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Namespace = namespace
+	cd.Name = name
+	cd.Spec.PowerState = powerState
+	return cd
+}
+
+// poolClusterInNamespace returns a ClusterDeployment of a pool in the given namespace, claimed by the given claim if
+// it is not empty.
+func poolClusterInNamespace(poolNS, claimName string, powerState hivev1.ClusterPowerState) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeploymentFromPool(poolNS, "pool", claimName)
+	cd.Namespace = "pool-cluster-namespace"
+	cd.Name = "pool-cd"
+	cd.Spec.PowerState = powerState
+	return cd
+}
+
 func TestClusterDeploymentValidatingResource(t *testing.T) {
 	// Arrange
 	data := NewClusterDeploymentValidatingAdmissionHook(createDecoder(t))
@@ -232,6 +251,8 @@ func TestClusterDeploymentValidate(t *testing.T) {
 		enabledFeatureGates []string
 		awsPrivateLink      *hivev1.AWSPrivateLinkConfig
 		supportedContracts  contracts.SupportedContractImplementationsList
+		// existing, if set, are the objects used to enforce ClusterQuotas
+		existing []runtime.Object
 	}{
 		{
 			name:            "Test valid create",
@@ -401,6 +422,38 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: true,
 		},
+		{
+			name:      "Test Update HibernationSchedule",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = &hivev1.HibernationSchedule{
+					TimeZone: "America/New_York",
+					RunWindows: []hivev1.HibernationRunWindow{{
+						Days:  []hivev1.Weekday{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+						Start: "08:00",
+						End:   "19:00",
+					}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test Update HibernationSchedule with invalid time zone",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = &hivev1.HibernationSchedule{
+					TimeZone:   "Not/A_Zone",
+					RunWindows: []hivev1.HibernationRunWindow{{Start: "08:00", End: "19:00"}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test Update Operation is NOT allowed with different immutable data",
 			oldObject:       validAWSClusterDeployment(),
@@ -825,6 +878,65 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: false,
 		},
+		{
+			name: "Azure update region to retry fallback region",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Status.ProvisionRetries = &hivev1.ProvisionRetriesStatus{
+					Fallback: &hivev1.ProvisionRetryFallback{Region: "fallback-region"},
+				}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.Region = "fallback-region"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Azure update region to other than retry fallback region",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Status.ProvisionRetries = &hivev1.ProvisionRetriesStatus{
+					Fallback: &hivev1.ProvisionRetryFallback{Region: "fallback-region"},
+				}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.Region = "other-region"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure update region to retry fallback region: installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+					InfraID: "an-infra-id",
+				}
+				cd.Status.ProvisionRetries = &hivev1.ProvisionRetriesStatus{
+					Fallback: &hivev1.ProvisionRetryFallback{Region: "fallback-region"},
+				}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+					InfraID: "an-infra-id",
+				}
+				cd.Spec.Platform.Azure.Region = "fallback-region"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
 		{
 			name: "Azure set resource group name: installed",
 			oldObject: func() *hivev1.ClusterDeployment {
@@ -1561,6 +1673,101 @@ func TestClusterDeploymentValidate(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name:      "create within cluster deployment quota",
+			newObject: clusterDeploymentInNamespace("test-namespace", "new-cd", ""),
+			operation: admissionv1beta1.Create,
+			existing: clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{ClusterDeployments: pointer.Int32Ptr(2)},
+				clusterDeploymentInNamespace("test-namespace", "cd1", ""),
+			),
+			expectedAllowed: true,
+		},
+		{
+			name:      "create exceeding cluster deployment quota",
+			newObject: clusterDeploymentInNamespace("test-namespace", "new-cd", hivev1.ClusterPowerStateHibernating),
+			operation: admissionv1beta1.Create,
+			existing: clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{ClusterDeployments: pointer.Int32Ptr(2)},
+				clusterDeploymentInNamespace("test-namespace", "cd1", ""),
+				clusterDeploymentInNamespace("test-namespace", "cd2", hivev1.ClusterPowerStateHibernating),
+			),
+			expectedAllowed: false,
+		},
+		{
+			name: "create pool cluster exceeding pool namespace cluster deployment quota",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeploymentFromPool("test-namespace", "pool", "")
+				cd.Namespace = "pool-cluster-namespace"
+				cd.Name = "new-cd"
+				return cd
+			}(),
+			operation: admissionv1beta1.Create,
+			existing: clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{ClusterDeployments: pointer.Int32Ptr(1)},
+				clusterDeploymentInNamespace("test-namespace", "cd1", ""),
+			),
+			expectedAllowed: false,
+		},
+		{
+			name:      "create running exceeding running quota",
+			newObject: clusterDeploymentInNamespace("test-namespace", "new-cd", hivev1.ClusterPowerStateRunning),
+			operation: admissionv1beta1.Create,
+			existing: clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{RunningClusterDeployments: pointer.Int32Ptr(1)},
+				clusterDeploymentInNamespace("test-namespace", "cd1", ""),
+			),
+			expectedAllowed: false,
+		},
+		{
+			name:      "create hibernating within running quota",
+			newObject: clusterDeploymentInNamespace("test-namespace", "new-cd", hivev1.ClusterPowerStateHibernating),
+			operation: admissionv1beta1.Create,
+			existing: clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{RunningClusterDeployments: pointer.Int32Ptr(1)},
+				clusterDeploymentInNamespace("test-namespace", "cd1", ""),
+			),
+			expectedAllowed: true,
+		},
+		{
+			name:      "resume exceeding running quota",
+			oldObject: clusterDeploymentInNamespace("test-namespace", "cd2", hivev1.ClusterPowerStateHibernating),
+			newObject: clusterDeploymentInNamespace("test-namespace", "cd2", hivev1.ClusterPowerStateRunning),
+			operation: admissionv1beta1.Update,
+			existing: clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{RunningClusterDeployments: pointer.Int32Ptr(1)},
+				clusterDeploymentInNamespace("test-namespace", "cd1", ""),
+				clusterDeploymentInNamespace("test-namespace", "cd2", hivev1.ClusterPowerStateHibernating),
+			),
+			expectedAllowed: false,
+		},
+		{
+			name:      "resume claimed pool cluster exceeding pool namespace running quota",
+			oldObject: poolClusterInNamespace("test-namespace", "claim", hivev1.ClusterPowerStateHibernating),
+			newObject: poolClusterInNamespace("test-namespace", "claim", hivev1.ClusterPowerStateRunning),
+			operation: admissionv1beta1.Update,
+			existing: clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{RunningClusterDeployments: pointer.Int32Ptr(1)},
+				clusterDeploymentInNamespace("test-namespace", "cd1", ""),
+				poolClusterInNamespace("test-namespace", "claim", hivev1.ClusterPowerStateHibernating),
+			),
+			expectedAllowed: false,
+		},
+		{
+			name:      "resume unclaimed pool cluster exceeding pool namespace running quota",
+			oldObject: poolClusterInNamespace("test-namespace", "", hivev1.ClusterPowerStateHibernating),
+			newObject: poolClusterInNamespace("test-namespace", "", hivev1.ClusterPowerStateRunning),
+			operation: admissionv1beta1.Update,
+			existing: clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{RunningClusterDeployments: pointer.Int32Ptr(1)},
+				clusterDeploymentInNamespace("test-namespace", "cd1", ""),
+				poolClusterInNamespace("test-namespace", "", hivev1.ClusterPowerStateHibernating),
+			),
+			expectedAllowed: true,
+		},
+		{
+			name:      "hibernate over running quota",
+			oldObject: clusterDeploymentInNamespace("test-namespace", "cd2", hivev1.ClusterPowerStateRunning),
+			newObject: clusterDeploymentInNamespace("test-namespace", "cd2", hivev1.ClusterPowerStateHibernating),
+			operation: admissionv1beta1.Update,
+			existing: clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{RunningClusterDeployments: pointer.Int32Ptr(1)},
+				clusterDeploymentInNamespace("test-namespace", "cd1", ""),
+				clusterDeploymentInNamespace("test-namespace", "cd2", hivev1.ClusterPowerStateRunning),
+			),
+			expectedAllowed: true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1577,6 +1784,9 @@ func TestClusterDeploymentValidate(t *testing.T) {
 				awsPrivateLinkConfig: tc.awsPrivateLink,
 				supportedContracts:   tc.supportedContracts,
 			}
+			if tc.existing != nil {
+				data.client = testfake.NewFakeClientBuilder().WithRuntimeObjects(tc.existing...).Build()
+			}
 
 			if tc.gvr == nil {
 				tc.gvr = &metav1.GroupVersionResource{
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -18,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
@@ -179,7 +181,7 @@ func (a *ClusterDeploymentCustomizationValidatingAdmissionHook) validateCreate(a
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
-	allErrs = append(allErrs, validateInstallConfigPatches(specPath.Child("installConfigPatches"), cdc.Spec.InstallConfigPatches)...)
+	allErrs = append(allErrs, validateCustomizationSpec(specPath, &cdc.Spec)...)
 
 	if len(allErrs) > 0 {
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
@@ -239,7 +241,7 @@ func (a *ClusterDeploymentCustomizationValidatingAdmissionHook) validateUpdate(a
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
-	allErrs = append(allErrs, validateInstallConfigPatches(specPath, newObject.Spec.InstallConfigPatches)...)
+	allErrs = append(allErrs, validateCustomizationSpec(specPath, &newObject.Spec)...)
 
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
@@ -257,15 +259,45 @@ func (a *ClusterDeploymentCustomizationValidatingAdmissionHook) validateUpdate(a
 	}
 }
 
-func validateInstallConfigPatches(path *field.Path, patches []hivev1.PatchEntity) field.ErrorList {
+func validateCustomizationSpec(path *field.Path, spec *hivev1.ClusterDeploymentCustomizationSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateCustomizationPatches(path.Child("installConfigPatches"), "install config", spec.InstallConfigPatches)...)
+	allErrs = append(allErrs, validateCustomizationPatches(path.Child("clusterDeploymentPatches"), "cluster deployment", spec.ClusterDeploymentPatches)...)
+	allErrs = append(allErrs, validateCustomizationPatches(path.Child("machinePoolPatches"), "machine pool", spec.MachinePoolPatches)...)
+	allErrs = append(allErrs, validateCustomizationPatches(path.Child("manifestPatches"), "manifest", spec.ManifestPatches)...)
+	for i, patch := range spec.ClusterDeploymentPatches {
+		if controllerutils.IsProtectedPatchPath(patch.Path) || (patch.From != "" && controllerutils.IsProtectedPatchPath(patch.From)) {
+			allErrs = append(allErrs, field.Invalid(path.Child("clusterDeploymentPatches").Index(i), patch,
+				"cluster deployment patch must not change the name, namespace or clusterPoolRef, nor replace the labels or annotations"))
+		}
+	}
+	return allErrs
+}
+
+func validateCustomizationPatches(path *field.Path, target string, patches []hivev1.PatchEntity) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	for i, patch := range patches {
-		if !isValidOP(yamlpatch.Op(patch.Op)) {
-			allErrs = append(allErrs, field.Invalid(path.Index(i), patch, "install config patch op must be a valid json patch operation"))
+		op := yamlpatch.Op(patch.Op)
+		if !isValidOP(op) {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), patch, fmt.Sprintf("%s patch op must be a valid json patch operation", target)))
 		}
 		if len(patch.Path) == 0 || !strings.HasPrefix(patch.Path, "/") {
-			allErrs = append(allErrs, field.Invalid(path.Index(i), patch, "install config patch path must start with '/'"))
+			allErrs = append(allErrs, field.Invalid(path.Index(i), patch, fmt.Sprintf("%s patch path must start with '/'", target)))
+		}
+		if patch.TypedValue != nil && len(patch.TypedValue.Raw) > 0 {
+			if patch.Value != "" {
+				allErrs = append(allErrs, field.Invalid(path.Index(i).Child("value"), patch.Value, fmt.Sprintf("%s patch value must be empty if typedValue is set", target)))
+			}
+			if !json.Valid(patch.TypedValue.Raw) {
+				allErrs = append(allErrs, field.Invalid(path.Index(i).Child("typedValue"), string(patch.TypedValue.Raw), fmt.Sprintf("%s patch typedValue must be valid JSON", target)))
+			}
+		}
+		switch op {
+		case yamlpatch.OpMove, yamlpatch.OpCopy:
+			if len(patch.From) == 0 || !strings.HasPrefix(patch.From, "/") {
+				allErrs = append(allErrs, field.Invalid(path.Index(i).Child("from"), patch.From, fmt.Sprintf("%s patch from must start with '/' for %s", target, op)))
+			}
 		}
 	}
 	return allErrs
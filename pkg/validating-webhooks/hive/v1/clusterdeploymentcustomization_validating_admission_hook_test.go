@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestValidateCustomizationSpec_ClusterDeploymentPatches(t *testing.T) {
+	cases := []struct {
+		name        string
+		patch       hivev1.PatchEntity
+		expectValid bool
+	}{
+		{
+			name:        "label entry",
+			patch:       hivev1.PatchEntity{Op: "add", Path: "/metadata/labels/topology", Value: "large"},
+			expectValid: true,
+		},
+		{
+			name:        "annotation entry",
+			patch:       hivev1.PatchEntity{Op: "remove", Path: "/metadata/annotations/example.com~1note"},
+			expectValid: true,
+		},
+		{
+			name:        "platform field",
+			patch:       hivev1.PatchEntity{Op: "replace", Path: "/spec/platform/aws/region", Value: "us-west-2"},
+			expectValid: true,
+		},
+		{
+			name:  "name",
+			patch: hivev1.PatchEntity{Op: "replace", Path: "/metadata/name", Value: "other"},
+		},
+		{
+			name:  "namespace",
+			patch: hivev1.PatchEntity{Op: "replace", Path: "/metadata/namespace", Value: "other"},
+		},
+		{
+			name:  "whole metadata",
+			patch: hivev1.PatchEntity{Op: "remove", Path: "/metadata"},
+		},
+		{
+			name:  "whole labels",
+			patch: hivev1.PatchEntity{Op: "replace", Path: "/metadata/labels", Value: "{}"},
+		},
+		{
+			name:  "whole annotations",
+			patch: hivev1.PatchEntity{Op: "remove", Path: "/metadata/annotations"},
+		},
+		{
+			name:  "cluster pool reference",
+			patch: hivev1.PatchEntity{Op: "remove", Path: "/spec/clusterPoolRef"},
+		},
+		{
+			name:  "cluster pool reference field",
+			patch: hivev1.PatchEntity{Op: "replace", Path: "/spec/clusterPoolRef/poolName", Value: "other"},
+		},
+		{
+			name:  "whole spec",
+			patch: hivev1.PatchEntity{Op: "remove", Path: "/spec"},
+		},
+		{
+			name:  "move from cluster pool reference",
+			patch: hivev1.PatchEntity{Op: "move", From: "/spec/clusterPoolRef/namespace", Path: "/metadata/labels/pool"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &hivev1.ClusterDeploymentCustomizationSpec{ClusterDeploymentPatches: []hivev1.PatchEntity{tc.patch}}
+			errs := validateCustomizationSpec(field.NewPath("spec"), spec)
+			if tc.expectValid {
+				assert.Empty(t, errs, "unexpected validation errors")
+			} else {
+				assert.NotEmpty(t, errs, "expected validation error")
+			}
+		})
+	}
+}
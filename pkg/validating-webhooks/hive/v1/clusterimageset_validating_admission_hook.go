@@ -2,10 +2,12 @@ package v1
 
 import (
 	"net/http"
+	"net/url"
 	"os"
 	"regexp"
 	"strings"
 
+	"github.com/blang/semver/v4"
 	log "github.com/sirupsen/logrus"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
@@ -161,7 +163,7 @@ func (a *ClusterImageSetValidatingAdmissionHook) validateCreate(admissionSpec *a
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
-	allErrs = append(allErrs, validateReleaseImage(specPath.Child("releaseImage"), newObject.Spec.ReleaseImage)...)
+	allErrs = append(allErrs, validateClusterImageSetSpec(specPath, &newObject.Spec)...)
 
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
@@ -222,7 +224,7 @@ func (a *ClusterImageSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
-	allErrs = append(allErrs, validateReleaseImage(specPath.Child("releaseImage"), newObject.Spec.ReleaseImage)...)
+	allErrs = append(allErrs, validateClusterImageSetSpec(specPath, &newObject.Spec)...)
 
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
@@ -240,6 +242,43 @@ func (a *ClusterImageSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 	}
 }
 
+func validateClusterImageSetSpec(path *field.Path, spec *hivev1.ClusterImageSetSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Channel != nil {
+		allErrs = append(allErrs, validateChannel(path.Child("channel"), spec.Channel)...)
+		// The release image is set by Hive once the channel is resolved.
+		if spec.ReleaseImage == "" {
+			return allErrs
+		}
+	}
+	return append(allErrs, validateReleaseImage(path.Child("releaseImage"), spec.ReleaseImage)...)
+}
+
+func validateChannel(path *field.Path, channel *hivev1.ClusterImageSetChannel) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if channel.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("name"), "channel name must be specified"))
+	}
+	switch {
+	case channel.GraphURL == "" && channel.GraphConfigMapRef == nil:
+		allErrs = append(allErrs, field.Required(path, "one of graphURL or graphConfigMapRef must be specified"))
+	case channel.GraphURL != "" && channel.GraphConfigMapRef != nil:
+		allErrs = append(allErrs, field.Invalid(path, channel.GraphURL, "only one of graphURL or graphConfigMapRef may be specified"))
+	case channel.GraphURL != "":
+		if u, err := url.Parse(channel.GraphURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(path.Child("graphURL"), channel.GraphURL, "must be an http or https URL"))
+		}
+	case channel.GraphConfigMapRef.Name == "":
+		allErrs = append(allErrs, field.Required(path.Child("graphConfigMapRef", "name"), "ConfigMap name must be specified"))
+	}
+	if channel.Version != "" {
+		if _, err := semver.ParseRange(channel.Version); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("version"), channel.Version, err.Error()))
+		}
+	}
+	return allErrs
+}
+
 // validReleaseDigest is a verification rule to filter clearly invalid digests.
 var validReleaseDigest = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$`)
 
@@ -7,6 +7,7 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/stretchr/testify/assert"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -90,6 +91,73 @@ func TestClusterImageSetValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
+		{
+			name: "Test valid ClusterImageSet.Spec with channel and no release image",
+			newSpec: hivev1.ClusterImageSetSpec{
+				Channel: &hivev1.ClusterImageSetChannel{
+					Name:     "stable-4.14",
+					GraphURL: "https://graph.example.com/api/upgrades_info/v1/graph",
+					Version:  "4.14.x",
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test valid ClusterImageSet.Spec with channel from ConfigMap",
+			newSpec: hivev1.ClusterImageSetSpec{
+				ReleaseImage: "image@sha256:abc",
+				Channel: &hivev1.ClusterImageSetChannel{
+					Name:              "stable-4.14",
+					GraphConfigMapRef: &corev1.LocalObjectReference{Name: "graph"},
+				},
+			},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test invalid ClusterImageSet.Spec with channel without graph",
+			newSpec: hivev1.ClusterImageSetSpec{
+				Channel: &hivev1.ClusterImageSetChannel{Name: "stable-4.14"},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test invalid ClusterImageSet.Spec with channel with both graph sources",
+			newSpec: hivev1.ClusterImageSetSpec{
+				Channel: &hivev1.ClusterImageSetChannel{
+					Name:              "stable-4.14",
+					GraphURL:          "https://graph.example.com/api/upgrades_info/v1/graph",
+					GraphConfigMapRef: &corev1.LocalObjectReference{Name: "graph"},
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test invalid ClusterImageSet.Spec with channel with bad URL",
+			newSpec: hivev1.ClusterImageSetSpec{
+				Channel: &hivev1.ClusterImageSetChannel{
+					Name:     "stable-4.14",
+					GraphURL: "graph.example.com",
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test invalid ClusterImageSet.Spec with channel with bad version range",
+			newSpec: hivev1.ClusterImageSetSpec{
+				Channel: &hivev1.ClusterImageSetChannel{
+					Name:     "stable-4.14",
+					GraphURL: "https://graph.example.com/api/upgrades_info/v1/graph",
+					Version:  "four",
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test unable to marshal new object during create",
 			newObjectRaw:    []byte{0},
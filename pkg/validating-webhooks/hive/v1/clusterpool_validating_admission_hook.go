@@ -3,6 +3,7 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -10,12 +11,15 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
@@ -27,9 +31,13 @@ const (
 	clusterPoolAdmissionVersion = "v1"
 )
 
+var clusterPoolGroupResource = schema.GroupResource{Group: clusterPoolGroup, Resource: clusterPoolResource}
+
 // ClusterPoolValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type ClusterPoolValidatingAdmissionHook struct {
 	decoder *admission.Decoder
+	// client is used to enforce ClusterQuotas. Quotas are not enforced if it is nil.
+	client client.Client
 }
 
 // NewClusterPoolValidatingAdmissionHook constructs a new ClusterPoolValidatingAdmissionHook
@@ -66,7 +74,16 @@ func (a *ClusterPoolValidatingAdmissionHook) Initialize(kubeClientConfig *rest.C
 		"version":  clusterPoolAdmissionVersion,
 		"resource": "clusterpoolvalidator",
 	}).Info("Initializing validation REST resource")
-	return nil // No initialization needed right now.
+	if kubeClientConfig == nil {
+		// Without a client, ClusterQuotas are not enforced.
+		return nil
+	}
+	c, err := getClusterQuotaClient(kubeClientConfig, stopCh)
+	if err != nil {
+		return err
+	}
+	a.client = c
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -174,10 +191,7 @@ func (a *ClusterPoolValidatingAdmissionHook) validateCreate(admissionSpec *admis
 		}
 	}
 
-	allErrs := field.ErrorList{}
-	specPath := field.NewPath("spec")
-
-	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs := validateClusterPoolSpec(field.NewPath("spec"), &newObject.Spec)
 
 	if len(allErrs) > 0 {
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
@@ -187,6 +201,11 @@ func (a *ClusterPoolValidatingAdmissionHook) validateCreate(admissionSpec *admis
 		}
 	}
 
+	requested := hivev1.ClusterQuotaUsage{ClusterPoolSize: controllerutils.ClusterPoolQuotaSize(newObject)}
+	if r := validateClusterQuotas(a.client, clusterPoolGroupResource, newObject.Name, newObject.Namespace, requested, contextLogger); r != nil {
+		return r
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
@@ -234,23 +253,99 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 	// Add the new data to the contextLogger
 	contextLogger.Data["oldObject.Name"] = oldObject.Name
 
-	allErrs := field.ErrorList{}
+	// Pools created before this webhook was registered may already fail validation. Errors in fields that were
+	// already invalid are returned as warnings, so that such pools may still be updated, e.g. scaled down, and only
+	// errors introduced by the update are denied.
 	specPath := field.NewPath("spec")
-
-	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs, existingErrs := newFieldErrors(
+		validateClusterPoolSpec(specPath, &newObject.Spec),
+		validateClusterPoolSpec(specPath, &oldObject.Spec),
+	)
+	var warnings []string
+	for _, err := range existingErrs {
+		warnings = append(warnings, err.Error())
+	}
 
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
 		return &admissionv1beta1.AdmissionResponse{
-			Allowed: false,
-			Result:  &status,
+			Allowed:  false,
+			Result:   &status,
+			Warnings: warnings,
+		}
+	}
+	if len(warnings) > 0 {
+		contextLogger.WithField("warnings", warnings).Info("allowing update of cluster pool that was already invalid")
+	}
+
+	// Only growth of the pool is checked, so that a pool may always be shrunk toward its quota.
+	if newObject.DeletionTimestamp == nil {
+		requested := hivev1.ClusterQuotaUsage{
+			ClusterPoolSize: controllerutils.ClusterPoolQuotaSize(newObject) - controllerutils.ClusterPoolQuotaSize(oldObject),
+		}
+		if r := validateClusterQuotas(a.client, clusterPoolGroupResource, newObject.Name, newObject.Namespace, requested, contextLogger); r != nil {
+			r.Warnings = warnings
+			return r
 		}
 	}
 
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
-		Allowed: true,
+		Allowed:  true,
+		Warnings: warnings,
+	}
+}
+
+// validateClusterPoolSpec validates the fields of a ClusterPool spec checked on both create and update.
+func validateClusterPoolSpec(specPath *field.Path, spec *hivev1.ClusterPoolSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateClusterPlatform(specPath, spec.Platform)...)
+	if spec.HibernationConfig != nil {
+		allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationConfig", "schedule"), spec.HibernationConfig.Schedule)...)
+	}
+	allErrs = append(allErrs, validateClusterPoolAutoscaling(specPath, spec)...)
+	return allErrs
+}
+
+// newFieldErrors splits the errors of an updated object into those in fields that had no error before the update,
+// and those in fields that were already invalid.
+func newFieldErrors(newErrs, oldErrs field.ErrorList) (introduced, existing field.ErrorList) {
+	oldFields := sets.NewString()
+	for _, err := range oldErrs {
+		oldFields.Insert(err.Field)
+	}
+	for _, err := range newErrs {
+		if oldFields.Has(err.Field) {
+			existing = append(existing, err)
+		} else {
+			introduced = append(introduced, err)
+		}
+	}
+	return introduced, existing
+}
+
+// validateClusterPoolAutoscaling ensures the autoscaling bounds are consistent with the pool's Size and RunningCount,
+// which act as the lower bounds.
+func validateClusterPoolAutoscaling(specPath *field.Path, spec *hivev1.ClusterPoolSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	as := spec.Autoscaling
+	if as == nil {
+		return allErrs
+	}
+	asPath := specPath.Child("autoscaling")
+	if as.MaxSize < spec.Size {
+		allErrs = append(allErrs, field.Invalid(asPath.Child("maxSize"), as.MaxSize, "must not be less than size"))
+	}
+	if as.MaxRunningCount != nil && *as.MaxRunningCount < spec.RunningCount {
+		allErrs = append(allErrs, field.Invalid(asPath.Child("maxRunningCount"), *as.MaxRunningCount, "must not be less than runningCount"))
+	}
+	if as.Window != nil && as.Window.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(asPath.Child("window"), as.Window.Duration.String(), "must be positive"))
+	}
+	if as.History != nil && as.History.Duration < 24*time.Hour {
+		allErrs = append(allErrs, field.Invalid(asPath.Child("history"), as.History.Duration.String(), "must be at least one day"))
 	}
+	return allErrs
 }
@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -10,12 +11,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
 	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testfake "github.com/openshift/hive/pkg/test/fake"
 )
 
 func clusterPoolTemplate() *hivev1.ClusterPool {
@@ -61,6 +65,27 @@ func invalidOpenStackClusterPool() *hivev1.ClusterPool {
 	return cp
 }
 
+// clusterQuotaObjects returns a namespace selected by a ClusterQuota with the given limits, the quota, and the other
+// objects given. The usage of the quota is recorded in its status, as the clusterquota controller would.
+func clusterQuotaObjects(namespace string, hard hivev1.ClusterQuotaLimits, objs ...runtime.Object) []runtime.Object {
+	quota := &hivev1.ClusterQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-quota"},
+		Spec: hivev1.ClusterQuotaSpec{
+			NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "test"}},
+			Hard:              hard,
+		},
+	}
+	objs = append(objs, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace, Labels: map[string]string{"tenant": "test"}},
+	})
+	consumption, err := controllerutils.GetClusterQuotaConsumption(testfake.NewFakeClientBuilder().WithRuntimeObjects(objs...).Build(), quota)
+	if err != nil {
+		panic(err)
+	}
+	quota.Status.Used = consumption.Used
+	return append(objs, quota)
+}
+
 func TestClusterPoolInitialize(t *testing.T) {
 	data := NewClusterPoolValidatingAdmissionHook(createDecoder(t))
 	err := data.Initialize(nil, nil)
@@ -76,7 +101,11 @@ func TestClusterPoolValidate(t *testing.T) {
 		oldObjectRaw    []byte
 		operation       admissionv1beta1.Operation
 		expectedAllowed bool
-		gvr             *metav1.GroupVersionResource
+		// expectedWarnings is the number of warnings expected in the response
+		expectedWarnings int
+		gvr              *metav1.GroupVersionResource
+		// existing, if set, are the objects used to enforce ClusterQuotas
+		existing []runtime.Object
 	}{
 		{
 			name:            "Test valid create",
@@ -216,6 +245,244 @@ func TestClusterPoolValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name: "valid hibernation schedule",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.HibernationConfig = &hivev1.HibernationConfig{
+					Schedule: &hivev1.HibernationSchedule{
+						TimeZone:   "America/New_York",
+						RunWindows: []hivev1.HibernationRunWindow{{Start: "08:00", End: "19:00"}},
+					},
+				}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "hibernation schedule with invalid time zone",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.HibernationConfig = &hivev1.HibernationConfig{
+					Schedule: &hivev1.HibernationSchedule{
+						TimeZone:   "Not/A_Zone",
+						RunWindows: []hivev1.HibernationRunWindow{{Start: "08:00", End: "19:00"}},
+					},
+				}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "valid autoscaling",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Size = 1
+				cp.Spec.RunningCount = 1
+				cp.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+					Mode:            hivev1.ClusterPoolAutoscalingTimeOfDay,
+					MaxSize:         5,
+					MaxRunningCount: pointer.Int32Ptr(2),
+					History:         &metav1.Duration{Duration: 72 * time.Hour},
+				}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "autoscaling max size less than size",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Size = 3
+				cp.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MaxSize: 2}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Update,
+			oldObject:       validAWSClusterPool(),
+			expectedAllowed: false,
+		},
+		{
+			name: "scale down pool with existing invalid hibernation schedule",
+			oldObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Size = 3
+				cp.Spec.HibernationConfig = &hivev1.HibernationConfig{
+					Schedule: &hivev1.HibernationSchedule{TimeZone: "Not/A_Zone"},
+				}
+				return cp
+			}(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Size = 2
+				cp.Spec.HibernationConfig = &hivev1.HibernationConfig{
+					Schedule: &hivev1.HibernationSchedule{TimeZone: "Not/A_Zone"},
+				}
+				return cp
+			}(),
+			operation:        admissionv1beta1.Update,
+			expectedAllowed:  true,
+			expectedWarnings: 1,
+		},
+		{
+			name: "scale down pool with existing autoscaling max size less than size",
+			oldObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Size = 5
+				cp.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MaxSize: 3}
+				return cp
+			}(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Size = 4
+				cp.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MaxSize: 3}
+				return cp
+			}(),
+			operation:        admissionv1beta1.Update,
+			expectedAllowed:  true,
+			expectedWarnings: 1,
+		},
+		{
+			name: "invalid hibernation schedule added to pool with existing autoscaling error",
+			oldObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Size = 5
+				cp.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MaxSize: 3}
+				return cp
+			}(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Size = 5
+				cp.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MaxSize: 3}
+				cp.Spec.HibernationConfig = &hivev1.HibernationConfig{
+					Schedule: &hivev1.HibernationSchedule{TimeZone: "Not/A_Zone"},
+				}
+				return cp
+			}(),
+			operation:        admissionv1beta1.Update,
+			expectedAllowed:  false,
+			expectedWarnings: 1,
+		},
+		{
+			name: "autoscaling max running count less than running count",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Size = 2
+				cp.Spec.RunningCount = 2
+				cp.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MaxSize: 4, MaxRunningCount: pointer.Int32Ptr(1)}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create within cluster pool size quota",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Namespace = "test-namespace"
+				cp.Spec.Size = 2
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			existing:        clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{ClusterPoolSize: pointer.Int32Ptr(2)}),
+			expectedAllowed: true,
+		},
+		{
+			name: "create exceeding cluster pool size quota",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Namespace = "test-namespace"
+				cp.Spec.Size = 1
+				return cp
+			}(),
+			operation: admissionv1beta1.Create,
+			existing: clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{ClusterPoolSize: pointer.Int32Ptr(2)},
+				&hivev1.ClusterPool{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "other-pool"},
+					Spec:       hivev1.ClusterPoolSpec{Size: 2},
+				},
+			),
+			expectedAllowed: false,
+		},
+		{
+			name: "create with autoscaling exceeding cluster pool size quota",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Namespace = "test-namespace"
+				cp.Spec.Size = 1
+				cp.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MaxSize: 3}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			existing:        clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{ClusterPoolSize: pointer.Int32Ptr(2)}),
+			expectedAllowed: false,
+		},
+		{
+			name: "create in namespace not selected by quota",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Namespace = "other-namespace"
+				cp.Spec.Size = 5
+				return cp
+			}(),
+			operation: admissionv1beta1.Create,
+			existing: clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{ClusterPoolSize: pointer.Int32Ptr(2)},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other-namespace"}},
+			),
+			expectedAllowed: true,
+		},
+		{
+			name: "shrink pool over cluster pool size quota",
+			oldObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Namespace = "test-namespace"
+				cp.Name = "pool"
+				cp.Spec.Size = 5
+				return cp
+			}(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Namespace = "test-namespace"
+				cp.Name = "pool"
+				cp.Spec.Size = 4
+				return cp
+			}(),
+			operation: admissionv1beta1.Update,
+			existing: clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{ClusterPoolSize: pointer.Int32Ptr(2)},
+				&hivev1.ClusterPool{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "pool"},
+					Spec:       hivev1.ClusterPoolSpec{Size: 5},
+				},
+			),
+			expectedAllowed: true,
+		},
+		{
+			name: "grow pool exceeding cluster pool size quota",
+			oldObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Namespace = "test-namespace"
+				cp.Name = "pool"
+				cp.Spec.Size = 2
+				return cp
+			}(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Namespace = "test-namespace"
+				cp.Name = "pool"
+				cp.Spec.Size = 3
+				return cp
+			}(),
+			operation: admissionv1beta1.Update,
+			existing: clusterQuotaObjects("test-namespace", hivev1.ClusterQuotaLimits{ClusterPoolSize: pointer.Int32Ptr(2)},
+				&hivev1.ClusterPool{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "pool"},
+					Spec:       hivev1.ClusterPoolSpec{Size: 2},
+				},
+			),
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test valid delete",
 			oldObject:       validAWSClusterPool(),
@@ -230,6 +497,9 @@ func TestClusterPoolValidate(t *testing.T) {
 			data := ClusterPoolValidatingAdmissionHook{
 				decoder: createDecoder(t),
 			}
+			if tc.existing != nil {
+				data.client = testfake.NewFakeClientBuilder().WithRuntimeObjects(tc.existing...).Build()
+			}
 
 			if tc.gvr == nil {
 				tc.gvr = &metav1.GroupVersionResource{
@@ -265,6 +535,7 @@ func TestClusterPoolValidate(t *testing.T) {
 			if !assert.Equal(t, tc.expectedAllowed, response.Allowed) {
 				t.Logf("Response result = %#v", response.Result)
 			}
+			assert.Len(t, response.Warnings, tc.expectedWarnings, "unexpected warnings")
 		})
 	}
 }
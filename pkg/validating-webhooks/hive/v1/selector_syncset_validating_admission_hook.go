@@ -2,6 +2,8 @@ package v1
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -9,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -159,6 +162,8 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateCreate(admissionSpec *a
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec").Child("patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec").Child("secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateHealthChecks(newObject.Spec.HealthChecks, newObject.Spec.HealthCheckTimeout, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateRolloutStrategy(newObject.Spec.RolloutStrategy, field.NewPath("spec", "rolloutStrategy"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -206,6 +211,8 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec", "patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateHealthChecks(newObject.Spec.HealthChecks, newObject.Spec.HealthCheckTimeout, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateRolloutStrategy(newObject.Spec.RolloutStrategy, field.NewPath("spec", "rolloutStrategy"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -222,3 +229,31 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 		Allowed: true,
 	}
 }
+
+func validateRolloutStrategy(strategy *hivev1.SelectorSyncSetRolloutStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if strategy == nil {
+		return allErrs
+	}
+	waveSizePath := fldPath.Child("waveSize")
+	switch strategy.WaveSize.Type {
+	case intstr.Int:
+		if strategy.WaveSize.IntVal < 1 {
+			allErrs = append(allErrs, field.Invalid(waveSizePath, strategy.WaveSize.IntVal, "must be at least 1"))
+		}
+	case intstr.String:
+		percent, err := strconv.Atoi(strings.TrimSuffix(strategy.WaveSize.StrVal, "%"))
+		if err != nil || !strings.HasSuffix(strategy.WaveSize.StrVal, "%") {
+			allErrs = append(allErrs, field.Invalid(waveSizePath, strategy.WaveSize.StrVal, "must be an integer or a percentage, e.g. \"10%\""))
+		} else if percent < 1 || percent > 100 {
+			allErrs = append(allErrs, field.Invalid(waveSizePath, strategy.WaveSize.StrVal, "must be between 1% and 100%"))
+		}
+	}
+	if strategy.PauseBetweenWaves != nil && strategy.PauseBetweenWaves.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("pauseBetweenWaves"), strategy.PauseBetweenWaves.Duration.String(), "must not be negative"))
+	}
+	if strategy.MaxFailuresPerWave < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxFailuresPerWave"), strategy.MaxFailuresPerWave, "must not be negative"))
+	}
+	return allErrs
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/stretchr/testify/assert"
@@ -10,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestSelectorSyncSetValidatingResource(t *testing.T) {
@@ -355,6 +357,76 @@ func TestSelectorSyncSetValidate(t *testing.T) {
 			selectorSyncSet: testSelectorSyncSetWithResources(`{"apiVersion": "authorization.openshift.io/v1", "kind": "SubjectAccessReview"}`),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid wave count rollout strategy create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				sss := testSelectorSyncSet()
+				sss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{WaveSize: intstr.FromInt(5)}
+				return sss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test valid wave percentage rollout strategy create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				sss := testSelectorSyncSet()
+				sss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{WaveSize: intstr.FromString("10%"), PauseBetweenWaves: &metav1.Duration{Duration: time.Hour}, MaxFailuresPerWave: 1}
+				return sss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test zero wave count rollout strategy create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				sss := testSelectorSyncSet()
+				sss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{WaveSize: intstr.FromInt(0)}
+				return sss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid wave percentage rollout strategy create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				sss := testSelectorSyncSet()
+				sss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{WaveSize: intstr.FromString("150%")}
+				return sss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test unparseable wave size rollout strategy create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				sss := testSelectorSyncSet()
+				sss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{WaveSize: intstr.FromString("ten")}
+				return sss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test negative pause rollout strategy create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				sss := testSelectorSyncSet()
+				sss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{WaveSize: intstr.FromInt(1), PauseBetweenWaves: &metav1.Duration{Duration: -time.Minute}}
+				return sss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test negative max failures rollout strategy create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				sss := testSelectorSyncSet()
+				sss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{WaveSize: intstr.FromInt(1), MaxFailuresPerWave: -1}
+				return sss
+			}(),
+			expectedAllowed: false,
+		},
 	}
 
 	for _, tc := range cases {
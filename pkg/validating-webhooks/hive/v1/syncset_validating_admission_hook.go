@@ -196,6 +196,7 @@ func (a *SyncSetValidatingAdmissionHook) validateCreate(admissionSpec *admission
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec").Child("secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateHealthChecks(newObject.Spec.HealthChecks, newObject.Spec.HealthCheckTimeout, field.NewPath("spec"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -244,6 +245,7 @@ func (a *SyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *admission
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateHealthChecks(newObject.Spec.HealthChecks, newObject.Spec.HealthCheckTimeout, field.NewPath("spec"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -280,6 +282,29 @@ func validateResourceApplyMode(resourceApplyMode hivev1.SyncSetResourceApplyMode
 	return allErrs
 }
 
+func validateHealthChecks(healthChecks []hivev1.SyncSetHealthCheck, timeout *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, check := range healthChecks {
+		checkPath := fldPath.Child("healthChecks").Index(i)
+		if check.APIVersion == "" {
+			allErrs = append(allErrs, field.Required(checkPath.Child("apiVersion"), "APIVersion is required"))
+		}
+		if check.Kind == "" {
+			allErrs = append(allErrs, field.Required(checkPath.Child("kind"), "Kind is required"))
+		}
+		if check.Name == "" {
+			allErrs = append(allErrs, field.Required(checkPath.Child("name"), "Name is required"))
+		}
+		if check.ConditionType == "" {
+			allErrs = append(allErrs, field.Required(checkPath.Child("conditionType"), "ConditionType is required"))
+		}
+	}
+	if timeout != nil && timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("healthCheckTimeout"), timeout.Duration.String(), "must be positive"))
+	}
+	return allErrs
+}
+
 func validateResources(resources []runtime.RawExtension, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for i, resource := range resources {
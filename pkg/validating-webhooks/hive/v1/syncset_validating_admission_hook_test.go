@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -242,6 +243,52 @@ func TestSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid health check create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.HealthChecks = []hivev1.SyncSetHealthCheck{testHealthCheck()}
+				ss.Spec.HealthCheckTimeout = &metav1.Duration{Duration: 5 * time.Minute}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid health check no conditionType update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				check := testHealthCheck()
+				check.ConditionType = ""
+				ss.Spec.HealthChecks = []hivev1.SyncSetHealthCheck{check}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid health check no name create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				check := testHealthCheck()
+				check.Name = ""
+				ss.Spec.HealthChecks = []hivev1.SyncSetHealthCheck{check}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid zero healthCheckTimeout create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.HealthChecks = []hivev1.SyncSetHealthCheck{testHealthCheck()}
+				ss.Spec.HealthCheckTimeout = &metav1.Duration{}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test invalid unmarshalable Resource create",
 			operation:       admissionv1beta1.Create,
@@ -471,3 +518,13 @@ func testSyncSetWithResources(resources ...string) *hivev1.SyncSet {
 	}
 	return ss
 }
+
+func testHealthCheck() hivev1.SyncSetHealthCheck {
+	return hivev1.SyncSetHealthCheck{
+		APIVersion:    "apps/v1",
+		Kind:          "Deployment",
+		Namespace:     "foo",
+		Name:          "foo",
+		ConditionType: "Available",
+	}
+}
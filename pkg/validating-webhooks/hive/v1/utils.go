@@ -1,10 +1,32 @@
 package v1
 
 import (
+	goerrors "errors"
+	"fmt"
 	"strconv"
+	"sync"
 
-	"github.com/openshift/hive/pkg/constants"
+	log "github.com/sirupsen/logrus"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+var (
+	clusterQuotaClientOnce sync.Once
+	clusterQuotaClient     client.Client
+	clusterQuotaClientErr  error
 )
 
 func creationHooksDisabled(o metav1.Object) bool {
@@ -18,3 +40,118 @@ func creationHooksDisabled(o metav1.Object) bool {
 	}
 	return b
 }
+
+// validateClusterQuotas returns a response denying the request if adding the requested amounts in the namespace would
+// exceed a ClusterQuota, or nil if the request is within quota. Quotas are not enforced if there is no client.
+func validateClusterQuotas(c client.Client, resource schema.GroupResource, name, namespace string, requested hivev1.ClusterQuotaUsage, contextLogger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	if c == nil {
+		return nil
+	}
+	message, err := controllerutils.CheckClusterQuotas(c, namespace, requested)
+	if err != nil {
+		contextLogger.WithError(err).Error("failed to check cluster quotas")
+		status := errors.NewInternalError(err).Status()
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		}
+	}
+	if message == "" {
+		return nil
+	}
+	contextLogger.WithField("quotaViolations", message).Info("failed validation")
+	status := errors.NewForbidden(resource, name, goerrors.New(message)).Status()
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result:  &status,
+	}
+}
+
+// getClusterQuotaClient returns the client used to enforce ClusterQuotas, shared by the webhooks. ClusterQuotas,
+// ClusterDeployments and ClusterPools are read from an informer cache, so that checking a request does not list every
+// cluster and pool. Only the fields counted by quotas are kept of ClusterDeployments and ClusterPools, so that the
+// cache stays small however many there are. Namespaces are read directly, so that an object created right after its
+// namespace is checked against the quotas selecting it.
+func getClusterQuotaClient(kubeClientConfig *rest.Config, stopCh <-chan struct{}) (client.Client, error) {
+	clusterQuotaClientOnce.Do(func() {
+		clusterQuotaClient, clusterQuotaClientErr = newClusterQuotaClient(kubeClientConfig, stopCh)
+	})
+	return clusterQuotaClient, clusterQuotaClientErr
+}
+
+func newClusterQuotaClient(kubeClientConfig *rest.Config, stopCh <-chan struct{}) (client.Client, error) {
+	ctx := wait.ContextForChannel(stopCh)
+	c, err := cache.New(kubeClientConfig, cache.Options{
+		Scheme: scheme.GetScheme(),
+		ByObject: map[client.Object]cache.ByObject{
+			&hivev1.ClusterDeployment{}: {Transform: trimClusterDeploymentForClusterQuota},
+			&hivev1.ClusterPool{}:       {Transform: trimClusterPoolForClusterQuota},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range []client.Object{&hivev1.ClusterQuota{}, &hivev1.ClusterDeployment{}, &hivev1.ClusterPool{}} {
+		if _, err := c.GetInformer(ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+	go func() {
+		if err := c.Start(ctx); err != nil {
+			log.WithError(err).Error("cluster quota cache stopped")
+		}
+	}()
+	if !c.WaitForCacheSync(ctx) {
+		return nil, fmt.Errorf("failed to sync cluster quota cache")
+	}
+	return client.New(kubeClientConfig, client.Options{
+		Scheme: scheme.GetScheme(),
+		Cache: &client.CacheOptions{
+			Reader:     c,
+			DisableFor: []client.Object{&corev1.Namespace{}},
+		},
+	})
+}
+
+// trimClusterDeploymentForClusterQuota drops all the fields of a ClusterDeployment but those counted by ClusterQuotas.
+func trimClusterDeploymentForClusterQuota(obj interface{}) (interface{}, error) {
+	cd, ok := obj.(*hivev1.ClusterDeployment)
+	if !ok {
+		return obj, nil
+	}
+	return &hivev1.ClusterDeployment{
+		TypeMeta:   cd.TypeMeta,
+		ObjectMeta: trimObjectMetaForClusterQuota(cd.ObjectMeta),
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterPoolRef: cd.Spec.ClusterPoolRef,
+			PowerState:     cd.Spec.PowerState,
+		},
+	}, nil
+}
+
+// trimClusterPoolForClusterQuota drops all the fields of a ClusterPool but those counted by ClusterQuotas.
+func trimClusterPoolForClusterQuota(obj interface{}) (interface{}, error) {
+	pool, ok := obj.(*hivev1.ClusterPool)
+	if !ok {
+		return obj, nil
+	}
+	return &hivev1.ClusterPool{
+		TypeMeta:   pool.TypeMeta,
+		ObjectMeta: trimObjectMetaForClusterQuota(pool.ObjectMeta),
+		Spec: hivev1.ClusterPoolSpec{
+			Size:        pool.Spec.Size,
+			Autoscaling: pool.Spec.Autoscaling,
+		},
+	}, nil
+}
+
+func trimObjectMetaForClusterQuota(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:              meta.Name,
+		Namespace:         meta.Namespace,
+		UID:               meta.UID,
+		ResourceVersion:   meta.ResourceVersion,
+		CreationTimestamp: meta.CreationTimestamp,
+		DeletionTimestamp: meta.DeletionTimestamp,
+	}
+}
@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const hiveAdmissionRoleFile = "../../../../config/hiveadmission/hiveadmission_rbac_role.yaml"
+
+// hiveAdmissionRBACClient returns a fake client holding the given objects which, like the API server, forbids any
+// request not granted by the ClusterRole of the hiveadmission service account.
+func hiveAdmissionRBACClient(t *testing.T, objs ...runtime.Object) client.Client {
+	data, err := os.ReadFile(hiveAdmissionRoleFile)
+	require.NoError(t, err, "unable to read hiveadmission role")
+	role := &rbacv1.ClusterRole{}
+	require.NoError(t, yaml.Unmarshal(data, role), "unable to parse hiveadmission role")
+
+	authorize := func(obj runtime.Object, verb, subresource string) error {
+		gvk, err := apiutil.GVKForObject(obj, scheme.GetScheme())
+		if err != nil {
+			return err
+		}
+		// Good enough for the kinds used by the webhooks: ClusterDeployment(List) -> clusterdeployments
+		resource := strings.ToLower(strings.TrimSuffix(gvk.Kind, "List")) + "s"
+		if subresource != "" {
+			resource += "/" + subresource
+		}
+		for _, rule := range role.Rules {
+			if contains(rule.APIGroups, gvk.Group) && contains(rule.Resources, resource) && contains(rule.Verbs, verb) {
+				return nil
+			}
+		}
+		return errors.NewForbidden(schema.GroupResource{Group: gvk.Group, Resource: resource}, "", nil)
+	}
+
+	return testfake.NewFakeClientBuilder().WithRuntimeObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if err := authorize(obj, "get", ""); err != nil {
+				return err
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if err := authorize(list, "list", ""); err != nil {
+				return err
+			}
+			return c.List(ctx, list, opts...)
+		},
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if err := authorize(obj, "create", ""); err != nil {
+				return err
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if err := authorize(obj, "update", ""); err != nil {
+				return err
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			if err := authorize(obj, "update", subResourceName); err != nil {
+				return err
+			}
+			return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+		},
+	}).Build()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value || v == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateClusterQuotasWithAdmissionRBAC(t *testing.T) {
+	cases := []struct {
+		name            string
+		resource        schema.GroupResource
+		hard            hivev1.ClusterQuotaLimits
+		requested       hivev1.ClusterQuotaUsage
+		existing        []runtime.Object
+		expectedAllowed bool
+	}{
+		{
+			name:            "cluster deployment create within quota",
+			resource:        clusterDeploymentGroupResource,
+			hard:            hivev1.ClusterQuotaLimits{ClusterDeployments: pointer.Int32Ptr(2)},
+			requested:       hivev1.ClusterQuotaUsage{ClusterDeployments: 1, RunningClusterDeployments: 1},
+			existing:        []runtime.Object{clusterDeploymentInNamespace("test-namespace", "cd1", "")},
+			expectedAllowed: true,
+		},
+		{
+			name:            "cluster deployment create exceeding quota",
+			resource:        clusterDeploymentGroupResource,
+			hard:            hivev1.ClusterQuotaLimits{ClusterDeployments: pointer.Int32Ptr(1)},
+			requested:       hivev1.ClusterQuotaUsage{ClusterDeployments: 1, RunningClusterDeployments: 1},
+			existing:        []runtime.Object{clusterDeploymentInNamespace("test-namespace", "cd1", "")},
+			expectedAllowed: false,
+		},
+		{
+			name:      "cluster deployment resume within quota",
+			resource:  clusterDeploymentGroupResource,
+			hard:      hivev1.ClusterQuotaLimits{RunningClusterDeployments: pointer.Int32Ptr(1)},
+			requested: hivev1.ClusterQuotaUsage{RunningClusterDeployments: 1},
+			existing: []runtime.Object{
+				clusterDeploymentInNamespace("test-namespace", "cd1", hivev1.ClusterPowerStateHibernating),
+			},
+			expectedAllowed: true,
+		},
+		{
+			name:            "cluster pool create within quota",
+			resource:        clusterPoolGroupResource,
+			hard:            hivev1.ClusterQuotaLimits{ClusterPoolSize: pointer.Int32Ptr(2)},
+			requested:       hivev1.ClusterQuotaUsage{ClusterPoolSize: 2},
+			expectedAllowed: true,
+		},
+		{
+			name:     "cluster pool grow exceeding quota",
+			resource: clusterPoolGroupResource,
+			hard:     hivev1.ClusterQuotaLimits{ClusterPoolSize: pointer.Int32Ptr(2)},
+			existing: []runtime.Object{&hivev1.ClusterPool{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "pool"},
+				Spec:       hivev1.ClusterPoolSpec{Size: 2},
+			}},
+			requested:       hivev1.ClusterQuotaUsage{ClusterPoolSize: 1},
+			expectedAllowed: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := hiveAdmissionRBACClient(t, clusterQuotaObjects("test-namespace", tc.hard, tc.existing...)...)
+			response := validateClusterQuotas(c, tc.resource, "test", "test-namespace", tc.requested, log.WithField("test", tc.name))
+			if tc.expectedAllowed {
+				assert.Nil(t, response, "expected request to be allowed")
+				return
+			}
+			require.NotNil(t, response, "expected request to be denied")
+			assert.Equal(t, metav1.StatusReasonForbidden, response.Result.Reason, "expected quota violation")
+			assert.Contains(t, response.Result.Message, "exceeded ClusterQuota", "expected quota violation")
+		})
+	}
+}